@@ -27,6 +27,7 @@ import (
 	keyvaultv1alpha1 "github.com/crossplane/provider-azure/apis/keyvault/v1alpha1"
 	networkv1alpha3 "github.com/crossplane/provider-azure/apis/network/v1alpha3"
 	storagev1alpha3 "github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	storagev1beta1 "github.com/crossplane/provider-azure/apis/storage/v1beta1"
 	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
 	azurev1beta1 "github.com/crossplane/provider-azure/apis/v1beta1"
 )
@@ -43,6 +44,7 @@ func init() {
 		keyvaultv1alpha1.SchemeBuilder.AddToScheme,
 		networkv1alpha3.SchemeBuilder.AddToScheme,
 		storagev1alpha3.SchemeBuilder.AddToScheme,
+		storagev1beta1.SchemeBuilder.AddToScheme,
 	)
 }
 
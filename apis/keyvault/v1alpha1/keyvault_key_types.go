@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// KeyVaultKeyAttributesParameters defines the desired state of an Azure Key Vault Key Attributes.
+type KeyVaultKeyAttributesParameters struct {
+	// Enabled - Determines whether the object is enabled.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// NotBeforeDate - Not before date in UTC (RFC3339 format).
+	// +optional
+	NotBeforeDate *metav1.Time `json:"notBeforeDate,omitempty"`
+
+	// Expires - Expiry date in UTC (RFC3339 format).
+	// +optional
+	ExpirationDate *metav1.Time `json:"expirationDate,omitempty"`
+}
+
+// KeyVaultKeyParameters defines the desired state of an Azure Key Vault Key.
+// https://docs.microsoft.com/en-us/rest/api/keyvault/#key-operations
+type KeyVaultKeyParameters struct {
+	// VaultBaseURL - The vault name, for example https://myvault.vault.azure.net.
+	VaultBaseURL string `json:"vaultBaseUrl"`
+
+	// Name - The name of the key
+	Name string `json:"name"`
+
+	// KeyType - The type of the key. Possible values include: 'EC', 'EC-HSM', 'RSA', 'RSA-HSM'
+	// +immutable
+	// +kubebuilder:validation:Enum=EC;EC-HSM;RSA;RSA-HSM
+	KeyType string `json:"keyType"`
+
+	// KeySize - The key size in bits of an RSA key, for example 2048, 3072, or 4096.
+	// +immutable
+	// +optional
+	KeySize *int `json:"keySize,omitempty"`
+
+	// Curve - The elliptic curve name of an EC key. Possible values include: 'P-256', 'P-384', 'P-521', 'P-256K'
+	// +immutable
+	// +kubebuilder:validation:Enum=P-256;P-384;P-521;P-256K
+	// +optional
+	Curve *string `json:"curve,omitempty"`
+
+	// KeyOperations - The operations the key may be used for. Possible values include: 'encrypt', 'decrypt', 'sign', 'verify', 'wrapKey', 'unwrapKey'
+	// +optional
+	KeyOperations []string `json:"keyOperations,omitempty"`
+
+	// KeyAttributes - The key management attributes
+	// +optional
+	KeyAttributes *KeyVaultKeyAttributesParameters `json:"attributes,omitempty"`
+
+	// Tags - Application-specific metadata in the form of key-value pairs
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A KeyVaultKeySpec defines the desired state of a Key.
+type KeyVaultKeySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       KeyVaultKeyParameters `json:"forProvider"`
+}
+
+// KeyVaultKeyAttributesObservation represents the observed state of an Azure Key Vault Key Attributes.
+type KeyVaultKeyAttributesObservation struct {
+	// RecoveryLevel - Reflects the deletion recovery level currently in effect for keys in the current vault.
+	RecoveryLevel string `json:"recoveryLevel,omitempty"`
+
+	// Created - Creation time in UTC.
+	Created *metav1.Time `json:"created,omitempty"`
+
+	// Updated - Last updated time in UTC.
+	Updated *metav1.Time `json:"updated,omitempty"`
+}
+
+// KeyVaultKeyObservation represents the observed state of the Key object in Azure.
+type KeyVaultKeyObservation struct {
+	// ID - The identifier of the current version of the key.
+	ID string `json:"id,omitempty"`
+
+	// Version - The current version of the key.
+	Version string `json:"version,omitempty"`
+
+	// Attributes - The key management attributes.
+	Attributes *KeyVaultKeyAttributesObservation `json:"attributes,omitempty"`
+
+	// Managed - True if the key's lifetime is managed by key vault, for example because it backs a certificate.
+	Managed *bool `json:"managed,omitempty"`
+}
+
+// A KeyVaultKeyStatus represents the observed state of a Key.
+type KeyVaultKeyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          KeyVaultKeyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A KeyVaultKey is a managed resource that represents an Azure Key Vault Key.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="VERSION",type="string",JSONPath=".status.atProvider.version"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure},shortName=kvkey
+type KeyVaultKey struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   KeyVaultKeySpec   `json:"spec"`
+	Status KeyVaultKeyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// KeyVaultKeyList contains a list of Key.
+type KeyVaultKeyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []KeyVaultKey `json:"items"`
+}
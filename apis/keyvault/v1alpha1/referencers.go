@@ -0,0 +1,36 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strings"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+// KeyVaultKeyID extracts the versionless identifier of the supplied managed
+// resource, which must be a KeyVaultKey, from its status.
+func KeyVaultKeyID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		k, ok := mg.(*KeyVaultKey)
+		if !ok || k.Status.AtProvider.Version == "" {
+			return ""
+		}
+		return strings.TrimSuffix(k.Status.AtProvider.ID, "/"+k.Status.AtProvider.Version)
+	}
+}
@@ -45,6 +45,15 @@ var (
 	KeyVaultSecretGroupVersionKind = SchemeGroupVersion.WithKind(KeyVaultSecretKind)
 )
 
+// KeyVaultKey type metadata.
+var (
+	KeyVaultKeyKind             = reflect.TypeOf(KeyVaultKey{}).Name()
+	KeyVaultKeyGroupKind        = schema.GroupKind{Group: Group, Kind: KeyVaultKeyKind}.String()
+	KeyVaultKeyKindAPIVersion   = KeyVaultKeyKind + "." + SchemeGroupVersion.String()
+	KeyVaultKeyGroupVersionKind = SchemeGroupVersion.WithKind(KeyVaultKeyKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&KeyVaultSecret{}, &KeyVaultSecretList{})
+	SchemeBuilder.Register(&KeyVaultKey{}, &KeyVaultKeyList{})
 }
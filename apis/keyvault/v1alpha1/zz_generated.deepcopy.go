@@ -24,6 +24,217 @@ import (
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultKey) DeepCopyInto(out *KeyVaultKey) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultKey.
+func (in *KeyVaultKey) DeepCopy() *KeyVaultKey {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultKey)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeyVaultKey) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultKeyAttributesObservation) DeepCopyInto(out *KeyVaultKeyAttributesObservation) {
+	*out = *in
+	if in.Created != nil {
+		in, out := &in.Created, &out.Created
+		*out = (*in).DeepCopy()
+	}
+	if in.Updated != nil {
+		in, out := &in.Updated, &out.Updated
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultKeyAttributesObservation.
+func (in *KeyVaultKeyAttributesObservation) DeepCopy() *KeyVaultKeyAttributesObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultKeyAttributesObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultKeyAttributesParameters) DeepCopyInto(out *KeyVaultKeyAttributesParameters) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NotBeforeDate != nil {
+		in, out := &in.NotBeforeDate, &out.NotBeforeDate
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpirationDate != nil {
+		in, out := &in.ExpirationDate, &out.ExpirationDate
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultKeyAttributesParameters.
+func (in *KeyVaultKeyAttributesParameters) DeepCopy() *KeyVaultKeyAttributesParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultKeyAttributesParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultKeyList) DeepCopyInto(out *KeyVaultKeyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]KeyVaultKey, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultKeyList.
+func (in *KeyVaultKeyList) DeepCopy() *KeyVaultKeyList {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultKeyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *KeyVaultKeyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultKeyObservation) DeepCopyInto(out *KeyVaultKeyObservation) {
+	*out = *in
+	if in.Attributes != nil {
+		in, out := &in.Attributes, &out.Attributes
+		*out = new(KeyVaultKeyAttributesObservation)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Managed != nil {
+		in, out := &in.Managed, &out.Managed
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultKeyObservation.
+func (in *KeyVaultKeyObservation) DeepCopy() *KeyVaultKeyObservation {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultKeyObservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultKeyParameters) DeepCopyInto(out *KeyVaultKeyParameters) {
+	*out = *in
+	if in.KeySize != nil {
+		in, out := &in.KeySize, &out.KeySize
+		*out = new(int)
+		**out = **in
+	}
+	if in.Curve != nil {
+		in, out := &in.Curve, &out.Curve
+		*out = new(string)
+		**out = **in
+	}
+	if in.KeyOperations != nil {
+		in, out := &in.KeyOperations, &out.KeyOperations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.KeyAttributes != nil {
+		in, out := &in.KeyAttributes, &out.KeyAttributes
+		*out = new(KeyVaultKeyAttributesParameters)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tags != nil {
+		in, out := &in.Tags, &out.Tags
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultKeyParameters.
+func (in *KeyVaultKeyParameters) DeepCopy() *KeyVaultKeyParameters {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultKeyParameters)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultKeySpec) DeepCopyInto(out *KeyVaultKeySpec) {
+	*out = *in
+	in.ResourceSpec.DeepCopyInto(&out.ResourceSpec)
+	in.ForProvider.DeepCopyInto(&out.ForProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultKeySpec.
+func (in *KeyVaultKeySpec) DeepCopy() *KeyVaultKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KeyVaultKeyStatus) DeepCopyInto(out *KeyVaultKeyStatus) {
+	*out = *in
+	in.ResourceStatus.DeepCopyInto(&out.ResourceStatus)
+	in.AtProvider.DeepCopyInto(&out.AtProvider)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KeyVaultKeyStatus.
+func (in *KeyVaultKeyStatus) DeepCopy() *KeyVaultKeyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(KeyVaultKeyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KeyVaultSecret) DeepCopyInto(out *KeyVaultSecret) {
 	*out = *in
@@ -20,6 +20,15 @@ package v1alpha1
 
 import resource "github.com/crossplane/crossplane-runtime/pkg/resource"
 
+// GetItems of this KeyVaultKeyList.
+func (l *KeyVaultKeyList) GetItems() []resource.Managed {
+	items := make([]resource.Managed, len(l.Items))
+	for i := range l.Items {
+		items[i] = &l.Items[i]
+	}
+	return items
+}
+
 // GetItems of this KeyVaultSecretList.
 func (l *KeyVaultSecretList) GetItems() []resource.Managed {
 	items := make([]resource.Managed, len(l.Items))
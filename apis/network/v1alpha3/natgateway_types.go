@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// NATGatewayPropertiesFormat defines properties of a NATGateway.
+type NATGatewayPropertiesFormat struct {
+	// IdleTimeoutInMinutes - The idle timeout of outbound connections.
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=120
+	// +optional
+	IdleTimeoutInMinutes *int `json:"idleTimeoutInMinutes,omitempty"`
+
+	// PublicIPAddressIDs - The IDs of the Standard public IP addresses used
+	// for outbound connections.
+	// +optional
+	PublicIPAddressIDs []string `json:"publicIPAddressIds,omitempty"`
+
+	// PublicIPAddressIDRefs - References to PublicIPAddresses to retrieve
+	// their IDs
+	// +optional
+	PublicIPAddressIDRefs []xpv1.Reference `json:"publicIPAddressIdRefs,omitempty"`
+
+	// PublicIPAddressIDSelector - Select references to PublicIPAddresses to
+	// retrieve their IDs
+	// +optional
+	PublicIPAddressIDSelector *xpv1.Selector `json:"publicIPAddressIdSelector,omitempty"`
+
+	// PublicIPPrefixIDs - The IDs of the public IP prefixes used for
+	// outbound connections.
+	// +optional
+	PublicIPPrefixIDs []string `json:"publicIPPrefixIds,omitempty"`
+
+	// PublicIPPrefixIDRefs - References to PublicIPPrefixes to retrieve their
+	// IDs
+	// +optional
+	PublicIPPrefixIDRefs []xpv1.Reference `json:"publicIPPrefixIdRefs,omitempty"`
+
+	// PublicIPPrefixIDSelector - Select references to PublicIPPrefixes to
+	// retrieve their IDs
+	// +optional
+	PublicIPPrefixIDSelector *xpv1.Selector `json:"publicIPPrefixIdSelector,omitempty"`
+}
+
+// A NATGatewaySpec defines the desired state of a NATGateway.
+type NATGatewaySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// ResourceGroupName - Name of the NAT Gateway's resource group.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the NAT Gateway's resource
+	// group.
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to the the NAT
+	// Gateway's resource group.
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// NATGatewayPropertiesFormat - Properties of the NAT gateway. Subnets
+	// are associated with the NAT gateway by Subnet resources.
+	// +optional
+	NATGatewayPropertiesFormat `json:"properties,omitempty"`
+
+	// Zones - The availability zone the NAT gateway is deployed in.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// Location - Resource location.
+	Location string `json:"location"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A NATGatewayStatus represents the observed state of a NATGateway.
+type NATGatewayStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// State of this NATGateway.
+	State string `json:"state,omitempty"`
+
+	// ID of this NATGateway.
+	ID string `json:"id,omitempty"`
+
+	// Etag - A unique read-only string that changes whenever the resource is
+	// updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ResourceGUID - The GUID of this NATGateway.
+	ResourceGUID string `json:"resourceGuid,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A NATGateway is a managed resource that represents an Azure NAT Gateway,
+// which provides outbound internet connectivity to the subnets associated
+// with it.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type NATGateway struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   NATGatewaySpec   `json:"spec"`
+	Status NATGatewayStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// NATGatewayList contains a list of NATGateway items
+type NATGatewayList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []NATGateway `json:"items"`
+}
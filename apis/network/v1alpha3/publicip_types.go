@@ -0,0 +1,264 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A PublicIPAddressSKU is the SKU of a public IP address.
+type PublicIPAddressSKU struct {
+	// Name of the SKU. Standard public IP addresses are required by Standard
+	// load balancers, and therefore by AKS clusters.
+	// +kubebuilder:validation:Enum=Basic;Standard
+	Name string `json:"name"`
+}
+
+// PublicIPAddressDNSSettings define the DNS record of a public IP address.
+type PublicIPAddressDNSSettings struct {
+	// DomainNameLabel - The label of the A record that resolves to the public
+	// IP address, in the cloudapp.azure.com zone of the region.
+	DomainNameLabel string `json:"domainNameLabel"`
+
+	// ReverseFQDN - A fully qualified domain name that resolves to this
+	// public IP address. The PTR record of the address resolves to it.
+	// +optional
+	ReverseFQDN string `json:"reverseFqdn,omitempty"`
+}
+
+// PublicIPAddressPropertiesFormat defines properties of a PublicIPAddress.
+type PublicIPAddressPropertiesFormat struct {
+	// PublicIPAllocationMethod - Whether the address is allocated when it is
+	// created or when it is associated with a resource. Standard public IP
+	// addresses must be Static.
+	// +kubebuilder:validation:Enum=Static;Dynamic
+	PublicIPAllocationMethod string `json:"publicIPAllocationMethod"`
+
+	// PublicIPAddressVersion - The IP version of the address. Defaults to
+	// IPv4.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +optional
+	PublicIPAddressVersion string `json:"publicIPAddressVersion,omitempty"`
+
+	// DNSSettings - The DNS record of the public IP address.
+	// +optional
+	DNSSettings *PublicIPAddressDNSSettings `json:"dnsSettings,omitempty"`
+
+	// IdleTimeoutInMinutes - The idle timeout of TCP connections.
+	// +kubebuilder:validation:Minimum=4
+	// +kubebuilder:validation:Maximum=30
+	// +optional
+	IdleTimeoutInMinutes *int `json:"idleTimeoutInMinutes,omitempty"`
+
+	// PublicIPPrefixID - The ID of the public IP prefix to allocate the
+	// address from.
+	// +optional
+	PublicIPPrefixID string `json:"publicIPPrefixId,omitempty"`
+
+	// PublicIPPrefixIDRef - A reference to a PublicIPPrefix to retrieve its
+	// ID
+	// +optional
+	PublicIPPrefixIDRef *xpv1.Reference `json:"publicIPPrefixIdRef,omitempty"`
+
+	// PublicIPPrefixIDSelector - Select a reference to a PublicIPPrefix to
+	// retrieve its ID
+	// +optional
+	PublicIPPrefixIDSelector *xpv1.Selector `json:"publicIPPrefixIdSelector,omitempty"`
+}
+
+// A PublicIPAddressSpec defines the desired state of a PublicIPAddress.
+type PublicIPAddressSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// ResourceGroupName - Name of the Public IP Address's resource group.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the Public IP Address's
+	// resource group.
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to the the Public IP
+	// Address's resource group.
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SKU - The SKU of the public IP address. Defaults to Basic.
+	// +optional
+	SKU *PublicIPAddressSKU `json:"sku,omitempty"`
+
+	// PublicIPAddressPropertiesFormat - Properties of the public IP address.
+	PublicIPAddressPropertiesFormat `json:"properties"`
+
+	// Zones - The availability zones the public IP address is allocated in.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// Location - Resource location.
+	Location string `json:"location"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A PublicIPAddressStatus represents the observed state of a
+// PublicIPAddress.
+type PublicIPAddressStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// State of this PublicIPAddress.
+	State string `json:"state,omitempty"`
+
+	// ID of this PublicIPAddress.
+	ID string `json:"id,omitempty"`
+
+	// Etag - A unique read-only string that changes whenever the resource is
+	// updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ResourceGUID - The GUID of this PublicIPAddress.
+	ResourceGUID string `json:"resourceGuid,omitempty"`
+
+	// IPAddress - The allocated IP address, if any. Dynamic addresses are
+	// only allocated once they are associated with a resource.
+	IPAddress string `json:"ipAddress,omitempty"`
+
+	// FQDN - The fully qualified domain name that resolves to the IP address,
+	// if a domain name label is specified.
+	FQDN string `json:"fqdn,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A PublicIPAddress is a managed resource that represents an Azure Public IP
+// Address.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="ADDRESS",type="string",JSONPath=".status.ipAddress"
+// +kubebuilder:printcolumn:name="FQDN",type="string",JSONPath=".status.fqdn"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type PublicIPAddress struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PublicIPAddressSpec   `json:"spec"`
+	Status PublicIPAddressStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PublicIPAddressList contains a list of PublicIPAddress items
+type PublicIPAddressList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PublicIPAddress `json:"items"`
+}
+
+// PublicIPPrefixPropertiesFormat defines properties of a PublicIPPrefix.
+type PublicIPPrefixPropertiesFormat struct {
+	// PrefixLength - The length of the prefix, e.g. 28 for 16 IPv4
+	// addresses.
+	// +kubebuilder:validation:Minimum=0
+	PrefixLength int `json:"prefixLength"`
+
+	// PublicIPAddressVersion - The IP version of the prefix. Defaults to
+	// IPv4.
+	// +kubebuilder:validation:Enum=IPv4;IPv6
+	// +optional
+	PublicIPAddressVersion string `json:"publicIPAddressVersion,omitempty"`
+}
+
+// A PublicIPPrefixSpec defines the desired state of a PublicIPPrefix.
+type PublicIPPrefixSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// ResourceGroupName - Name of the Public IP Prefix's resource group.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the Public IP Prefix's
+	// resource group.
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to the the Public IP
+	// Prefix's resource group.
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// PublicIPPrefixPropertiesFormat - Properties of the public IP prefix.
+	PublicIPPrefixPropertiesFormat `json:"properties"`
+
+	// Zones - The availability zones the public IP prefix is allocated in.
+	// +optional
+	Zones []string `json:"zones,omitempty"`
+
+	// Location - Resource location.
+	Location string `json:"location"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A PublicIPPrefixStatus represents the observed state of a PublicIPPrefix.
+type PublicIPPrefixStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// State of this PublicIPPrefix.
+	State string `json:"state,omitempty"`
+
+	// ID of this PublicIPPrefix.
+	ID string `json:"id,omitempty"`
+
+	// Etag - A unique read-only string that changes whenever the resource is
+	// updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ResourceGUID - The GUID of this PublicIPPrefix.
+	ResourceGUID string `json:"resourceGuid,omitempty"`
+
+	// IPPrefix - The allocated prefix, in CIDR notation.
+	IPPrefix string `json:"ipPrefix,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A PublicIPPrefix is a managed resource that represents an Azure Public IP
+// Prefix, a contiguous range of public IP addresses.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PREFIX",type="string",JSONPath=".status.ipPrefix"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type PublicIPPrefix struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PublicIPPrefixSpec   `json:"spec"`
+	Status PublicIPPrefixStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PublicIPPrefixList contains a list of PublicIPPrefix items
+type PublicIPPrefixList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PublicIPPrefix `json:"items"`
+}
@@ -40,6 +40,78 @@ func SubnetID() reference.ExtractValueFn {
 	}
 }
 
+// VirtualNetworkID extracts status.ID from the supplied managed resource, which
+// must be a VirtualNetwork.
+func VirtualNetworkID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		v, ok := mg.(*VirtualNetwork)
+		if !ok {
+			return ""
+		}
+		return v.Status.ID
+	}
+}
+
+// SecurityGroupID extracts status.ID from the supplied managed resource, which
+// must be a SecurityGroup.
+func SecurityGroupID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		s, ok := mg.(*SecurityGroup)
+		if !ok {
+			return ""
+		}
+		return s.Status.ID
+	}
+}
+
+// RouteTableID extracts status.ID from the supplied managed resource, which
+// must be a RouteTable.
+func RouteTableID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		s, ok := mg.(*RouteTable)
+		if !ok {
+			return ""
+		}
+		return s.Status.ID
+	}
+}
+
+// NATGatewayID extracts status.ID from the supplied managed resource, which
+// must be a NATGateway.
+func NATGatewayID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		n, ok := mg.(*NATGateway)
+		if !ok {
+			return ""
+		}
+		return n.Status.ID
+	}
+}
+
+// PublicIPAddressID extracts status.ID from the supplied managed resource,
+// which must be a PublicIPAddress.
+func PublicIPAddressID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		p, ok := mg.(*PublicIPAddress)
+		if !ok {
+			return ""
+		}
+		return p.Status.ID
+	}
+}
+
+// PublicIPPrefixID extracts status.ID from the supplied managed resource,
+// which must be a PublicIPPrefix.
+func PublicIPPrefixID() reference.ExtractValueFn {
+	return func(mg resource.Managed) string {
+		p, ok := mg.(*PublicIPPrefix)
+		if !ok {
+			return ""
+		}
+		return p.Status.ID
+	}
+}
+
 // ResolveReferences of this VirtualNetwork
 func (mg *VirtualNetwork) ResolveReferences(ctx context.Context, c client.Reader) error {
 	r := reference.NewAPIResolver(c, mg)
@@ -93,5 +165,313 @@ func (mg *Subnet) ResolveReferences(ctx context.Context, c client.Reader) error
 	mg.Spec.VirtualNetworkName = rsp.ResolvedValue
 	mg.Spec.VirtualNetworkNameRef = rsp.ResolvedReference
 
+	// Resolve spec.properties.networkSecurityGroupId
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.NetworkSecurityGroupID,
+		Reference:    mg.Spec.NetworkSecurityGroupIDRef,
+		Selector:     mg.Spec.NetworkSecurityGroupIDSelector,
+		To:           reference.To{Managed: &SecurityGroup{}, List: &SecurityGroupList{}},
+		Extract:      SecurityGroupID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.properties.networkSecurityGroupId")
+	}
+	mg.Spec.NetworkSecurityGroupID = rsp.ResolvedValue
+	mg.Spec.NetworkSecurityGroupIDRef = rsp.ResolvedReference
+
+	// Resolve spec.properties.routeTableId
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.RouteTableID,
+		Reference:    mg.Spec.RouteTableIDRef,
+		Selector:     mg.Spec.RouteTableIDSelector,
+		To:           reference.To{Managed: &RouteTable{}, List: &RouteTableList{}},
+		Extract:      RouteTableID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.properties.routeTableId")
+	}
+	mg.Spec.RouteTableID = rsp.ResolvedValue
+	mg.Spec.RouteTableIDRef = rsp.ResolvedReference
+
+	// Resolve spec.properties.natGatewayId
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.NATGatewayID,
+		Reference:    mg.Spec.NATGatewayIDRef,
+		Selector:     mg.Spec.NATGatewayIDSelector,
+		To:           reference.To{Managed: &NATGateway{}, List: &NATGatewayList{}},
+		Extract:      NATGatewayID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.properties.natGatewayId")
+	}
+	mg.Spec.NATGatewayID = rsp.ResolvedValue
+	mg.Spec.NATGatewayIDRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this SecurityGroup
+func (mg *SecurityGroup) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ResourceGroupName,
+		Reference:    mg.Spec.ResourceGroupNameRef,
+		Selector:     mg.Spec.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.resourceGroupName")
+	}
+	mg.Spec.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ResourceGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this SecurityRule
+func (mg *SecurityRule) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ResourceGroupName,
+		Reference:    mg.Spec.ResourceGroupNameRef,
+		Selector:     mg.Spec.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.resourceGroupName")
+	}
+	mg.Spec.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.securityGroupName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.SecurityGroupName,
+		Reference:    mg.Spec.SecurityGroupNameRef,
+		Selector:     mg.Spec.SecurityGroupNameSelector,
+		To:           reference.To{Managed: &SecurityGroup{}, List: &SecurityGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.securityGroupName")
+	}
+	mg.Spec.SecurityGroupName = rsp.ResolvedValue
+	mg.Spec.SecurityGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this RouteTable
+func (mg *RouteTable) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ResourceGroupName,
+		Reference:    mg.Spec.ResourceGroupNameRef,
+		Selector:     mg.Spec.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.resourceGroupName")
+	}
+	mg.Spec.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ResourceGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Route
+func (mg *Route) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ResourceGroupName,
+		Reference:    mg.Spec.ResourceGroupNameRef,
+		Selector:     mg.Spec.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.resourceGroupName")
+	}
+	mg.Spec.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.routeTableName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.RouteTableName,
+		Reference:    mg.Spec.RouteTableNameRef,
+		Selector:     mg.Spec.RouteTableNameSelector,
+		To:           reference.To{Managed: &RouteTable{}, List: &RouteTableList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.routeTableName")
+	}
+	mg.Spec.RouteTableName = rsp.ResolvedValue
+	mg.Spec.RouteTableNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this VirtualNetworkPeering
+func (mg *VirtualNetworkPeering) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ResourceGroupName,
+		Reference:    mg.Spec.ResourceGroupNameRef,
+		Selector:     mg.Spec.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.resourceGroupName")
+	}
+	mg.Spec.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.virtualNetworkName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.VirtualNetworkName,
+		Reference:    mg.Spec.VirtualNetworkNameRef,
+		Selector:     mg.Spec.VirtualNetworkNameSelector,
+		To:           reference.To{Managed: &VirtualNetwork{}, List: &VirtualNetworkList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.virtualNetworkName")
+	}
+	mg.Spec.VirtualNetworkName = rsp.ResolvedValue
+	mg.Spec.VirtualNetworkNameRef = rsp.ResolvedReference
+
+	// Resolve spec.properties.remoteVirtualNetworkId
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.RemoteVirtualNetworkID,
+		Reference:    mg.Spec.RemoteVirtualNetworkIDRef,
+		Selector:     mg.Spec.RemoteVirtualNetworkIDSelector,
+		To:           reference.To{Managed: &VirtualNetwork{}, List: &VirtualNetworkList{}},
+		Extract:      VirtualNetworkID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.properties.remoteVirtualNetworkId")
+	}
+	mg.Spec.RemoteVirtualNetworkID = rsp.ResolvedValue
+	mg.Spec.RemoteVirtualNetworkIDRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this PublicIPAddress
+func (mg *PublicIPAddress) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ResourceGroupName,
+		Reference:    mg.Spec.ResourceGroupNameRef,
+		Selector:     mg.Spec.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.resourceGroupName")
+	}
+	mg.Spec.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.properties.publicIPPrefixId
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.PublicIPPrefixID,
+		Reference:    mg.Spec.PublicIPPrefixIDRef,
+		Selector:     mg.Spec.PublicIPPrefixIDSelector,
+		To:           reference.To{Managed: &PublicIPPrefix{}, List: &PublicIPPrefixList{}},
+		Extract:      PublicIPPrefixID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.properties.publicIPPrefixId")
+	}
+	mg.Spec.PublicIPPrefixID = rsp.ResolvedValue
+	mg.Spec.PublicIPPrefixIDRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this PublicIPPrefix
+func (mg *PublicIPPrefix) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ResourceGroupName,
+		Reference:    mg.Spec.ResourceGroupNameRef,
+		Selector:     mg.Spec.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.resourceGroupName")
+	}
+	mg.Spec.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ResourceGroupNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this NATGateway
+func (mg *NATGateway) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ResourceGroupName,
+		Reference:    mg.Spec.ResourceGroupNameRef,
+		Selector:     mg.Spec.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.resourceGroupName")
+	}
+	mg.Spec.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.properties.publicIPAddressIds
+	mrsp, err := r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.PublicIPAddressIDs,
+		References:    mg.Spec.PublicIPAddressIDRefs,
+		Selector:      mg.Spec.PublicIPAddressIDSelector,
+		To:            reference.To{Managed: &PublicIPAddress{}, List: &PublicIPAddressList{}},
+		Extract:       PublicIPAddressID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.properties.publicIPAddressIds")
+	}
+	mg.Spec.PublicIPAddressIDs = mrsp.ResolvedValues
+	mg.Spec.PublicIPAddressIDRefs = mrsp.ResolvedReferences
+
+	// Resolve spec.properties.publicIPPrefixIds
+	mrsp, err = r.ResolveMultiple(ctx, reference.MultiResolutionRequest{
+		CurrentValues: mg.Spec.PublicIPPrefixIDs,
+		References:    mg.Spec.PublicIPPrefixIDRefs,
+		Selector:      mg.Spec.PublicIPPrefixIDSelector,
+		To:            reference.To{Managed: &PublicIPPrefix{}, List: &PublicIPPrefixList{}},
+		Extract:       PublicIPPrefixID(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.properties.publicIPPrefixIds")
+	}
+	mg.Spec.PublicIPPrefixIDs = mrsp.ResolvedValues
+	mg.Spec.PublicIPPrefixIDRefs = mrsp.ResolvedReferences
+
 	return nil
 }
@@ -53,7 +53,79 @@ var (
 	SubnetGroupVersionKind = SchemeGroupVersion.WithKind(SubnetKind)
 )
 
+// SecurityGroup type metadata.
+var (
+	SecurityGroupKind             = reflect.TypeOf(SecurityGroup{}).Name()
+	SecurityGroupGroupKind        = schema.GroupKind{Group: Group, Kind: SecurityGroupKind}.String()
+	SecurityGroupKindAPIVersion   = SecurityGroupKind + "." + SchemeGroupVersion.String()
+	SecurityGroupGroupVersionKind = SchemeGroupVersion.WithKind(SecurityGroupKind)
+)
+
+// SecurityRule type metadata.
+var (
+	SecurityRuleKind             = reflect.TypeOf(SecurityRule{}).Name()
+	SecurityRuleGroupKind        = schema.GroupKind{Group: Group, Kind: SecurityRuleKind}.String()
+	SecurityRuleKindAPIVersion   = SecurityRuleKind + "." + SchemeGroupVersion.String()
+	SecurityRuleGroupVersionKind = SchemeGroupVersion.WithKind(SecurityRuleKind)
+)
+
+// RouteTable type metadata.
+var (
+	RouteTableKind             = reflect.TypeOf(RouteTable{}).Name()
+	RouteTableGroupKind        = schema.GroupKind{Group: Group, Kind: RouteTableKind}.String()
+	RouteTableKindAPIVersion   = RouteTableKind + "." + SchemeGroupVersion.String()
+	RouteTableGroupVersionKind = SchemeGroupVersion.WithKind(RouteTableKind)
+)
+
+// Route type metadata.
+var (
+	RouteKind             = reflect.TypeOf(Route{}).Name()
+	RouteGroupKind        = schema.GroupKind{Group: Group, Kind: RouteKind}.String()
+	RouteKindAPIVersion   = RouteKind + "." + SchemeGroupVersion.String()
+	RouteGroupVersionKind = SchemeGroupVersion.WithKind(RouteKind)
+)
+
+// VirtualNetworkPeering type metadata.
+var (
+	VirtualNetworkPeeringKind             = reflect.TypeOf(VirtualNetworkPeering{}).Name()
+	VirtualNetworkPeeringGroupKind        = schema.GroupKind{Group: Group, Kind: VirtualNetworkPeeringKind}.String()
+	VirtualNetworkPeeringKindAPIVersion   = VirtualNetworkPeeringKind + "." + SchemeGroupVersion.String()
+	VirtualNetworkPeeringGroupVersionKind = SchemeGroupVersion.WithKind(VirtualNetworkPeeringKind)
+)
+
+// PublicIPAddress type metadata.
+var (
+	PublicIPAddressKind             = reflect.TypeOf(PublicIPAddress{}).Name()
+	PublicIPAddressGroupKind        = schema.GroupKind{Group: Group, Kind: PublicIPAddressKind}.String()
+	PublicIPAddressKindAPIVersion   = PublicIPAddressKind + "." + SchemeGroupVersion.String()
+	PublicIPAddressGroupVersionKind = SchemeGroupVersion.WithKind(PublicIPAddressKind)
+)
+
+// PublicIPPrefix type metadata.
+var (
+	PublicIPPrefixKind             = reflect.TypeOf(PublicIPPrefix{}).Name()
+	PublicIPPrefixGroupKind        = schema.GroupKind{Group: Group, Kind: PublicIPPrefixKind}.String()
+	PublicIPPrefixKindAPIVersion   = PublicIPPrefixKind + "." + SchemeGroupVersion.String()
+	PublicIPPrefixGroupVersionKind = SchemeGroupVersion.WithKind(PublicIPPrefixKind)
+)
+
+// NATGateway type metadata.
+var (
+	NATGatewayKind             = reflect.TypeOf(NATGateway{}).Name()
+	NATGatewayGroupKind        = schema.GroupKind{Group: Group, Kind: NATGatewayKind}.String()
+	NATGatewayKindAPIVersion   = NATGatewayKind + "." + SchemeGroupVersion.String()
+	NATGatewayGroupVersionKind = SchemeGroupVersion.WithKind(NATGatewayKind)
+)
+
 func init() {
 	SchemeBuilder.Register(&VirtualNetwork{}, &VirtualNetworkList{})
 	SchemeBuilder.Register(&Subnet{}, &SubnetList{})
+	SchemeBuilder.Register(&SecurityGroup{}, &SecurityGroupList{})
+	SchemeBuilder.Register(&SecurityRule{}, &SecurityRuleList{})
+	SchemeBuilder.Register(&RouteTable{}, &RouteTableList{})
+	SchemeBuilder.Register(&Route{}, &RouteList{})
+	SchemeBuilder.Register(&VirtualNetworkPeering{}, &VirtualNetworkPeeringList{})
+	SchemeBuilder.Register(&PublicIPAddress{}, &PublicIPAddressList{})
+	SchemeBuilder.Register(&PublicIPPrefix{}, &PublicIPPrefixList{})
+	SchemeBuilder.Register(&NATGateway{}, &NATGatewayList{})
 }
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// RouteTablePropertiesFormat defines properties of a route table.
+type RouteTablePropertiesFormat struct {
+	// DisableBGPRoutePropagation - Whether to disable the propagation of
+	// routes learned by BGP to the subnets associated with the route table.
+	// +optional
+	DisableBGPRoutePropagation bool `json:"disableBgpRoutePropagation,omitempty"`
+}
+
+// A RouteTableSpec defines the desired state of a RouteTable.
+type RouteTableSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// ResourceGroupName - Name of the Route Table's resource group.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the Route Table's resource
+	// group.
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to the the Route
+	// Table's resource group.
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// RouteTablePropertiesFormat - Properties of the route table. Routes are
+	// managed by Route resources.
+	// +optional
+	RouteTablePropertiesFormat `json:"properties,omitempty"`
+
+	// Location - Resource location.
+	Location string `json:"location"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A RouteTableStatus represents the observed state of a RouteTable.
+type RouteTableStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// State of this RouteTable.
+	State string `json:"state,omitempty"`
+
+	// ID of this RouteTable.
+	ID string `json:"id,omitempty"`
+
+	// Etag - A unique read-only string that changes whenever the resource is
+	// updated.
+	Etag string `json:"etag,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A RouteTable is a managed resource that represents an Azure Route Table.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type RouteTable struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteTableSpec   `json:"spec"`
+	Status RouteTableStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteTableList contains a list of RouteTable items
+type RouteTableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RouteTable `json:"items"`
+}
+
+// RoutePropertiesFormat defines properties of a route.
+type RoutePropertiesFormat struct {
+	// AddressPrefix - The destination CIDR to which the route applies.
+	AddressPrefix string `json:"addressPrefix"`
+
+	// NextHopType - The type of Azure hop the packet should be sent to.
+	// +kubebuilder:validation:Enum=VirtualNetworkGateway;VnetLocal;Internet;VirtualAppliance;None
+	NextHopType string `json:"nextHopType"`
+
+	// NextHopIPAddress - The IP address packets should be forwarded to. Only
+	// allowed when NextHopType is VirtualAppliance.
+	// +optional
+	NextHopIPAddress string `json:"nextHopIpAddress,omitempty"`
+}
+
+// A RouteSpec defines the desired state of a Route.
+type RouteSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// RouteTableName - Name of the Route's route table.
+	RouteTableName string `json:"routeTableName,omitempty"`
+
+	// RouteTableNameRef references to a RouteTable to retrieve its name
+	RouteTableNameRef *xpv1.Reference `json:"routeTableNameRef,omitempty"`
+
+	// RouteTableNameSelector selects a reference to a RouteTable to retrieve
+	// its name
+	RouteTableNameSelector *xpv1.Selector `json:"routeTableNameSelector,omitempty"`
+
+	// ResourceGroupName - Name of the Route's resource group.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the Route's resource group.
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Selects a reference to the the Route's
+	// resource group.
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// RoutePropertiesFormat - Properties of the route.
+	RoutePropertiesFormat `json:"properties"`
+}
+
+// A RouteStatus represents the observed state of a Route.
+type RouteStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// State of this Route.
+	State string `json:"state,omitempty"`
+
+	// Etag - A unique string that changes whenever the resource is updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ID of this Route.
+	ID string `json:"id,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Route is a managed resource that represents a user-defined route of an
+// Azure Route Table.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="PREFIX",type="string",JSONPath=".spec.properties.addressPrefix"
+// +kubebuilder:printcolumn:name="NEXT-HOP",type="string",JSONPath=".spec.properties.nextHopType"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type Route struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RouteSpec   `json:"spec"`
+	Status RouteStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RouteList contains a list of Route items
+type RouteList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Route `json:"items"`
+}
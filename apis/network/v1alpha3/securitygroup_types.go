@@ -0,0 +1,245 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// SecurityRulePropertiesFormat defines properties of a security rule.
+type SecurityRulePropertiesFormat struct {
+	// Description - A description for this rule. Restricted to 140 chars.
+	// +kubebuilder:validation:MaxLength=140
+	// +optional
+	Description string `json:"description,omitempty"`
+
+	// Protocol - Network protocol this rule applies to; one of 'Tcp',
+	// 'Udp', 'Icmp', 'Esp' or '*'.
+	// +kubebuilder:validation:Enum=Tcp;Udp;Icmp;Esp;*
+	Protocol string `json:"protocol"`
+
+	// SourcePortRanges - The source ports or ranges, each an integer or a
+	// range between 0 and 65535. An asterisk '*' matches all ports.
+	// +kubebuilder:validation:MinItems=1
+	SourcePortRanges []string `json:"sourcePortRanges"`
+
+	// DestinationPortRanges - The destination ports or ranges, each an
+	// integer or a range between 0 and 65535. An asterisk '*' matches all
+	// ports.
+	// +kubebuilder:validation:MinItems=1
+	DestinationPortRanges []string `json:"destinationPortRanges"`
+
+	// SourceAddressPrefixes - The CIDRs or source IP ranges. An asterisk '*'
+	// matches all source IPs. A single service tag such as
+	// 'VirtualNetwork', 'AzureLoadBalancer' or 'Internet' can also be used.
+	// +optional
+	SourceAddressPrefixes []string `json:"sourceAddressPrefixes,omitempty"`
+
+	// SourceApplicationSecurityGroupIDs - The IDs of the application
+	// security groups specified as source.
+	// +optional
+	SourceApplicationSecurityGroupIDs []string `json:"sourceApplicationSecurityGroupIds,omitempty"`
+
+	// DestinationAddressPrefixes - The CIDRs or destination IP ranges. An
+	// asterisk '*' matches all destination IPs. A single service tag such
+	// as 'VirtualNetwork', 'AzureLoadBalancer' or 'Internet' can also be
+	// used.
+	// +optional
+	DestinationAddressPrefixes []string `json:"destinationAddressPrefixes,omitempty"`
+
+	// DestinationApplicationSecurityGroupIDs - The IDs of the application
+	// security groups specified as destination.
+	// +optional
+	DestinationApplicationSecurityGroupIDs []string `json:"destinationApplicationSecurityGroupIds,omitempty"`
+
+	// Access - Whether network traffic is allowed or denied; either 'Allow'
+	// or 'Deny'.
+	// +kubebuilder:validation:Enum=Allow;Deny
+	Access string `json:"access"`
+
+	// Priority - The priority of the rule, between 100 and 4096. The
+	// priority must be unique for each rule of a security group. The lower
+	// the priority number, the higher the priority of the rule.
+	// +kubebuilder:validation:Minimum=100
+	// +kubebuilder:validation:Maximum=4096
+	Priority int `json:"priority"`
+
+	// Direction - Whether the rule is evaluated on incoming or outgoing
+	// traffic; either 'Inbound' or 'Outbound'.
+	// +kubebuilder:validation:Enum=Inbound;Outbound
+	Direction string `json:"direction"`
+}
+
+// A SecurityGroupRule is a security rule defined inline in a SecurityGroup.
+type SecurityGroupRule struct {
+	// Name of the rule, which must be unique within the SecurityGroup.
+	Name string `json:"name"`
+
+	// SecurityRulePropertiesFormat - Properties of the rule.
+	SecurityRulePropertiesFormat `json:"properties"`
+}
+
+// A SecurityGroupSpec defines the desired state of a SecurityGroup.
+type SecurityGroupSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// ResourceGroupName - Name of the Security Group's resource group.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the Security Group's
+	// resource group.
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to the the Security
+	// Group's resource group.
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SecurityRules - The security rules of the security group. The order
+	// of rules is not significant. Rules are not managed by the
+	// SecurityGroup if this is omitted, in which case they may be managed
+	// by SecurityRule resources. Do not use both.
+	// +optional
+	SecurityRules []SecurityGroupRule `json:"securityRules,omitempty"`
+
+	// Location - Resource location.
+	Location string `json:"location"`
+
+	// Tags - Resource tags.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// A SecurityGroupStatus represents the observed state of a SecurityGroup.
+type SecurityGroupStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// State of this SecurityGroup.
+	State string `json:"state,omitempty"`
+
+	// ID of this SecurityGroup.
+	ID string `json:"id,omitempty"`
+
+	// Etag - A unique read-only string that changes whenever the resource is
+	// updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ResourceGUID - The GUID of this SecurityGroup.
+	ResourceGUID string `json:"resourceGuid,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SecurityGroup is a managed resource that represents an Azure Network
+// Security Group.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="LOCATION",type="string",JSONPath=".spec.location"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type SecurityGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecurityGroupSpec   `json:"spec"`
+	Status SecurityGroupStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecurityGroupList contains a list of SecurityGroup items
+type SecurityGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityGroup `json:"items"`
+}
+
+// A SecurityRuleSpec defines the desired state of a SecurityRule.
+type SecurityRuleSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// SecurityGroupName - Name of the Security Rule's security group.
+	SecurityGroupName string `json:"securityGroupName,omitempty"`
+
+	// SecurityGroupNameRef references to a SecurityGroup to retrieve its
+	// name
+	SecurityGroupNameRef *xpv1.Reference `json:"securityGroupNameRef,omitempty"`
+
+	// SecurityGroupNameSelector selects a reference to a SecurityGroup to
+	// retrieve its name
+	SecurityGroupNameSelector *xpv1.Selector `json:"securityGroupNameSelector,omitempty"`
+
+	// ResourceGroupName - Name of the Security Rule's resource group.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the Security Rule's
+	// resource group.
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Selects a reference to the the Security
+	// Rule's resource group.
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// SecurityRulePropertiesFormat - Properties of the security rule.
+	SecurityRulePropertiesFormat `json:"properties"`
+}
+
+// A SecurityRuleStatus represents the observed state of a SecurityRule.
+type SecurityRuleStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// State of this SecurityRule.
+	State string `json:"state,omitempty"`
+
+	// Etag - A unique string that changes whenever the resource is updated.
+	Etag string `json:"etag,omitempty"`
+
+	// ID of this SecurityRule.
+	ID string `json:"id,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A SecurityRule is a managed resource that represents a rule of an Azure
+// Network Security Group.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.state"
+// +kubebuilder:printcolumn:name="DIRECTION",type="string",JSONPath=".spec.properties.direction"
+// +kubebuilder:printcolumn:name="PRIORITY",type="integer",JSONPath=".spec.properties.priority"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type SecurityRule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   SecurityRuleSpec   `json:"spec"`
+	Status SecurityRuleStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// SecurityRuleList contains a list of SecurityRule items
+type SecurityRuleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []SecurityRule `json:"items"`
+}
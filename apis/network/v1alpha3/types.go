@@ -143,13 +143,93 @@ type ServiceEndpointPropertiesFormat struct {
 	ProvisioningState string `json:"provisioningState,omitempty"`
 }
 
-// SubnetPropertiesFormat defines properties of a Subnet.
+// A Delegation delegates a Subnet to an Azure service.
+type Delegation struct {
+	// Name - The name of the delegation, unique within the subnet.
+	Name string `json:"name"`
+
+	// ServiceName - The name of the service to whom the subnet should be
+	// delegated, e.g. Microsoft.DBforPostgreSQL/flexibleServers.
+	ServiceName string `json:"serviceName"`
+
+	// Actions - The actions permitted to the service upon delegation. Azure
+	// chooses the actions for the service if they are omitted.
+	// +optional
+	Actions []string `json:"actions,omitempty"`
+}
+
+// SubnetPropertiesFormat defines properties of a Subnet. Security group,
+// route table and NAT gateway associations that are omitted are left as they
+// are, so that they may be managed elsewhere.
 type SubnetPropertiesFormat struct {
-	// AddressPrefix - The address prefix for the subnet.
-	AddressPrefix string `json:"addressPrefix"`
+	// AddressPrefix - The address prefix for the subnet. Either AddressPrefix
+	// or AddressPrefixes must be specified.
+	// +optional
+	AddressPrefix string `json:"addressPrefix,omitempty"`
+
+	// AddressPrefixes - List of address prefixes for the subnet.
+	// +optional
+	AddressPrefixes []string `json:"addressPrefixes,omitempty"`
 
 	// ServiceEndpoints - An array of service endpoints.
 	ServiceEndpoints []ServiceEndpointPropertiesFormat `json:"serviceEndpoints,omitempty"`
+
+	// NetworkSecurityGroupID - The ID of the network security group associated
+	// with the subnet.
+	// +optional
+	NetworkSecurityGroupID string `json:"networkSecurityGroupId,omitempty"`
+
+	// NetworkSecurityGroupIDRef - A reference to a SecurityGroup to retrieve
+	// its ID
+	// +optional
+	NetworkSecurityGroupIDRef *xpv1.Reference `json:"networkSecurityGroupIdRef,omitempty"`
+
+	// NetworkSecurityGroupIDSelector - Select a reference to a SecurityGroup
+	// to retrieve its ID
+	// +optional
+	NetworkSecurityGroupIDSelector *xpv1.Selector `json:"networkSecurityGroupIdSelector,omitempty"`
+
+	// RouteTableID - The ID of the route table associated with the subnet.
+	// +optional
+	RouteTableID string `json:"routeTableId,omitempty"`
+
+	// RouteTableIDRef - A reference to a RouteTable to retrieve its ID
+	// +optional
+	RouteTableIDRef *xpv1.Reference `json:"routeTableIdRef,omitempty"`
+
+	// RouteTableIDSelector - Select a reference to a RouteTable to retrieve
+	// its ID
+	// +optional
+	RouteTableIDSelector *xpv1.Selector `json:"routeTableIdSelector,omitempty"`
+
+	// NATGatewayID - The ID of the NAT gateway associated with the subnet.
+	// +optional
+	NATGatewayID string `json:"natGatewayId,omitempty"`
+
+	// NATGatewayIDRef - A reference to a NATGateway to retrieve its ID
+	// +optional
+	NATGatewayIDRef *xpv1.Reference `json:"natGatewayIdRef,omitempty"`
+
+	// NATGatewayIDSelector - Select a reference to a NATGateway to retrieve
+	// its ID
+	// +optional
+	NATGatewayIDSelector *xpv1.Selector `json:"natGatewayIdSelector,omitempty"`
+
+	// Delegations - The services to which the subnet is delegated.
+	// +optional
+	Delegations []Delegation `json:"delegations,omitempty"`
+
+	// PrivateEndpointNetworkPolicies - Whether network policies are applied
+	// to private endpoints in the subnet.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	PrivateEndpointNetworkPolicies string `json:"privateEndpointNetworkPolicies,omitempty"`
+
+	// PrivateLinkServiceNetworkPolicies - Whether network policies are applied
+	// to private link services in the subnet.
+	// +kubebuilder:validation:Enum=Enabled;Disabled
+	// +optional
+	PrivateLinkServiceNetworkPolicies string `json:"privateLinkServiceNetworkPolicies,omitempty"`
 }
 
 // A SubnetSpec defines the desired state of a Subnet.
@@ -0,0 +1,176 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha3
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// VirtualNetworkPeeringOptions control the traffic allowed over a virtual
+// network peering.
+type VirtualNetworkPeeringOptions struct {
+	// AllowVirtualNetworkAccess - Whether the VMs in the local virtual
+	// network can access the VMs in the remote virtual network. Azure allows
+	// access if this is omitted.
+	// +optional
+	AllowVirtualNetworkAccess *bool `json:"allowVirtualNetworkAccess,omitempty"`
+
+	// AllowForwardedTraffic - Whether traffic forwarded by VMs in the remote
+	// virtual network is allowed into the local virtual network.
+	// +optional
+	AllowForwardedTraffic bool `json:"allowForwardedTraffic,omitempty"`
+
+	// AllowGatewayTransit - Whether the remote virtual network can use the
+	// gateways of the local virtual network.
+	// +optional
+	AllowGatewayTransit bool `json:"allowGatewayTransit,omitempty"`
+
+	// UseRemoteGateways - Whether the local virtual network uses the gateways
+	// of the remote virtual network. The remote peering must allow gateway
+	// transit.
+	// +optional
+	UseRemoteGateways bool `json:"useRemoteGateways,omitempty"`
+}
+
+// VirtualNetworkPeeringPropertiesFormat defines properties of a
+// VirtualNetworkPeering.
+type VirtualNetworkPeeringPropertiesFormat struct {
+	// RemoteVirtualNetworkID - The ID of the remote virtual network, which
+	// may be in another subscription.
+	RemoteVirtualNetworkID string `json:"remoteVirtualNetworkId,omitempty"`
+
+	// RemoteVirtualNetworkIDRef - A reference to a VirtualNetwork to
+	// retrieve its ID
+	// +optional
+	RemoteVirtualNetworkIDRef *xpv1.Reference `json:"remoteVirtualNetworkIdRef,omitempty"`
+
+	// RemoteVirtualNetworkIDSelector - Select a reference to a
+	// VirtualNetwork to retrieve its ID
+	// +optional
+	RemoteVirtualNetworkIDSelector *xpv1.Selector `json:"remoteVirtualNetworkIdSelector,omitempty"`
+
+	VirtualNetworkPeeringOptions `json:",inline"`
+}
+
+// A ReverseVirtualNetworkPeering is the peering from the remote virtual
+// network back to the local virtual network.
+type ReverseVirtualNetworkPeering struct {
+	// Name of the peering in the remote virtual network. Defaults to the
+	// external name of the VirtualNetworkPeering.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	VirtualNetworkPeeringOptions `json:",inline"`
+}
+
+// A VirtualNetworkPeeringSpec defines the desired state of a
+// VirtualNetworkPeering.
+type VirtualNetworkPeeringSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+
+	// VirtualNetworkName - Name of the Virtual Network Peering's local
+	// virtual network.
+	VirtualNetworkName string `json:"virtualNetworkName,omitempty"`
+
+	// VirtualNetworkNameRef references to a VirtualNetwork to retrieve its
+	// name
+	VirtualNetworkNameRef *xpv1.Reference `json:"virtualNetworkNameRef,omitempty"`
+
+	// VirtualNetworkNameSelector selects a reference to a VirtualNetwork to
+	// retrieve its name
+	VirtualNetworkNameSelector *xpv1.Selector `json:"virtualNetworkNameSelector,omitempty"`
+
+	// ResourceGroupName - Name of the local virtual network's resource group.
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to the the local virtual network's
+	// resource group.
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Selects a reference to the the local
+	// virtual network's resource group.
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// VirtualNetworkPeeringPropertiesFormat - Properties of the virtual
+	// network peering.
+	VirtualNetworkPeeringPropertiesFormat `json:"properties"`
+
+	// ReversePeering - The peering from the remote virtual network back to
+	// the local virtual network. It is reconciled along with this peering if
+	// specified, otherwise it must be created separately for the peering to
+	// become connected.
+	// +optional
+	ReversePeering *ReverseVirtualNetworkPeering `json:"reversePeering,omitempty"`
+}
+
+// A VirtualNetworkPeeringStatus represents the observed state of a
+// VirtualNetworkPeering.
+type VirtualNetworkPeeringStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+
+	// State of this VirtualNetworkPeering.
+	State string `json:"state,omitempty"`
+
+	// PeeringState - The status of the peering, i.e. Initiated, Connected or
+	// Disconnected.
+	PeeringState string `json:"peeringState,omitempty"`
+
+	// ReversePeeringState - The status of the reverse peering, if it is
+	// reconciled along with this peering.
+	ReversePeeringState string `json:"reversePeeringState,omitempty"`
+
+	// ReversePeeringID - The ID of the reverse peering, if it is reconciled
+	// along with this peering. The reverse peering is deleted when it is no
+	// longer specified.
+	ReversePeeringID string `json:"reversePeeringId,omitempty"`
+
+	// ID of this VirtualNetworkPeering.
+	ID string `json:"id,omitempty"`
+
+	// Etag - A unique read-only string that changes whenever the resource is
+	// updated.
+	Etag string `json:"etag,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A VirtualNetworkPeering is a managed resource that represents a peering
+// between two Azure Virtual Networks.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="PEERING",type="string",JSONPath=".status.peeringState"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type VirtualNetworkPeering struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualNetworkPeeringSpec   `json:"spec"`
+	Status VirtualNetworkPeeringStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualNetworkPeeringList contains a list of VirtualNetworkPeering items
+type VirtualNetworkPeeringList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualNetworkPeering `json:"items"`
+}
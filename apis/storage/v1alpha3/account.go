@@ -621,7 +621,8 @@ func newStorageAccountStatusProperties(s *storage.AccountProperties) *StorageAcc
 }
 
 // A StorageAccountSpec defines the desired state of an Azure Blob Storage
-// account.
+// account. It was the spec of the v1alpha3 Account, which has been superseded
+// by the v1beta1 Account.
 type StorageAccountSpec struct {
 	// Identity - The identity of the resource.
 	// +optional
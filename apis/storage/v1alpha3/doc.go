@@ -14,8 +14,10 @@ See the License for the specific language governing permissions and
 limitations under the License.
 */
 
-// Package v1alpha3 contains managed resources for Azure storage services such
-// as containers and accounts.
+// Package v1alpha3 contains deprecated managed resources for Azure storage
+// services such as containers and accounts. They are served so that existing
+// resources and manifests keep working, but are stored as, and reconciled as,
+// their v1beta1 equivalents.
 // +kubebuilder:object:generate=true
 // +groupName=storage.azure.crossplane.io
 // +versionName=v1alpha3
@@ -49,6 +49,10 @@ type AccountStatus struct {
 
 // An Account is a managed resource that represents an Azure Blob Service
 // Account.
+//
+// Deprecated: Use the v1beta1 Account. Accounts are stored as v1beta1
+// Accounts; an Account that is created or updated as a v1alpha3 Account is
+// migrated to the v1beta1 forProvider fields when it is next reconciled.
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="RESOURCE_GROUP",type="string",JSONPath=".spec.resourceGroupName"
 // +kubebuilder:printcolumn:name="ACCOUNT_NAME",type="string",JSONPath=".spec.storageAccountName"
@@ -96,7 +100,12 @@ type ContainerStatus struct {
 // +kubebuilder:object:root=true
 
 // A Container is a managed resource that represents an Azure Blob Storage
-// Container.
+// Container. The providerConfigRef of a v1alpha3 Container names the Account
+// in which it exists.
+//
+// Deprecated: Use the v1beta1 Container. Containers are stored as v1beta1
+// Containers; a Container that is created or updated as a v1alpha3 Container
+// is migrated to the v1beta1 forProvider fields when it is next reconciled.
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
 // +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
 // +kubebuilder:printcolumn:name="STORAGE_ACCOUNT",type="string",JSONPath=".spec.accountRef.name"
@@ -0,0 +1,470 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+)
+
+// An SKU of an Azure Storage Account.
+type SKU struct {
+	// Name of the SKU. Possible values include: 'Standard_LRS',
+	// 'Standard_GRS', 'Standard_RAGRS', 'Standard_ZRS', 'Premium_LRS',
+	// 'Premium_ZRS', 'Standard_GZRS', 'Standard_RAGZRS'
+	// +kubebuilder:validation:Enum=Standard_LRS;Standard_GRS;Standard_RAGRS;Standard_ZRS;Premium_LRS;Premium_ZRS;Standard_GZRS;Standard_RAGZRS
+	Name string `json:"name"`
+}
+
+// An Identity of an Azure Storage Account.
+type Identity struct {
+	// Type of the identity. The only supported type is 'SystemAssigned'.
+	// +kubebuilder:validation:Enum=SystemAssigned
+	Type string `json:"type"`
+}
+
+// A CustomDomain assigned to an Azure Storage Account.
+type CustomDomain struct {
+	// Name of the custom domain. Name is the CNAME source. Use an empty
+	// string to clear the existing custom domain.
+	Name string `json:"name"`
+
+	// UseSubDomainName indicates whether indirect CNAME validation is
+	// enabled.
+	// +optional
+	UseSubDomainName *bool `json:"useSubDomainName,omitempty"`
+}
+
+// EncryptionServices are the storage services whose data is encrypted.
+type EncryptionServices struct {
+	// Blob encryption is enabled.
+	// +optional
+	Blob *bool `json:"blob,omitempty"`
+
+	// File encryption is enabled.
+	// +optional
+	File *bool `json:"file,omitempty"`
+}
+
+// KeyVaultProperties identify the Key Vault key used to encrypt an Azure
+// Storage Account.
+type KeyVaultProperties struct {
+	// KeyName of the Key Vault key.
+	// +optional
+	KeyName *string `json:"keyName,omitempty"`
+
+	// KeyVersion of the Key Vault key.
+	// +optional
+	KeyVersion *string `json:"keyVersion,omitempty"`
+
+	// KeyVaultURI of the Key Vault.
+	// +optional
+	KeyVaultURI *string `json:"keyVaultUri,omitempty"`
+}
+
+// Encryption settings of an Azure Storage Account.
+type Encryption struct {
+	// Services whose data is encrypted.
+	// +optional
+	Services *EncryptionServices `json:"services,omitempty"`
+
+	// KeySource of the encryption keys. Possible values include:
+	// 'Microsoft.Storage', 'Microsoft.Keyvault'
+	// +kubebuilder:validation:Enum=Microsoft.Storage;Microsoft.Keyvault
+	// +optional
+	KeySource *string `json:"keySource,omitempty"`
+
+	// KeyVaultProperties identify the Key Vault key used for encryption
+	// when the KeySource is Microsoft.Keyvault.
+	// +optional
+	KeyVaultProperties *KeyVaultProperties `json:"keyVaultProperties,omitempty"`
+
+	// KeyVaultKeyID is the versionless identifier of a Key Vault key with
+	// which to encrypt the Account, for example
+	// https://myvault.vault.azure.net/keys/mykey. The Account is given a
+	// system-assigned identity, which is granted access to the key by an
+	// access policy of its vault before the KeySource is switched to
+	// Microsoft.Keyvault. The latest version of the key is always used. The
+	// KeySource and KeyVaultProperties are ignored when this is set.
+	// +optional
+	KeyVaultKeyID *string `json:"keyVaultKeyId,omitempty"`
+
+	// KeyVaultKeyIDRef references a KeyVaultKey to retrieve its
+	// KeyVaultKeyID.
+	// +optional
+	KeyVaultKeyIDRef *xpv1.Reference `json:"keyVaultKeyIdRef,omitempty"`
+
+	// KeyVaultKeyIDSelector selects a reference to a KeyVaultKey to retrieve
+	// its KeyVaultKeyID.
+	// +optional
+	KeyVaultKeyIDSelector *xpv1.Selector `json:"keyVaultKeyIdSelector,omitempty"`
+}
+
+// An EncryptionObservation represents the observed encryption of an Account.
+type EncryptionObservation struct {
+	// KeySource of the encryption keys.
+	KeySource string `json:"keySource,omitempty"`
+
+	// KeyVersion of the Key Vault key in use, if any.
+	KeyVersion string `json:"keyVersion,omitempty"`
+
+	// LastKeyRotationTime is the time at which the Key Vault key in use
+	// was last rotated.
+	LastKeyRotationTime *metav1.Time `json:"lastKeyRotationTime,omitempty"`
+}
+
+// A VirtualNetworkRule allows traffic from a virtual network subnet.
+type VirtualNetworkRule struct {
+	// VirtualNetworkResourceID is the resource ID of a subnet, for example
+	// /subscriptions/{subscriptionId}/resourceGroups/{groupName}/providers/Microsoft.Network/virtualNetworks/{vnetName}/subnets/{subnetName}.
+	// The subnet must have the Microsoft.Storage service endpoint enabled.
+	// +optional
+	VirtualNetworkResourceID string `json:"id,omitempty"`
+
+	// VirtualNetworkResourceIDRef references a Subnet to retrieve its ID.
+	// +optional
+	VirtualNetworkResourceIDRef *xpv1.Reference `json:"idRef,omitempty"`
+
+	// VirtualNetworkResourceIDSelector selects a reference to a Subnet to
+	// retrieve its ID.
+	// +optional
+	VirtualNetworkResourceIDSelector *xpv1.Selector `json:"idSelector,omitempty"`
+
+	// Action of the rule. Possible values include: 'Allow'
+	// +kubebuilder:validation:Enum=Allow
+	// +optional
+	Action *string `json:"action,omitempty"`
+}
+
+// An IPRule allows traffic from an IP address or range.
+type IPRule struct {
+	// IPAddressOrRange in CIDR format. Only IPv4 addresses are allowed.
+	IPAddressOrRange string `json:"value"`
+
+	// Action of the rule. Possible values include: 'Allow'
+	// +kubebuilder:validation:Enum=Allow
+	// +optional
+	Action *string `json:"action,omitempty"`
+}
+
+// A NetworkRuleSet controls the network access to an Azure Storage Account.
+type NetworkRuleSet struct {
+	// Bypass specifies whether traffic is bypassed for Logging, Metrics or
+	// AzureServices. Possible values are any combination of Logging, Metrics
+	// and AzureServices (for example, "Logging, Metrics"), or None to bypass
+	// none of those traffics.
+	// +optional
+	Bypass *string `json:"bypass,omitempty"`
+
+	// VirtualNetworkRules that allow traffic from virtual network subnets.
+	// +optional
+	VirtualNetworkRules []VirtualNetworkRule `json:"virtualNetworkRules,omitempty"`
+
+	// IPRules that allow traffic from IP addresses or ranges.
+	// +optional
+	IPRules []IPRule `json:"ipRules,omitempty"`
+
+	// DefaultAction when no other rule matches. Possible values include:
+	// 'Allow', 'Deny'
+	// +kubebuilder:validation:Enum=Allow;Deny
+	DefaultAction string `json:"defaultAction"`
+}
+
+// A StaticWebsite is hosted from the $web blob container of an Azure Storage
+// Account.
+type StaticWebsite struct {
+	// Enabled indicates whether the Account hosts a static website.
+	Enabled bool `json:"enabled"`
+
+	// IndexDocument is the default name of the index page under each
+	// directory, for example index.html.
+	// +optional
+	IndexDocument *string `json:"indexDocument,omitempty"`
+
+	// ErrorDocument404Path is the absolute path of the page returned when a
+	// requested page does not exist, for example error/404.html.
+	// +optional
+	ErrorDocument404Path *string `json:"errorDocument404Path,omitempty"`
+}
+
+// AccountParameters define the desired state of an Azure Storage Account.
+// https://docs.microsoft.com/en-us/rest/api/storagerp/storageaccounts/create
+type AccountParameters struct {
+	// ResourceGroupName in which to create this Account.
+	// +immutable
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef to fetch resource group name.
+	// +immutable
+	// +optional
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector to select a reference to a resource group.
+	// +immutable
+	// +optional
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// Location in which to create this Account.
+	// +immutable
+	Location string `json:"location"`
+
+	// Kind of this Account. Possible values include: 'Storage',
+	// 'StorageV2', 'BlobStorage', 'FileStorage', 'BlockBlobStorage'
+	// +kubebuilder:validation:Enum=Storage;StorageV2;BlobStorage;FileStorage;BlockBlobStorage
+	Kind string `json:"kind"`
+
+	// SKU of this Account.
+	SKU SKU `json:"sku"`
+
+	// Identity of this Account.
+	// +optional
+	Identity *Identity `json:"identity,omitempty"`
+
+	// AccessTier used for billing. Required for Accounts where kind is
+	// BlobStorage. Possible values include: 'Hot', 'Cool'
+	// +kubebuilder:validation:Enum=Hot;Cool
+	// +optional
+	AccessTier *string `json:"accessTier,omitempty"`
+
+	// CustomDomain assigned to this Account. Only one custom domain is
+	// supported per Account.
+	// +optional
+	CustomDomain *CustomDomain `json:"customDomain,omitempty"`
+
+	// EnableHTTPSTrafficOnly allows only HTTPS traffic to this Account.
+	// +optional
+	EnableHTTPSTrafficOnly *bool `json:"supportsHttpsTrafficOnly,omitempty"`
+
+	// IsHNSEnabled enables the hierarchical namespace of this Account, which
+	// is required to create Data Lake Storage Gen2 Filesystems and Paths.
+	// +immutable
+	// +optional
+	IsHNSEnabled *bool `json:"isHnsEnabled,omitempty"`
+
+	// Encryption settings of this Account.
+	// +optional
+	Encryption *Encryption `json:"encryption,omitempty"`
+
+	// NetworkRuleSet controls the network access to this Account.
+	// +optional
+	NetworkRuleSet *NetworkRuleSet `json:"networkAcls,omitempty"`
+
+	// StaticWebsite configures this Account to host a static website from
+	// its $web blob container.
+	// +optional
+	StaticWebsite *StaticWebsite `json:"staticWebsite,omitempty"`
+
+	// Tags of this Account. A maximum of 15 tags can be provided. Each tag
+	// must have a key no longer than 128 characters and a value no longer
+	// than 256 characters.
+	// +optional
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// Names of the access keys of an Account.
+const (
+	KeyNamePrimary   = "key1"
+	KeyNameSecondary = "key2"
+)
+
+// KeyRotation configures scheduled rotation of the access keys of an
+// Account. Each rotation regenerates the inactive key and publishes it to the
+// connection secret, then regenerates the previously active key once the
+// grace period has passed. The keys alternate between rotations. Service
+// shared access signatures that were signed with a key are invalidated when
+// it is regenerated.
+type KeyRotation struct {
+	// Interval between rotations, for example 720h. Must be positive.
+	Interval metav1.Duration `json:"interval"`
+
+	// GracePeriod for which the previously active key remains valid after a
+	// rotation, so that clients may pick up the newly published key. Must be
+	// positive and shorter than the interval, otherwise keys are not rotated.
+	// Defaults to one hour.
+	// +optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
+// KeyRotationStatus represents the state of the access key rotation of an
+// Account.
+type KeyRotationStatus struct {
+	// ActiveKeyName is the name of the access key that is published to the
+	// connection secret; either key1 or key2.
+	ActiveKeyName string `json:"activeKeyName,omitempty"`
+
+	// LastRotationTime is the time at which the active key was last
+	// switched.
+	LastRotationTime *metav1.Time `json:"lastRotationTime,omitempty"`
+
+	// RetiringKeyName is the name of the previously active access key, which
+	// will be regenerated once the grace period has passed.
+	RetiringKeyName string `json:"retiringKeyName,omitempty"`
+}
+
+// An AccountSpec defines the desired state of an Account.
+type AccountSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       AccountParameters `json:"forProvider"`
+
+	// SharedAccessSignature configures a shared access signature (SAS) to
+	// publish to the connection secret of this Account instead of its
+	// access key.
+	// +optional
+	SharedAccessSignature *AccountSharedAccessSignature `json:"sharedAccessSignature,omitempty"`
+
+	// KeyRotation configures scheduled rotation of the access keys of this
+	// Account.
+	// +optional
+	KeyRotation *KeyRotation `json:"keyRotation,omitempty"`
+
+	// DeprecatedResourceGroupName is the resourceGroupName of a v1alpha3
+	// Account. It is migrated to forProvider and cleared when the Account
+	// is reconciled.
+	// Deprecated: Use forProvider.resourceGroupName.
+	// +optional
+	DeprecatedResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// DeprecatedStorageAccountSpec is the storageAccountSpec of a v1alpha3
+	// Account. It is migrated to forProvider and cleared when the Account
+	// is reconciled.
+	// Deprecated: Use forProvider.
+	// +optional
+	DeprecatedStorageAccountSpec *v1alpha3.StorageAccountSpec `json:"storageAccountSpec,omitempty"`
+}
+
+// Endpoints of an Azure Storage Account.
+type Endpoints struct {
+	// Blob endpoint.
+	Blob string `json:"blob,omitempty"`
+
+	// Queue endpoint.
+	Queue string `json:"queue,omitempty"`
+
+	// Table endpoint.
+	Table string `json:"table,omitempty"`
+
+	// File endpoint.
+	File string `json:"file,omitempty"`
+
+	// Web endpoint, from which the static website is served.
+	Web string `json:"web,omitempty"`
+}
+
+// An IdentityObservation represents the observed identity of an Account.
+type IdentityObservation struct {
+	// PrincipalID of the identity.
+	PrincipalID string `json:"principalId,omitempty"`
+
+	// TenantID of the identity.
+	TenantID string `json:"tenantId,omitempty"`
+}
+
+// An AccountObservation represents the observed state of an Account.
+type AccountObservation struct {
+	// ID of this Account.
+	ID string `json:"id,omitempty"`
+
+	// Name of this Account.
+	Name string `json:"name,omitempty"`
+
+	// Type of this Account.
+	Type string `json:"type,omitempty"`
+
+	// ProvisioningState of this Account. Possible values include:
+	// 'Creating', 'ResolvingDNS', 'Succeeded'
+	ProvisioningState string `json:"provisioningState,omitempty"`
+
+	// Identity of this Account.
+	Identity *IdentityObservation `json:"identity,omitempty"`
+
+	// Encryption of this Account.
+	Encryption *EncryptionObservation `json:"encryption,omitempty"`
+
+	// CreationTime of this Account.
+	CreationTime *metav1.Time `json:"creationTime,omitempty"`
+
+	// LastGeoFailoverTime is the time of the most recent failover to the
+	// secondary location. Only available if the SKU is geo-redundant.
+	LastGeoFailoverTime *metav1.Time `json:"lastGeoFailoverTime,omitempty"`
+
+	// PrimaryEndpoints of this Account.
+	PrimaryEndpoints *Endpoints `json:"primaryEndpoints,omitempty"`
+
+	// PrimaryLocation of this Account.
+	PrimaryLocation string `json:"primaryLocation,omitempty"`
+
+	// StatusOfPrimary indicates whether the primary location is available.
+	StatusOfPrimary string `json:"statusOfPrimary,omitempty"`
+
+	// SecondaryEndpoints of this Account. Only available if the SKU is
+	// read-access geo-redundant.
+	SecondaryEndpoints *Endpoints `json:"secondaryEndpoints,omitempty"`
+
+	// SecondaryLocation of this Account. Only available if the SKU is
+	// geo-redundant.
+	SecondaryLocation string `json:"secondaryLocation,omitempty"`
+
+	// StatusOfSecondary indicates whether the secondary location is
+	// available. Only available if the SKU is geo-redundant.
+	StatusOfSecondary string `json:"statusOfSecondary,omitempty"`
+}
+
+// An AccountStatus represents the observed state of an Account.
+type AccountStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          AccountObservation `json:"atProvider,omitempty"`
+
+	// SharedAccessSignature most recently published to the connection
+	// secret of this Account.
+	SharedAccessSignature *SharedAccessSignatureStatus `json:"sharedAccessSignature,omitempty"`
+
+	// KeyRotation represents the state of the access key rotation of this
+	// Account.
+	KeyRotation *KeyRotationStatus `json:"keyRotation,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An Account is a managed resource that represents an Azure Storage Account.
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.atProvider.provisioningState"
+// +kubebuilder:printcolumn:name="KIND",type="string",JSONPath=".spec.forProvider.kind"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type Account struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   AccountSpec   `json:"spec"`
+	Status AccountStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// AccountList contains a list of Account.
+type AccountList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Account `json:"items"`
+}
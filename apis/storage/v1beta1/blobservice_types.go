@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A DeleteRetentionPolicy retains deleted data for a number of days, during
+// which it may be restored.
+type DeleteRetentionPolicy struct {
+	// Enabled is true if deleted data is retained.
+	Enabled bool `json:"enabled"`
+
+	// Days for which deleted data is retained.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=365
+	// +optional
+	Days *int `json:"days,omitempty"`
+}
+
+// A ChangeFeed records changes to the blobs of an Account.
+type ChangeFeed struct {
+	// Enabled is true if the change feed is enabled.
+	Enabled bool `json:"enabled"`
+}
+
+// A LastAccessTimeTrackingPolicy records when each blob was last read or
+// written, which lifecycle management rules may act upon.
+type LastAccessTimeTrackingPolicy struct {
+	// Enabled is true if the last access time of blobs is tracked.
+	Enabled bool `json:"enabled"`
+}
+
+// A CORSRule allows requests from other origins.
+type CORSRule struct {
+	// AllowedOrigins that may make cross-origin requests, or '*' to allow
+	// all origins.
+	AllowedOrigins []string `json:"allowedOrigins"`
+
+	// AllowedMethods that origins may use.
+	AllowedMethods []string `json:"allowedMethods"`
+
+	// MaxAgeInSeconds that a client may cache the preflight response.
+	// +kubebuilder:validation:Minimum=0
+	MaxAgeInSeconds int `json:"maxAgeInSeconds"`
+
+	// ExposedHeaders that may be exposed to clients.
+	ExposedHeaders []string `json:"exposedHeaders"`
+
+	// AllowedHeaders that may be part of cross-origin requests.
+	AllowedHeaders []string `json:"allowedHeaders"`
+}
+
+// BlobServiceParameters define the desired state of the blob service of an
+// Azure Storage Account.
+type BlobServiceParameters struct {
+	// ResourceGroupName of the resource group of the storage Account.
+	// +immutable
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to a ResourceGroup object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to a ResourceGroup
+	// object to retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// AccountName of the storage Account whose blob service is configured.
+	// +immutable
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// AccountNameRef - A reference to an Account object to retrieve its
+	// name
+	// +immutable
+	// +optional
+	AccountNameRef *xpv1.Reference `json:"accountNameRef,omitempty"`
+
+	// AccountNameSelector - Select a reference to an Account object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	AccountNameSelector *xpv1.Selector `json:"accountNameSelector,omitempty"`
+
+	// DeleteRetentionPolicy retains deleted blobs.
+	// +optional
+	DeleteRetentionPolicy *DeleteRetentionPolicy `json:"deleteRetentionPolicy,omitempty"`
+
+	// ContainerDeleteRetentionPolicy retains deleted containers.
+	// +optional
+	ContainerDeleteRetentionPolicy *DeleteRetentionPolicy `json:"containerDeleteRetentionPolicy,omitempty"`
+
+	// IsVersioningEnabled is true if blob versioning is enabled.
+	// +optional
+	IsVersioningEnabled *bool `json:"isVersioningEnabled,omitempty"`
+
+	// ChangeFeed of the blob service.
+	// +optional
+	ChangeFeed *ChangeFeed `json:"changeFeed,omitempty"`
+
+	// LastAccessTimeTrackingPolicy of the blob service.
+	// +optional
+	LastAccessTimeTrackingPolicy *LastAccessTimeTrackingPolicy `json:"lastAccessTimeTrackingPolicy,omitempty"`
+
+	// CORSRules of the blob service. Up to five rules may be specified.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	CORSRules []CORSRule `json:"corsRules,omitempty"`
+}
+
+// A BlobServiceSpec defines the desired state of a BlobService.
+type BlobServiceSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       BlobServiceParameters `json:"forProvider"`
+}
+
+// A BlobServiceObservation represents the observed state of a BlobService.
+type BlobServiceObservation struct {
+	// ID of this BlobService.
+	ID string `json:"id,omitempty"`
+}
+
+// A BlobServiceStatus represents the observed state of a BlobService.
+type BlobServiceStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          BlobServiceObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A BlobService is a managed resource that represents the blob service
+// properties of an Azure Storage Account. The blob service exists as long as
+// its Account does; deleting a BlobService leaves its properties unchanged.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STORAGE_ACCOUNT",type="string",JSONPath=".spec.forProvider.accountName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type BlobService struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   BlobServiceSpec   `json:"spec"`
+	Status BlobServiceStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// BlobServiceList contains a list of BlobService.
+type BlobServiceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []BlobService `json:"items"`
+}
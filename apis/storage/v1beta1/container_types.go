@@ -0,0 +1,266 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// Public access levels of a Container.
+const (
+	// PublicAccessBlob allows anonymous read access to blobs, but not to
+	// container data.
+	PublicAccessBlob = "blob"
+
+	// PublicAccessContainer allows anonymous read access to blobs and
+	// container data, including listing blobs.
+	PublicAccessContainer = "container"
+)
+
+// Modes of authorizing requests to the blob service of a storage Account.
+const (
+	// AuthorizationModeSharedKey authorizes requests with the first access
+	// key of the storage Account.
+	AuthorizationModeSharedKey = "SharedKey"
+
+	// AuthorizationModeAzureAD authorizes requests with an Azure AD token
+	// issued to the service principal of the ProviderConfig, which must be
+	// granted a data plane role such as Storage Blob Data Contributor. It
+	// works with storage Accounts that do not allow shared key access.
+	AuthorizationModeAzureAD = "AzureAD"
+)
+
+// States of an ImmutabilityPolicy.
+const (
+	ImmutabilityPolicyStateUnlocked = "Unlocked"
+	ImmutabilityPolicyStateLocked   = "Locked"
+)
+
+// An ImmutabilityPolicy prevents the blobs of a Container from being modified
+// or deleted until they are older than its immutability period. Once locked,
+// the policy cannot be removed, unlocked, or shortened, and its immutability
+// period can only be extended.
+type ImmutabilityPolicy struct {
+	// ImmutabilityPeriodSinceCreationInDays is the number of days for which
+	// a blob is immutable after it was created.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=146000
+	ImmutabilityPeriodSinceCreationInDays int `json:"immutabilityPeriodSinceCreationInDays"`
+
+	// AllowProtectedAppendWrites allows new blocks to be written to append
+	// blobs while they are immutable. It cannot be changed once the policy
+	// is locked.
+	// +optional
+	AllowProtectedAppendWrites bool `json:"allowProtectedAppendWrites,omitempty"`
+
+	// Locked indicates whether the policy should be locked. Locking a
+	// policy cannot be undone.
+	// +optional
+	Locked bool `json:"locked,omitempty"`
+}
+
+// A LegalHold prevents the blobs of a Container from being modified or
+// deleted while it has at least one tag.
+type LegalHold struct {
+	// Tags of the legal hold, each of 3 to 23 alphanumeric characters. Tags
+	// set outside of this Container are cleared. The Container has no legal
+	// hold if this is empty.
+	// +optional
+	Tags []string `json:"tags,omitempty"`
+}
+
+// ContainerParameters define the desired state of an Azure Blob Storage
+// Container.
+type ContainerParameters struct {
+	// ResourceGroupName of the resource group of the storage Account in
+	// which the Container exists.
+	// +immutable
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to a ResourceGroup object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to a ResourceGroup
+	// object to retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// AccountName of the storage Account in which the Container exists.
+	// +immutable
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// AccountNameRef - A reference to an Account object to retrieve its
+	// name
+	// +immutable
+	// +optional
+	AccountNameRef *xpv1.Reference `json:"accountNameRef,omitempty"`
+
+	// AccountNameSelector - Select a reference to an Account object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	AccountNameSelector *xpv1.Selector `json:"accountNameSelector,omitempty"`
+
+	// Metadata of the Container. Azure stores metadata keys in lower case,
+	// so keys are compared case-insensitively.
+	// +optional
+	Metadata map[string]string `json:"metadata,omitempty"`
+
+	// PublicAccessType of the Container; either 'blob' or 'container'.
+	// The Container is private if this is omitted.
+	// +kubebuilder:validation:Enum=blob;container
+	// +optional
+	PublicAccessType string `json:"publicAccessType,omitempty"`
+
+	// AuthorizationMode used to manage the Container through the blob
+	// service of its storage Account; either SharedKey or AzureAD. Defaults
+	// to SharedKey. Use AzureAD, and a UserDelegation shared access
+	// signature, if the Account does not allow shared key access.
+	// +kubebuilder:validation:Enum=SharedKey;AzureAD
+	// +optional
+	AuthorizationMode *string `json:"authorizationMode,omitempty"`
+
+	// ImmutabilityPolicy of the Container. The policy is not managed if
+	// this is omitted.
+	// +optional
+	ImmutabilityPolicy *ImmutabilityPolicy `json:"immutabilityPolicy,omitempty"`
+
+	// LegalHold of the Container. Legal holds are not managed if this is
+	// omitted.
+	// +optional
+	LegalHold *LegalHold `json:"legalHold,omitempty"`
+}
+
+// A ContainerSpec defines the desired state of a Container.
+type ContainerSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ContainerParameters `json:"forProvider"`
+
+	// SharedAccessSignature configures a shared access signature (SAS) to
+	// publish to the connection secret of this Container.
+	// +optional
+	SharedAccessSignature *ContainerSharedAccessSignature `json:"sharedAccessSignature,omitempty"`
+
+	// DeprecatedMetadata is the metadata of a v1alpha3 Container. It is
+	// migrated to forProvider and cleared when the Container is reconciled.
+	// Deprecated: Use forProvider.metadata.
+	// +optional
+	DeprecatedMetadata map[string]string `json:"metadata,omitempty"`
+
+	// DeprecatedPublicAccessType is the publicAccessType of a v1alpha3
+	// Container. It is migrated to forProvider and cleared when the
+	// Container is reconciled.
+	// Deprecated: Use forProvider.publicAccessType.
+	// +optional
+	DeprecatedPublicAccessType string `json:"publicAccessType,omitempty"`
+}
+
+// An ImmutabilityPolicyObservation represents the observed state of the
+// immutability policy of a Container.
+type ImmutabilityPolicyObservation struct {
+	// ImmutabilityPeriodSinceCreationInDays is the number of days for which
+	// a blob is immutable after it was created.
+	ImmutabilityPeriodSinceCreationInDays int `json:"immutabilityPeriodSinceCreationInDays,omitempty"`
+
+	// AllowProtectedAppendWrites indicates whether new blocks may be written
+	// to immutable append blobs.
+	AllowProtectedAppendWrites bool `json:"allowProtectedAppendWrites,omitempty"`
+
+	// State of the policy; either Unlocked or Locked.
+	State string `json:"state,omitempty"`
+
+	// ETag of the policy.
+	ETag string `json:"etag,omitempty"`
+}
+
+// A ContainerObservation represents the observed state of a Container.
+type ContainerObservation struct {
+	// ETag of the Container.
+	ETag string `json:"etag,omitempty"`
+
+	// LastModified is the time the Container or its properties were last
+	// modified.
+	LastModified *metav1.Time `json:"lastModified,omitempty"`
+
+	// LeaseState of the Container. Possible values include: 'available',
+	// 'leased', 'expired', 'breaking', 'broken'
+	LeaseState string `json:"leaseState,omitempty"`
+
+	// LeaseStatus of the Container. Possible values include: 'locked',
+	// 'unlocked'
+	LeaseStatus string `json:"leaseStatus,omitempty"`
+
+	// HasImmutabilityPolicy is true if the Container has an immutability
+	// policy.
+	HasImmutabilityPolicy bool `json:"hasImmutabilityPolicy,omitempty"`
+
+	// HasLegalHold is true if the Container has at least one legal hold.
+	HasLegalHold bool `json:"hasLegalHold,omitempty"`
+
+	// ImmutabilityPolicy of the Container.
+	ImmutabilityPolicy *ImmutabilityPolicyObservation `json:"immutabilityPolicy,omitempty"`
+
+	// LegalHoldTags of the Container.
+	LegalHoldTags []string `json:"legalHoldTags,omitempty"`
+}
+
+// A ContainerStatus represents the observed state of a Container.
+type ContainerStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ContainerObservation `json:"atProvider,omitempty"`
+
+	// SharedAccessSignature most recently published to the connection
+	// secret of this Container.
+	SharedAccessSignature *SharedAccessSignatureStatus `json:"sharedAccessSignature,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Container is a managed resource that represents an Azure Blob Storage
+// Container.
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STORAGE_ACCOUNT",type="string",JSONPath=".spec.forProvider.accountName"
+// +kubebuilder:printcolumn:name="PUBLIC_ACCESS_TYPE",type="string",JSONPath=".spec.forProvider.publicAccessType"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type Container struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ContainerSpec   `json:"spec"`
+	Status ContainerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ContainerList contains a list of Container.
+type ContainerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Container `json:"items"`
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+)
+
+// ConvertStorageAccountSpec converts the resource group name and
+// StorageAccountSpec of a v1alpha3 Account to the AccountParameters of a
+// v1beta1 Account. Fields of the StorageAccountSpec that were only ever
+// observed, such as the principal ID of the identity or the capabilities of
+// the SKU, are not converted.
+func ConvertStorageAccountSpec(resourceGroupName string, s *v1alpha3.StorageAccountSpec) AccountParameters {
+	p := AccountParameters{ResourceGroupName: resourceGroupName}
+	if s == nil {
+		return p
+	}
+	p.Location = s.Location
+	p.Kind = string(s.Kind)
+	if s.Sku != nil {
+		p.SKU = SKU{Name: string(s.Sku.Name)}
+	}
+	if s.Identity != nil && s.Identity.Type != "" {
+		p.Identity = &Identity{Type: s.Identity.Type}
+	}
+	p.Tags = s.Tags
+	if s.StorageAccountSpecProperties == nil {
+		return p
+	}
+	if s.AccessTier != "" {
+		p.AccessTier = toStringPtr(string(s.AccessTier))
+	}
+	if s.CustomDomain != nil {
+		p.CustomDomain = &CustomDomain{
+			Name:             s.CustomDomain.Name,
+			UseSubDomainName: toBoolPtr(s.CustomDomain.UseSubDomainName),
+		}
+	}
+	p.EnableHTTPSTrafficOnly = toBoolPtr(s.EnableHTTPSTrafficOnly)
+	p.Encryption = convertEncryption(s.Encryption)
+	p.NetworkRuleSet = convertNetworkRuleSet(s.NetworkRuleSet)
+	return p
+}
+
+func convertEncryption(e *v1alpha3.Encryption) *Encryption {
+	if e == nil {
+		return nil
+	}
+	out := &Encryption{}
+	if e.Services != nil {
+		out.Services = &EncryptionServices{
+			Blob: toBoolPtr(e.Services.Blob),
+			File: toBoolPtr(e.Services.File),
+		}
+	}
+	if e.KeySource != "" {
+		out.KeySource = toStringPtr(string(e.KeySource))
+	}
+	if kv := e.KeyVaultProperties; kv != nil {
+		out.KeyVaultProperties = &KeyVaultProperties{
+			KeyName:     toStringPtr(kv.KeyName),
+			KeyVersion:  toStringPtr(kv.KeyVersion),
+			KeyVaultURI: toStringPtr(kv.KeyVaultURI),
+		}
+	}
+	return out
+}
+
+func convertNetworkRuleSet(n *v1alpha3.NetworkRuleSet) *NetworkRuleSet {
+	if n == nil {
+		return nil
+	}
+	out := &NetworkRuleSet{DefaultAction: string(n.DefaultAction)}
+	if n.Bypass != "" {
+		out.Bypass = toStringPtr(string(n.Bypass))
+	}
+	for _, r := range n.VirtualNetworkRules {
+		out.VirtualNetworkRules = append(out.VirtualNetworkRules, VirtualNetworkRule{
+			VirtualNetworkResourceID: r.VirtualNetworkResourceID,
+			Action:                   toStringPtr(string(r.Action)),
+		})
+	}
+	for _, r := range n.IPRules {
+		out.IPRules = append(out.IPRules, IPRule{
+			IPAddressOrRange: r.IPAddressOrRange,
+			Action:           toStringPtr(string(r.Action)),
+		})
+	}
+	return out
+}
+
+func toStringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func toBoolPtr(b bool) *bool {
+	return &b
+}
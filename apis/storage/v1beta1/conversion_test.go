@@ -0,0 +1,114 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+)
+
+func TestConvertStorageAccountSpec(t *testing.T) {
+	yes, no := true, false
+	str := func(s string) *string { return &s }
+
+	cases := map[string]struct {
+		rg   string
+		s    *v1alpha3.StorageAccountSpec
+		want AccountParameters
+	}{
+		"Nil": {
+			rg:   "cool-rg",
+			want: AccountParameters{ResourceGroupName: "cool-rg"},
+		},
+		"NoProperties": {
+			rg: "cool-rg",
+			s: &v1alpha3.StorageAccountSpec{
+				Identity: &v1alpha3.Identity{PrincipalID: "observed"},
+				Kind:     storage.Storage,
+				Location: "West US 2",
+				Sku:      &v1alpha3.Sku{Name: storage.StandardLRS, Tier: storage.Standard},
+				Tags:     map[string]string{"cool": "very"},
+			},
+			want: AccountParameters{
+				ResourceGroupName: "cool-rg",
+				Location:          "West US 2",
+				Kind:              "Storage",
+				SKU:               SKU{Name: "Standard_LRS"},
+				Tags:              map[string]string{"cool": "very"},
+			},
+		},
+		"Full": {
+			rg: "cool-rg",
+			s: &v1alpha3.StorageAccountSpec{
+				Identity: &v1alpha3.Identity{Type: "SystemAssigned"},
+				Kind:     storage.BlobStorage,
+				Location: "West US 2",
+				Sku:      &v1alpha3.Sku{Name: storage.StandardRAGRS},
+				StorageAccountSpecProperties: &v1alpha3.StorageAccountSpecProperties{
+					AccessTier:             storage.Cool,
+					CustomDomain:           &v1alpha3.CustomDomain{Name: "example.org", UseSubDomainName: true},
+					EnableHTTPSTrafficOnly: true,
+					Encryption: &v1alpha3.Encryption{
+						Services:           &v1alpha3.EnabledEncryptionServices{Blob: true},
+						KeySource:          storage.MicrosoftKeyvault,
+						KeyVaultProperties: &v1alpha3.KeyVaultProperties{KeyName: "key", KeyVaultURI: "https://vault"},
+					},
+					NetworkRuleSet: &v1alpha3.NetworkRuleSet{
+						Bypass:              storage.AzureServices,
+						VirtualNetworkRules: []v1alpha3.VirtualNetworkRule{{VirtualNetworkResourceID: "subnet", Action: storage.Allow}},
+						IPRules:             []v1alpha3.IPRule{{IPAddressOrRange: "10.0.0.0/8", Action: storage.Allow}},
+						DefaultAction:       storage.DefaultActionDeny,
+					},
+				},
+			},
+			want: AccountParameters{
+				ResourceGroupName:      "cool-rg",
+				Location:               "West US 2",
+				Kind:                   "BlobStorage",
+				SKU:                    SKU{Name: "Standard_RAGRS"},
+				Identity:               &Identity{Type: "SystemAssigned"},
+				AccessTier:             str("Cool"),
+				CustomDomain:           &CustomDomain{Name: "example.org", UseSubDomainName: &yes},
+				EnableHTTPSTrafficOnly: &yes,
+				Encryption: &Encryption{
+					Services:           &EncryptionServices{Blob: &yes, File: &no},
+					KeySource:          str("Microsoft.Keyvault"),
+					KeyVaultProperties: &KeyVaultProperties{KeyName: str("key"), KeyVaultURI: str("https://vault")},
+				},
+				NetworkRuleSet: &NetworkRuleSet{
+					Bypass:              str("AzureServices"),
+					VirtualNetworkRules: []VirtualNetworkRule{{VirtualNetworkResourceID: "subnet", Action: str("Allow")}},
+					IPRules:             []IPRule{{IPAddressOrRange: "10.0.0.0/8", Action: str("Allow")}},
+					DefaultAction:       "Deny",
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := ConvertStorageAccountSpec(tc.rg, tc.s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("ConvertStorageAccountSpec(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,280 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// FilesystemParameters define the desired state of an Azure Data Lake
+// Storage Gen2 filesystem.
+type FilesystemParameters struct {
+	// ResourceGroupName of the resource group of the storage Account in
+	// which the Filesystem exists.
+	// +immutable
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to a ResourceGroup object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to a ResourceGroup
+	// object to retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// AccountName of the storage Account in which the Filesystem exists.
+	// The Account must have its hierarchical namespace enabled.
+	// +immutable
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// AccountNameRef - A reference to an Account object to retrieve its
+	// name
+	// +immutable
+	// +optional
+	AccountNameRef *xpv1.Reference `json:"accountNameRef,omitempty"`
+
+	// AccountNameSelector - Select a reference to an Account object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	AccountNameSelector *xpv1.Selector `json:"accountNameSelector,omitempty"`
+
+	// Properties of the Filesystem; user-defined name and value pairs.
+	// +optional
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+// A FilesystemSpec defines the desired state of a Filesystem.
+type FilesystemSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       FilesystemParameters `json:"forProvider"`
+}
+
+// A FilesystemObservation represents the observed state of a Filesystem.
+type FilesystemObservation struct {
+	// ETag of this Filesystem.
+	ETag string `json:"etag,omitempty"`
+
+	// LastModifiedTime is the time the Filesystem was last modified.
+	LastModifiedTime *metav1.Time `json:"lastModifiedTime,omitempty"`
+}
+
+// A FilesystemStatus represents the observed state of a Filesystem.
+type FilesystemStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          FilesystemObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Filesystem is a managed resource that represents an Azure Data Lake
+// Storage Gen2 filesystem.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STORAGE_ACCOUNT",type="string",JSONPath=".spec.forProvider.accountName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type Filesystem struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FilesystemSpec   `json:"spec"`
+	Status FilesystemStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FilesystemList contains a list of Filesystem.
+type FilesystemList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Filesystem `json:"items"`
+}
+
+// Types of POSIX access control list entries.
+const (
+	ACLEntryTypeUser  = "user"
+	ACLEntryTypeGroup = "group"
+	ACLEntryTypeMask  = "mask"
+	ACLEntryTypeOther = "other"
+)
+
+// An ACLEntry grants permissions on a Path to a user or group. The ACL of a
+// Path must include entries without an ID for the user, group, and other
+// types, which grant the permissions of the owning user, the owning group,
+// and everyone else.
+type ACLEntry struct {
+	// Default indicates that this entry belongs to the default ACL of a
+	// directory, which new children of the directory inherit, rather than
+	// to its access ACL.
+	// +optional
+	Default bool `json:"default,omitempty"`
+
+	// Type of this entry.
+	// +kubebuilder:validation:Enum=user;group;mask;other
+	Type string `json:"type"`
+
+	// ID is the Azure Active Directory object ID of the user, group, or
+	// service principal granted permissions by this entry. It is omitted
+	// for the owning user and group, and for mask and other entries.
+	// +optional
+	ID string `json:"id,omitempty"`
+
+	// Permissions granted by this entry, for example r-x.
+	// +kubebuilder:validation:Pattern=`^[r-][w-][x-]$`
+	Permissions string `json:"permissions"`
+}
+
+// PathParameters define the desired state of an Azure Data Lake Storage Gen2
+// directory.
+type PathParameters struct {
+	// ResourceGroupName of the resource group of the storage Account in
+	// which the Path exists.
+	// +immutable
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to a ResourceGroup object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to a ResourceGroup
+	// object to retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// AccountName of the storage Account in which the Path exists.
+	// +immutable
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// AccountNameRef - A reference to an Account object to retrieve its
+	// name
+	// +immutable
+	// +optional
+	AccountNameRef *xpv1.Reference `json:"accountNameRef,omitempty"`
+
+	// AccountNameSelector - Select a reference to an Account object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	AccountNameSelector *xpv1.Selector `json:"accountNameSelector,omitempty"`
+
+	// FilesystemName of the Filesystem in which the Path exists.
+	// +immutable
+	// +optional
+	FilesystemName string `json:"filesystemName,omitempty"`
+
+	// FilesystemNameRef - A reference to a Filesystem object to retrieve
+	// its name
+	// +immutable
+	// +optional
+	FilesystemNameRef *xpv1.Reference `json:"filesystemNameRef,omitempty"`
+
+	// FilesystemNameSelector - Select a reference to a Filesystem object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	FilesystemNameSelector *xpv1.Selector `json:"filesystemNameSelector,omitempty"`
+
+	// Path of the directory within the Filesystem, for example
+	// raw/sales. Missing parent directories are created.
+	// +immutable
+	// +kubebuilder:validation:MinLength=1
+	Path string `json:"path"`
+
+	// Owner of the directory; the Azure Active Directory object ID of a
+	// user or service principal.
+	// +optional
+	Owner *string `json:"owner,omitempty"`
+
+	// Group that owns the directory; the Azure Active Directory object ID of
+	// a group.
+	// +optional
+	Group *string `json:"group,omitempty"`
+
+	// ACL is the POSIX access control list of the directory, including its
+	// default entries.
+	// +optional
+	ACL []ACLEntry `json:"acl,omitempty"`
+}
+
+// A PathSpec defines the desired state of a Path.
+type PathSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       PathParameters `json:"forProvider"`
+}
+
+// A PathObservation represents the observed state of a Path.
+type PathObservation struct {
+	// ETag of this Path.
+	ETag string `json:"etag,omitempty"`
+
+	// LastModifiedTime is the time the Path was last modified.
+	LastModifiedTime *metav1.Time `json:"lastModifiedTime,omitempty"`
+
+	// Permissions of the owning user, the owning group, and everyone else,
+	// in symbolic notation, for example rwxr-x---+.
+	Permissions string `json:"permissions,omitempty"`
+}
+
+// A PathStatus represents the observed state of a Path.
+type PathStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          PathObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Path is a managed resource that represents a directory in an Azure Data
+// Lake Storage Gen2 Filesystem, and its access control list. A Path is not
+// deleted until the directory is empty.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="FILESYSTEM",type="string",JSONPath=".spec.forProvider.filesystemName"
+// +kubebuilder:printcolumn:name="PATH",type="string",JSONPath=".spec.forProvider.path"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type Path struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PathSpec   `json:"spec"`
+	Status PathStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PathList contains a list of Path.
+type PathList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Path `json:"items"`
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// FileShareParameters define the desired state of an Azure Files share.
+type FileShareParameters struct {
+	// ResourceGroupName of the resource group of the storage Account in
+	// which the FileShare exists.
+	// +immutable
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to a ResourceGroup object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to a ResourceGroup
+	// object to retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// AccountName of the storage Account in which the FileShare exists.
+	// +immutable
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// AccountNameRef - A reference to an Account object to retrieve its
+	// name
+	// +immutable
+	// +optional
+	AccountNameRef *xpv1.Reference `json:"accountNameRef,omitempty"`
+
+	// AccountNameSelector - Select a reference to an Account object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	AccountNameSelector *xpv1.Selector `json:"accountNameSelector,omitempty"`
+
+	// ShareQuota is the maximum size of the share, in gigabytes. It may be
+	// up to 5120, or up to 102400 for accounts with large file shares
+	// enabled.
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=102400
+	// +optional
+	ShareQuota *int `json:"shareQuota,omitempty"`
+
+	// AccessTier of the share. General purpose v2 accounts may choose
+	// between TransactionOptimized, Hot, and Cool. FileStorage accounts may
+	// only choose Premium.
+	// +kubebuilder:validation:Enum=TransactionOptimized;Hot;Cool;Premium
+	// +optional
+	AccessTier *string `json:"accessTier,omitempty"`
+
+	// EnabledProtocols of the share; either SMB or NFS. NFS shares require
+	// a premium FileStorage account.
+	// +immutable
+	// +kubebuilder:validation:Enum=SMB;NFS
+	// +optional
+	EnabledProtocols *string `json:"enabledProtocols,omitempty"`
+
+	// RootSquash configures how the root user of an NFS client is mapped.
+	// It applies only to NFS shares.
+	// +kubebuilder:validation:Enum=NoRootSquash;RootSquash;AllSquash
+	// +optional
+	RootSquash *string `json:"rootSquash,omitempty"`
+}
+
+// A FileShareSpec defines the desired state of a FileShare.
+type FileShareSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       FileShareParameters `json:"forProvider"`
+}
+
+// A FileShareObservation represents the observed state of a FileShare.
+type FileShareObservation struct {
+	// ID of this FileShare.
+	ID string `json:"id,omitempty"`
+
+	// LastModifiedTime is the time the share was last modified.
+	LastModifiedTime *metav1.Time `json:"lastModifiedTime,omitempty"`
+
+	// AccessTierStatus indicates whether a transition to a new access tier
+	// is pending.
+	AccessTierStatus string `json:"accessTierStatus,omitempty"`
+
+	// ShareUsageBytes is the approximate size of the data stored on the
+	// share.
+	ShareUsageBytes int `json:"shareUsageBytes,omitempty"`
+}
+
+// A FileShareStatus represents the observed state of a FileShare.
+type FileShareStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          FileShareObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A FileShare is a managed resource that represents an Azure Files share.
+// Its connection secret contains the azurestorageaccountname and
+// azurestorageaccountkey keys expected by the Azure Files CSI driver, and the
+// URL of the share as its endpoint.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STORAGE_ACCOUNT",type="string",JSONPath=".spec.forProvider.accountName"
+// +kubebuilder:printcolumn:name="QUOTA",type="integer",JSONPath=".spec.forProvider.shareQuota"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type FileShare struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FileShareSpec   `json:"spec"`
+	Status FileShareStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// FileShareList contains a list of FileShare.
+type FileShareList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FileShare `json:"items"`
+}
@@ -0,0 +1,244 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A DateAfterModification action applies to blobs that have not been
+// modified for the supplied number of days.
+type DateAfterModification struct {
+	// DaysAfterModificationGreaterThan is the number of days after the last
+	// modification of a blob before the action applies.
+	// +kubebuilder:validation:Minimum=0
+	DaysAfterModificationGreaterThan int `json:"daysAfterModificationGreaterThan"`
+}
+
+// A DateAfterCreation action applies to snapshots or versions that were
+// created at least the supplied number of days ago.
+type DateAfterCreation struct {
+	// DaysAfterCreationGreaterThan is the number of days after the creation
+	// of a snapshot or version before the action applies.
+	// +kubebuilder:validation:Minimum=0
+	DaysAfterCreationGreaterThan int `json:"daysAfterCreationGreaterThan"`
+}
+
+// ManagementPolicyBaseBlob actions apply to base blobs.
+type ManagementPolicyBaseBlob struct {
+	// TierToCool moves blobs to the cool tier.
+	// +optional
+	TierToCool *DateAfterModification `json:"tierToCool,omitempty"`
+
+	// TierToArchive moves blobs to the archive tier.
+	// +optional
+	TierToArchive *DateAfterModification `json:"tierToArchive,omitempty"`
+
+	// Delete deletes blobs.
+	// +optional
+	Delete *DateAfterModification `json:"delete,omitempty"`
+}
+
+// ManagementPolicySnapshot actions apply to blob snapshots.
+type ManagementPolicySnapshot struct {
+	// Delete deletes snapshots.
+	// +optional
+	Delete *DateAfterCreation `json:"delete,omitempty"`
+}
+
+// ManagementPolicyVersion actions apply to previous versions of blobs.
+type ManagementPolicyVersion struct {
+	// TierToCool moves versions to the cool tier.
+	// +optional
+	TierToCool *DateAfterCreation `json:"tierToCool,omitempty"`
+
+	// TierToArchive moves versions to the archive tier.
+	// +optional
+	TierToArchive *DateAfterCreation `json:"tierToArchive,omitempty"`
+
+	// Delete deletes versions.
+	// +optional
+	Delete *DateAfterCreation `json:"delete,omitempty"`
+}
+
+// ManagementPolicyActions are the actions taken on the blobs that match the
+// filters of a rule.
+type ManagementPolicyActions struct {
+	// BaseBlob actions apply to base blobs.
+	// +optional
+	BaseBlob *ManagementPolicyBaseBlob `json:"baseBlob,omitempty"`
+
+	// Snapshot actions apply to blob snapshots.
+	// +optional
+	Snapshot *ManagementPolicySnapshot `json:"snapshot,omitempty"`
+
+	// Version actions apply to previous versions of blobs. Versioning must
+	// be enabled on the blob service of the storage Account.
+	// +optional
+	Version *ManagementPolicyVersion `json:"version,omitempty"`
+}
+
+// A TagFilter matches blobs by their blob index tags.
+type TagFilter struct {
+	// Name of the blob index tag.
+	Name string `json:"name"`
+
+	// Op is the comparison operator. The only supported operator is '=='.
+	// +kubebuilder:validation:Enum="=="
+	Op string `json:"op"`
+
+	// Value of the blob index tag.
+	Value string `json:"value"`
+}
+
+// ManagementPolicyFilters limit the blobs to which the actions of a rule
+// apply.
+type ManagementPolicyFilters struct {
+	// PrefixMatch is a list of blob name prefixes, each of which starts with
+	// a container name.
+	// +optional
+	PrefixMatch []string `json:"prefixMatch,omitempty"`
+
+	// BlobTypes to which the rule applies. The only supported type is
+	// 'blockBlob'.
+	BlobTypes []string `json:"blobTypes"`
+
+	// BlobIndexMatch is a list of blob index tag filters, all of which must
+	// match.
+	// +optional
+	BlobIndexMatch []TagFilter `json:"blobIndexMatch,omitempty"`
+}
+
+// A ManagementPolicyDefinition defines which blobs a rule applies to, and
+// the actions it takes.
+type ManagementPolicyDefinition struct {
+	// Actions taken on matching blobs.
+	Actions ManagementPolicyActions `json:"actions"`
+
+	// Filters limit the blobs to which the actions apply.
+	// +optional
+	Filters *ManagementPolicyFilters `json:"filters,omitempty"`
+}
+
+// A ManagementPolicyRule is a lifecycle management rule.
+type ManagementPolicyRule struct {
+	// Name of the rule, which must be unique within the policy.
+	Name string `json:"name"`
+
+	// Enabled is false if the rule is disabled. Rules are enabled by
+	// default.
+	// +optional
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Definition of the rule.
+	Definition ManagementPolicyDefinition `json:"definition"`
+}
+
+// ManagementPolicyParameters define the desired state of the lifecycle
+// management policy of an Azure Storage Account.
+type ManagementPolicyParameters struct {
+	// ResourceGroupName of the resource group of the storage Account.
+	// +immutable
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to a ResourceGroup object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to a ResourceGroup
+	// object to retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// AccountName of the storage Account to which the policy applies.
+	// +immutable
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// AccountNameRef - A reference to an Account object to retrieve its
+	// name
+	// +immutable
+	// +optional
+	AccountNameRef *xpv1.Reference `json:"accountNameRef,omitempty"`
+
+	// AccountNameSelector - Select a reference to an Account object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	AccountNameSelector *xpv1.Selector `json:"accountNameSelector,omitempty"`
+
+	// Rules of the policy.
+	// +kubebuilder:validation:MinItems=1
+	Rules []ManagementPolicyRule `json:"rules"`
+}
+
+// A ManagementPolicySpec defines the desired state of a ManagementPolicy.
+type ManagementPolicySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ManagementPolicyParameters `json:"forProvider"`
+}
+
+// A ManagementPolicyObservation represents the observed state of a
+// ManagementPolicy.
+type ManagementPolicyObservation struct {
+	// ID of this ManagementPolicy.
+	ID string `json:"id,omitempty"`
+
+	// LastModifiedTime of this ManagementPolicy.
+	LastModifiedTime *metav1.Time `json:"lastModifiedTime,omitempty"`
+}
+
+// A ManagementPolicyStatus represents the observed state of a
+// ManagementPolicy.
+type ManagementPolicyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ManagementPolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A ManagementPolicy is a managed resource that represents the lifecycle
+// management policy of an Azure Storage Account. An Account has at most one
+// ManagementPolicy.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STORAGE_ACCOUNT",type="string",JSONPath=".spec.forProvider.accountName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type ManagementPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ManagementPolicySpec   `json:"spec"`
+	Status ManagementPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ManagementPolicyList contains a list of ManagementPolicy.
+type ManagementPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ManagementPolicy `json:"items"`
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// ObjectReplicationFilters limit the blobs that are replicated by a rule.
+type ObjectReplicationFilters struct {
+	// PrefixMatch is a list of blob name prefixes. Only blobs whose names
+	// begin with one of these prefixes are replicated.
+	// +optional
+	PrefixMatch []string `json:"prefixMatch,omitempty"`
+
+	// MinCreationTime is the time after which blobs must have been created
+	// in order to be replicated. All blobs are replicated if this is
+	// omitted.
+	// +optional
+	MinCreationTime *metav1.Time `json:"minCreationTime,omitempty"`
+}
+
+// An ObjectReplicationRule replicates blobs from a container of the source
+// storage Account to a container of the destination storage Account.
+type ObjectReplicationRule struct {
+	// SourceContainer is the name of the container in the source storage
+	// Account from which blobs are replicated.
+	SourceContainer string `json:"sourceContainer"`
+
+	// DestinationContainer is the name of the container in the destination
+	// storage Account to which blobs are replicated.
+	DestinationContainer string `json:"destinationContainer"`
+
+	// Filters limit the blobs that are replicated.
+	// +optional
+	Filters *ObjectReplicationFilters `json:"filters,omitempty"`
+}
+
+// ObjectReplicationPolicyParameters define the desired state of an Azure
+// Storage object replication policy.
+type ObjectReplicationPolicyParameters struct {
+	// SourceResourceGroupName of the resource group of the source storage
+	// Account.
+	// +immutable
+	// +optional
+	SourceResourceGroupName string `json:"sourceResourceGroupName,omitempty"`
+
+	// SourceResourceGroupNameRef - A reference to a ResourceGroup object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	SourceResourceGroupNameRef *xpv1.Reference `json:"sourceResourceGroupNameRef,omitempty"`
+
+	// SourceResourceGroupNameSelector - Select a reference to a
+	// ResourceGroup object to retrieve its name
+	// +immutable
+	// +optional
+	SourceResourceGroupNameSelector *xpv1.Selector `json:"sourceResourceGroupNameSelector,omitempty"`
+
+	// SourceAccountName of the storage Account from which blobs are
+	// replicated.
+	// +immutable
+	// +optional
+	SourceAccountName string `json:"sourceAccountName,omitempty"`
+
+	// SourceAccountNameRef - A reference to an Account object to retrieve
+	// its name
+	// +immutable
+	// +optional
+	SourceAccountNameRef *xpv1.Reference `json:"sourceAccountNameRef,omitempty"`
+
+	// SourceAccountNameSelector - Select a reference to an Account object
+	// to retrieve its name
+	// +immutable
+	// +optional
+	SourceAccountNameSelector *xpv1.Selector `json:"sourceAccountNameSelector,omitempty"`
+
+	// DestinationResourceGroupName of the resource group of the destination
+	// storage Account.
+	// +immutable
+	// +optional
+	DestinationResourceGroupName string `json:"destinationResourceGroupName,omitempty"`
+
+	// DestinationResourceGroupNameRef - A reference to a ResourceGroup
+	// object to retrieve its name
+	// +immutable
+	// +optional
+	DestinationResourceGroupNameRef *xpv1.Reference `json:"destinationResourceGroupNameRef,omitempty"`
+
+	// DestinationResourceGroupNameSelector - Select a reference to a
+	// ResourceGroup object to retrieve its name
+	// +immutable
+	// +optional
+	DestinationResourceGroupNameSelector *xpv1.Selector `json:"destinationResourceGroupNameSelector,omitempty"`
+
+	// DestinationAccountName of the storage Account to which blobs are
+	// replicated.
+	// +immutable
+	// +optional
+	DestinationAccountName string `json:"destinationAccountName,omitempty"`
+
+	// DestinationAccountNameRef - A reference to an Account object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	DestinationAccountNameRef *xpv1.Reference `json:"destinationAccountNameRef,omitempty"`
+
+	// DestinationAccountNameSelector - Select a reference to an Account
+	// object to retrieve its name
+	// +immutable
+	// +optional
+	DestinationAccountNameSelector *xpv1.Selector `json:"destinationAccountNameSelector,omitempty"`
+
+	// Rules of the policy. Each pair of source and destination containers
+	// may appear in at most one rule.
+	// +kubebuilder:validation:MinItems=1
+	Rules []ObjectReplicationRule `json:"rules"`
+}
+
+// An ObjectReplicationPolicySpec defines the desired state of an
+// ObjectReplicationPolicy.
+type ObjectReplicationPolicySpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       ObjectReplicationPolicyParameters `json:"forProvider"`
+}
+
+// An ObjectReplicationRuleObservation represents the observed state of a
+// rule of an ObjectReplicationPolicy.
+type ObjectReplicationRuleObservation struct {
+	// RuleID that Azure generated for the rule.
+	RuleID string `json:"ruleId"`
+
+	// SourceContainer of the rule.
+	SourceContainer string `json:"sourceContainer"`
+
+	// DestinationContainer of the rule.
+	DestinationContainer string `json:"destinationContainer"`
+}
+
+// An ObjectReplicationPolicyObservation represents the observed state of an
+// ObjectReplicationPolicy.
+type ObjectReplicationPolicyObservation struct {
+	// PolicyID that Azure generated for this ObjectReplicationPolicy. It is
+	// also the external name of the ObjectReplicationPolicy.
+	PolicyID string `json:"policyId,omitempty"`
+
+	// EnabledTime is the time at which the policy was enabled on the source
+	// storage Account.
+	EnabledTime *metav1.Time `json:"enabledTime,omitempty"`
+
+	// Rules of this ObjectReplicationPolicy.
+	Rules []ObjectReplicationRuleObservation `json:"rules,omitempty"`
+}
+
+// An ObjectReplicationPolicyStatus represents the observed state of an
+// ObjectReplicationPolicy.
+type ObjectReplicationPolicyStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          ObjectReplicationPolicyObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// An ObjectReplicationPolicy is a managed resource that represents an Azure
+// Storage object replication policy, which asynchronously copies block blobs
+// from a source storage Account to a destination storage Account. The policy
+// exists on both Accounts; it is created on the destination Account first,
+// which generates its ID.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="SOURCE",type="string",JSONPath=".spec.forProvider.sourceAccountName"
+// +kubebuilder:printcolumn:name="DESTINATION",type="string",JSONPath=".spec.forProvider.destinationAccountName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type ObjectReplicationPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ObjectReplicationPolicySpec   `json:"spec"`
+	Status ObjectReplicationPolicyStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ObjectReplicationPolicyList contains a list of ObjectReplicationPolicy.
+type ObjectReplicationPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ObjectReplicationPolicy `json:"items"`
+}
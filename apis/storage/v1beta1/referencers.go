@@ -0,0 +1,444 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/reference"
+
+	kvv1alpha1 "github.com/crossplane/provider-azure/apis/keyvault/v1alpha1"
+	networkv1alpha3 "github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
+)
+
+// ResolveReferences of this Account.
+func (mg *Account) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.encryption.keyVaultKeyId
+	if e := mg.Spec.ForProvider.Encryption; e != nil {
+		rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+			CurrentValue: reference.FromPtrValue(e.KeyVaultKeyID),
+			Reference:    e.KeyVaultKeyIDRef,
+			Selector:     e.KeyVaultKeyIDSelector,
+			To:           reference.To{Managed: &kvv1alpha1.KeyVaultKey{}, List: &kvv1alpha1.KeyVaultKeyList{}},
+			Extract:      kvv1alpha1.KeyVaultKeyID(),
+		})
+		if err != nil {
+			return errors.Wrap(err, "spec.forProvider.encryption.keyVaultKeyId")
+		}
+		e.KeyVaultKeyID = reference.ToPtrValue(rsp.ResolvedValue)
+		e.KeyVaultKeyIDRef = rsp.ResolvedReference
+	}
+
+	// Resolve spec.forProvider.networkAcls.virtualNetworkRules[*].id
+	if n := mg.Spec.ForProvider.NetworkRuleSet; n != nil {
+		for i := range n.VirtualNetworkRules {
+			vnr := &n.VirtualNetworkRules[i]
+			rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+				CurrentValue: vnr.VirtualNetworkResourceID,
+				Reference:    vnr.VirtualNetworkResourceIDRef,
+				Selector:     vnr.VirtualNetworkResourceIDSelector,
+				To:           reference.To{Managed: &networkv1alpha3.Subnet{}, List: &networkv1alpha3.SubnetList{}},
+				Extract:      networkv1alpha3.SubnetID(),
+			})
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("spec.forProvider.networkAcls.virtualNetworkRules[%d].id", i))
+			}
+			vnr.VirtualNetworkResourceID = rsp.ResolvedValue
+			vnr.VirtualNetworkResourceIDRef = rsp.ResolvedReference
+		}
+	}
+
+	return nil
+}
+
+// ResolveReferences of this Container.
+func (mg *Container) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.accountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AccountName,
+		Reference:    mg.Spec.ForProvider.AccountNameRef,
+		Selector:     mg.Spec.ForProvider.AccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accountName")
+	}
+	mg.Spec.ForProvider.AccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.AccountNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this ManagementPolicy.
+func (mg *ManagementPolicy) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.accountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AccountName,
+		Reference:    mg.Spec.ForProvider.AccountNameRef,
+		Selector:     mg.Spec.ForProvider.AccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accountName")
+	}
+	mg.Spec.ForProvider.AccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.AccountNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this BlobService.
+func (mg *BlobService) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.accountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AccountName,
+		Reference:    mg.Spec.ForProvider.AccountNameRef,
+		Selector:     mg.Spec.ForProvider.AccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accountName")
+	}
+	mg.Spec.ForProvider.AccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.AccountNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this FileShare.
+func (mg *FileShare) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.accountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AccountName,
+		Reference:    mg.Spec.ForProvider.AccountNameRef,
+		Selector:     mg.Spec.ForProvider.AccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accountName")
+	}
+	mg.Spec.ForProvider.AccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.AccountNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Queue.
+func (mg *Queue) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.accountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AccountName,
+		Reference:    mg.Spec.ForProvider.AccountNameRef,
+		Selector:     mg.Spec.ForProvider.AccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accountName")
+	}
+	mg.Spec.ForProvider.AccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.AccountNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Table.
+func (mg *Table) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.accountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AccountName,
+		Reference:    mg.Spec.ForProvider.AccountNameRef,
+		Selector:     mg.Spec.ForProvider.AccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accountName")
+	}
+	mg.Spec.ForProvider.AccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.AccountNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Filesystem.
+func (mg *Filesystem) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.accountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AccountName,
+		Reference:    mg.Spec.ForProvider.AccountNameRef,
+		Selector:     mg.Spec.ForProvider.AccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accountName")
+	}
+	mg.Spec.ForProvider.AccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.AccountNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this Path.
+func (mg *Path) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.resourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.ResourceGroupName,
+		Reference:    mg.Spec.ForProvider.ResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.ResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.resourceGroupName")
+	}
+	mg.Spec.ForProvider.ResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.ResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.accountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.AccountName,
+		Reference:    mg.Spec.ForProvider.AccountNameRef,
+		Selector:     mg.Spec.ForProvider.AccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.accountName")
+	}
+	mg.Spec.ForProvider.AccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.AccountNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.filesystemName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.FilesystemName,
+		Reference:    mg.Spec.ForProvider.FilesystemNameRef,
+		Selector:     mg.Spec.ForProvider.FilesystemNameSelector,
+		To:           reference.To{Managed: &Filesystem{}, List: &FilesystemList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.filesystemName")
+	}
+	mg.Spec.ForProvider.FilesystemName = rsp.ResolvedValue
+	mg.Spec.ForProvider.FilesystemNameRef = rsp.ResolvedReference
+
+	return nil
+}
+
+// ResolveReferences of this ObjectReplicationPolicy.
+func (mg *ObjectReplicationPolicy) ResolveReferences(ctx context.Context, c client.Reader) error {
+	r := reference.NewAPIResolver(c, mg)
+
+	// Resolve spec.forProvider.sourceResourceGroupName
+	rsp, err := r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.SourceResourceGroupName,
+		Reference:    mg.Spec.ForProvider.SourceResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.SourceResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.sourceResourceGroupName")
+	}
+	mg.Spec.ForProvider.SourceResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.SourceResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.sourceAccountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.SourceAccountName,
+		Reference:    mg.Spec.ForProvider.SourceAccountNameRef,
+		Selector:     mg.Spec.ForProvider.SourceAccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.sourceAccountName")
+	}
+	mg.Spec.ForProvider.SourceAccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.SourceAccountNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.destinationResourceGroupName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.DestinationResourceGroupName,
+		Reference:    mg.Spec.ForProvider.DestinationResourceGroupNameRef,
+		Selector:     mg.Spec.ForProvider.DestinationResourceGroupNameSelector,
+		To:           reference.To{Managed: &v1alpha3.ResourceGroup{}, List: &v1alpha3.ResourceGroupList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.destinationResourceGroupName")
+	}
+	mg.Spec.ForProvider.DestinationResourceGroupName = rsp.ResolvedValue
+	mg.Spec.ForProvider.DestinationResourceGroupNameRef = rsp.ResolvedReference
+
+	// Resolve spec.forProvider.destinationAccountName
+	rsp, err = r.Resolve(ctx, reference.ResolutionRequest{
+		CurrentValue: mg.Spec.ForProvider.DestinationAccountName,
+		Reference:    mg.Spec.ForProvider.DestinationAccountNameRef,
+		Selector:     mg.Spec.ForProvider.DestinationAccountNameSelector,
+		To:           reference.To{Managed: &Account{}, List: &AccountList{}},
+		Extract:      reference.ExternalName(),
+	})
+	if err != nil {
+		return errors.Wrap(err, "spec.forProvider.destinationAccountName")
+	}
+	mg.Spec.ForProvider.DestinationAccountName = rsp.ResolvedValue
+	mg.Spec.ForProvider.DestinationAccountNameRef = rsp.ResolvedReference
+
+	return nil
+}
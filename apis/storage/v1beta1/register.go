@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/scheme"
+)
+
+// Package type metadata.
+const (
+	Group   = "storage.azure.crossplane.io"
+	Version = "v1beta1"
+)
+
+var (
+	// SchemeGroupVersion is group version used to register these objects
+	SchemeGroupVersion = schema.GroupVersion{Group: Group, Version: Version}
+
+	// SchemeBuilder is used to add go types to the GroupVersionKind scheme
+	SchemeBuilder = &scheme.Builder{GroupVersion: SchemeGroupVersion}
+)
+
+// Account type metadata.
+var (
+	AccountKind             = reflect.TypeOf(Account{}).Name()
+	AccountGroupKind        = schema.GroupKind{Group: Group, Kind: AccountKind}.String()
+	AccountKindAPIVersion   = AccountKind + "." + SchemeGroupVersion.String()
+	AccountGroupVersionKind = SchemeGroupVersion.WithKind(AccountKind)
+)
+
+// Container type metadata.
+var (
+	ContainerKind             = reflect.TypeOf(Container{}).Name()
+	ContainerGroupKind        = schema.GroupKind{Group: Group, Kind: ContainerKind}.String()
+	ContainerKindAPIVersion   = ContainerKind + "." + SchemeGroupVersion.String()
+	ContainerGroupVersionKind = SchemeGroupVersion.WithKind(ContainerKind)
+)
+
+// ManagementPolicy type metadata.
+var (
+	ManagementPolicyKind             = reflect.TypeOf(ManagementPolicy{}).Name()
+	ManagementPolicyGroupKind        = schema.GroupKind{Group: Group, Kind: ManagementPolicyKind}.String()
+	ManagementPolicyKindAPIVersion   = ManagementPolicyKind + "." + SchemeGroupVersion.String()
+	ManagementPolicyGroupVersionKind = SchemeGroupVersion.WithKind(ManagementPolicyKind)
+)
+
+// BlobService type metadata.
+var (
+	BlobServiceKind             = reflect.TypeOf(BlobService{}).Name()
+	BlobServiceGroupKind        = schema.GroupKind{Group: Group, Kind: BlobServiceKind}.String()
+	BlobServiceKindAPIVersion   = BlobServiceKind + "." + SchemeGroupVersion.String()
+	BlobServiceGroupVersionKind = SchemeGroupVersion.WithKind(BlobServiceKind)
+)
+
+// FileShare type metadata.
+var (
+	FileShareKind             = reflect.TypeOf(FileShare{}).Name()
+	FileShareGroupKind        = schema.GroupKind{Group: Group, Kind: FileShareKind}.String()
+	FileShareKindAPIVersion   = FileShareKind + "." + SchemeGroupVersion.String()
+	FileShareGroupVersionKind = SchemeGroupVersion.WithKind(FileShareKind)
+)
+
+// Queue type metadata.
+var (
+	QueueKind             = reflect.TypeOf(Queue{}).Name()
+	QueueGroupKind        = schema.GroupKind{Group: Group, Kind: QueueKind}.String()
+	QueueKindAPIVersion   = QueueKind + "." + SchemeGroupVersion.String()
+	QueueGroupVersionKind = SchemeGroupVersion.WithKind(QueueKind)
+)
+
+// Table type metadata.
+var (
+	TableKind             = reflect.TypeOf(Table{}).Name()
+	TableGroupKind        = schema.GroupKind{Group: Group, Kind: TableKind}.String()
+	TableKindAPIVersion   = TableKind + "." + SchemeGroupVersion.String()
+	TableGroupVersionKind = SchemeGroupVersion.WithKind(TableKind)
+)
+
+// Filesystem type metadata.
+var (
+	FilesystemKind             = reflect.TypeOf(Filesystem{}).Name()
+	FilesystemGroupKind        = schema.GroupKind{Group: Group, Kind: FilesystemKind}.String()
+	FilesystemKindAPIVersion   = FilesystemKind + "." + SchemeGroupVersion.String()
+	FilesystemGroupVersionKind = SchemeGroupVersion.WithKind(FilesystemKind)
+)
+
+// Path type metadata.
+var (
+	PathKind             = reflect.TypeOf(Path{}).Name()
+	PathGroupKind        = schema.GroupKind{Group: Group, Kind: PathKind}.String()
+	PathKindAPIVersion   = PathKind + "." + SchemeGroupVersion.String()
+	PathGroupVersionKind = SchemeGroupVersion.WithKind(PathKind)
+)
+
+// ObjectReplicationPolicy type metadata.
+var (
+	ObjectReplicationPolicyKind             = reflect.TypeOf(ObjectReplicationPolicy{}).Name()
+	ObjectReplicationPolicyGroupKind        = schema.GroupKind{Group: Group, Kind: ObjectReplicationPolicyKind}.String()
+	ObjectReplicationPolicyKindAPIVersion   = ObjectReplicationPolicyKind + "." + SchemeGroupVersion.String()
+	ObjectReplicationPolicyGroupVersionKind = SchemeGroupVersion.WithKind(ObjectReplicationPolicyKind)
+)
+
+func init() {
+	SchemeBuilder.Register(&Account{}, &AccountList{})
+	SchemeBuilder.Register(&Container{}, &ContainerList{})
+	SchemeBuilder.Register(&ManagementPolicy{}, &ManagementPolicyList{})
+	SchemeBuilder.Register(&BlobService{}, &BlobServiceList{})
+	SchemeBuilder.Register(&FileShare{}, &FileShareList{})
+	SchemeBuilder.Register(&Queue{}, &QueueList{})
+	SchemeBuilder.Register(&Table{}, &TableList{})
+	SchemeBuilder.Register(&Filesystem{}, &FilesystemList{})
+	SchemeBuilder.Register(&Path{}, &PathList{})
+	SchemeBuilder.Register(&ObjectReplicationPolicy{}, &ObjectReplicationPolicyList{})
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Types of shared access signature a Container may publish.
+const (
+	// SASTypeService is a service SAS, which is signed with an access key of
+	// the storage Account.
+	SASTypeService = "Service"
+
+	// SASTypeUserDelegation is a user delegation SAS, which is signed with a
+	// user delegation key obtained using the credentials of the provider.
+	// The provider's service principal must be allowed to generate user
+	// delegation keys, for example by the Storage Blob Delegator role.
+	SASTypeUserDelegation = "UserDelegation"
+)
+
+// SharedAccessSignatureParameters configure a shared access signature (SAS)
+// that is published to the connection secret of a resource. A new SAS is
+// issued before the current one expires, or when the permissions or other
+// access it grants change. Changes to its lifetime take effect when the next
+// SAS is issued.
+type SharedAccessSignatureParameters struct {
+	// Permissions granted by the SAS, for example 'rl' to read and list.
+	Permissions string `json:"permissions"`
+
+	// IPRange from which requests using the SAS are accepted; either a
+	// single IP address or a range such as 168.1.5.60-168.1.5.70. Requests
+	// are accepted from any IP address if this is omitted.
+	// +optional
+	IPRange *string `json:"ipRange,omitempty"`
+
+	// Lifetime of each SAS. Defaults to 24 hours.
+	// +optional
+	Lifetime *metav1.Duration `json:"lifetime,omitempty"`
+
+	// RenewBefore is how long before its expiry a SAS is replaced by a new
+	// one. Defaults to a third of its lifetime.
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+}
+
+// A ContainerSharedAccessSignature is a SAS that grants access to a
+// Container.
+type ContainerSharedAccessSignature struct {
+	SharedAccessSignatureParameters `json:",inline"`
+
+	// Type of the SAS; either Service or UserDelegation. Defaults to
+	// Service.
+	// +kubebuilder:validation:Enum=Service;UserDelegation
+	// +optional
+	Type string `json:"type,omitempty"`
+}
+
+// An AccountSharedAccessSignature is a SAS that grants access to the services
+// of a storage Account.
+type AccountSharedAccessSignature struct {
+	SharedAccessSignatureParameters `json:",inline"`
+
+	// Services the SAS grants access to; any combination of b (blob), q
+	// (queue), t (table) and f (file).
+	// +kubebuilder:validation:Pattern=`^[bqtf]+$`
+	Services string `json:"services"`
+
+	// ResourceTypes the SAS grants access to; any combination of s
+	// (service), c (container) and o (object).
+	// +kubebuilder:validation:Pattern=`^[sco]+$`
+	ResourceTypes string `json:"resourceTypes"`
+}
+
+// SharedAccessSignatureStatus represents the SAS most recently published to
+// the connection secret of a resource.
+type SharedAccessSignatureStatus struct {
+	// ExpiryTime of the SAS.
+	ExpiryTime *metav1.Time `json:"expiryTime,omitempty"`
+
+	// ParametersHash is a hash of the parameters with which the SAS was
+	// issued. A new SAS is issued when they change.
+	ParametersHash string `json:"parametersHash,omitempty"`
+}
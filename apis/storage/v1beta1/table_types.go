@@ -0,0 +1,145 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+)
+
+// A TableAccessPolicy grants the holders of a shared access signature access
+// to a Table.
+type TableAccessPolicy struct {
+	// StartTime at which the policy becomes valid.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// ExpiryTime at which the policy ceases to be valid.
+	// +optional
+	ExpiryTime *metav1.Time `json:"expiryTime,omitempty"`
+
+	// Permission granted by the policy; any combination of r (read), a
+	// (add), u (update) and d (delete).
+	// +kubebuilder:validation:Pattern=`^[raud]+$`
+	Permission string `json:"permission"`
+}
+
+// A TableSignedIdentifier is a stored access policy of a Table, identified by
+// the ID shared access signatures use to refer to it.
+type TableSignedIdentifier struct {
+	// ID of the policy, up to 64 characters long.
+	// +kubebuilder:validation:MaxLength=64
+	ID string `json:"id"`
+
+	// AccessPolicy granted by this identifier.
+	// +optional
+	AccessPolicy *TableAccessPolicy `json:"accessPolicy,omitempty"`
+}
+
+// TableParameters define the desired state of an Azure Storage Table.
+type TableParameters struct {
+	// ResourceGroupName of the resource group of the storage Account in
+	// which the Table exists.
+	// +immutable
+	// +optional
+	ResourceGroupName string `json:"resourceGroupName,omitempty"`
+
+	// ResourceGroupNameRef - A reference to a ResourceGroup object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameRef *xpv1.Reference `json:"resourceGroupNameRef,omitempty"`
+
+	// ResourceGroupNameSelector - Select a reference to a ResourceGroup
+	// object to retrieve its name
+	// +immutable
+	// +optional
+	ResourceGroupNameSelector *xpv1.Selector `json:"resourceGroupNameSelector,omitempty"`
+
+	// AccountName of the storage Account in which the Table exists.
+	// +immutable
+	// +optional
+	AccountName string `json:"accountName,omitempty"`
+
+	// AccountNameRef - A reference to an Account object to retrieve its
+	// name
+	// +immutable
+	// +optional
+	AccountNameRef *xpv1.Reference `json:"accountNameRef,omitempty"`
+
+	// AccountNameSelector - Select a reference to an Account object to
+	// retrieve its name
+	// +immutable
+	// +optional
+	AccountNameSelector *xpv1.Selector `json:"accountNameSelector,omitempty"`
+
+	// SignedIdentifiers are the stored access policies of the Table. Up to
+	// five may be specified.
+	// +kubebuilder:validation:MaxItems=5
+	// +optional
+	SignedIdentifiers []TableSignedIdentifier `json:"signedIdentifiers,omitempty"`
+}
+
+// A TableSpec defines the desired state of a Table.
+type TableSpec struct {
+	xpv1.ResourceSpec `json:",inline"`
+	ForProvider       TableParameters `json:"forProvider"`
+}
+
+// A TableObservation represents the observed state of a Table.
+type TableObservation struct {
+	// ID of this Table.
+	ID string `json:"id,omitempty"`
+
+	// TableName is the name of the Table.
+	TableName string `json:"tableName,omitempty"`
+}
+
+// A TableStatus represents the observed state of a Table.
+type TableStatus struct {
+	xpv1.ResourceStatus `json:",inline"`
+	AtProvider          TableObservation `json:"atProvider,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// A Table is a managed resource that represents an Azure Storage Table. It is
+// managed through the Azure management API, so it does not require shared key
+// access to its storage Account.
+// +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="SYNCED",type="string",JSONPath=".status.conditions[?(@.type=='Synced')].status"
+// +kubebuilder:printcolumn:name="STORAGE_ACCOUNT",type="string",JSONPath=".spec.forProvider.accountName"
+// +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,categories={crossplane,managed,azure}
+type Table struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   TableSpec   `json:"spec"`
+	Status TableStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// TableList contains a list of Table.
+type TableList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Table `json:"items"`
+}
@@ -17,11 +17,16 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
+	"strconv"
+	"time"
 
+	"github.com/pkg/errors"
 	"gopkg.in/alecthomas/kingpin.v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
@@ -29,18 +34,38 @@ import (
 
 	"github.com/crossplane/provider-azure/apis"
 	"github.com/crossplane/provider-azure/pkg/controller"
+	"github.com/crossplane/provider-azure/pkg/health"
+	"github.com/crossplane/provider-azure/pkg/tracing"
 )
 
+// traceShutdownTimeout bounds how long pending spans may take to flush when
+// the provider stops.
+const traceShutdownTimeout = 10 * time.Second
+
 func main() {
 	var (
-		app            = kingpin.New(filepath.Base(os.Args[0]), "Azure support for Crossplane.").DefaultEnvars()
-		debug          = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
-		syncInterval   = app.Flag("sync", "Sync interval controls how often all resources will be double checked for drift.").Short('s').Default("1h").Duration()
-		pollInterval   = app.Flag("poll", "Poll interval controls how often an individual resource should be checked for drift.").Default("1m").Duration()
-		leaderElection = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		app                = kingpin.New(filepath.Base(os.Args[0]), "Azure support for Crossplane.").DefaultEnvars()
+		debug              = app.Flag("debug", "Run with debug logging.").Short('d').Bool()
+		syncInterval       = app.Flag("sync", "Sync interval controls how often all resources will be double checked for drift.").Short('s').Default("1h").Duration()
+		pollInterval       = app.Flag("poll", "Poll interval controls how often an individual resource should be checked for drift.").Default("1m").Duration()
+		leaderElection     = app.Flag("leader-election", "Use leader election for the conroller manager.").Short('l').Default("false").OverrideDefaultFromEnvar("LEADER_ELECTION").Bool()
+		enableGroups       = app.Flag("enable-controller-group", "Run only the supplied group of controllers. May be repeated. All groups run if omitted.").Enums(groupNames()...)
+		disableGroups      = app.Flag("disable-controller-group", "Do not run the supplied group of controllers. May be repeated.").Enums(groupNames()...)
+		maxReconciles      = app.Flag("max-concurrent-reconciles", "Maximum number of concurrent reconciles each controller runs.").Default(strconv.Itoa(controller.DefaultMaxConcurrentReconciles)).Int()
+		maxGroupReconciles = app.Flag("group-max-concurrent-reconciles", "Maximum number of concurrent reconciles each controller in a group runs, as group=number. May be repeated.").StringMap()
+		metricsAddr        = app.Flag("metrics-bind-address", "The address the metrics endpoint binds to. Set to 0 to disable metrics.").Default(":8080").String()
+		healthAddr         = app.Flag("health-probe-bind-address", "The address the liveness and readiness probe endpoints bind to. Set to 0 to disable probes.").Default(":8081").String()
+		readyAuth          = app.Flag("readiness-check-auth", "Report ready only when at least one ProviderConfig can authenticate to Azure. Leave this disabled if the provider must become ready before a ProviderConfig is created.").Default("false").Bool()
+		readyAuthTTL       = app.Flag("readiness-check-auth-ttl", "How long a successful ProviderConfig authentication is trusted by the readiness check before authenticating again.").Default(health.DefaultAuthenticationTTL.String()).Duration()
+		otlpEndpoint       = app.Flag("otlp-endpoint", "The host:port of an OTLP gRPC collector to which traces of reconciles and Azure API calls are exported. Tracing is disabled if omitted.").String()
+		otlpInsecure       = app.Flag("otlp-insecure", "Connect to the OTLP collector without transport security.").Default("false").Bool()
+		traceSampleRatio   = app.Flag("trace-sample-ratio", "The fraction of reconciles that are traced, between 0 and 1.").Default("1").Float64()
 	)
 	kingpin.MustParse(app.Parse(os.Args[1:]))
 
+	o, err := newControllerOptions(*enableGroups, *disableGroups, *maxReconciles, *maxGroupReconciles)
+	kingpin.FatalIfError(err, "Cannot configure Azure controllers")
+
 	zl := zap.New(zap.UseDevMode(*debug))
 	log := logging.NewLogrLogger(zl.WithName("provider-azure"))
 	if *debug {
@@ -52,18 +77,102 @@ func main() {
 
 	log.Debug("Starting", "sync-period", syncInterval.String())
 
+	// Tracing must be set up before any Azure SDK clients are created in
+	// order for their HTTP requests to be traced.
+	shutdownTracing := func(context.Context) error { return nil }
+	if *otlpEndpoint != "" {
+		if *traceSampleRatio < 0 || *traceSampleRatio > 1 {
+			kingpin.Fatalf("trace sample ratio must be between 0 and 1, not %g", *traceSampleRatio)
+		}
+		shutdownTracing, err = tracing.Setup(context.Background(), tracing.Options{
+			Endpoint:    *otlpEndpoint,
+			Insecure:    *otlpInsecure,
+			SampleRatio: *traceSampleRatio,
+		})
+		kingpin.FatalIfError(err, "Cannot set up tracing")
+		log.Debug("Exporting traces", "endpoint", *otlpEndpoint, "sample-ratio", *traceSampleRatio)
+	}
+
 	cfg, err := ctrl.GetConfig()
 	kingpin.FatalIfError(err, "Cannot get API server rest config")
 
 	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
-		LeaderElection:   *leaderElection,
-		LeaderElectionID: "crossplane-leader-election-provider-azure",
-		SyncPeriod:       syncInterval,
+		LeaderElection:         *leaderElection,
+		LeaderElectionID:       "crossplane-leader-election-provider-azure",
+		SyncPeriod:             syncInterval,
+		MetricsBindAddress:     *metricsAddr,
+		HealthProbeBindAddress: *healthAddr,
+
+		// Kinds whose controllers are disabled may still be read, for example
+		// to resolve a cross resource reference. We read them directly from
+		// the API server so that we never need to watch them.
+		ClientDisableCacheFor: o.DisabledKinds(),
 	})
 	kingpin.FatalIfError(err, "Cannot create controller manager")
 
 	kingpin.FatalIfError(apis.AddToScheme(mgr.GetScheme()), "Cannot add Azure APIs to scheme")
-	kingpin.FatalIfError(controller.Setup(mgr, log, ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS), *pollInterval), "Cannot setup Azure controllers")
-	kingpin.FatalIfError(mgr.Start(ctrl.SetupSignalHandler()), "Cannot start controller manager")
+	kingpin.FatalIfError(mgr.AddHealthzCheck("ping", healthz.Ping), "Cannot add liveness check")
+	kingpin.FatalIfError(mgr.AddReadyzCheck("api-server", health.APIServer(mgr.GetAPIReader())), "Cannot add API server readiness check")
+	if *readyAuth {
+		pcc := health.NewProviderConfigChecker(mgr.GetClient(), health.WithAuthenticationTTL(*readyAuthTTL))
+		kingpin.FatalIfError(mgr.AddReadyzCheck("provider-config", pcc.Check), "Cannot add ProviderConfig readiness check")
+	}
+	kingpin.FatalIfError(controller.Setup(mgr, log, ratelimiter.NewDefaultProviderRateLimiter(ratelimiter.DefaultProviderRPS), *pollInterval, o), "Cannot setup Azure controllers")
+	err = mgr.Start(ctrl.SetupSignalHandler())
+
+	ctx, cancel := context.WithTimeout(context.Background(), traceShutdownTimeout)
+	defer cancel()
+	if serr := shutdownTracing(ctx); serr != nil {
+		log.Info("Cannot flush traces", "error", serr)
+	}
+	kingpin.FatalIfError(err, "Cannot start controller manager")
+}
+
+func groupNames() []string {
+	names := make([]string, len(controller.Groups()))
+	for i, g := range controller.Groups() {
+		names[i] = string(g)
+	}
+	return names
+}
+
+func newControllerOptions(enable, disable []string, maxReconciles int, maxGroupReconciles map[string]string) (controller.Options, error) {
+	o := controller.Options{
+		Disabled:                map[controller.Group]bool{},
+		MaxConcurrentReconciles: map[controller.Group]int{},
+	}
+
+	if maxReconciles < 1 {
+		return o, errors.Errorf("max concurrent reconciles must be at least 1, not %d", maxReconciles)
+	}
+
+	for _, g := range controller.Groups() {
+		o.Disabled[g] = len(enable) > 0
+		o.MaxConcurrentReconciles[g] = maxReconciles
+	}
+	for _, g := range enable {
+		if _, ok := o.Disabled[controller.Group(g)]; !ok {
+			return o, errors.Errorf("unknown controller group %q", g)
+		}
+		o.Disabled[controller.Group(g)] = false
+	}
+	for _, g := range disable {
+		if _, ok := o.Disabled[controller.Group(g)]; !ok {
+			return o, errors.Errorf("unknown controller group %q", g)
+		}
+		o.Disabled[controller.Group(g)] = true
+	}
+
+	for g, v := range maxGroupReconciles {
+		if _, ok := o.Disabled[controller.Group(g)]; !ok {
+			return o, errors.Errorf("unknown controller group %q", g)
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return o, errors.Errorf("max concurrent reconciles for controller group %q must be a number of at least 1, not %q", g, v)
+		}
+		o.MaxConcurrentReconciles[controller.Group(g)] = n
+	}
 
+	return o, nil
 }
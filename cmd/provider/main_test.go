@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/pkg/controller"
+)
+
+// options returns controller options in which the supplied groups are
+// disabled and every group runs n concurrent reconciles, unless overridden.
+func options(n int, disabled []controller.Group, overrides map[controller.Group]int) controller.Options {
+	o := controller.Options{
+		Disabled:                map[controller.Group]bool{},
+		MaxConcurrentReconciles: map[controller.Group]int{},
+	}
+	for _, g := range controller.Groups() {
+		o.Disabled[g] = false
+		o.MaxConcurrentReconciles[g] = n
+	}
+	for _, g := range disabled {
+		o.Disabled[g] = true
+	}
+	for g, v := range overrides {
+		o.MaxConcurrentReconciles[g] = v
+	}
+	return o
+}
+
+// allBut returns every controller group except those supplied.
+func allBut(except ...controller.Group) []controller.Group {
+	skip := map[controller.Group]bool{}
+	for _, g := range except {
+		skip[g] = true
+	}
+	var groups []controller.Group
+	for _, g := range controller.Groups() {
+		if !skip[g] {
+			groups = append(groups, g)
+		}
+	}
+	return groups
+}
+
+func TestNewControllerOptions(t *testing.T) {
+	type args struct {
+		enable             []string
+		disable            []string
+		maxReconciles      int
+		maxGroupReconciles map[string]string
+	}
+	type want struct {
+		o   controller.Options
+		err error
+	}
+
+	cases := map[string]struct {
+		reason string
+		args   args
+		want   want
+	}{
+		"Defaults": {
+			reason: "All groups should be enabled when no groups are enabled or disabled.",
+			args:   args{maxReconciles: 1},
+			want:   want{o: options(1, nil, nil)},
+		},
+		"EnableGroups": {
+			reason: "Only the enabled groups should run when any group is enabled.",
+			args: args{
+				enable:        []string{string(controller.GroupStorage), string(controller.GroupNetwork)},
+				maxReconciles: 1,
+			},
+			want: want{o: options(1, allBut(controller.GroupStorage, controller.GroupNetwork), nil)},
+		},
+		"DisableGroups": {
+			reason: "Disabled groups should not run.",
+			args: args{
+				disable:       []string{string(controller.GroupCompute)},
+				maxReconciles: 1,
+			},
+			want: want{o: options(1, []controller.Group{controller.GroupCompute}, nil)},
+		},
+		"DisableOverridesEnable": {
+			reason: "A group that is both enabled and disabled should not run.",
+			args: args{
+				enable:        []string{string(controller.GroupStorage), string(controller.GroupNetwork)},
+				disable:       []string{string(controller.GroupNetwork)},
+				maxReconciles: 1,
+			},
+			want: want{o: options(1, allBut(controller.GroupStorage), nil)},
+		},
+		"UnknownEnabledGroup": {
+			reason: "An unknown enabled group should be rejected.",
+			args: args{
+				enable:        []string{"coolgroup"},
+				maxReconciles: 1,
+			},
+			want: want{err: errors.New(`unknown controller group "coolgroup"`)},
+		},
+		"UnknownDisabledGroup": {
+			reason: "An unknown disabled group should be rejected.",
+			args: args{
+				disable:       []string{"coolgroup"},
+				maxReconciles: 1,
+			},
+			want: want{err: errors.New(`unknown controller group "coolgroup"`)},
+		},
+		"MaxReconciles": {
+			reason: "Every group should run the supplied number of concurrent reconciles.",
+			args:   args{maxReconciles: 5},
+			want:   want{o: options(5, nil, nil)},
+		},
+		"NonPositiveMaxReconciles": {
+			reason: "Fewer than one concurrent reconcile should be rejected.",
+			args:   args{maxReconciles: 0},
+			want:   want{err: errors.New("max concurrent reconciles must be at least 1, not 0")},
+		},
+		"GroupMaxReconciles": {
+			reason: "A group's concurrent reconciles should override the default.",
+			args: args{
+				maxReconciles:      2,
+				maxGroupReconciles: map[string]string{string(controller.GroupStorage): "10"},
+			},
+			want: want{o: options(2, nil, map[controller.Group]int{controller.GroupStorage: 10})},
+		},
+		"UnknownGroupMaxReconciles": {
+			reason: "Concurrent reconciles for an unknown group should be rejected.",
+			args: args{
+				maxReconciles:      1,
+				maxGroupReconciles: map[string]string{"coolgroup": "10"},
+			},
+			want: want{err: errors.New(`unknown controller group "coolgroup"`)},
+		},
+		"NonPositiveGroupMaxReconciles": {
+			reason: "Fewer than one concurrent reconcile for a group should be rejected.",
+			args: args{
+				maxReconciles:      1,
+				maxGroupReconciles: map[string]string{string(controller.GroupStorage): "-1"},
+			},
+			want: want{err: errors.New(`max concurrent reconciles for controller group "storage" must be a number of at least 1, not "-1"`)},
+		},
+		"NonNumericGroupMaxReconciles": {
+			reason: "Concurrent reconciles for a group that are not a number should be rejected.",
+			args: args{
+				maxReconciles:      1,
+				maxGroupReconciles: map[string]string{string(controller.GroupStorage): "many"},
+			},
+			want: want{err: errors.New(`max concurrent reconciles for controller group "storage" must be a number of at least 1, not "many"`)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := newControllerOptions(tc.args.enable, tc.args.disable, tc.args.maxReconciles, tc.args.maxGroupReconciles)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("\n%s\nnewControllerOptions(...): -want error, +got error:\n%s", tc.reason, diff)
+			}
+			if tc.want.err != nil {
+				return
+			}
+			if diff := cmp.Diff(tc.want.o, o, cmpopts.EquateEmpty()); diff != "" {
+				t.Errorf("\n%s\nnewControllerOptions(...): -want, +got:\n%s", tc.reason, diff)
+			}
+		})
+	}
+}
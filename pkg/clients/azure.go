@@ -27,6 +27,8 @@ import (
 	"github.com/Azure/go-autorest/autorest/azure/auth"
 	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +37,7 @@ import (
 
 	"github.com/crossplane/provider-azure/apis/v1alpha3"
 	"github.com/crossplane/provider-azure/apis/v1beta1"
+	"github.com/crossplane/provider-azure/pkg/tracing"
 )
 
 const (
@@ -54,6 +57,8 @@ const (
 	errNeitherPCNorPGiven        = "neither providerConfigRef nor providerRef was supplied"
 	errUnmarshalCredentialSecret = "cannot unmarshal the data in credentials secret"
 	errGetAuthorizer             = "cannot get authorizer from client credentials config"
+	errGetCredentials            = "cannot get credentials"
+	errGetToken                  = "cannot get token from client credentials config"
 )
 
 // A FieldOption determines how common Go types are translated to the types
@@ -86,6 +91,9 @@ const (
 // GetAuthInfo figures out how to connect to Azure API and returns the necessary
 // information to be used for controllers to construct their specific clients.
 func GetAuthInfo(ctx context.Context, c client.Client, mg resource.Managed) (content map[string]string, authorizer autorest.Authorizer, err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "GetAuthInfo")
+	defer func() { tracing.End(span, err) }()
+
 	switch {
 	case mg.GetProviderConfigReference() != nil:
 		return UseProviderConfig(ctx, c, mg)
@@ -133,20 +141,77 @@ func UseProviderConfig(ctx context.Context, c client.Client, mg resource.Managed
 		return nil, nil, errors.Wrap(err, errGetProviderConfig)
 	}
 
+	m, err := ProviderConfigCredentials(ctx, c, pc)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg := newClientCredentialsConfig(m)
+
+	a, err := cfg.Authorizer()
+	return m, a, errors.Wrap(err, errGetAuthorizer)
+}
+
+// ProviderConfigCredentials returns the content of the credentials referenced
+// by the supplied ProviderConfig.
+func ProviderConfigCredentials(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) (map[string]string, error) {
 	data, err := resource.CommonCredentialExtractor(ctx, pc.Spec.Credentials.Source, c, pc.Spec.Credentials.CommonCredentialSelectors)
 	if err != nil {
-		return nil, nil, errors.Wrap(err, "cannot get credentials")
+		return nil, errors.Wrap(err, errGetCredentials)
 	}
 	m := map[string]string{}
 	if err := json.Unmarshal(data, &m); err != nil {
-		return nil, nil, errors.Wrap(err, errUnmarshalCredentialSecret)
+		return nil, errors.Wrap(err, errUnmarshalCredentialSecret)
 	}
-	cfg := auth.NewClientCredentialsConfig(m[CredentialsKeyClientID], m[CredentialsKeyClientSecret], m[CredentialsKeyTenantID])
-	cfg.AADEndpoint = m[CredentialsKeyActiveDirectoryEndpointURL]
-	cfg.Resource = m[CredentialsKeyResourceManagerEndpointURL]
+	return m, nil
+}
+
+// Authenticate returns an error if the supplied credentials cannot be used to
+// obtain a token from Azure Active Directory.
+func Authenticate(ctx context.Context, creds map[string]string) error {
+	spt, err := newClientCredentialsConfig(creds).ServicePrincipalToken()
+	if err != nil {
+		return errors.Wrap(err, errGetToken)
+	}
+	return errors.Wrap(spt.RefreshWithContext(ctx), errGetToken)
+}
+
+// StorageResource is the resource for which tokens must be issued to access
+// the Azure Storage data plane.
+const StorageResource = "https://storage.azure.com/"
 
+// GetToken returns an OAuth token for the supplied resource, obtained from
+// Azure Active Directory using the supplied credentials.
+func GetToken(ctx context.Context, creds map[string]string, resource string) (string, error) {
+	cfg := newClientCredentialsConfig(creds)
+	cfg.Resource = resource
+	spt, err := cfg.ServicePrincipalToken()
+	if err != nil {
+		return "", errors.Wrap(err, errGetToken)
+	}
+	if err := spt.RefreshWithContext(ctx); err != nil {
+		return "", errors.Wrap(err, errGetToken)
+	}
+	return spt.OAuthToken(), nil
+}
+
+// KeyVaultResource is the resource for which tokens must be issued to access
+// the Azure Key Vault data plane.
+const KeyVaultResource = "https://vault.azure.net"
+
+// GetAuthorizer returns an authorizer that uses the supplied credentials to
+// obtain tokens for the supplied resource from Azure Active Directory.
+func GetAuthorizer(creds map[string]string, resource string) (autorest.Authorizer, error) {
+	cfg := newClientCredentialsConfig(creds)
+	cfg.Resource = resource
 	a, err := cfg.Authorizer()
-	return m, a, errors.Wrap(err, errGetAuthorizer)
+	return a, errors.Wrap(err, errGetAuthorizer)
+}
+
+func newClientCredentialsConfig(creds map[string]string) auth.ClientCredentialsConfig {
+	cfg := auth.NewClientCredentialsConfig(creds[CredentialsKeyClientID], creds[CredentialsKeyClientSecret], creds[CredentialsKeyTenantID])
+	cfg.AADEndpoint = creds[CredentialsKeyActiveDirectoryEndpointURL]
+	cfg.Resource = creds[CredentialsKeyResourceManagerEndpointURL]
+	return cfg
 }
 
 // Client struct that represents the information needed to connect to the Azure services as a client
@@ -216,6 +281,9 @@ func FetchAsyncOperation(ctx context.Context, client autorest.Sender, as *v1alph
 	if as == nil || as.PollingURL == "" || as.Method == "" {
 		return nil
 	}
+	ctx, span := tracing.Tracer().Start(ctx, "FetchAsyncOperation")
+	defer span.End()
+
 	// NOTE(muvaf):There is NewFutureFromResponse method to construct Future
 	// object but that requires http.Request object. Even though we construct a
 	// fake http.Request object, the poll operation makes decisions based on the
@@ -241,8 +309,10 @@ func FetchAsyncOperation(ctx context.Context, client autorest.Sender, as *v1alph
 	// related to fetch call.
 	_, err = op.DoneWithContext(ctx, client)
 	as.Status = op.Status()
+	span.SetAttributes(attribute.String("azure.async_operation.status", as.Status))
 	if err != nil {
 		as.ErrorMessage = err.Error()
+		span.SetStatus(codes.Error, as.ErrorMessage)
 	}
 	return nil
 }
@@ -262,6 +332,23 @@ func IsNotFound(err error) bool {
 	return statusCode == http.StatusNotFound
 }
 
+// IsForbidden returns a value indicating whether the given error is an
+// Azure autorest.DetailedError with a 403 status code, i.e. whether the caller
+// is not authorized to perform the request.
+func IsForbidden(err error) bool {
+	detailedError, ok := err.(autorest.DetailedError)
+	if !ok {
+		return false
+	}
+
+	statusCode, ok := detailedError.StatusCode.(int)
+	if !ok {
+		return false
+	}
+
+	return statusCode == http.StatusForbidden
+}
+
 // ToStringPtr converts the supplied string for use with the Azure Go SDK.
 func ToStringPtr(s string, o ...FieldOption) *string {
 	for _, fo := range o {
@@ -163,6 +163,24 @@ func TestIsNotFound(t *testing.T) {
 	}
 }
 
+func TestIsForbidden(t *testing.T) {
+	g := gomega.NewGomegaWithT(t)
+
+	cases := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{autorest.DetailedError{StatusCode: http.StatusNotFound}, false},
+		{autorest.DetailedError{StatusCode: http.StatusForbidden}, true},
+	}
+
+	for _, tt := range cases {
+		actual := IsForbidden(tt.err)
+		g.Expect(actual).To(gomega.Equal(tt.expected))
+	}
+}
+
 func TestStringHelpers(t *testing.T) {
 	t.Run("ToStringMap", func(t *testing.T) {
 		original := make(map[string]*string)
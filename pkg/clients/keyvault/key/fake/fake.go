@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	mgmtkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault"
+	mgmtkeyvaultapi "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault/keyvaultapi"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault/keyvaultapi"
+)
+
+var _ keyvaultapi.BaseClientAPI = &MockClient{}
+
+// MockClient is a fake implementation of keyvaultapi.BaseClientAPI.
+type MockClient struct {
+	keyvaultapi.BaseClientAPI
+
+	MockCreateKey func(ctx context.Context, vaultBaseURL string, keyName string, parameters keyvault.KeyCreateParameters) (result keyvault.KeyBundle, err error)
+	MockDeleteKey func(ctx context.Context, vaultBaseURL string, keyName string) (result keyvault.DeletedKeyBundle, err error)
+	MockGetKey    func(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string) (result keyvault.KeyBundle, err error)
+	MockUpdateKey func(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string, parameters keyvault.KeyUpdateParameters) (result keyvault.KeyBundle, err error)
+}
+
+// CreateKey calls the MockClient's MockCreateKey method.
+func (c *MockClient) CreateKey(ctx context.Context, vaultBaseURL string, keyName string, parameters keyvault.KeyCreateParameters) (result keyvault.KeyBundle, err error) {
+	return c.MockCreateKey(ctx, vaultBaseURL, keyName, parameters)
+}
+
+// DeleteKey calls the MockClient's MockDeleteKey method.
+func (c *MockClient) DeleteKey(ctx context.Context, vaultBaseURL string, keyName string) (result keyvault.DeletedKeyBundle, err error) {
+	return c.MockDeleteKey(ctx, vaultBaseURL, keyName)
+}
+
+// GetKey calls the MockClient's MockGetKey method.
+func (c *MockClient) GetKey(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string) (result keyvault.KeyBundle, err error) {
+	return c.MockGetKey(ctx, vaultBaseURL, keyName, keyVersion)
+}
+
+// UpdateKey calls the MockClient's MockUpdateKey method.
+func (c *MockClient) UpdateKey(ctx context.Context, vaultBaseURL string, keyName string, keyVersion string, parameters keyvault.KeyUpdateParameters) (result keyvault.KeyBundle, err error) {
+	return c.MockUpdateKey(ctx, vaultBaseURL, keyName, keyVersion, parameters)
+}
+
+var _ mgmtkeyvaultapi.VaultsClientAPI = &MockVaultsClient{}
+
+// MockVaultsClient is a fake implementation of mgmtkeyvaultapi.VaultsClientAPI.
+type MockVaultsClient struct {
+	mgmtkeyvaultapi.VaultsClientAPI
+
+	MockListBySubscriptionComplete func(ctx context.Context, top *int32) (result mgmtkeyvault.VaultListResultIterator, err error)
+	MockUpdateAccessPolicy         func(ctx context.Context, resourceGroupName string, vaultName string, operationKind mgmtkeyvault.AccessPolicyUpdateKind, parameters mgmtkeyvault.VaultAccessPolicyParameters) (result mgmtkeyvault.VaultAccessPolicyParameters, err error)
+}
+
+// ListBySubscriptionComplete calls the MockVaultsClient's
+// MockListBySubscriptionComplete method.
+func (c *MockVaultsClient) ListBySubscriptionComplete(ctx context.Context, top *int32) (result mgmtkeyvault.VaultListResultIterator, err error) {
+	return c.MockListBySubscriptionComplete(ctx, top)
+}
+
+// UpdateAccessPolicy calls the MockVaultsClient's MockUpdateAccessPolicy
+// method.
+func (c *MockVaultsClient) UpdateAccessPolicy(ctx context.Context, resourceGroupName string, vaultName string, operationKind mgmtkeyvault.AccessPolicyUpdateKind, parameters mgmtkeyvault.VaultAccessPolicyParameters) (result mgmtkeyvault.VaultAccessPolicyParameters, err error) {
+	return c.MockUpdateAccessPolicy(ctx, resourceGroupName, vaultName, operationKind, parameters)
+}
+
+// NewVaultListResultIterator returns an iterator over a single page of the
+// supplied Vaults.
+func NewVaultListResultIterator(ctx context.Context, v ...mgmtkeyvault.Vault) mgmtkeyvault.VaultListResultIterator {
+	first := true
+	page := mgmtkeyvault.NewVaultListResultPage(func(_ context.Context, _ mgmtkeyvault.VaultListResult) (mgmtkeyvault.VaultListResult, error) {
+		if !first {
+			return mgmtkeyvault.VaultListResult{}, nil
+		}
+		first = false
+		return mgmtkeyvault.VaultListResult{Value: &v}, nil
+	})
+	_ = page.NextWithContext(ctx)
+	return mgmtkeyvault.NewVaultListResultIterator(page)
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package key
+
+import (
+	"context"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	mgmtkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault"
+	mgmtkeyvaultapi "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault/keyvaultapi"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/keyvault/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Error strings.
+const (
+	errParseKeyID    = "cannot parse Key Vault key identifier"
+	errListVaults    = "cannot list Key Vaults"
+	errVaultNotFound = "cannot find Key Vault"
+	errUpdatePolicy  = "cannot update Key Vault access policy"
+)
+
+// A VersionedKey identifies a version of a Key Vault key.
+type VersionedKey struct {
+	// VaultBaseURL of the Key Vault, for example
+	// https://myvault.vault.azure.net.
+	VaultBaseURL string
+
+	// Name of the key.
+	Name string
+
+	// Version of the key. Empty for the latest version.
+	Version string
+}
+
+// ParseID parses a Key Vault key identifier of the form
+// https://myvault.vault.azure.net/keys/mykey, optionally followed by the
+// version of the key.
+func ParseID(id string) (VersionedKey, error) {
+	u, err := url.Parse(id)
+	if err != nil {
+		return VersionedKey{}, errors.Wrap(err, errParseKeyID)
+	}
+	p := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if u.Host == "" || len(p) < 2 || len(p) > 3 || p[0] != "keys" {
+		return VersionedKey{}, errors.Errorf("%s: %s", errParseKeyID, id)
+	}
+	k := VersionedKey{VaultBaseURL: u.Scheme + "://" + u.Host, Name: p[1]}
+	if len(p) == 3 {
+		k.Version = p[2]
+	}
+	return k, nil
+}
+
+// VaultName returns the name of the Key Vault with the supplied base URL.
+func VaultName(vaultBaseURL string) string {
+	u, err := url.Parse(vaultBaseURL)
+	if err != nil {
+		return ""
+	}
+	return strings.Split(u.Hostname(), ".")[0]
+}
+
+// NewKeyCreateParameters returns key creation parameters suitable for use with
+// the Azure API.
+func NewKeyCreateParameters(p v1alpha1.KeyVaultKeyParameters) keyvault.KeyCreateParameters {
+	return keyvault.KeyCreateParameters{
+		Kty:           keyvault.JSONWebKeyType(p.KeyType),
+		KeySize:       azure.ToInt32(p.KeySize),
+		Curve:         keyvault.JSONWebKeyCurveName(azure.ToString(p.Curve)),
+		KeyOps:        newKeyOperations(p.KeyOperations),
+		KeyAttributes: newKeyAttributes(p.KeyAttributes),
+		Tags:          azure.ToStringPtrMap(p.Tags),
+	}
+}
+
+// NewKeyUpdateParameters returns key update parameters suitable for use with
+// the Azure API.
+func NewKeyUpdateParameters(p v1alpha1.KeyVaultKeyParameters) keyvault.KeyUpdateParameters {
+	return keyvault.KeyUpdateParameters{
+		KeyOps:        newKeyOperations(p.KeyOperations),
+		KeyAttributes: newKeyAttributes(p.KeyAttributes),
+		Tags:          azure.ToStringPtrMap(p.Tags),
+	}
+}
+
+// GenerateObservation produces a KeyVaultKeyObservation from the
+// keyvault.KeyBundle received from Azure.
+func GenerateObservation(az keyvault.KeyBundle) v1alpha1.KeyVaultKeyObservation {
+	o := v1alpha1.KeyVaultKeyObservation{Managed: az.Managed}
+	if az.Key != nil {
+		o.ID = azure.ToString(az.Key.Kid)
+		if k, err := ParseID(o.ID); err == nil {
+			o.Version = k.Version
+		}
+	}
+	if a := az.Attributes; a != nil {
+		o.Attributes = &v1alpha1.KeyVaultKeyAttributesObservation{
+			RecoveryLevel: string(a.RecoveryLevel),
+			Created:       toMetaTime(a.Created),
+			Updated:       toMetaTime(a.Updated),
+		}
+	}
+	return o
+}
+
+// LateInitialize fills the empty fields of the supplied KeyVaultKeyParameters
+// with the values of the key received from Azure.
+func LateInitialize(p *v1alpha1.KeyVaultKeyParameters, az keyvault.KeyBundle) {
+	if az.Key != nil {
+		if az.Key.Crv != "" {
+			p.Curve = azure.LateInitializeStringPtrFromPtr(p.Curve, azure.ToStringPtr(string(az.Key.Crv)))
+		}
+		p.KeyOperations = azure.LateInitializeStringValArrFromArrPtr(p.KeyOperations, az.Key.KeyOps)
+	}
+	p.Tags = azure.LateInitializeStringMap(p.Tags, az.Tags)
+	if a := az.Attributes; a != nil {
+		if p.KeyAttributes == nil {
+			p.KeyAttributes = &v1alpha1.KeyVaultKeyAttributesParameters{}
+		}
+		p.KeyAttributes.Enabled = azure.LateInitializeBoolPtrFromPtr(p.KeyAttributes.Enabled, a.Enabled)
+		if p.KeyAttributes.NotBeforeDate == nil {
+			p.KeyAttributes.NotBeforeDate = toMetaTime(a.NotBefore)
+		}
+		if p.KeyAttributes.ExpirationDate == nil {
+			p.KeyAttributes.ExpirationDate = toMetaTime(a.Expires)
+		}
+	}
+}
+
+// IsUpToDate returns true if the supplied key received from Azure matches the
+// supplied KeyVaultKeyParameters. Fields that cannot be updated are not
+// considered.
+func IsUpToDate(p v1alpha1.KeyVaultKeyParameters, az keyvault.KeyBundle) bool {
+	desired := p.DeepCopy()
+	LateInitialize(desired, az)
+
+	observed := &v1alpha1.KeyVaultKeyParameters{}
+	LateInitialize(observed, az)
+
+	return cmp.Equal(desired.KeyAttributes, observed.KeyAttributes) &&
+		cmp.Equal(desired.Tags, observed.Tags, cmpopts.EquateEmpty()) &&
+		cmp.Equal(desired.KeyOperations, observed.KeyOperations, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+// GrantStorageAccess adds an access policy to the Key Vault with the supplied
+// base URL that allows the identity with the supplied object ID to use its
+// keys to encrypt storage Accounts, unless such a policy exists. Vaults that
+// use Azure role-based access control rather than access policies are not
+// supported.
+func GrantStorageAccess(ctx context.Context, c mgmtkeyvaultapi.VaultsClientAPI, vaultBaseURL, objectID string) error {
+	v, err := findVault(ctx, c, VaultName(vaultBaseURL))
+	if err != nil {
+		return err
+	}
+	if hasStoragePermissions(v, objectID) {
+		return nil
+	}
+	p := mgmtkeyvault.VaultAccessPolicyParameters{
+		Properties: &mgmtkeyvault.VaultAccessPolicyProperties{
+			AccessPolicies: &[]mgmtkeyvault.AccessPolicyEntry{{
+				TenantID: v.Properties.TenantID,
+				ObjectID: azure.ToStringPtr(objectID),
+				Permissions: &mgmtkeyvault.Permissions{
+					Keys: &storageKeyPermissions,
+				},
+			}},
+		},
+	}
+	_, err = c.UpdateAccessPolicy(ctx, resourceGroupName(azure.ToString(v.ID)), azure.ToString(v.Name), mgmtkeyvault.Add, p)
+	return errors.Wrap(err, errUpdatePolicy)
+}
+
+// The key permissions required to encrypt storage Accounts.
+var storageKeyPermissions = []mgmtkeyvault.KeyPermissions{
+	mgmtkeyvault.KeyPermissionsGet,
+	mgmtkeyvault.KeyPermissionsWrapKey,
+	mgmtkeyvault.KeyPermissionsUnwrapKey,
+}
+
+func findVault(ctx context.Context, c mgmtkeyvaultapi.VaultsClientAPI, name string) (mgmtkeyvault.Vault, error) {
+	it, err := c.ListBySubscriptionComplete(ctx, nil)
+	for ; err == nil && it.NotDone(); err = it.NextWithContext(ctx) {
+		if v := it.Value(); strings.EqualFold(azure.ToString(v.Name), name) && v.Properties != nil {
+			return v, nil
+		}
+	}
+	if err != nil {
+		return mgmtkeyvault.Vault{}, errors.Wrap(err, errListVaults)
+	}
+	return mgmtkeyvault.Vault{}, errors.Errorf("%s: %s", errVaultNotFound, name)
+}
+
+func hasStoragePermissions(v mgmtkeyvault.Vault, objectID string) bool {
+	if v.Properties.AccessPolicies == nil {
+		return false
+	}
+	for _, e := range *v.Properties.AccessPolicies {
+		if azure.ToString(e.ObjectID) != objectID || e.Permissions == nil || e.Permissions.Keys == nil {
+			continue
+		}
+		granted := map[string]bool{}
+		for _, p := range *e.Permissions.Keys {
+			granted[strings.ToLower(string(p))] = true
+		}
+		ok := true
+		for _, p := range storageKeyPermissions {
+			ok = ok && granted[strings.ToLower(string(p))]
+		}
+		if ok {
+			return true
+		}
+	}
+	return false
+}
+
+// resourceGroupName returns the resource group of the Azure resource with the
+// supplied ID.
+func resourceGroupName(id string) string {
+	p := strings.Split(id, "/")
+	for i := range p {
+		if strings.EqualFold(p[i], "resourceGroups") && i+1 < len(p) {
+			return p[i+1]
+		}
+	}
+	return ""
+}
+
+func newKeyOperations(ops []string) *[]keyvault.JSONWebKeyOperation {
+	if len(ops) == 0 {
+		return nil
+	}
+	out := make([]keyvault.JSONWebKeyOperation, len(ops))
+	for i := range ops {
+		out[i] = keyvault.JSONWebKeyOperation(ops[i])
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return &out
+}
+
+func newKeyAttributes(a *v1alpha1.KeyVaultKeyAttributesParameters) *keyvault.KeyAttributes {
+	if a == nil {
+		return nil
+	}
+	return &keyvault.KeyAttributes{
+		Enabled:   a.Enabled,
+		NotBefore: toUnixTime(a.NotBeforeDate),
+		Expires:   toUnixTime(a.ExpirationDate),
+	}
+}
+
+func toMetaTime(t *date.UnixTime) *metav1.Time {
+	if t == nil {
+		return nil
+	}
+	return &metav1.Time{Time: time.Time(*t)}
+}
+
+func toUnixTime(t *metav1.Time) *date.UnixTime {
+	if t == nil {
+		return nil
+	}
+	u := date.UnixTime(t.Time)
+	return &u
+}
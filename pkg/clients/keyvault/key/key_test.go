@@ -0,0 +1,207 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package key
+
+import (
+	"context"
+	"testing"
+
+	mgmtkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/keyvault/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/keyvault/key/fake"
+)
+
+const (
+	vaultBaseURL = "https://myvault.vault.azure.net"
+	vaultID      = "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.KeyVault/vaults/myvault"
+	principalID  = "principal"
+)
+
+var errorBoom = errors.New("boom")
+
+func TestParseID(t *testing.T) {
+	type want struct {
+		k   VersionedKey
+		err bool
+	}
+
+	cases := map[string]struct {
+		id string
+		want
+	}{
+		"Versionless": {
+			id:   vaultBaseURL + "/keys/coolkey",
+			want: want{k: VersionedKey{VaultBaseURL: vaultBaseURL, Name: "coolkey"}},
+		},
+		"Versioned": {
+			id:   vaultBaseURL + "/keys/coolkey/0123",
+			want: want{k: VersionedKey{VaultBaseURL: vaultBaseURL, Name: "coolkey", Version: "0123"}},
+		},
+		"NotAKey": {
+			id:   vaultBaseURL + "/secrets/coolsecret",
+			want: want{err: true},
+		},
+		"NoHost": {
+			id:   "/keys/coolkey",
+			want: want{err: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			k, err := ParseID(tc.id)
+			if diff := cmp.Diff(tc.want.k, k); diff != "" {
+				t.Errorf("ParseID(...): -want, +got\n%s", diff)
+			}
+			if tc.want.err != (err != nil) {
+				t.Errorf("ParseID(...): want error %t, got %v", tc.want.err, err)
+			}
+		})
+	}
+}
+
+func TestIsUpToDate(t *testing.T) {
+	az := keyvault.KeyBundle{
+		Key:        &keyvault.JSONWebKey{KeyOps: &[]string{"wrapKey", "unwrapKey"}},
+		Attributes: &keyvault.KeyAttributes{Enabled: azure.ToBoolPtr(true)},
+	}
+
+	cases := map[string]struct {
+		p    v1alpha1.KeyVaultKeyParameters
+		want bool
+	}{
+		"KeyOperationsInAnyOrder": {
+			p:    v1alpha1.KeyVaultKeyParameters{KeyOperations: []string{"unwrapKey", "wrapKey"}},
+			want: true,
+		},
+		"KeyOperationsDiffer": {
+			p:    v1alpha1.KeyVaultKeyParameters{KeyOperations: []string{"unwrapKey"}},
+			want: false,
+		},
+		"Disabled": {
+			p: v1alpha1.KeyVaultKeyParameters{
+				KeyAttributes: &v1alpha1.KeyVaultKeyAttributesParameters{Enabled: azure.ToBoolPtr(false, azure.FieldRequired)},
+			},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsUpToDate(tc.p, az); got != tc.want {
+				t.Errorf("IsUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestGrantStorageAccess(t *testing.T) {
+	vault := func(keys ...mgmtkeyvault.KeyPermissions) mgmtkeyvault.Vault {
+		v := mgmtkeyvault.Vault{
+			ID:         azure.ToStringPtr(vaultID),
+			Name:       azure.ToStringPtr("myvault"),
+			Properties: &mgmtkeyvault.VaultProperties{AccessPolicies: &[]mgmtkeyvault.AccessPolicyEntry{}},
+		}
+		if len(keys) > 0 {
+			v.Properties.AccessPolicies = &[]mgmtkeyvault.AccessPolicyEntry{{
+				ObjectID:    azure.ToStringPtr(principalID),
+				Permissions: &mgmtkeyvault.Permissions{Keys: &keys},
+			}}
+		}
+		return v
+	}
+
+	cases := map[string]struct {
+		c   *fake.MockVaultsClient
+		err error
+	}{
+		"Granted": {
+			c: &fake.MockVaultsClient{
+				MockListBySubscriptionComplete: func(ctx context.Context, _ *int32) (mgmtkeyvault.VaultListResultIterator, error) {
+					return fake.NewVaultListResultIterator(ctx, vault()), nil
+				},
+				MockUpdateAccessPolicy: func(_ context.Context, rg string, name string, kind mgmtkeyvault.AccessPolicyUpdateKind, p mgmtkeyvault.VaultAccessPolicyParameters) (mgmtkeyvault.VaultAccessPolicyParameters, error) {
+					want := mgmtkeyvault.VaultAccessPolicyParameters{
+						Properties: &mgmtkeyvault.VaultAccessPolicyProperties{
+							AccessPolicies: &[]mgmtkeyvault.AccessPolicyEntry{{
+								ObjectID:    azure.ToStringPtr(principalID),
+								Permissions: &mgmtkeyvault.Permissions{Keys: &storageKeyPermissions},
+							}},
+						},
+					}
+					if rg != "coolgroup" || name != "myvault" || kind != mgmtkeyvault.Add {
+						return mgmtkeyvault.VaultAccessPolicyParameters{}, errors.Errorf("unexpected %s %s %s", rg, name, kind)
+					}
+					if diff := cmp.Diff(want, p); diff != "" {
+						return mgmtkeyvault.VaultAccessPolicyParameters{}, errors.New(diff)
+					}
+					return p, nil
+				},
+			},
+		},
+		"AlreadyGranted": {
+			c: &fake.MockVaultsClient{
+				MockListBySubscriptionComplete: func(ctx context.Context, _ *int32) (mgmtkeyvault.VaultListResultIterator, error) {
+					return fake.NewVaultListResultIterator(ctx, vault("Get", "WrapKey", "UnwrapKey", "List")), nil
+				},
+			},
+		},
+		"VaultNotFound": {
+			c: &fake.MockVaultsClient{
+				MockListBySubscriptionComplete: func(ctx context.Context, _ *int32) (mgmtkeyvault.VaultListResultIterator, error) {
+					return fake.NewVaultListResultIterator(ctx), nil
+				},
+			},
+			err: errors.Errorf("%s: %s", errVaultNotFound, "myvault"),
+		},
+		"ListFailed": {
+			c: &fake.MockVaultsClient{
+				MockListBySubscriptionComplete: func(_ context.Context, _ *int32) (mgmtkeyvault.VaultListResultIterator, error) {
+					return mgmtkeyvault.VaultListResultIterator{}, errorBoom
+				},
+			},
+			err: errors.Wrap(errorBoom, errListVaults),
+		},
+		"UpdateFailed": {
+			c: &fake.MockVaultsClient{
+				MockListBySubscriptionComplete: func(ctx context.Context, _ *int32) (mgmtkeyvault.VaultListResultIterator, error) {
+					return fake.NewVaultListResultIterator(ctx, vault("get")), nil
+				},
+				MockUpdateAccessPolicy: func(_ context.Context, _ string, _ string, _ mgmtkeyvault.AccessPolicyUpdateKind, _ mgmtkeyvault.VaultAccessPolicyParameters) (mgmtkeyvault.VaultAccessPolicyParameters, error) {
+					return mgmtkeyvault.VaultAccessPolicyParameters{}, errorBoom
+				},
+			},
+			err: errors.Wrap(errorBoom, errUpdatePolicy),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := GrantStorageAccess(context.Background(), tc.c, vaultBaseURL, principalID)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("GrantStorageAccess(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
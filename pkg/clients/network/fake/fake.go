@@ -86,3 +86,221 @@ func (c *MockSubnetsClient) Get(ctx context.Context, resourceGroupName string, v
 func (c *MockSubnetsClient) List(ctx context.Context, resourceGroupName string, virtualNetworkName string) (result network.SubnetListResultPage, err error) {
 	return c.MockList(ctx, resourceGroupName, virtualNetworkName)
 }
+
+var _ networkapi.SecurityGroupsClientAPI = &MockSecurityGroupsClient{}
+
+// MockSecurityGroupsClient is a fake implementation of
+// network.SecurityGroupsClient.
+type MockSecurityGroupsClient struct {
+	networkapi.SecurityGroupsClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, parameters network.SecurityGroup) (result network.SecurityGroupsCreateOrUpdateFuture, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, networkSecurityGroupName string) (result network.SecurityGroupsDeleteFuture, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, expand string) (result network.SecurityGroup, err error)
+}
+
+// CreateOrUpdate calls the MockSecurityGroupsClient's MockCreateOrUpdate
+// method.
+func (c *MockSecurityGroupsClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, parameters network.SecurityGroup) (result network.SecurityGroupsCreateOrUpdateFuture, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, networkSecurityGroupName, parameters)
+}
+
+// Delete calls the MockSecurityGroupsClient's MockDelete method.
+func (c *MockSecurityGroupsClient) Delete(ctx context.Context, resourceGroupName string, networkSecurityGroupName string) (result network.SecurityGroupsDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, networkSecurityGroupName)
+}
+
+// Get calls the MockSecurityGroupsClient's MockGet method.
+func (c *MockSecurityGroupsClient) Get(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, expand string) (result network.SecurityGroup, err error) {
+	return c.MockGet(ctx, resourceGroupName, networkSecurityGroupName, expand)
+}
+
+var _ networkapi.SecurityRulesClientAPI = &MockSecurityRulesClient{}
+
+// MockSecurityRulesClient is a fake implementation of
+// network.SecurityRulesClient.
+type MockSecurityRulesClient struct {
+	networkapi.SecurityRulesClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, securityRuleName string, securityRuleParameters network.SecurityRule) (result network.SecurityRulesCreateOrUpdateFuture, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, securityRuleName string) (result network.SecurityRulesDeleteFuture, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, securityRuleName string) (result network.SecurityRule, err error)
+}
+
+// CreateOrUpdate calls the MockSecurityRulesClient's MockCreateOrUpdate
+// method.
+func (c *MockSecurityRulesClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, securityRuleName string, securityRuleParameters network.SecurityRule) (result network.SecurityRulesCreateOrUpdateFuture, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, networkSecurityGroupName, securityRuleName, securityRuleParameters)
+}
+
+// Delete calls the MockSecurityRulesClient's MockDelete method.
+func (c *MockSecurityRulesClient) Delete(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, securityRuleName string) (result network.SecurityRulesDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, networkSecurityGroupName, securityRuleName)
+}
+
+// Get calls the MockSecurityRulesClient's MockGet method.
+func (c *MockSecurityRulesClient) Get(ctx context.Context, resourceGroupName string, networkSecurityGroupName string, securityRuleName string) (result network.SecurityRule, err error) {
+	return c.MockGet(ctx, resourceGroupName, networkSecurityGroupName, securityRuleName)
+}
+
+var _ networkapi.RouteTablesClientAPI = &MockRouteTablesClient{}
+
+// MockRouteTablesClient is a fake implementation of network.RouteTablesClient.
+type MockRouteTablesClient struct {
+	networkapi.RouteTablesClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, routeTableName string, parameters network.RouteTable) (result network.RouteTablesCreateOrUpdateFuture, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, routeTableName string) (result network.RouteTablesDeleteFuture, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, routeTableName string, expand string) (result network.RouteTable, err error)
+}
+
+// CreateOrUpdate calls the MockRouteTablesClient's MockCreateOrUpdate method.
+func (c *MockRouteTablesClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, routeTableName string, parameters network.RouteTable) (result network.RouteTablesCreateOrUpdateFuture, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, routeTableName, parameters)
+}
+
+// Delete calls the MockRouteTablesClient's MockDelete method.
+func (c *MockRouteTablesClient) Delete(ctx context.Context, resourceGroupName string, routeTableName string) (result network.RouteTablesDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, routeTableName)
+}
+
+// Get calls the MockRouteTablesClient's MockGet method.
+func (c *MockRouteTablesClient) Get(ctx context.Context, resourceGroupName string, routeTableName string, expand string) (result network.RouteTable, err error) {
+	return c.MockGet(ctx, resourceGroupName, routeTableName, expand)
+}
+
+var _ networkapi.NatGatewaysClientAPI = &MockNatGatewaysClient{}
+
+// MockNatGatewaysClient is a fake implementation of network.NatGatewaysClient.
+type MockNatGatewaysClient struct {
+	networkapi.NatGatewaysClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, natGatewayName string, parameters network.NatGateway) (result network.NatGatewaysCreateOrUpdateFuture, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, natGatewayName string) (result network.NatGatewaysDeleteFuture, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, natGatewayName string, expand string) (result network.NatGateway, err error)
+}
+
+// CreateOrUpdate calls the MockNatGatewaysClient's MockCreateOrUpdate method.
+func (c *MockNatGatewaysClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, natGatewayName string, parameters network.NatGateway) (result network.NatGatewaysCreateOrUpdateFuture, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, natGatewayName, parameters)
+}
+
+// Delete calls the MockNatGatewaysClient's MockDelete method.
+func (c *MockNatGatewaysClient) Delete(ctx context.Context, resourceGroupName string, natGatewayName string) (result network.NatGatewaysDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, natGatewayName)
+}
+
+// Get calls the MockNatGatewaysClient's MockGet method.
+func (c *MockNatGatewaysClient) Get(ctx context.Context, resourceGroupName string, natGatewayName string, expand string) (result network.NatGateway, err error) {
+	return c.MockGet(ctx, resourceGroupName, natGatewayName, expand)
+}
+
+var _ networkapi.RoutesClientAPI = &MockRoutesClient{}
+
+// MockRoutesClient is a fake implementation of network.RoutesClient.
+type MockRoutesClient struct {
+	networkapi.RoutesClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, routeTableName string, routeName string, routeParameters network.Route) (result network.RoutesCreateOrUpdateFuture, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, routeTableName string, routeName string) (result network.RoutesDeleteFuture, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, routeTableName string, routeName string) (result network.Route, err error)
+}
+
+// CreateOrUpdate calls the MockRoutesClient's MockCreateOrUpdate method.
+func (c *MockRoutesClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, routeTableName string, routeName string, routeParameters network.Route) (result network.RoutesCreateOrUpdateFuture, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, routeTableName, routeName, routeParameters)
+}
+
+// Delete calls the MockRoutesClient's MockDelete method.
+func (c *MockRoutesClient) Delete(ctx context.Context, resourceGroupName string, routeTableName string, routeName string) (result network.RoutesDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, routeTableName, routeName)
+}
+
+// Get calls the MockRoutesClient's MockGet method.
+func (c *MockRoutesClient) Get(ctx context.Context, resourceGroupName string, routeTableName string, routeName string) (result network.Route, err error) {
+	return c.MockGet(ctx, resourceGroupName, routeTableName, routeName)
+}
+
+var _ networkapi.VirtualNetworkPeeringsClientAPI = &MockVirtualNetworkPeeringsClient{}
+
+// MockVirtualNetworkPeeringsClient is a fake implementation of
+// network.VirtualNetworkPeeringsClient.
+type MockVirtualNetworkPeeringsClient struct {
+	networkapi.VirtualNetworkPeeringsClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, virtualNetworkName string, virtualNetworkPeeringName string, virtualNetworkPeeringParameters network.VirtualNetworkPeering) (result network.VirtualNetworkPeeringsCreateOrUpdateFuture, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, virtualNetworkName string, virtualNetworkPeeringName string) (result network.VirtualNetworkPeeringsDeleteFuture, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, virtualNetworkName string, virtualNetworkPeeringName string) (result network.VirtualNetworkPeering, err error)
+}
+
+// CreateOrUpdate calls the MockVirtualNetworkPeeringsClient's
+// MockCreateOrUpdate method.
+func (c *MockVirtualNetworkPeeringsClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, virtualNetworkName string, virtualNetworkPeeringName string, virtualNetworkPeeringParameters network.VirtualNetworkPeering) (result network.VirtualNetworkPeeringsCreateOrUpdateFuture, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, virtualNetworkName, virtualNetworkPeeringName, virtualNetworkPeeringParameters)
+}
+
+// Delete calls the MockVirtualNetworkPeeringsClient's MockDelete method.
+func (c *MockVirtualNetworkPeeringsClient) Delete(ctx context.Context, resourceGroupName string, virtualNetworkName string, virtualNetworkPeeringName string) (result network.VirtualNetworkPeeringsDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, virtualNetworkName, virtualNetworkPeeringName)
+}
+
+// Get calls the MockVirtualNetworkPeeringsClient's MockGet method.
+func (c *MockVirtualNetworkPeeringsClient) Get(ctx context.Context, resourceGroupName string, virtualNetworkName string, virtualNetworkPeeringName string) (result network.VirtualNetworkPeering, err error) {
+	return c.MockGet(ctx, resourceGroupName, virtualNetworkName, virtualNetworkPeeringName)
+}
+
+var _ networkapi.PublicIPAddressesClientAPI = &MockPublicIPAddressesClient{}
+
+// MockPublicIPAddressesClient is a fake implementation of
+// network.PublicIPAddressesClient.
+type MockPublicIPAddressesClient struct {
+	networkapi.PublicIPAddressesClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, publicIPAddressName string, parameters network.PublicIPAddress) (result network.PublicIPAddressesCreateOrUpdateFuture, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, publicIPAddressName string) (result network.PublicIPAddressesDeleteFuture, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, publicIPAddressName string, expand string) (result network.PublicIPAddress, err error)
+}
+
+// CreateOrUpdate calls the MockPublicIPAddressesClient's MockCreateOrUpdate
+// method.
+func (c *MockPublicIPAddressesClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, publicIPAddressName string, parameters network.PublicIPAddress) (result network.PublicIPAddressesCreateOrUpdateFuture, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, publicIPAddressName, parameters)
+}
+
+// Delete calls the MockPublicIPAddressesClient's MockDelete method.
+func (c *MockPublicIPAddressesClient) Delete(ctx context.Context, resourceGroupName string, publicIPAddressName string) (result network.PublicIPAddressesDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, publicIPAddressName)
+}
+
+// Get calls the MockPublicIPAddressesClient's MockGet method.
+func (c *MockPublicIPAddressesClient) Get(ctx context.Context, resourceGroupName string, publicIPAddressName string, expand string) (result network.PublicIPAddress, err error) {
+	return c.MockGet(ctx, resourceGroupName, publicIPAddressName, expand)
+}
+
+var _ networkapi.PublicIPPrefixesClientAPI = &MockPublicIPPrefixesClient{}
+
+// MockPublicIPPrefixesClient is a fake implementation of
+// network.PublicIPPrefixesClient.
+type MockPublicIPPrefixesClient struct {
+	networkapi.PublicIPPrefixesClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, publicIPPrefixName string, parameters network.PublicIPPrefix) (result network.PublicIPPrefixesCreateOrUpdateFuture, err error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, publicIPPrefixName string) (result network.PublicIPPrefixesDeleteFuture, err error)
+	MockGet            func(ctx context.Context, resourceGroupName string, publicIPPrefixName string, expand string) (result network.PublicIPPrefix, err error)
+}
+
+// CreateOrUpdate calls the MockPublicIPPrefixesClient's MockCreateOrUpdate
+// method.
+func (c *MockPublicIPPrefixesClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, publicIPPrefixName string, parameters network.PublicIPPrefix) (result network.PublicIPPrefixesCreateOrUpdateFuture, err error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, publicIPPrefixName, parameters)
+}
+
+// Delete calls the MockPublicIPPrefixesClient's MockDelete method.
+func (c *MockPublicIPPrefixesClient) Delete(ctx context.Context, resourceGroupName string, publicIPPrefixName string) (result network.PublicIPPrefixesDeleteFuture, err error) {
+	return c.MockDelete(ctx, resourceGroupName, publicIPPrefixName)
+}
+
+// Get calls the MockPublicIPPrefixesClient's MockGet method.
+func (c *MockPublicIPPrefixesClient) Get(ctx context.Context, resourceGroupName string, publicIPPrefixName string, expand string) (result network.PublicIPPrefix, err error) {
+	return c.MockGet(ctx, resourceGroupName, publicIPPrefixName, expand)
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// NewNATGatewayParameters returns an Azure NatGateway object from a NAT
+// gateway spec. NAT gateways are only available in the Standard SKU.
+func NewNATGatewayParameters(n *v1alpha3.NATGateway) networkmgmt.NatGateway {
+	az := networkmgmt.NatGateway{
+		Location: azure.ToStringPtr(n.Spec.Location),
+		Tags:     azure.ToStringPtrMap(n.Spec.Tags),
+		Sku:      &networkmgmt.NatGatewaySku{Name: networkmgmt.Standard},
+		NatGatewayPropertiesFormat: &networkmgmt.NatGatewayPropertiesFormat{
+			IdleTimeoutInMinutes: azure.ToInt32(n.Spec.IdleTimeoutInMinutes),
+			PublicIPAddresses:    newSubResources(n.Spec.PublicIPAddressIDs),
+			PublicIPPrefixes:     newSubResources(n.Spec.PublicIPPrefixIDs),
+		},
+	}
+	if len(n.Spec.Zones) > 0 {
+		zones := make([]string, len(n.Spec.Zones))
+		copy(zones, n.Spec.Zones)
+		az.Zones = &zones
+	}
+	return az
+}
+
+func newSubResources(ids []string) *[]networkmgmt.SubResource {
+	if len(ids) == 0 {
+		return nil
+	}
+	s := make([]networkmgmt.SubResource, len(ids))
+	for i, id := range ids {
+		s[i] = networkmgmt.SubResource{ID: azure.ToStringPtr(id)}
+	}
+	return &s
+}
+
+// NATGatewayNeedsUpdate determines if a NAT gateway needs to be updated. The
+// zones of a NAT gateway cannot be updated, so they are not compared. The
+// idle timeout is only compared if it is specified, because Azure defaults
+// it.
+func NATGatewayNeedsUpdate(kube *v1alpha3.NATGateway, az networkmgmt.NatGateway) bool {
+	if !reflect.DeepEqual(azure.ToStringPtrMap(kube.Spec.Tags), az.Tags) {
+		return true
+	}
+	if az.NatGatewayPropertiesFormat == nil {
+		return true
+	}
+	p := kube.Spec.NATGatewayPropertiesFormat
+	if p.IdleTimeoutInMinutes != nil && *p.IdleTimeoutInMinutes != azure.ToInt(az.IdleTimeoutInMinutes) {
+		return true
+	}
+	return !sameIDs(p.PublicIPAddressIDs, az.PublicIPAddresses) || !sameIDs(p.PublicIPPrefixIDs, az.PublicIPPrefixes)
+}
+
+// sameIDs reports whether the supplied IDs are those of the supplied sub
+// resources, regardless of order and case.
+func sameIDs(ids []string, s *[]networkmgmt.SubResource) bool {
+	want := make([]string, len(ids))
+	for i, id := range ids {
+		want[i] = strings.ToLower(id)
+	}
+	got := []string{}
+	if s != nil {
+		for _, r := range *s {
+			got = append(got, strings.ToLower(azure.ToString(r.ID)))
+		}
+	}
+	sort.Strings(want)
+	sort.Strings(got)
+	return reflect.DeepEqual(want, got)
+}
+
+// UpdateNATGatewayStatusFromAzure updates the status related to the external
+// Azure NAT gateway in the NATGatewayStatus
+func UpdateNATGatewayStatusFromAzure(n *v1alpha3.NATGateway, az networkmgmt.NatGateway) {
+	n.Status.ID = azure.ToString(az.ID)
+	n.Status.Etag = azure.ToString(az.Etag)
+	if az.NatGatewayPropertiesFormat != nil {
+		n.Status.State = azure.ToString(az.ProvisioningState)
+		n.Status.ResourceGUID = azure.ToString(az.ResourceGUID)
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"strings"
+	"testing"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const (
+	publicIPAddressID = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/publicIPAddresses/cool-ip"
+	publicIPPrefixID  = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/publicIPPrefixes/cool-prefix"
+)
+
+func TestNewNATGatewayParameters(t *testing.T) {
+	timeout := 10
+
+	cases := []struct {
+		name string
+		n    *v1alpha3.NATGateway
+		want networkmgmt.NatGateway
+	}{
+		{
+			name: "Minimal",
+			n: &v1alpha3.NATGateway{
+				Spec: v1alpha3.NATGatewaySpec{
+					Location: location,
+					Tags:     tags,
+				},
+			},
+			want: networkmgmt.NatGateway{
+				Location:                   azure.ToStringPtr(location),
+				Tags:                       azure.ToStringPtrMap(tags),
+				Sku:                        &networkmgmt.NatGatewaySku{Name: networkmgmt.Standard},
+				NatGatewayPropertiesFormat: &networkmgmt.NatGatewayPropertiesFormat{},
+			},
+		},
+		{
+			name: "Full",
+			n: &v1alpha3.NATGateway{
+				Spec: v1alpha3.NATGatewaySpec{
+					Location: location,
+					Zones:    []string{"1"},
+					NATGatewayPropertiesFormat: v1alpha3.NATGatewayPropertiesFormat{
+						IdleTimeoutInMinutes: &timeout,
+						PublicIPAddressIDs:   []string{publicIPAddressID},
+						PublicIPPrefixIDs:    []string{publicIPPrefixID},
+					},
+				},
+			},
+			want: networkmgmt.NatGateway{
+				Location: azure.ToStringPtr(location),
+				Sku:      &networkmgmt.NatGatewaySku{Name: networkmgmt.Standard},
+				Zones:    &[]string{"1"},
+				NatGatewayPropertiesFormat: &networkmgmt.NatGatewayPropertiesFormat{
+					IdleTimeoutInMinutes: azure.ToInt32Ptr(10),
+					PublicIPAddresses:    &[]networkmgmt.SubResource{{ID: azure.ToStringPtr(publicIPAddressID)}},
+					PublicIPPrefixes:     &[]networkmgmt.SubResource{{ID: azure.ToStringPtr(publicIPPrefixID)}},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewNATGatewayParameters(tc.n)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewNATGatewayParameters(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNATGatewayNeedsUpdate(t *testing.T) {
+	timeout := 10
+	kube := &v1alpha3.NATGateway{
+		Spec: v1alpha3.NATGatewaySpec{
+			Tags: tags,
+			NATGatewayPropertiesFormat: v1alpha3.NATGatewayPropertiesFormat{
+				PublicIPAddressIDs: []string{publicIPAddressID},
+			},
+		},
+	}
+
+	cases := []struct {
+		name string
+		kube *v1alpha3.NATGateway
+		az   networkmgmt.NatGateway
+		want bool
+	}{
+		{
+			name: "NoUpdate",
+			kube: kube,
+			az: networkmgmt.NatGateway{
+				Tags: azure.ToStringPtrMap(tags),
+				NatGatewayPropertiesFormat: &networkmgmt.NatGatewayPropertiesFormat{
+					IdleTimeoutInMinutes: azure.ToInt32Ptr(4),
+					PublicIPAddresses:    &[]networkmgmt.SubResource{{ID: azure.ToStringPtr(strings.ToLower(publicIPAddressID))}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "NeedsUpdateIdleTimeout",
+			kube: &v1alpha3.NATGateway{
+				Spec: v1alpha3.NATGatewaySpec{
+					NATGatewayPropertiesFormat: v1alpha3.NATGatewayPropertiesFormat{
+						IdleTimeoutInMinutes: &timeout,
+					},
+				},
+			},
+			az: networkmgmt.NatGateway{
+				NatGatewayPropertiesFormat: &networkmgmt.NatGatewayPropertiesFormat{
+					IdleTimeoutInMinutes: azure.ToInt32Ptr(4),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdatePublicIPAddresses",
+			kube: kube,
+			az: networkmgmt.NatGateway{
+				Tags:                       azure.ToStringPtrMap(tags),
+				NatGatewayPropertiesFormat: &networkmgmt.NatGatewayPropertiesFormat{},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdatePublicIPPrefixes",
+			kube: kube,
+			az: networkmgmt.NatGateway{
+				Tags: azure.ToStringPtrMap(tags),
+				NatGatewayPropertiesFormat: &networkmgmt.NatGatewayPropertiesFormat{
+					PublicIPAddresses: &[]networkmgmt.SubResource{{ID: azure.ToStringPtr(publicIPAddressID)}},
+					PublicIPPrefixes:  &[]networkmgmt.SubResource{{ID: azure.ToStringPtr(publicIPPrefixID)}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdateTags",
+			kube: kube,
+			az: networkmgmt.NatGateway{
+				NatGatewayPropertiesFormat: &networkmgmt.NatGatewayPropertiesFormat{
+					PublicIPAddresses: &[]networkmgmt.SubResource{{ID: azure.ToStringPtr(publicIPAddressID)}},
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NATGatewayNeedsUpdate(tc.kube, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NATGatewayNeedsUpdate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,8 +18,11 @@ package network
 
 import (
 	"reflect"
+	"strings"
 
 	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
@@ -68,14 +71,61 @@ func UpdateVirtualNetworkStatusFromAzure(v *v1alpha3.VirtualNetwork, az networkm
 	v.Status.Type = azure.ToString(az.Type)
 }
 
-// NewSubnetParameters returns an Azure Subnet object from a subnet spec
-func NewSubnetParameters(s *v1alpha3.Subnet) networkmgmt.Subnet {
-	return networkmgmt.Subnet{
-		SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
-			AddressPrefix:    azure.ToStringPtr(s.Spec.SubnetPropertiesFormat.AddressPrefix),
-			ServiceEndpoints: NewServiceEndpoints(s.Spec.SubnetPropertiesFormat.ServiceEndpoints),
-		},
+// NewSubnetParameters returns an Azure Subnet object from a subnet spec. The
+// security group, route table and NAT gateway associations of the supplied
+// observed subnet, if any, are kept unless they are specified, so that
+// associations managed elsewhere are not removed.
+func NewSubnetParameters(s *v1alpha3.Subnet, observed *networkmgmt.SubnetPropertiesFormat) networkmgmt.Subnet {
+	p := s.Spec.SubnetPropertiesFormat
+	az := &networkmgmt.SubnetPropertiesFormat{
+		AddressPrefix:                     azure.ToStringPtr(p.AddressPrefix),
+		ServiceEndpoints:                  NewServiceEndpoints(p.ServiceEndpoints),
+		Delegations:                       NewDelegations(p.Delegations),
+		PrivateEndpointNetworkPolicies:    azure.ToStringPtr(p.PrivateEndpointNetworkPolicies),
+		PrivateLinkServiceNetworkPolicies: azure.ToStringPtr(p.PrivateLinkServiceNetworkPolicies),
+	}
+	if len(p.AddressPrefixes) > 0 {
+		prefixes := make([]string, len(p.AddressPrefixes))
+		copy(prefixes, p.AddressPrefixes)
+		az.AddressPrefixes = &prefixes
+	}
+	if observed != nil {
+		az.NetworkSecurityGroup = observed.NetworkSecurityGroup
+		az.RouteTable = observed.RouteTable
+		az.NatGateway = observed.NatGateway
+	}
+	if p.NetworkSecurityGroupID != "" {
+		az.NetworkSecurityGroup = &networkmgmt.SecurityGroup{ID: azure.ToStringPtr(p.NetworkSecurityGroupID)}
+	}
+	if p.RouteTableID != "" {
+		az.RouteTable = &networkmgmt.RouteTable{ID: azure.ToStringPtr(p.RouteTableID)}
+	}
+	if p.NATGatewayID != "" {
+		az.NatGateway = &networkmgmt.SubResource{ID: azure.ToStringPtr(p.NATGatewayID)}
+	}
+	return networkmgmt.Subnet{SubnetPropertiesFormat: az}
+}
+
+// NewDelegations converts to Azure Delegations
+func NewDelegations(d []v1alpha3.Delegation) *[]networkmgmt.Delegation {
+	if len(d) == 0 {
+		return nil
 	}
+	delegations := make([]networkmgmt.Delegation, len(d))
+	for i, del := range d {
+		delegations[i] = networkmgmt.Delegation{
+			Name: azure.ToStringPtr(del.Name),
+			ServiceDelegationPropertiesFormat: &networkmgmt.ServiceDelegationPropertiesFormat{
+				ServiceName: azure.ToStringPtr(del.ServiceName),
+			},
+		}
+		if len(del.Actions) > 0 {
+			actions := make([]string, len(del.Actions))
+			copy(actions, del.Actions)
+			delegations[i].Actions = &actions
+		}
+	}
+	return &delegations
 }
 
 // NewServiceEndpoints converts to Azure ServiceEndpointPropertiesFormat
@@ -91,11 +141,70 @@ func NewServiceEndpoints(e []v1alpha3.ServiceEndpointPropertiesFormat) *[]networ
 	return &endpoints
 }
 
-// SubnetNeedsUpdate determines if a virtual network need to be updated
+// SubnetNeedsUpdate determines if a subnet needs to be updated. Network
+// policies and delegation actions are only compared when they are specified,
+// because Azure defaults them. Security group, route table and NAT gateway
+// associations are only compared when they are specified, because they may be
+// managed elsewhere, for example by AKS or Azure Policy.
 func SubnetNeedsUpdate(kube *v1alpha3.Subnet, az networkmgmt.Subnet) bool {
-	up := NewSubnetParameters(kube)
+	if az.SubnetPropertiesFormat == nil {
+		return true
+	}
+	p := kube.Spec.SubnetPropertiesFormat
+	var nsg, rt, nat *string
+	if az.NetworkSecurityGroup != nil {
+		nsg = az.NetworkSecurityGroup.ID
+	}
+	if az.RouteTable != nil {
+		rt = az.RouteTable.ID
+	}
+	if az.NatGateway != nil {
+		nat = az.NatGateway.ID
+	}
 
-	return !reflect.DeepEqual(up.SubnetPropertiesFormat.AddressPrefix, az.SubnetPropertiesFormat.AddressPrefix)
+	switch {
+	case !cmp.Equal(toList(azure.ToStringPtr(p.AddressPrefix), &p.AddressPrefixes), toList(az.AddressPrefix, az.AddressPrefixes),
+		cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b })):
+		return true
+	case p.NetworkSecurityGroupID != "" && !strings.EqualFold(p.NetworkSecurityGroupID, azure.ToString(nsg)):
+		return true
+	case p.RouteTableID != "" && !strings.EqualFold(p.RouteTableID, azure.ToString(rt)):
+		return true
+	case p.NATGatewayID != "" && !strings.EqualFold(p.NATGatewayID, azure.ToString(nat)):
+		return true
+	case p.PrivateEndpointNetworkPolicies != "" && p.PrivateEndpointNetworkPolicies != azure.ToString(az.PrivateEndpointNetworkPolicies):
+		return true
+	case p.PrivateLinkServiceNetworkPolicies != "" && p.PrivateLinkServiceNetworkPolicies != azure.ToString(az.PrivateLinkServiceNetworkPolicies):
+		return true
+	}
+	return !delegationsUpToDate(p.Delegations, az.Delegations)
+}
+
+func delegationsUpToDate(kube []v1alpha3.Delegation, az *[]networkmgmt.Delegation) bool {
+	observed := map[string]networkmgmt.ServiceDelegationPropertiesFormat{}
+	if az != nil {
+		for _, d := range *az {
+			if d.ServiceDelegationPropertiesFormat != nil {
+				observed[azure.ToString(d.Name)] = *d.ServiceDelegationPropertiesFormat
+			}
+		}
+	}
+	if len(kube) != len(observed) {
+		return false
+	}
+	for _, d := range kube {
+		o, ok := observed[d.Name]
+		if !ok || azure.ToString(o.ServiceName) != d.ServiceName {
+			return false
+		}
+		if len(d.Actions) == 0 {
+			continue
+		}
+		if !cmp.Equal(d.Actions, toList(nil, o.Actions), cmpopts.SortSlices(func(a, b string) bool { return a < b })) {
+			return false
+		}
+	}
+	return true
 }
 
 // UpdateSubnetStatusFromAzure updates the status related to the external
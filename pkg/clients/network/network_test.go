@@ -17,6 +17,7 @@ limitations under the License.
 package network
 
 import (
+	"strings"
 	"testing"
 
 	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
@@ -45,6 +46,12 @@ var (
 	etag         = "a-very-cool-etag"
 	resourceType = "resource-type"
 	purpose      = "cool-purpose"
+
+	nsgID             = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/networkSecurityGroups/cool-nsg"
+	routeTableID      = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/routeTables/cool-rt"
+	natGatewayID      = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/natGateways/cool-nat"
+	delegationName    = "cool-delegation"
+	delegationService = "Microsoft.DBforPostgreSQL/flexibleServers"
 )
 
 func TestNewVirtualNetworkParameters(t *testing.T) {
@@ -357,9 +364,10 @@ func TestUpdateVirtualNetworkStatusFromAzure(t *testing.T) {
 
 func TestNewSubnetParameters(t *testing.T) {
 	cases := []struct {
-		name string
-		r    *v1alpha3.Subnet
-		want networkmgmt.Subnet
+		name     string
+		r        *v1alpha3.Subnet
+		observed *networkmgmt.SubnetPropertiesFormat
+		want     networkmgmt.Subnet
 	}{
 		{
 			name: "Successful",
@@ -378,11 +386,68 @@ func TestNewSubnetParameters(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "SuccessfulAssociations",
+			r: &v1alpha3.Subnet{
+				ObjectMeta: metav1.ObjectMeta{UID: uid},
+				Spec: v1alpha3.SubnetSpec{
+					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+						AddressPrefixes:                addressPrefixes,
+						NetworkSecurityGroupID:         nsgID,
+						RouteTableID:                   routeTableID,
+						NATGatewayID:                   natGatewayID,
+						Delegations:                    []v1alpha3.Delegation{{Name: delegationName, ServiceName: delegationService}},
+						PrivateEndpointNetworkPolicies: "Disabled",
+					},
+				},
+			},
+			want: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefixes:      &addressPrefixes,
+					ServiceEndpoints:     NewServiceEndpoints(nil),
+					NetworkSecurityGroup: &networkmgmt.SecurityGroup{ID: azure.ToStringPtr(nsgID)},
+					RouteTable:           &networkmgmt.RouteTable{ID: azure.ToStringPtr(routeTableID)},
+					NatGateway:           &networkmgmt.SubResource{ID: azure.ToStringPtr(natGatewayID)},
+					Delegations: &[]networkmgmt.Delegation{{
+						Name: azure.ToStringPtr(delegationName),
+						ServiceDelegationPropertiesFormat: &networkmgmt.ServiceDelegationPropertiesFormat{
+							ServiceName: azure.ToStringPtr(delegationService),
+						},
+					}},
+					PrivateEndpointNetworkPolicies: azure.ToStringPtr("Disabled"),
+				},
+			},
+		},
+		{
+			name: "SuccessfulKeepObservedAssociations",
+			r: &v1alpha3.Subnet{
+				ObjectMeta: metav1.ObjectMeta{UID: uid},
+				Spec: v1alpha3.SubnetSpec{
+					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+						AddressPrefix: addressPrefix,
+						NATGatewayID:  natGatewayID,
+					},
+				},
+			},
+			observed: &networkmgmt.SubnetPropertiesFormat{
+				NetworkSecurityGroup: &networkmgmt.SecurityGroup{ID: azure.ToStringPtr(nsgID)},
+				RouteTable:           &networkmgmt.RouteTable{ID: azure.ToStringPtr(routeTableID)},
+			},
+			want: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefix:        azure.ToStringPtr(addressPrefix),
+					ServiceEndpoints:     NewServiceEndpoints(nil),
+					NetworkSecurityGroup: &networkmgmt.SecurityGroup{ID: azure.ToStringPtr(nsgID)},
+					RouteTable:           &networkmgmt.RouteTable{ID: azure.ToStringPtr(routeTableID)},
+					NatGateway:           &networkmgmt.SubResource{ID: azure.ToStringPtr(natGatewayID)},
+				},
+			},
+		},
 	}
 
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
-			got := NewSubnetParameters(tc.r)
+			got := NewSubnetParameters(tc.r, tc.observed)
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Errorf("NewSubnetParameters(...): -want, +got\n%s", diff)
 			}
@@ -461,6 +526,131 @@ func TestSubnetNeedsUpdate(t *testing.T) {
 			},
 			want: false,
 		},
+		{
+			name: "NoUpdateAssociations",
+			kube: &v1alpha3.Subnet{
+				Spec: v1alpha3.SubnetSpec{
+					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+						AddressPrefixes:        []string{"10.0.1.0/24", addressPrefix},
+						NetworkSecurityGroupID: nsgID,
+						RouteTableID:           routeTableID,
+						Delegations:            []v1alpha3.Delegation{{Name: delegationName, ServiceName: delegationService}},
+					},
+				},
+			},
+			az: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefixes:      &[]string{addressPrefix, "10.0.1.0/24"},
+					NetworkSecurityGroup: &networkmgmt.SecurityGroup{ID: azure.ToStringPtr(strings.ToUpper(nsgID))},
+					RouteTable:           &networkmgmt.RouteTable{ID: azure.ToStringPtr(routeTableID)},
+					Delegations: &[]networkmgmt.Delegation{{
+						Name: azure.ToStringPtr(delegationName),
+						ServiceDelegationPropertiesFormat: &networkmgmt.ServiceDelegationPropertiesFormat{
+							ServiceName: azure.ToStringPtr(delegationService),
+							Actions:     &[]string{"Microsoft.Network/virtualNetworks/subnets/join/action"},
+						},
+					}},
+					PrivateEndpointNetworkPolicies:    azure.ToStringPtr("Enabled"),
+					PrivateLinkServiceNetworkPolicies: azure.ToStringPtr("Enabled"),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "NoUpdateAssociationsManagedElsewhere",
+			kube: &v1alpha3.Subnet{
+				Spec: v1alpha3.SubnetSpec{
+					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+						AddressPrefix: addressPrefix,
+					},
+				},
+			},
+			az: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefix:        &addressPrefix,
+					NetworkSecurityGroup: &networkmgmt.SecurityGroup{ID: azure.ToStringPtr(nsgID)},
+					RouteTable:           &networkmgmt.RouteTable{ID: azure.ToStringPtr(routeTableID)},
+					NatGateway:           &networkmgmt.SubResource{ID: azure.ToStringPtr(natGatewayID)},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "NeedsUpdateSecurityGroupChanged",
+			kube: &v1alpha3.Subnet{
+				Spec: v1alpha3.SubnetSpec{
+					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+						AddressPrefix:          addressPrefix,
+						NetworkSecurityGroupID: nsgID,
+					},
+				},
+			},
+			az: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefix:        &addressPrefix,
+					NetworkSecurityGroup: &networkmgmt.SecurityGroup{ID: azure.ToStringPtr(nsgID + "-old")},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdateNATGateway",
+			kube: &v1alpha3.Subnet{
+				Spec: v1alpha3.SubnetSpec{
+					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+						AddressPrefix: addressPrefix,
+						NATGatewayID:  natGatewayID,
+					},
+				},
+			},
+			az: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefix: &addressPrefix,
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdateDelegationService",
+			kube: &v1alpha3.Subnet{
+				Spec: v1alpha3.SubnetSpec{
+					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+						AddressPrefix: addressPrefix,
+						Delegations:   []v1alpha3.Delegation{{Name: delegationName, ServiceName: delegationService}},
+					},
+				},
+			},
+			az: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefix: &addressPrefix,
+					Delegations: &[]networkmgmt.Delegation{{
+						Name: azure.ToStringPtr(delegationName),
+						ServiceDelegationPropertiesFormat: &networkmgmt.ServiceDelegationPropertiesFormat{
+							ServiceName: azure.ToStringPtr("Microsoft.Sql/managedInstances"),
+						},
+					}},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdateNetworkPolicies",
+			kube: &v1alpha3.Subnet{
+				Spec: v1alpha3.SubnetSpec{
+					SubnetPropertiesFormat: v1alpha3.SubnetPropertiesFormat{
+						AddressPrefix:                     addressPrefix,
+						PrivateLinkServiceNetworkPolicies: "Disabled",
+					},
+				},
+			},
+			az: networkmgmt.Subnet{
+				SubnetPropertiesFormat: &networkmgmt.SubnetPropertiesFormat{
+					AddressPrefix:                     &addressPrefix,
+					PrivateLinkServiceNetworkPolicies: azure.ToStringPtr("Enabled"),
+				},
+			},
+			want: true,
+		},
 	}
 
 	for _, tc := range cases {
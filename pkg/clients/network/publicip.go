@@ -0,0 +1,150 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"reflect"
+	"strings"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Connection secret keys of a PublicIPAddress and a PublicIPPrefix.
+const (
+	ConnectionSecretKeyIPAddress = "ipAddress"
+	ConnectionSecretKeyFQDN      = "fqdn"
+	ConnectionSecretKeyIPPrefix  = "ipPrefix"
+)
+
+// NewPublicIPAddressParameters returns an Azure PublicIPAddress object from a
+// public IP address spec
+func NewPublicIPAddressParameters(p *v1alpha3.PublicIPAddress) networkmgmt.PublicIPAddress {
+	az := networkmgmt.PublicIPAddress{
+		Location: azure.ToStringPtr(p.Spec.Location),
+		Tags:     azure.ToStringPtrMap(p.Spec.Tags),
+		PublicIPAddressPropertiesFormat: &networkmgmt.PublicIPAddressPropertiesFormat{
+			PublicIPAllocationMethod: networkmgmt.IPAllocationMethod(p.Spec.PublicIPAllocationMethod),
+			PublicIPAddressVersion:   networkmgmt.IPVersion(p.Spec.PublicIPAddressVersion),
+			IdleTimeoutInMinutes:     azure.ToInt32(p.Spec.IdleTimeoutInMinutes),
+		},
+	}
+	if p.Spec.SKU != nil {
+		az.Sku = &networkmgmt.PublicIPAddressSku{Name: networkmgmt.PublicIPAddressSkuName(p.Spec.SKU.Name)}
+	}
+	if len(p.Spec.Zones) > 0 {
+		zones := make([]string, len(p.Spec.Zones))
+		copy(zones, p.Spec.Zones)
+		az.Zones = &zones
+	}
+	if d := p.Spec.DNSSettings; d != nil {
+		az.DNSSettings = &networkmgmt.PublicIPAddressDNSSettings{
+			DomainNameLabel: azure.ToStringPtr(d.DomainNameLabel),
+			ReverseFqdn:     azure.ToStringPtr(d.ReverseFQDN),
+		}
+	}
+	if p.Spec.PublicIPPrefixID != "" {
+		az.PublicIPPrefix = &networkmgmt.SubResource{ID: azure.ToStringPtr(p.Spec.PublicIPPrefixID)}
+	}
+	return az
+}
+
+// PublicIPAddressNeedsUpdate determines if a public IP address needs to be
+// updated. The SKU, IP version, zones and prefix of a public IP address cannot
+// be updated, so they are not compared.
+func PublicIPAddressNeedsUpdate(kube *v1alpha3.PublicIPAddress, az networkmgmt.PublicIPAddress) bool {
+	if !reflect.DeepEqual(azure.ToStringPtrMap(kube.Spec.Tags), az.Tags) {
+		return true
+	}
+	if az.PublicIPAddressPropertiesFormat == nil {
+		return true
+	}
+	p := kube.Spec.PublicIPAddressPropertiesFormat
+	if !strings.EqualFold(p.PublicIPAllocationMethod, string(az.PublicIPAllocationMethod)) {
+		return true
+	}
+	if p.IdleTimeoutInMinutes != nil && *p.IdleTimeoutInMinutes != azure.ToInt(az.IdleTimeoutInMinutes) {
+		return true
+	}
+	var label, reverse *string
+	if az.DNSSettings != nil {
+		label, reverse = az.DNSSettings.DomainNameLabel, az.DNSSettings.ReverseFqdn
+	}
+	var want v1alpha3.PublicIPAddressDNSSettings
+	if p.DNSSettings != nil {
+		want = *p.DNSSettings
+	}
+	return want.DomainNameLabel != azure.ToString(label) || want.ReverseFQDN != azure.ToString(reverse)
+}
+
+// UpdatePublicIPAddressStatusFromAzure updates the status related to the
+// external Azure public IP address in the PublicIPAddressStatus
+func UpdatePublicIPAddressStatusFromAzure(p *v1alpha3.PublicIPAddress, az networkmgmt.PublicIPAddress) {
+	p.Status.ID = azure.ToString(az.ID)
+	p.Status.Etag = azure.ToString(az.Etag)
+	if az.PublicIPAddressPropertiesFormat == nil {
+		return
+	}
+	p.Status.State = azure.ToString(az.ProvisioningState)
+	p.Status.ResourceGUID = azure.ToString(az.ResourceGUID)
+	p.Status.IPAddress = azure.ToString(az.IPAddress)
+	p.Status.FQDN = ""
+	if az.DNSSettings != nil {
+		p.Status.FQDN = azure.ToString(az.DNSSettings.Fqdn)
+	}
+}
+
+// NewPublicIPPrefixParameters returns an Azure PublicIPPrefix object from a
+// public IP prefix spec. Public IP prefixes are only available in the Standard
+// SKU.
+func NewPublicIPPrefixParameters(p *v1alpha3.PublicIPPrefix) networkmgmt.PublicIPPrefix {
+	az := networkmgmt.PublicIPPrefix{
+		Location: azure.ToStringPtr(p.Spec.Location),
+		Tags:     azure.ToStringPtrMap(p.Spec.Tags),
+		Sku:      &networkmgmt.PublicIPPrefixSku{Name: networkmgmt.PublicIPPrefixSkuNameStandard},
+		PublicIPPrefixPropertiesFormat: &networkmgmt.PublicIPPrefixPropertiesFormat{
+			PrefixLength:           azure.ToInt32Ptr(p.Spec.PrefixLength, azure.FieldRequired),
+			PublicIPAddressVersion: networkmgmt.IPVersion(p.Spec.PublicIPAddressVersion),
+		},
+	}
+	if len(p.Spec.Zones) > 0 {
+		zones := make([]string, len(p.Spec.Zones))
+		copy(zones, p.Spec.Zones)
+		az.Zones = &zones
+	}
+	return az
+}
+
+// PublicIPPrefixNeedsUpdate determines if a public IP prefix needs to be
+// updated. Only the tags of a public IP prefix can be updated.
+func PublicIPPrefixNeedsUpdate(kube *v1alpha3.PublicIPPrefix, az networkmgmt.PublicIPPrefix) bool {
+	return !reflect.DeepEqual(azure.ToStringPtrMap(kube.Spec.Tags), az.Tags)
+}
+
+// UpdatePublicIPPrefixStatusFromAzure updates the status related to the
+// external Azure public IP prefix in the PublicIPPrefixStatus
+func UpdatePublicIPPrefixStatusFromAzure(p *v1alpha3.PublicIPPrefix, az networkmgmt.PublicIPPrefix) {
+	p.Status.ID = azure.ToString(az.ID)
+	p.Status.Etag = azure.ToString(az.Etag)
+	if az.PublicIPPrefixPropertiesFormat != nil {
+		p.Status.State = azure.ToString(az.ProvisioningState)
+		p.Status.ResourceGUID = azure.ToString(az.ResourceGUID)
+		p.Status.IPPrefix = azure.ToString(az.IPPrefix)
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestNewPublicIPAddressParameters(t *testing.T) {
+	timeout := 10
+	prefixID := "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Network/publicIPPrefixes/prefix"
+
+	cases := []struct {
+		name string
+		p    *v1alpha3.PublicIPAddress
+		want networkmgmt.PublicIPAddress
+	}{
+		{
+			name: "Minimal",
+			p: &v1alpha3.PublicIPAddress{
+				Spec: v1alpha3.PublicIPAddressSpec{
+					Location: location,
+					PublicIPAddressPropertiesFormat: v1alpha3.PublicIPAddressPropertiesFormat{
+						PublicIPAllocationMethod: "Dynamic",
+					},
+				},
+			},
+			want: networkmgmt.PublicIPAddress{
+				Location: azure.ToStringPtr(location),
+				PublicIPAddressPropertiesFormat: &networkmgmt.PublicIPAddressPropertiesFormat{
+					PublicIPAllocationMethod: networkmgmt.Dynamic,
+				},
+			},
+		},
+		{
+			name: "Full",
+			p: &v1alpha3.PublicIPAddress{
+				Spec: v1alpha3.PublicIPAddressSpec{
+					Location: location,
+					Tags:     tags,
+					SKU:      &v1alpha3.PublicIPAddressSKU{Name: "Standard"},
+					Zones:    []string{"1", "2"},
+					PublicIPAddressPropertiesFormat: v1alpha3.PublicIPAddressPropertiesFormat{
+						PublicIPAllocationMethod: "Static",
+						PublicIPAddressVersion:   "IPv4",
+						IdleTimeoutInMinutes:     &timeout,
+						DNSSettings:              &v1alpha3.PublicIPAddressDNSSettings{DomainNameLabel: "cool"},
+						PublicIPPrefixID:         prefixID,
+					},
+				},
+			},
+			want: networkmgmt.PublicIPAddress{
+				Location: azure.ToStringPtr(location),
+				Tags:     azure.ToStringPtrMap(tags),
+				Sku:      &networkmgmt.PublicIPAddressSku{Name: networkmgmt.PublicIPAddressSkuNameStandard},
+				Zones:    &[]string{"1", "2"},
+				PublicIPAddressPropertiesFormat: &networkmgmt.PublicIPAddressPropertiesFormat{
+					PublicIPAllocationMethod: networkmgmt.Static,
+					PublicIPAddressVersion:   networkmgmt.IPv4,
+					IdleTimeoutInMinutes:     azure.ToInt32Ptr(timeout),
+					DNSSettings: &networkmgmt.PublicIPAddressDNSSettings{
+						DomainNameLabel: azure.ToStringPtr("cool"),
+					},
+					PublicIPPrefix: &networkmgmt.SubResource{ID: azure.ToStringPtr(prefixID)},
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewPublicIPAddressParameters(tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewPublicIPAddressParameters(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPublicIPAddressNeedsUpdate(t *testing.T) {
+	timeout := 10
+	kube := &v1alpha3.PublicIPAddress{
+		Spec: v1alpha3.PublicIPAddressSpec{
+			Tags: tags,
+			PublicIPAddressPropertiesFormat: v1alpha3.PublicIPAddressPropertiesFormat{
+				PublicIPAllocationMethod: "Static",
+				IdleTimeoutInMinutes:     &timeout,
+				DNSSettings:              &v1alpha3.PublicIPAddressDNSSettings{DomainNameLabel: "cool"},
+			},
+		},
+	}
+
+	cases := []struct {
+		name string
+		kube *v1alpha3.PublicIPAddress
+		az   networkmgmt.PublicIPAddress
+		want bool
+	}{
+		{
+			name: "NoUpdate",
+			kube: kube,
+			az: networkmgmt.PublicIPAddress{
+				Tags: azure.ToStringPtrMap(tags),
+				PublicIPAddressPropertiesFormat: &networkmgmt.PublicIPAddressPropertiesFormat{
+					PublicIPAllocationMethod: networkmgmt.Static,
+					IdleTimeoutInMinutes:     azure.ToInt32Ptr(timeout),
+					DNSSettings: &networkmgmt.PublicIPAddressDNSSettings{
+						DomainNameLabel: azure.ToStringPtr("cool"),
+						Fqdn:            azure.ToStringPtr("cool.coolplace.cloudapp.azure.com"),
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "NeedsUpdateAllocationMethod",
+			kube: kube,
+			az: networkmgmt.PublicIPAddress{
+				Tags: azure.ToStringPtrMap(tags),
+				PublicIPAddressPropertiesFormat: &networkmgmt.PublicIPAddressPropertiesFormat{
+					PublicIPAllocationMethod: networkmgmt.Dynamic,
+					IdleTimeoutInMinutes:     azure.ToInt32Ptr(timeout),
+					DNSSettings: &networkmgmt.PublicIPAddressDNSSettings{
+						DomainNameLabel: azure.ToStringPtr("cool"),
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdateDNSSettings",
+			kube: kube,
+			az: networkmgmt.PublicIPAddress{
+				Tags: azure.ToStringPtrMap(tags),
+				PublicIPAddressPropertiesFormat: &networkmgmt.PublicIPAddressPropertiesFormat{
+					PublicIPAllocationMethod: networkmgmt.Static,
+					IdleTimeoutInMinutes:     azure.ToInt32Ptr(timeout),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdateTags",
+			kube: kube,
+			az: networkmgmt.PublicIPAddress{
+				PublicIPAddressPropertiesFormat: &networkmgmt.PublicIPAddressPropertiesFormat{
+					PublicIPAllocationMethod: networkmgmt.Static,
+					IdleTimeoutInMinutes:     azure.ToInt32Ptr(timeout),
+					DNSSettings: &networkmgmt.PublicIPAddressDNSSettings{
+						DomainNameLabel: azure.ToStringPtr("cool"),
+					},
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := PublicIPAddressNeedsUpdate(tc.kube, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("PublicIPAddressNeedsUpdate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewPublicIPPrefixParameters(t *testing.T) {
+	p := &v1alpha3.PublicIPPrefix{
+		Spec: v1alpha3.PublicIPPrefixSpec{
+			Location: location,
+			Tags:     tags,
+			Zones:    []string{"1"},
+			PublicIPPrefixPropertiesFormat: v1alpha3.PublicIPPrefixPropertiesFormat{
+				PrefixLength:           30,
+				PublicIPAddressVersion: "IPv4",
+			},
+		},
+	}
+	want := networkmgmt.PublicIPPrefix{
+		Location: azure.ToStringPtr(location),
+		Tags:     azure.ToStringPtrMap(tags),
+		Sku:      &networkmgmt.PublicIPPrefixSku{Name: networkmgmt.PublicIPPrefixSkuNameStandard},
+		Zones:    &[]string{"1"},
+		PublicIPPrefixPropertiesFormat: &networkmgmt.PublicIPPrefixPropertiesFormat{
+			PrefixLength:           azure.ToInt32Ptr(30),
+			PublicIPAddressVersion: networkmgmt.IPv4,
+		},
+	}
+
+	got := NewPublicIPPrefixParameters(p)
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("NewPublicIPPrefixParameters(...): -want, +got\n%s", diff)
+	}
+}
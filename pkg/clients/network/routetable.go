@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"reflect"
+	"strings"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// NewRouteTableParameters returns an Azure RouteTable object from a route
+// table spec. The supplied routes received from Azure are kept, so that routes
+// managed by Route resources are not removed.
+func NewRouteTableParameters(rt *v1alpha3.RouteTable, observed *[]networkmgmt.Route) networkmgmt.RouteTable {
+	return networkmgmt.RouteTable{
+		Location: azure.ToStringPtr(rt.Spec.Location),
+		Tags:     azure.ToStringPtrMap(rt.Spec.Tags),
+		RouteTablePropertiesFormat: &networkmgmt.RouteTablePropertiesFormat{
+			Routes:                     observed,
+			DisableBgpRoutePropagation: azure.ToBoolPtr(rt.Spec.DisableBGPRoutePropagation, azure.FieldRequired),
+		},
+	}
+}
+
+// RouteTableNeedsUpdate determines if a route table needs to be updated.
+func RouteTableNeedsUpdate(kube *v1alpha3.RouteTable, az networkmgmt.RouteTable) bool {
+	if !reflect.DeepEqual(azure.ToStringPtrMap(kube.Spec.Tags), az.Tags) {
+		return true
+	}
+	if az.RouteTablePropertiesFormat == nil {
+		return true
+	}
+	return kube.Spec.DisableBGPRoutePropagation != azure.ToBool(az.DisableBgpRoutePropagation)
+}
+
+// UpdateRouteTableStatusFromAzure updates the status related to the external
+// Azure route table in the RouteTableStatus
+func UpdateRouteTableStatusFromAzure(rt *v1alpha3.RouteTable, az networkmgmt.RouteTable) {
+	rt.Status.ID = azure.ToString(az.ID)
+	rt.Status.Etag = azure.ToString(az.Etag)
+	if az.RouteTablePropertiesFormat != nil {
+		rt.Status.State = azure.ToString(az.ProvisioningState)
+	}
+}
+
+// NewRouteParameters returns an Azure Route object from a route spec
+func NewRouteParameters(r *v1alpha3.Route) networkmgmt.Route {
+	return networkmgmt.Route{
+		RoutePropertiesFormat: &networkmgmt.RoutePropertiesFormat{
+			AddressPrefix:    azure.ToStringPtr(r.Spec.AddressPrefix),
+			NextHopType:      networkmgmt.RouteNextHopType(r.Spec.NextHopType),
+			NextHopIPAddress: azure.ToStringPtr(r.Spec.NextHopIPAddress),
+		},
+	}
+}
+
+// RouteNeedsUpdate determines if a route needs to be updated. Next hop types
+// are compared case-insensitively, as Azure does not preserve their case.
+func RouteNeedsUpdate(kube *v1alpha3.Route, az networkmgmt.Route) bool {
+	if az.RoutePropertiesFormat == nil {
+		return true
+	}
+	p := kube.Spec.RoutePropertiesFormat
+	return p.AddressPrefix != azure.ToString(az.AddressPrefix) ||
+		!strings.EqualFold(p.NextHopType, string(az.NextHopType)) ||
+		p.NextHopIPAddress != azure.ToString(az.NextHopIPAddress)
+}
+
+// UpdateRouteStatusFromAzure updates the status related to the external Azure
+// route in the RouteStatus
+func UpdateRouteStatusFromAzure(r *v1alpha3.Route, az networkmgmt.Route) {
+	r.Status.ID = azure.ToString(az.ID)
+	r.Status.Etag = azure.ToString(az.Etag)
+	if az.RoutePropertiesFormat != nil {
+		r.Status.State = azure.ToString(az.ProvisioningState)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestNewRouteTableParameters(t *testing.T) {
+	observed := &[]networkmgmt.Route{{Name: azure.ToStringPtr("managed-elsewhere")}}
+
+	cases := []struct {
+		name     string
+		r        *v1alpha3.RouteTable
+		observed *[]networkmgmt.Route
+		want     networkmgmt.RouteTable
+	}{
+		{
+			name: "KeepObservedRoutes",
+			r: &v1alpha3.RouteTable{
+				Spec: v1alpha3.RouteTableSpec{
+					Location: location,
+					Tags:     tags,
+				},
+			},
+			observed: observed,
+			want: networkmgmt.RouteTable{
+				Location: azure.ToStringPtr(location),
+				Tags:     azure.ToStringPtrMap(tags),
+				RouteTablePropertiesFormat: &networkmgmt.RouteTablePropertiesFormat{
+					Routes:                     observed,
+					DisableBgpRoutePropagation: azure.ToBoolPtr(false, azure.FieldRequired),
+				},
+			},
+		},
+		{
+			name: "DisableBGPRoutePropagation",
+			r: &v1alpha3.RouteTable{
+				Spec: v1alpha3.RouteTableSpec{
+					Location: location,
+					RouteTablePropertiesFormat: v1alpha3.RouteTablePropertiesFormat{
+						DisableBGPRoutePropagation: true,
+					},
+				},
+			},
+			want: networkmgmt.RouteTable{
+				Location: azure.ToStringPtr(location),
+				RouteTablePropertiesFormat: &networkmgmt.RouteTablePropertiesFormat{
+					DisableBgpRoutePropagation: azure.ToBoolPtr(true),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewRouteTableParameters(tc.r, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewRouteTableParameters(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRouteTableNeedsUpdate(t *testing.T) {
+	cases := []struct {
+		name string
+		kube *v1alpha3.RouteTable
+		az   networkmgmt.RouteTable
+		want bool
+	}{
+		{
+			name: "NoUpdate",
+			kube: &v1alpha3.RouteTable{Spec: v1alpha3.RouteTableSpec{Tags: tags}},
+			az: networkmgmt.RouteTable{
+				Tags:                       azure.ToStringPtrMap(tags),
+				RouteTablePropertiesFormat: &networkmgmt.RouteTablePropertiesFormat{},
+			},
+			want: false,
+		},
+		{
+			name: "NeedsUpdateBGPRoutePropagation",
+			kube: &v1alpha3.RouteTable{
+				Spec: v1alpha3.RouteTableSpec{
+					RouteTablePropertiesFormat: v1alpha3.RouteTablePropertiesFormat{
+						DisableBGPRoutePropagation: true,
+					},
+				},
+			},
+			az: networkmgmt.RouteTable{
+				RouteTablePropertiesFormat: &networkmgmt.RouteTablePropertiesFormat{
+					DisableBgpRoutePropagation: azure.ToBoolPtr(false, azure.FieldRequired),
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdateTags",
+			kube: &v1alpha3.RouteTable{Spec: v1alpha3.RouteTableSpec{Tags: tags}},
+			az: networkmgmt.RouteTable{
+				RouteTablePropertiesFormat: &networkmgmt.RouteTablePropertiesFormat{},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := RouteTableNeedsUpdate(tc.kube, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RouteTableNeedsUpdate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRouteNeedsUpdate(t *testing.T) {
+	kube := &v1alpha3.Route{
+		Spec: v1alpha3.RouteSpec{
+			RoutePropertiesFormat: v1alpha3.RoutePropertiesFormat{
+				AddressPrefix:    "0.0.0.0/0",
+				NextHopType:      "VirtualAppliance",
+				NextHopIPAddress: "10.0.0.4",
+			},
+		},
+	}
+
+	cases := []struct {
+		name string
+		kube *v1alpha3.Route
+		az   networkmgmt.Route
+		want bool
+	}{
+		{
+			name: "NoUpdate",
+			kube: kube,
+			az: networkmgmt.Route{
+				RoutePropertiesFormat: &networkmgmt.RoutePropertiesFormat{
+					AddressPrefix:    azure.ToStringPtr("0.0.0.0/0"),
+					NextHopType:      networkmgmt.RouteNextHopType("virtualAppliance"),
+					NextHopIPAddress: azure.ToStringPtr("10.0.0.4"),
+				},
+			},
+			want: false,
+		},
+		{
+			name: "NeedsUpdateNextHop",
+			kube: kube,
+			az: networkmgmt.Route{
+				RoutePropertiesFormat: &networkmgmt.RoutePropertiesFormat{
+					AddressPrefix: azure.ToStringPtr("0.0.0.0/0"),
+					NextHopType:   networkmgmt.RouteNextHopTypeInternet,
+				},
+			},
+			want: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := RouteNeedsUpdate(tc.kube, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("RouteNeedsUpdate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"reflect"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// NewSecurityGroupParameters returns an Azure SecurityGroup object from a
+// security group spec. The supplied security rules received from Azure are
+// kept if the spec does not define any, so that rules managed by
+// SecurityRule resources are not removed.
+func NewSecurityGroupParameters(sg *v1alpha3.SecurityGroup, observed *[]networkmgmt.SecurityRule) networkmgmt.SecurityGroup {
+	rules := observed
+	if len(sg.Spec.SecurityRules) > 0 {
+		r := make([]networkmgmt.SecurityRule, len(sg.Spec.SecurityRules))
+		for i, sr := range sg.Spec.SecurityRules {
+			r[i] = networkmgmt.SecurityRule{
+				Name:                         azure.ToStringPtr(sr.Name),
+				SecurityRulePropertiesFormat: NewSecurityRulePropertiesFormat(sr.SecurityRulePropertiesFormat),
+			}
+		}
+		rules = &r
+	}
+	return networkmgmt.SecurityGroup{
+		Location: azure.ToStringPtr(sg.Spec.Location),
+		Tags:     azure.ToStringPtrMap(sg.Spec.Tags),
+		SecurityGroupPropertiesFormat: &networkmgmt.SecurityGroupPropertiesFormat{
+			SecurityRules: rules,
+		},
+	}
+}
+
+// SecurityGroupNeedsUpdate determines if a security group needs to be
+// updated. The order of security rules, and of their port ranges, address
+// prefixes and application security groups, is not significant.
+func SecurityGroupNeedsUpdate(kube *v1alpha3.SecurityGroup, az networkmgmt.SecurityGroup) bool {
+	if !reflect.DeepEqual(azure.ToStringPtrMap(kube.Spec.Tags), az.Tags) {
+		return true
+	}
+	if len(kube.Spec.SecurityRules) == 0 {
+		return false
+	}
+	var observed []v1alpha3.SecurityGroupRule
+	if az.SecurityGroupPropertiesFormat != nil && az.SecurityRules != nil {
+		for _, sr := range *az.SecurityRules {
+			observed = append(observed, v1alpha3.SecurityGroupRule{
+				Name:                         azure.ToString(sr.Name),
+				SecurityRulePropertiesFormat: GenerateSecurityRulePropertiesFormat(sr.SecurityRulePropertiesFormat),
+			})
+		}
+	}
+	return !cmp.Equal(kube.Spec.SecurityRules, observed, cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b v1alpha3.SecurityGroupRule) bool { return a.Name < b.Name }),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+// UpdateSecurityGroupStatusFromAzure updates the status related to the
+// external Azure security group in the SecurityGroupStatus
+func UpdateSecurityGroupStatusFromAzure(sg *v1alpha3.SecurityGroup, az networkmgmt.SecurityGroup) {
+	sg.Status.ID = azure.ToString(az.ID)
+	sg.Status.Etag = azure.ToString(az.Etag)
+	if az.SecurityGroupPropertiesFormat != nil {
+		sg.Status.State = azure.ToString(az.ProvisioningState)
+		sg.Status.ResourceGUID = azure.ToString(az.ResourceGUID)
+	}
+}
+
+// NewSecurityRuleParameters returns an Azure SecurityRule object from a
+// security rule spec
+func NewSecurityRuleParameters(sr *v1alpha3.SecurityRule) networkmgmt.SecurityRule {
+	return networkmgmt.SecurityRule{
+		SecurityRulePropertiesFormat: NewSecurityRulePropertiesFormat(sr.Spec.SecurityRulePropertiesFormat),
+	}
+}
+
+// SecurityRuleNeedsUpdate determines if a security rule needs to be updated.
+// The order of port ranges, address prefixes and application security groups
+// is not significant.
+func SecurityRuleNeedsUpdate(kube *v1alpha3.SecurityRule, az networkmgmt.SecurityRule) bool {
+	return !cmp.Equal(kube.Spec.SecurityRulePropertiesFormat, GenerateSecurityRulePropertiesFormat(az.SecurityRulePropertiesFormat),
+		cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+// UpdateSecurityRuleStatusFromAzure updates the status related to the
+// external Azure security rule in the SecurityRuleStatus
+func UpdateSecurityRuleStatusFromAzure(sr *v1alpha3.SecurityRule, az networkmgmt.SecurityRule) {
+	sr.Status.ID = azure.ToString(az.ID)
+	sr.Status.Etag = azure.ToString(az.Etag)
+	if az.SecurityRulePropertiesFormat != nil {
+		sr.Status.State = azure.ToString(az.ProvisioningState)
+	}
+}
+
+// NewSecurityRulePropertiesFormat converts to Azure
+// SecurityRulePropertiesFormat. Azure accepts either a single port range or
+// address prefix, which may be '*' or a service tag, or a list of them, so a
+// list of one element is sent as a single value.
+func NewSecurityRulePropertiesFormat(p v1alpha3.SecurityRulePropertiesFormat) *networkmgmt.SecurityRulePropertiesFormat {
+	az := &networkmgmt.SecurityRulePropertiesFormat{
+		Description:                          azure.ToStringPtr(p.Description),
+		Protocol:                             networkmgmt.SecurityRuleProtocol(p.Protocol),
+		SourceApplicationSecurityGroups:      newApplicationSecurityGroups(p.SourceApplicationSecurityGroupIDs),
+		DestinationApplicationSecurityGroups: newApplicationSecurityGroups(p.DestinationApplicationSecurityGroupIDs),
+		Access:                               networkmgmt.SecurityRuleAccess(p.Access),
+		Priority:                             azure.ToInt32Ptr(p.Priority, azure.FieldRequired),
+		Direction:                            networkmgmt.SecurityRuleDirection(p.Direction),
+	}
+	az.SourcePortRange, az.SourcePortRanges = singleOrList(p.SourcePortRanges)
+	az.DestinationPortRange, az.DestinationPortRanges = singleOrList(p.DestinationPortRanges)
+	az.SourceAddressPrefix, az.SourceAddressPrefixes = singleOrList(p.SourceAddressPrefixes)
+	az.DestinationAddressPrefix, az.DestinationAddressPrefixes = singleOrList(p.DestinationAddressPrefixes)
+	return az
+}
+
+// GenerateSecurityRulePropertiesFormat produces a
+// SecurityRulePropertiesFormat from the properties of a security rule
+// received from Azure.
+func GenerateSecurityRulePropertiesFormat(az *networkmgmt.SecurityRulePropertiesFormat) v1alpha3.SecurityRulePropertiesFormat {
+	if az == nil {
+		return v1alpha3.SecurityRulePropertiesFormat{}
+	}
+	return v1alpha3.SecurityRulePropertiesFormat{
+		Description:                            azure.ToString(az.Description),
+		Protocol:                               string(az.Protocol),
+		SourcePortRanges:                       toList(az.SourcePortRange, az.SourcePortRanges),
+		DestinationPortRanges:                  toList(az.DestinationPortRange, az.DestinationPortRanges),
+		SourceAddressPrefixes:                  toList(az.SourceAddressPrefix, az.SourceAddressPrefixes),
+		SourceApplicationSecurityGroupIDs:      generateApplicationSecurityGroupIDs(az.SourceApplicationSecurityGroups),
+		DestinationAddressPrefixes:             toList(az.DestinationAddressPrefix, az.DestinationAddressPrefixes),
+		DestinationApplicationSecurityGroupIDs: generateApplicationSecurityGroupIDs(az.DestinationApplicationSecurityGroups),
+		Access:                                 string(az.Access),
+		Priority:                               azure.ToInt(az.Priority),
+		Direction:                              string(az.Direction),
+	}
+}
+
+func newApplicationSecurityGroups(ids []string) *[]networkmgmt.ApplicationSecurityGroup {
+	if len(ids) == 0 {
+		return nil
+	}
+	asgs := make([]networkmgmt.ApplicationSecurityGroup, len(ids))
+	for i, id := range ids {
+		asgs[i] = networkmgmt.ApplicationSecurityGroup{ID: azure.ToStringPtr(id)}
+	}
+	return &asgs
+}
+
+func generateApplicationSecurityGroupIDs(asgs *[]networkmgmt.ApplicationSecurityGroup) []string {
+	if asgs == nil {
+		return nil
+	}
+	ids := make([]string, 0, len(*asgs))
+	for _, asg := range *asgs {
+		ids = append(ids, azure.ToString(asg.ID))
+	}
+	return ids
+}
+
+func singleOrList(l []string) (*string, *[]string) {
+	switch len(l) {
+	case 0:
+		return nil, nil
+	case 1:
+		return azure.ToStringPtr(l[0]), nil
+	}
+	c := make([]string, len(l))
+	copy(c, l)
+	return nil, &c
+}
+
+func toList(single *string, list *[]string) []string {
+	var l []string
+	if s := azure.ToString(single); s != "" {
+		l = append(l, s)
+	}
+	if list != nil {
+		l = append(l, *list...)
+	}
+	return l
+}
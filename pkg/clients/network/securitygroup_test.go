@@ -0,0 +1,257 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"testing"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func securityRuleProperties() v1alpha3.SecurityRulePropertiesFormat {
+	return v1alpha3.SecurityRulePropertiesFormat{
+		Protocol:              "Tcp",
+		SourcePortRanges:      []string{"*"},
+		DestinationPortRanges: []string{"443", "80"},
+		SourceAddressPrefixes: []string{"Internet"},
+		Access:                "Allow",
+		Priority:              100,
+		Direction:             "Inbound",
+	}
+}
+
+func azureSecurityRuleProperties() *networkmgmt.SecurityRulePropertiesFormat {
+	return &networkmgmt.SecurityRulePropertiesFormat{
+		Protocol:              networkmgmt.SecurityRuleProtocolTCP,
+		SourcePortRange:       azure.ToStringPtr("*"),
+		DestinationPortRanges: &[]string{"443", "80"},
+		SourceAddressPrefix:   azure.ToStringPtr("Internet"),
+		Access:                networkmgmt.SecurityRuleAccessAllow,
+		Priority:              azure.ToInt32Ptr(100),
+		Direction:             networkmgmt.SecurityRuleDirectionInbound,
+	}
+}
+
+func TestNewSecurityRulePropertiesFormat(t *testing.T) {
+	cases := []struct {
+		name string
+		p    v1alpha3.SecurityRulePropertiesFormat
+		want *networkmgmt.SecurityRulePropertiesFormat
+	}{
+		{
+			name: "SingleAndList",
+			p:    securityRuleProperties(),
+			want: azureSecurityRuleProperties(),
+		},
+		{
+			name: "ApplicationSecurityGroups",
+			p: v1alpha3.SecurityRulePropertiesFormat{
+				Description:                       "cool-rule",
+				Protocol:                          "*",
+				SourcePortRanges:                  []string{"*"},
+				DestinationPortRanges:             []string{"*"},
+				SourceApplicationSecurityGroupIDs: []string{id},
+				DestinationAddressPrefixes:        []string{"10.0.0.0/24", "10.0.1.0/24"},
+				Access:                            "Deny",
+				Priority:                          4096,
+				Direction:                         "Outbound",
+			},
+			want: &networkmgmt.SecurityRulePropertiesFormat{
+				Description:                     azure.ToStringPtr("cool-rule"),
+				Protocol:                        networkmgmt.SecurityRuleProtocolAsterisk,
+				SourcePortRange:                 azure.ToStringPtr("*"),
+				DestinationPortRange:            azure.ToStringPtr("*"),
+				SourceApplicationSecurityGroups: &[]networkmgmt.ApplicationSecurityGroup{{ID: azure.ToStringPtr(id)}},
+				DestinationAddressPrefixes:      &[]string{"10.0.0.0/24", "10.0.1.0/24"},
+				Access:                          networkmgmt.SecurityRuleAccessDeny,
+				Priority:                        azure.ToInt32Ptr(4096),
+				Direction:                       networkmgmt.SecurityRuleDirectionOutbound,
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewSecurityRulePropertiesFormat(tc.p)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewSecurityRulePropertiesFormat(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.p, GenerateSecurityRulePropertiesFormat(got)); diff != "" {
+				t.Errorf("GenerateSecurityRulePropertiesFormat(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewSecurityGroupParameters(t *testing.T) {
+	observed := &[]networkmgmt.SecurityRule{{Name: azure.ToStringPtr("managed-elsewhere")}}
+
+	cases := []struct {
+		name     string
+		r        *v1alpha3.SecurityGroup
+		observed *[]networkmgmt.SecurityRule
+		want     networkmgmt.SecurityGroup
+	}{
+		{
+			name: "InlineRules",
+			r: &v1alpha3.SecurityGroup{
+				Spec: v1alpha3.SecurityGroupSpec{
+					Location:      location,
+					Tags:          tags,
+					SecurityRules: []v1alpha3.SecurityGroupRule{{Name: "https", SecurityRulePropertiesFormat: securityRuleProperties()}},
+				},
+			},
+			observed: observed,
+			want: networkmgmt.SecurityGroup{
+				Location: azure.ToStringPtr(location),
+				Tags:     azure.ToStringPtrMap(tags),
+				SecurityGroupPropertiesFormat: &networkmgmt.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]networkmgmt.SecurityRule{{
+						Name:                         azure.ToStringPtr("https"),
+						SecurityRulePropertiesFormat: azureSecurityRuleProperties(),
+					}},
+				},
+			},
+		},
+		{
+			name: "KeepObservedRules",
+			r: &v1alpha3.SecurityGroup{
+				Spec: v1alpha3.SecurityGroupSpec{
+					Location: location,
+				},
+			},
+			observed: observed,
+			want: networkmgmt.SecurityGroup{
+				Location: azure.ToStringPtr(location),
+				SecurityGroupPropertiesFormat: &networkmgmt.SecurityGroupPropertiesFormat{
+					SecurityRules: observed,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := NewSecurityGroupParameters(tc.r, tc.observed)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("NewSecurityGroupParameters(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSecurityGroupNeedsUpdate(t *testing.T) {
+	ssh := securityRuleProperties()
+	ssh.DestinationPortRanges = []string{"22"}
+	ssh.Priority = 200
+
+	azureSSH := azureSecurityRuleProperties()
+	azureSSH.DestinationPortRanges = nil
+	azureSSH.DestinationPortRange = azure.ToStringPtr("22")
+	azureSSH.Priority = azure.ToInt32Ptr(200)
+
+	kube := &v1alpha3.SecurityGroup{
+		Spec: v1alpha3.SecurityGroupSpec{
+			Tags: tags,
+			SecurityRules: []v1alpha3.SecurityGroupRule{
+				{Name: "https", SecurityRulePropertiesFormat: securityRuleProperties()},
+				{Name: "ssh", SecurityRulePropertiesFormat: ssh},
+			},
+		},
+	}
+
+	cases := []struct {
+		name string
+		kube *v1alpha3.SecurityGroup
+		az   networkmgmt.SecurityGroup
+		want bool
+	}{
+		{
+			name: "UpToDateDifferentOrder",
+			kube: kube,
+			az: networkmgmt.SecurityGroup{
+				Tags: azure.ToStringPtrMap(tags),
+				SecurityGroupPropertiesFormat: &networkmgmt.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]networkmgmt.SecurityRule{
+						{Name: azure.ToStringPtr("ssh"), SecurityRulePropertiesFormat: azureSSH},
+						{Name: azure.ToStringPtr("https"), SecurityRulePropertiesFormat: &networkmgmt.SecurityRulePropertiesFormat{
+							Protocol:              networkmgmt.SecurityRuleProtocolTCP,
+							SourcePortRange:       azure.ToStringPtr("*"),
+							DestinationPortRanges: &[]string{"80", "443"},
+							SourceAddressPrefix:   azure.ToStringPtr("Internet"),
+							Access:                networkmgmt.SecurityRuleAccessAllow,
+							Priority:              azure.ToInt32Ptr(100),
+							Direction:             networkmgmt.SecurityRuleDirectionInbound,
+						}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "NeedsUpdateMissingRule",
+			kube: kube,
+			az: networkmgmt.SecurityGroup{
+				Tags: azure.ToStringPtrMap(tags),
+				SecurityGroupPropertiesFormat: &networkmgmt.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]networkmgmt.SecurityRule{
+						{Name: azure.ToStringPtr("https"), SecurityRulePropertiesFormat: azureSecurityRuleProperties()},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "NeedsUpdateTags",
+			kube: kube,
+			az: networkmgmt.SecurityGroup{
+				SecurityGroupPropertiesFormat: &networkmgmt.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]networkmgmt.SecurityRule{
+						{Name: azure.ToStringPtr("https"), SecurityRulePropertiesFormat: azureSecurityRuleProperties()},
+						{Name: azure.ToStringPtr("ssh"), SecurityRulePropertiesFormat: azureSSH},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "UnmanagedRules",
+			kube: &v1alpha3.SecurityGroup{},
+			az: networkmgmt.SecurityGroup{
+				SecurityGroupPropertiesFormat: &networkmgmt.SecurityGroupPropertiesFormat{
+					SecurityRules: &[]networkmgmt.SecurityRule{
+						{Name: azure.ToStringPtr("https"), SecurityRulePropertiesFormat: azureSecurityRuleProperties()},
+					},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := SecurityGroupNeedsUpdate(tc.kube, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SecurityGroupNeedsUpdate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
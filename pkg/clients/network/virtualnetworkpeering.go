@@ -0,0 +1,111 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"fmt"
+	"strings"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// VirtualNetworkID returns the ID of the virtual network with the supplied
+// name in the supplied subscription and resource group.
+func VirtualNetworkID(subscriptionID, resourceGroupName, name string) string {
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Network/virtualNetworks/%s", subscriptionID, resourceGroupName, name)
+}
+
+const errParseVirtualNetworkPeeringID = "cannot parse virtual network peering ID"
+
+// A VirtualNetworkPeeringID identifies an Azure virtual network peering.
+type VirtualNetworkPeeringID struct {
+	SubscriptionID     string
+	ResourceGroupName  string
+	VirtualNetworkName string
+	Name               string
+}
+
+// String returns the resource ID of the virtual network peering.
+func (id VirtualNetworkPeeringID) String() string {
+	return VirtualNetworkID(id.SubscriptionID, id.ResourceGroupName, id.VirtualNetworkName) + "/virtualNetworkPeerings/" + id.Name
+}
+
+// ParseVirtualNetworkPeeringID parses the supplied resource ID of a virtual
+// network peering.
+func ParseVirtualNetworkPeeringID(id string) (VirtualNetworkPeeringID, error) {
+	p := strings.Split(strings.Trim(id, "/"), "/")
+	if len(p) != 10 || !strings.EqualFold(p[0], "subscriptions") || !strings.EqualFold(p[2], "resourceGroups") ||
+		!strings.EqualFold(p[6], "virtualNetworks") || !strings.EqualFold(p[8], "virtualNetworkPeerings") {
+		return VirtualNetworkPeeringID{}, errors.Errorf("%s: %s", errParseVirtualNetworkPeeringID, id)
+	}
+	return VirtualNetworkPeeringID{SubscriptionID: p[1], ResourceGroupName: p[3], VirtualNetworkName: p[7], Name: p[9]}, nil
+}
+
+// NewVirtualNetworkPeeringParameters returns an Azure VirtualNetworkPeering
+// object that peers with the supplied remote virtual network.
+func NewVirtualNetworkPeeringParameters(remoteID string, o v1alpha3.VirtualNetworkPeeringOptions) networkmgmt.VirtualNetworkPeering {
+	return networkmgmt.VirtualNetworkPeering{
+		VirtualNetworkPeeringPropertiesFormat: &networkmgmt.VirtualNetworkPeeringPropertiesFormat{
+			RemoteVirtualNetwork:      &networkmgmt.SubResource{ID: azure.ToStringPtr(remoteID)},
+			AllowVirtualNetworkAccess: o.AllowVirtualNetworkAccess,
+			AllowForwardedTraffic:     azure.ToBoolPtr(o.AllowForwardedTraffic, azure.FieldRequired),
+			AllowGatewayTransit:       azure.ToBoolPtr(o.AllowGatewayTransit, azure.FieldRequired),
+			UseRemoteGateways:         azure.ToBoolPtr(o.UseRemoteGateways, azure.FieldRequired),
+		},
+	}
+}
+
+// VirtualNetworkPeeringNeedsUpdate determines if a virtual network peering
+// with the supplied remote virtual network needs to be updated. Virtual
+// network access is only compared if it is specified, because Azure defaults
+// it.
+func VirtualNetworkPeeringNeedsUpdate(remoteID string, o v1alpha3.VirtualNetworkPeeringOptions, az networkmgmt.VirtualNetworkPeering) bool {
+	if az.VirtualNetworkPeeringPropertiesFormat == nil {
+		return true
+	}
+	var observedID *string
+	if az.RemoteVirtualNetwork != nil {
+		observedID = az.RemoteVirtualNetwork.ID
+	}
+	switch {
+	case !strings.EqualFold(remoteID, azure.ToString(observedID)):
+		return true
+	case o.AllowVirtualNetworkAccess != nil && *o.AllowVirtualNetworkAccess != azure.ToBool(az.AllowVirtualNetworkAccess):
+		return true
+	case o.AllowForwardedTraffic != azure.ToBool(az.AllowForwardedTraffic):
+		return true
+	case o.AllowGatewayTransit != azure.ToBool(az.AllowGatewayTransit):
+		return true
+	}
+	return o.UseRemoteGateways != azure.ToBool(az.UseRemoteGateways)
+}
+
+// UpdateVirtualNetworkPeeringStatusFromAzure updates the status related to
+// the external Azure virtual network peering in the
+// VirtualNetworkPeeringStatus
+func UpdateVirtualNetworkPeeringStatusFromAzure(p *v1alpha3.VirtualNetworkPeering, az networkmgmt.VirtualNetworkPeering) {
+	p.Status.ID = azure.ToString(az.ID)
+	p.Status.Etag = azure.ToString(az.Etag)
+	if az.VirtualNetworkPeeringPropertiesFormat != nil {
+		p.Status.State = azure.ToString(az.ProvisioningState)
+		p.Status.PeeringState = string(az.PeeringState)
+	}
+}
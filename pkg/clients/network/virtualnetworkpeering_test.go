@@ -0,0 +1,130 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package network
+
+import (
+	"strings"
+	"testing"
+
+	networkmgmt "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestParseVirtualNetworkPeeringID(t *testing.T) {
+	type want struct {
+		id  VirtualNetworkPeeringID
+		err error
+	}
+
+	cases := map[string]struct {
+		id string
+		want
+	}{
+		"Valid": {
+			id: "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Network/virtualNetworks/coolnet/virtualNetworkPeerings/coolpeering",
+			want: want{
+				id: VirtualNetworkPeeringID{SubscriptionID: "cool", ResourceGroupName: "coolgroup", VirtualNetworkName: "coolnet", Name: "coolpeering"},
+			},
+		},
+		"NotAPeering": {
+			id: "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Network/virtualNetworks/coolnet/subnets/coolsubnet",
+			want: want{
+				err: errors.Errorf("%s: %s", errParseVirtualNetworkPeeringID, "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Network/virtualNetworks/coolnet/subnets/coolsubnet"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			id, err := ParseVirtualNetworkPeeringID(tc.id)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseVirtualNetworkPeeringID(...): -want error, +got error\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.id, id); diff != "" {
+				t.Errorf("ParseVirtualNetworkPeeringID(...): -want, +got\n%s", diff)
+			}
+			if tc.want.err == nil && id.String() != tc.id {
+				t.Errorf("String(): want %q, got %q", tc.id, id.String())
+			}
+		})
+	}
+}
+
+func TestVirtualNetworkPeeringNeedsUpdate(t *testing.T) {
+	remoteID := VirtualNetworkID("sub", "rg", "hub")
+	observed := networkmgmt.VirtualNetworkPeering{
+		VirtualNetworkPeeringPropertiesFormat: &networkmgmt.VirtualNetworkPeeringPropertiesFormat{
+			RemoteVirtualNetwork:      &networkmgmt.SubResource{ID: azure.ToStringPtr(strings.ToLower(remoteID))},
+			AllowVirtualNetworkAccess: azure.ToBoolPtr(true),
+			AllowForwardedTraffic:     azure.ToBoolPtr(true),
+			AllowGatewayTransit:       azure.ToBoolPtr(false, azure.FieldRequired),
+			UseRemoteGateways:         azure.ToBoolPtr(false, azure.FieldRequired),
+		},
+	}
+
+	cases := []struct {
+		name     string
+		remoteID string
+		o        v1alpha3.VirtualNetworkPeeringOptions
+		az       networkmgmt.VirtualNetworkPeering
+		want     bool
+	}{
+		{
+			name:     "NoUpdate",
+			remoteID: remoteID,
+			o:        v1alpha3.VirtualNetworkPeeringOptions{AllowForwardedTraffic: true},
+			az:       observed,
+			want:     false,
+		},
+		{
+			name:     "NeedsUpdateVirtualNetworkAccess",
+			remoteID: remoteID,
+			o:        v1alpha3.VirtualNetworkPeeringOptions{AllowVirtualNetworkAccess: azure.ToBoolPtr(false, azure.FieldRequired), AllowForwardedTraffic: true},
+			az:       observed,
+			want:     true,
+		},
+		{
+			name:     "NeedsUpdateRemoteGateways",
+			remoteID: remoteID,
+			o:        v1alpha3.VirtualNetworkPeeringOptions{AllowForwardedTraffic: true, UseRemoteGateways: true},
+			az:       observed,
+			want:     true,
+		},
+		{
+			name:     "NeedsUpdateRemoteVirtualNetwork",
+			remoteID: VirtualNetworkID("sub", "rg", "other-hub"),
+			o:        v1alpha3.VirtualNetworkPeeringOptions{AllowForwardedTraffic: true},
+			az:       observed,
+			want:     true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := VirtualNetworkPeeringNeedsUpdate(tc.remoteID, tc.o, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("VirtualNetworkPeeringNeedsUpdate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
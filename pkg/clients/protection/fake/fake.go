@@ -0,0 +1,94 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks/locksapi"
+	"github.com/Azure/go-autorest/autorest"
+
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+)
+
+var _ locksapi.ManagementLocksClientAPI = &MockLocksClient{}
+
+// MockLocksClient is a fake implementation of the Azure management locks
+// client.
+type MockLocksClient struct {
+	locksapi.ManagementLocksClientAPI
+
+	MockCreateOrUpdateByScope func(ctx context.Context, scope string, lockName string, parameters locks.ManagementLockObject) (locks.ManagementLockObject, error)
+	MockDeleteByScope         func(ctx context.Context, scope string, lockName string) (autorest.Response, error)
+	MockGetByScope            func(ctx context.Context, scope string, lockName string) (locks.ManagementLockObject, error)
+}
+
+// CreateOrUpdateByScope calls the underlying MockCreateOrUpdateByScope method.
+func (m *MockLocksClient) CreateOrUpdateByScope(ctx context.Context, scope string, lockName string, parameters locks.ManagementLockObject) (locks.ManagementLockObject, error) {
+	return m.MockCreateOrUpdateByScope(ctx, scope, lockName, parameters)
+}
+
+// DeleteByScope calls the underlying MockDeleteByScope method.
+func (m *MockLocksClient) DeleteByScope(ctx context.Context, scope string, lockName string) (autorest.Response, error) {
+	return m.MockDeleteByScope(ctx, scope, lockName)
+}
+
+// GetByScope calls the underlying MockGetByScope method.
+func (m *MockLocksClient) GetByScope(ctx context.Context, scope string, lockName string) (locks.ManagementLockObject, error) {
+	return m.MockGetByScope(ctx, scope, lockName)
+}
+
+// MockProtector is a fake implementation of protection.Protector.
+type MockProtector struct {
+	MockProtect       func(ctx context.Context, mg resource.Managed, id string) error
+	MockUnprotect     func(ctx context.Context, mg resource.Managed, id string) error
+	MockAllowDeletion func(ctx context.Context, mg resource.Managed, id string) error
+}
+
+// Protect calls the underlying MockProtect method.
+func (m *MockProtector) Protect(ctx context.Context, mg resource.Managed, id string) error {
+	return m.MockProtect(ctx, mg, id)
+}
+
+// Unprotect calls the underlying MockUnprotect method.
+func (m *MockProtector) Unprotect(ctx context.Context, mg resource.Managed, id string) error {
+	return m.MockUnprotect(ctx, mg, id)
+}
+
+// AllowDeletion calls the underlying MockAllowDeletion method.
+func (m *MockProtector) AllowDeletion(ctx context.Context, mg resource.Managed, id string) error {
+	return m.MockAllowDeletion(ctx, mg, id)
+}
+
+// NewMockProtectFn returns a MockProtect function that returns the supplied
+// error.
+func NewMockProtectFn(err error) func(ctx context.Context, mg resource.Managed, id string) error {
+	return func(_ context.Context, _ resource.Managed, _ string) error { return err }
+}
+
+// NewMockUnprotectFn returns a MockUnprotect function that returns the
+// supplied error.
+func NewMockUnprotectFn(err error) func(ctx context.Context, mg resource.Managed, id string) error {
+	return func(_ context.Context, _ resource.Managed, _ string) error { return err }
+}
+
+// NewMockAllowDeletionFn returns a MockAllowDeletion function that returns the
+// supplied error.
+func NewMockAllowDeletionFn(err error) func(ctx context.Context, mg resource.Managed, id string) error {
+	return func(_ context.Context, _ resource.Managed, _ string) error { return err }
+}
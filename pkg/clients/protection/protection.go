@@ -0,0 +1,481 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package protection protects Azure resources from accidental deletion and
+// modification using management locks.
+package protection
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks/locksapi"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/fieldpath"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// AnnotationKeyDeletionProtection is the annotation used to protect a managed
+// resource from deletion. Its value is one of the DeletionProtection values.
+const AnnotationKeyDeletionProtection = "azure.crossplane.io/deletion-protection"
+
+// Values of the deletion protection annotation.
+const (
+	// DeletionProtectionEnabled refuses to delete the external resource.
+	DeletionProtectionEnabled = "Enabled"
+
+	// DeletionProtectionLocked refuses to delete the external resource, and
+	// applies a CanNotDelete management lock to it so that it may not be
+	// deleted outside of Crossplane either.
+	DeletionProtectionLocked = "Locked"
+
+	// DeletionProtectionDisabled allows the external resource to be deleted,
+	// and removes any management lock applied to it. Any value other than
+	// Enabled or Locked disables deletion protection.
+	DeletionProtectionDisabled = "Disabled"
+)
+
+// AnnotationKeyManagementLock is the annotation used to declare a management
+// lock on the external resource of a managed resource. Its value is the level
+// of the lock; either CanNotDelete or ReadOnly. Note that a ReadOnly lock also
+// prevents Crossplane from updating the external resource.
+const AnnotationKeyManagementLock = "azure.crossplane.io/management-lock"
+
+// ManagementLockNone removes the management lock declared by the management
+// lock annotation. Any value other than a valid lock level has the same effect.
+const ManagementLockNone = "None"
+
+// Names of the management locks applied by Crossplane.
+const (
+	// LockName is the name of the management lock applied to resources
+	// whose deletion protection is Locked.
+	LockName = "crossplane-deletion-protection"
+
+	// ManagementLockName is the name of the management lock declared by the
+	// management lock annotation.
+	ManagementLockName = "crossplane"
+)
+
+const (
+	lockNotes           = "Managed by Crossplane. Set the " + AnnotationKeyDeletionProtection + " annotation to " + DeletionProtectionDisabled + " to remove this lock."
+	managementLockNotes = "Managed by Crossplane. Set the " + AnnotationKeyManagementLock + " annotation to " + ManagementLockNone + " to remove this lock."
+)
+
+// Error strings.
+const (
+	errDeletionProtected = "deletion protection is enabled: set the " + AnnotationKeyDeletionProtection + " annotation to " + DeletionProtectionDisabled + " to allow deletion"
+	errGetLock           = "cannot get management lock"
+	errCreateLock        = "cannot create management lock"
+	errDeleteLock        = "cannot delete management lock"
+	errProtect           = "cannot reconcile management locks"
+)
+
+// TypeDeletionProtected resources may not be deleted.
+const TypeDeletionProtected xpv1.ConditionType = "DeletionProtected"
+
+// Reasons a resource is or is not protected from deletion.
+const (
+	ReasonDeletionRefused xpv1.ConditionReason = "DeletionRefused"
+	ReasonDeletionAllowed xpv1.ConditionReason = "DeletionAllowed"
+)
+
+// DeletionRefused returns a condition indicating that a managed resource was
+// not deleted because its deletion protection is enabled.
+func DeletionRefused() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionProtected,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionRefused,
+		Message:            errDeletionProtected,
+	}
+}
+
+// DeletionAllowed returns a condition indicating that a managed resource that
+// was previously refused deletion may now be deleted, because its deletion
+// protection has been disabled.
+func DeletionAllowed() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeDeletionProtected,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonDeletionAllowed,
+	}
+}
+
+// allowDeletion replaces the DeletionRefused condition of the supplied managed
+// resource, if any, with DeletionAllowed. Resources that were never refused
+// deletion are left without a DeletionProtected condition.
+func allowDeletion(mg resource.Managed) {
+	if mg.GetCondition(TypeDeletionProtected).Status == corev1.ConditionTrue {
+		mg.SetConditions(DeletionAllowed())
+	}
+}
+
+// IsProtected returns true if the supplied object is protected from deletion.
+func IsProtected(o metav1.Object) bool {
+	switch o.GetAnnotations()[AnnotationKeyDeletionProtection] {
+	case DeletionProtectionEnabled, DeletionProtectionLocked:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsLocked returns true if the supplied object's external resource should be
+// protected by a management lock.
+func IsLocked(o metav1.Object) bool {
+	return o.GetAnnotations()[AnnotationKeyDeletionProtection] == DeletionProtectionLocked
+}
+
+// A Protector protects the external resources of managed resources from
+// deletion and modification.
+type Protector interface {
+	// Protect the external resource with the supplied Azure resource ID
+	// according to the deletion protection and management lock annotations
+	// of the supplied managed resource.
+	Protect(ctx context.Context, mg resource.Managed, id string) error
+
+	// Unprotect removes the management locks that are no longer declared by
+	// the annotations of the supplied managed resource from the external
+	// resource with the supplied Azure resource ID. All management locks are
+	// removed once the managed resource has been deleted, unless it is
+	// protected from deletion.
+	Unprotect(ctx context.Context, mg resource.Managed, id string) error
+
+	// AllowDeletion returns an error if the supplied managed resource is
+	// protected from deletion. Otherwise it removes any management lock that
+	// would prevent the deletion of the external resource with the supplied
+	// Azure resource ID.
+	AllowDeletion(ctx context.Context, mg resource.Managed, id string) error
+}
+
+// A lock that should, or should not, exist on an external resource.
+type lock struct {
+	name  string
+	level locks.LockLevel
+	notes string
+}
+
+// locksFor returns the management locks declared by the annotations of the
+// supplied object. A lock without a level should not exist. Locks that are not
+// declared by an annotation are not returned, so that managing management
+// locks is not required unless the annotations are used.
+func locksFor(o metav1.Object) []lock {
+	var l []lock
+	a := o.GetAnnotations()
+	if _, ok := a[AnnotationKeyDeletionProtection]; ok {
+		dp := lock{name: LockName, notes: lockNotes}
+		if IsLocked(o) {
+			dp.level = locks.CanNotDelete
+		}
+		l = append(l, dp)
+	}
+	if v, ok := a[AnnotationKeyManagementLock]; ok {
+		ml := lock{name: ManagementLockName, notes: managementLockNotes}
+		switch locks.LockLevel(v) { // nolint:exhaustive
+		case locks.CanNotDelete, locks.ReadOnly:
+			ml.level = locks.LockLevel(v)
+		}
+		l = append(l, ml)
+	}
+	return l
+}
+
+// locking returns true if the annotations of the supplied object declare a
+// management lock that should exist.
+func locking(o metav1.Object) bool {
+	for _, l := range locksFor(o) {
+		if l.level != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// unlocking returns true if a management lock may need to be removed from the
+// external resource of the supplied managed resource, either because its
+// annotations declare that it should not exist or because the managed
+// resource was deleted.
+func unlocking(mg resource.Managed) bool {
+	if meta.WasDeleted(mg) {
+		return !IsProtected(mg)
+	}
+	for _, l := range locksFor(mg) {
+		if l.level == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// A LockProtector protects external resources using Azure management locks.
+type LockProtector struct {
+	client locksapi.ManagementLocksClientAPI
+}
+
+// NewLockProtector returns a Protector that uses the supplied client to manage
+// Azure management locks.
+func NewLockProtector(c locksapi.ManagementLocksClientAPI) *LockProtector {
+	return &LockProtector{client: c}
+}
+
+// Protect applies the management locks declared by the annotations of the
+// supplied managed resource to its external resource. A CanNotDelete lock is
+// declared when deletion protection is Locked, and any lock may be declared
+// using the management lock annotation. Locks that are no longer declared are
+// removed by Unprotect. A DeletionRefused condition is cleared once deletion
+// protection is disabled.
+func (p *LockProtector) Protect(ctx context.Context, mg resource.Managed, id string) error {
+	if !IsProtected(mg) {
+		allowDeletion(mg)
+	}
+	if id == "" {
+		return nil
+	}
+	for _, l := range locksFor(mg) {
+		if l.level == "" {
+			continue
+		}
+		if err := p.lock(ctx, id, l); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unprotect removes the management locks that the annotations of the supplied
+// managed resource declare should not exist from its external resource. Once
+// the managed resource has been deleted all locks applied by Protect are
+// removed, unless it is protected from deletion.
+func (p *LockProtector) Unprotect(ctx context.Context, mg resource.Managed, id string) error {
+	if id == "" {
+		return nil
+	}
+	if meta.WasDeleted(mg) {
+		if IsProtected(mg) {
+			return nil
+		}
+		return p.unlockAll(ctx, mg, id)
+	}
+	for _, l := range locksFor(mg) {
+		if l.level != "" {
+			continue
+		}
+		if err := p.unlock(ctx, id, l.name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AllowDeletion sets the DeletionProtected condition and returns an error if
+// the managed resource is protected from deletion. Otherwise it clears any
+// DeletionRefused condition and removes any management locks applied by
+// Protect.
+func (p *LockProtector) AllowDeletion(ctx context.Context, mg resource.Managed, id string) error {
+	if IsProtected(mg) {
+		mg.SetConditions(DeletionRefused())
+		return errors.New(errDeletionProtected)
+	}
+	allowDeletion(mg)
+	if id == "" {
+		return nil
+	}
+	return p.unlockAll(ctx, mg, id)
+}
+
+// unlockAll removes the management locks applied by Protect. Locks declared by
+// the annotations of the supplied managed resource are always removed. Locks
+// that are not, for example because their annotation was removed, are only
+// removed if they exist. Failing to read or remove them because the provider
+// may not manage locks is ignored; the built-in Contributor role may not, and
+// must still be able to delete resources that never used locks.
+func (p *LockProtector) unlockAll(ctx context.Context, mg resource.Managed, id string) error {
+	declared := map[string]bool{}
+	for _, l := range locksFor(mg) {
+		declared[l.name] = true
+	}
+	for _, name := range []string{LockName, ManagementLockName} {
+		if declared[name] {
+			if err := p.unlock(ctx, id, name); err != nil {
+				return err
+			}
+			continue
+		}
+		_, err := p.client.GetByScope(ctx, id, name)
+		if azure.IsNotFound(err) || azure.IsForbidden(err) {
+			continue
+		}
+		if err != nil {
+			return errors.Wrap(err, errGetLock)
+		}
+		if err := p.unlock(ctx, id, name); err != nil && !azure.IsForbidden(errors.Cause(err)) {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *LockProtector) lock(ctx context.Context, id string, l lock) error {
+	existing, err := p.client.GetByScope(ctx, id, l.name)
+	if resource.Ignore(azure.IsNotFound, err) != nil {
+		return errors.Wrap(err, errGetLock)
+	}
+	if err == nil && existing.ManagementLockProperties != nil && existing.Level == l.level {
+		return nil
+	}
+	_, err = p.client.CreateOrUpdateByScope(ctx, id, l.name, locks.ManagementLockObject{
+		ManagementLockProperties: &locks.ManagementLockProperties{
+			Level: l.level,
+			Notes: azure.ToStringPtr(l.notes),
+		},
+	})
+	return errors.Wrap(err, errCreateLock)
+}
+
+func (p *LockProtector) unlock(ctx context.Context, id, name string) error {
+	_, err := p.client.DeleteByScope(ctx, id, name)
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteLock)
+}
+
+// An IDFn returns the Azure resource ID of the external resource of the
+// supplied managed resource, which exists in the supplied subscription. It
+// returns an empty string if the ID is not yet known.
+type IDFn func(mg resource.Managed, subscriptionID string) string
+
+// IDAt returns an IDFn that reads the Azure resource ID from the supplied field
+// path of a managed resource, for example status.atProvider.id.
+func IDAt(path string) IDFn {
+	return func(mg resource.Managed, _ string) string {
+		p, err := fieldpath.PaveObject(mg)
+		if err != nil {
+			return ""
+		}
+		id, _ := p.GetString(path)
+		return id
+	}
+}
+
+// An ExternalConnecter protects the external resources of the managed
+// resources it connects to using management locks.
+type ExternalConnecter struct {
+	wrapped managed.ExternalConnecter
+	kube    client.Client
+	id      IDFn
+}
+
+// NewExternalConnecter wraps the supplied ExternalConnecter such that the
+// management locks declared by the annotations of a managed resource are
+// reconciled whenever its external resource is observed, and removed before
+// it is deleted. Deletion of resources whose deletion protection is enabled
+// is refused.
+func NewExternalConnecter(c managed.ExternalConnecter, kube client.Client, id IDFn) *ExternalConnecter {
+	return &ExternalConnecter{wrapped: c, kube: kube, id: id}
+}
+
+// Connect to the external resource of the supplied managed resource. The
+// management locks client is only created once management locks must be
+// reconciled, so that resources that do not use them don't pay for it.
+func (c *ExternalConnecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	ec, err := c.wrapped.Connect(ctx, mg)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		p  Protector
+		id string
+	)
+	return &external{
+		ExternalClient: ec,
+		connect: func(ctx context.Context) (Protector, string, error) {
+			if p != nil {
+				return p, id, nil
+			}
+			creds, auth, err := azure.GetAuthInfo(ctx, c.kube, mg)
+			if err != nil {
+				return nil, "", err
+			}
+			sub := creds[azure.CredentialsKeySubscriptionID]
+			lc := locks.NewManagementLocksClient(sub)
+			lc.Authorizer = auth
+			p, id = NewLockProtector(lc), c.id(mg, sub)
+			return p, id, nil
+		},
+	}, nil
+}
+
+type external struct {
+	managed.ExternalClient
+
+	// connect returns the Protector of the external resource and its Azure
+	// resource ID.
+	connect func(ctx context.Context) (Protector, string, error)
+}
+
+// Observe the external resource and reconcile its management locks. Locks
+// that are no longer declared, or all locks once the managed resource has been
+// deleted, are removed before the external resource is observed, because a
+// ReadOnly lock may prevent it from being observed. Declared locks are applied
+// after it is observed, unless the managed resource has been deleted.
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	if unlocking(mg) {
+		p, id, err := e.connect(ctx)
+		if err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errProtect)
+		}
+		if err := p.Unprotect(ctx, mg, id); err != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errProtect)
+		}
+	}
+	o, err := e.ExternalClient.Observe(ctx, mg)
+	if err != nil || !o.ResourceExists || meta.WasDeleted(mg) {
+		return o, err
+	}
+	if !locking(mg) {
+		if !IsProtected(mg) {
+			allowDeletion(mg)
+		}
+		return o, nil
+	}
+	p, id, err := e.connect(ctx)
+	if err != nil {
+		return o, errors.Wrap(err, errProtect)
+	}
+	return o, errors.Wrap(p.Protect(ctx, mg, id), errProtect)
+}
+
+// Delete the external resource, unless it is protected from deletion. Any
+// management locks are removed first.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	p, id, err := e.connect(ctx)
+	if err != nil {
+		return err
+	}
+	if err := p.AllowDeletion(ctx, mg, id); err != nil {
+		return err
+	}
+	return e.ExternalClient.Delete(ctx, mg)
+}
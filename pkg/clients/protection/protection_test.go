@@ -0,0 +1,616 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package protection
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/resources/mgmt/2016-09-01/locks"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	resourcefake "github.com/crossplane/crossplane-runtime/pkg/resource/fake"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/pkg/clients/protection/fake"
+)
+
+const id = "/subscriptions/sub/resourceGroups/rg/providers/Microsoft.Storage/storageAccounts/cool"
+
+var (
+	errBoom      = errors.New("boom")
+	errNotFound  = autorest.DetailedError{StatusCode: http.StatusNotFound}
+	errForbidden = autorest.DetailedError{StatusCode: http.StatusForbidden}
+)
+
+func protected(protection ...string) *resourcefake.Managed {
+	mg := &resourcefake.Managed{}
+	if len(protection) > 0 {
+		mg.SetAnnotations(map[string]string{AnnotationKeyDeletionProtection: protection[0]})
+	}
+	return mg
+}
+
+func refused(protection ...string) *resourcefake.Managed {
+	mg := protected(protection...)
+	mg.SetConditions(DeletionRefused())
+	return mg
+}
+
+func annotated(a map[string]string) *resourcefake.Managed {
+	mg := &resourcefake.Managed{}
+	mg.SetAnnotations(a)
+	return mg
+}
+
+func deleted(mg *resourcefake.Managed) *resourcefake.Managed {
+	now := metav1.Now()
+	mg.SetDeletionTimestamp(&now)
+	return mg
+}
+
+func lockAt(level locks.LockLevel) locks.ManagementLockObject {
+	return locks.ManagementLockObject{ManagementLockProperties: &locks.ManagementLockProperties{Level: level}}
+}
+
+func notLocked(_ context.Context, _ string, _ string) (locks.ManagementLockObject, error) {
+	return locks.ManagementLockObject{}, errNotFound
+}
+
+func connected(p Protector) func(ctx context.Context) (Protector, string, error) {
+	return func(_ context.Context) (Protector, string, error) { return p, id, nil }
+}
+
+func TestProtect(t *testing.T) {
+	type args struct {
+		mg resource.Managed
+		id string
+	}
+
+	cases := map[string]struct {
+		c          *fake.MockLocksClient
+		args       args
+		want       error
+		conditions []xpv1.Condition
+	}{
+		"NoAnnotation": {
+			c:    &fake.MockLocksClient{},
+			args: args{mg: protected(), id: id},
+		},
+		"NoID": {
+			c:    &fake.MockLocksClient{},
+			args: args{mg: protected(DeletionProtectionLocked)},
+		},
+		"Unlocked": {
+			c:    &fake.MockLocksClient{},
+			args: args{mg: protected(DeletionProtectionEnabled), id: id},
+		},
+		"GetLockError": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: func(_ context.Context, _ string, _ string) (locks.ManagementLockObject, error) {
+					return locks.ManagementLockObject{}, errBoom
+				},
+			},
+			args: args{mg: protected(DeletionProtectionLocked), id: id},
+			want: errors.Wrap(errBoom, errGetLock),
+		},
+		"AlreadyLocked": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: func(_ context.Context, _ string, _ string) (locks.ManagementLockObject, error) {
+					return lockAt(locks.CanNotDelete), nil
+				},
+			},
+			args: args{mg: protected(DeletionProtectionLocked), id: id},
+		},
+		"Lock": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: func(_ context.Context, _ string, _ string) (locks.ManagementLockObject, error) {
+					return locks.ManagementLockObject{}, errNotFound
+				},
+				MockCreateOrUpdateByScope: func(_ context.Context, scope string, name string, l locks.ManagementLockObject) (locks.ManagementLockObject, error) {
+					if scope != id || name != LockName || l.Level != locks.CanNotDelete {
+						return locks.ManagementLockObject{}, errors.New("unexpected lock")
+					}
+					return l, nil
+				},
+			},
+			args: args{mg: protected(DeletionProtectionLocked), id: id},
+		},
+		"LockError": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: func(_ context.Context, _ string, _ string) (locks.ManagementLockObject, error) {
+					return lockAt(locks.ReadOnly), nil
+				},
+				MockCreateOrUpdateByScope: func(_ context.Context, _ string, _ string, _ locks.ManagementLockObject) (locks.ManagementLockObject, error) {
+					return locks.ManagementLockObject{}, errBoom
+				},
+			},
+			args: args{mg: protected(DeletionProtectionLocked), id: id},
+			want: errors.Wrap(errBoom, errCreateLock),
+		},
+		"ManagementLock": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: func(_ context.Context, _ string, _ string) (locks.ManagementLockObject, error) {
+					return lockAt(locks.CanNotDelete), nil
+				},
+				MockCreateOrUpdateByScope: func(_ context.Context, scope string, name string, l locks.ManagementLockObject) (locks.ManagementLockObject, error) {
+					if scope != id || name != ManagementLockName || l.Level != locks.ReadOnly {
+						return locks.ManagementLockObject{}, errors.New("unexpected lock")
+					}
+					return l, nil
+				},
+			},
+			args: args{mg: annotated(map[string]string{AnnotationKeyManagementLock: string(locks.ReadOnly)}), id: id},
+		},
+		"DeletionNoLongerRefused": {
+			c:          &fake.MockLocksClient{},
+			args:       args{mg: refused(DeletionProtectionDisabled), id: id},
+			conditions: []xpv1.Condition{DeletionAllowed()},
+		},
+		"DeletionStillRefused": {
+			c:          &fake.MockLocksClient{},
+			args:       args{mg: refused(DeletionProtectionEnabled)},
+			conditions: []xpv1.Condition{DeletionRefused()},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewLockProtector(tc.c).Protect(context.Background(), tc.args.mg, tc.args.id)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Protect(...): -want error, +got error:\n%s", diff)
+			}
+			got := tc.args.mg.(*resourcefake.Managed).Conditions
+			if diff := cmp.Diff(tc.conditions, got, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("Protect(...): -want conditions, +got conditions:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUnprotect(t *testing.T) {
+	cases := map[string]struct {
+		c    *fake.MockLocksClient
+		mg   *resourcefake.Managed
+		want error
+	}{
+		"NoAnnotation": {
+			c:  &fake.MockLocksClient{},
+			mg: protected(),
+		},
+		"Locked": {
+			c:  &fake.MockLocksClient{},
+			mg: protected(DeletionProtectionLocked),
+		},
+		"Unlock": {
+			c: &fake.MockLocksClient{
+				MockDeleteByScope: func(_ context.Context, _ string, name string) (autorest.Response, error) {
+					if name != LockName {
+						return autorest.Response{}, errors.New("unexpected lock")
+					}
+					return autorest.Response{}, nil
+				},
+			},
+			mg: protected(DeletionProtectionEnabled),
+		},
+		"UnlockError": {
+			c: &fake.MockLocksClient{
+				MockDeleteByScope: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errBoom
+				},
+			},
+			mg:   protected(DeletionProtectionDisabled),
+			want: errors.Wrap(errBoom, errDeleteLock),
+		},
+		"ManagementLockNone": {
+			c: &fake.MockLocksClient{
+				MockDeleteByScope: func(_ context.Context, _ string, name string) (autorest.Response, error) {
+					if name != ManagementLockName {
+						return autorest.Response{}, errors.New("unexpected lock")
+					}
+					return autorest.Response{}, nil
+				},
+			},
+			mg: annotated(map[string]string{AnnotationKeyManagementLock: ManagementLockNone}),
+		},
+		"DeletedProtected": {
+			c:  &fake.MockLocksClient{},
+			mg: deleted(protected(DeletionProtectionLocked)),
+		},
+		"Deleted": {
+			c: &fake.MockLocksClient{
+				MockDeleteByScope: func(_ context.Context, _ string, name string) (autorest.Response, error) {
+					if name != ManagementLockName {
+						return autorest.Response{}, errors.New("unexpected lock")
+					}
+					return autorest.Response{}, nil
+				},
+				MockGetByScope: notLocked,
+			},
+			mg: deleted(annotated(map[string]string{AnnotationKeyManagementLock: string(locks.ReadOnly)})),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewLockProtector(tc.c).Unprotect(context.Background(), tc.mg, id)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Unprotect(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAllowDeletion(t *testing.T) {
+	type want struct {
+		err        error
+		conditions []xpv1.Condition
+	}
+
+	cases := map[string]struct {
+		c    *fake.MockLocksClient
+		mg   *resourcefake.Managed
+		want want
+	}{
+		"NoAnnotation": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: notLocked,
+				MockDeleteByScope: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errForbidden
+				},
+			},
+			mg: protected(),
+		},
+		"NoAnnotationLockRemains": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: func(_ context.Context, _ string, name string) (locks.ManagementLockObject, error) {
+					if name != ManagementLockName {
+						return locks.ManagementLockObject{}, errNotFound
+					}
+					return lockAt(locks.ReadOnly), nil
+				},
+				MockDeleteByScope: func(_ context.Context, _ string, name string) (autorest.Response, error) {
+					if name != ManagementLockName {
+						return autorest.Response{}, errors.New("unexpected lock")
+					}
+					return autorest.Response{}, nil
+				},
+			},
+			mg: protected(),
+		},
+		"NoAnnotationNotAuthorizedToReadLocks": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: func(_ context.Context, _ string, _ string) (locks.ManagementLockObject, error) {
+					return locks.ManagementLockObject{}, errForbidden
+				},
+			},
+			mg: protected(),
+		},
+		"NoAnnotationNotAuthorizedToRemoveLocks": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: func(_ context.Context, _ string, _ string) (locks.ManagementLockObject, error) {
+					return lockAt(locks.CanNotDelete), nil
+				},
+				MockDeleteByScope: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errForbidden
+				},
+			},
+			mg: protected(),
+		},
+		"NoAnnotationGetLockError": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: func(_ context.Context, _ string, _ string) (locks.ManagementLockObject, error) {
+					return locks.ManagementLockObject{}, errBoom
+				},
+			},
+			mg: protected(),
+			want: want{
+				err: errors.Wrap(errBoom, errGetLock),
+			},
+		},
+		"Enabled": {
+			c:  &fake.MockLocksClient{},
+			mg: protected(DeletionProtectionEnabled),
+			want: want{
+				err:        errors.New(errDeletionProtected),
+				conditions: []xpv1.Condition{DeletionRefused()},
+			},
+		},
+		"Locked": {
+			c:  &fake.MockLocksClient{},
+			mg: protected(DeletionProtectionLocked),
+			want: want{
+				err:        errors.New(errDeletionProtected),
+				conditions: []xpv1.Condition{DeletionRefused()},
+			},
+		},
+		"Disabled": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: notLocked,
+				MockDeleteByScope: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errNotFound
+				},
+			},
+			mg: protected(DeletionProtectionDisabled),
+		},
+		"DisabledAfterRefusal": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: notLocked,
+				MockDeleteByScope: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, nil
+				},
+			},
+			mg: refused(DeletionProtectionDisabled),
+			want: want{
+				conditions: []xpv1.Condition{DeletionAllowed()},
+			},
+		},
+		"UnlockError": {
+			c: &fake.MockLocksClient{
+				MockGetByScope: notLocked,
+				MockDeleteByScope: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errBoom
+				},
+			},
+			mg: protected(DeletionProtectionDisabled),
+			want: want{
+				err: errors.Wrap(errBoom, errDeleteLock),
+			},
+		},
+		"ManagementLock": {
+			c: &fake.MockLocksClient{
+				MockDeleteByScope: func(_ context.Context, _ string, name string) (autorest.Response, error) {
+					if name != LockName && name != ManagementLockName {
+						return autorest.Response{}, errors.New("unexpected lock")
+					}
+					return autorest.Response{}, nil
+				},
+			},
+			mg: annotated(map[string]string{
+				AnnotationKeyDeletionProtection: DeletionProtectionDisabled,
+				AnnotationKeyManagementLock:     string(locks.CanNotDelete),
+			}),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := NewLockProtector(tc.c).AllowDeletion(context.Background(), tc.mg, id)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("AllowDeletion(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.conditions, tc.mg.Conditions, cmpopts.EquateEmpty(), cmpopts.IgnoreFields(xpv1.Condition{}, "LastTransitionTime")); diff != "" {
+				t.Errorf("AllowDeletion(...): -want conditions, +got conditions:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExternalObserve(t *testing.T) {
+	type want struct {
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		e    *external
+		mg   resource.Managed
+		want want
+	}{
+		"ObserveError": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{}, errBoom
+					},
+				},
+			},
+			mg: protected(DeletionProtectionLocked),
+			want: want{
+				err: errBoom,
+			},
+		},
+		"DoesNotExist": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{}, nil
+					},
+				},
+			},
+			mg: protected(DeletionProtectionLocked),
+		},
+		"NoLocks": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, nil
+					},
+				},
+			},
+			mg: protected(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"Deleted": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, nil
+					},
+				},
+			},
+			mg: deleted(protected(DeletionProtectionLocked)),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"DeletedUnprotected": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, nil
+					},
+				},
+				connect: connected(&fake.MockProtector{MockUnprotect: fake.NewMockUnprotectFn(nil)}),
+			},
+			mg: deleted(protected()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"UnprotectBeforeObserve": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{}, errors.New("unexpected observe")
+					},
+				},
+				connect: connected(&fake.MockProtector{MockUnprotect: fake.NewMockUnprotectFn(errBoom)}),
+			},
+			mg: annotated(map[string]string{AnnotationKeyManagementLock: ManagementLockNone}),
+			want: want{
+				err: errors.Wrap(errBoom, errProtect),
+			},
+		},
+		"ReadOnlyLockRemovedBeforeObserveFails": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{}, errBoom
+					},
+				},
+				connect: connected(&fake.MockProtector{MockUnprotect: func(_ context.Context, _ resource.Managed, got string) error {
+					if got != id {
+						return errors.New("unexpected id")
+					}
+					return nil
+				}}),
+			},
+			mg: annotated(map[string]string{AnnotationKeyManagementLock: ManagementLockNone}),
+			want: want{
+				err: errBoom,
+			},
+		},
+		"ConnectError": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, nil
+					},
+				},
+				connect: func(_ context.Context) (Protector, string, error) { return nil, "", errBoom },
+			},
+			mg: protected(DeletionProtectionLocked),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				err: errors.Wrap(errBoom, errProtect),
+			},
+		},
+		"ProtectError": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true}, nil
+					},
+				},
+				connect: connected(&fake.MockProtector{MockProtect: fake.NewMockProtectFn(errBoom)}),
+			},
+			mg: protected(DeletionProtectionLocked),
+			want: want{
+				o:   managed.ExternalObservation{ResourceExists: true},
+				err: errors.Wrap(errBoom, errProtect),
+			},
+		},
+		"Protected": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					ObserveFn: func(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+						return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
+					},
+				},
+				connect: connected(&fake.MockProtector{MockProtect: func(_ context.Context, _ resource.Managed, got string) error {
+					if got != id {
+						return errors.New("unexpected id")
+					}
+					return nil
+				}}),
+			},
+			mg: protected(DeletionProtectionLocked),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(context.Background(), tc.mg)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestExternalDelete(t *testing.T) {
+	cases := map[string]struct {
+		e    *external
+		want error
+	}{
+		"NotAllowed": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					DeleteFn: func(_ context.Context, _ resource.Managed) error {
+						return errors.New("unexpected delete")
+					},
+				},
+				connect: connected(&fake.MockProtector{MockAllowDeletion: fake.NewMockAllowDeletionFn(errBoom)}),
+			},
+			want: errBoom,
+		},
+		"Allowed": {
+			e: &external{
+				ExternalClient: &managed.ExternalClientFns{
+					DeleteFn: func(_ context.Context, _ resource.Managed) error { return nil },
+				},
+				connect: connected(&fake.MockProtector{MockAllowDeletion: fake.NewMockAllowDeletionFn(nil)}),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(context.Background(), protected())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
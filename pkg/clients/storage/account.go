@@ -17,143 +17,388 @@ limitations under the License.
 package storage
 
 import (
-	"context"
-	"encoding/json"
-	"fmt"
-
-	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
-	"github.com/Azure/go-autorest/autorest/azure/auth"
-	"github.com/Azure/go-autorest/autorest/to"
-	"github.com/pkg/errors"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
-// NewStorageAccountClient create Azure storage.AccountClient using provided credentials data
-func NewStorageAccountClient(data []byte) (*storage.AccountsClient, error) {
-	creds := &azure.Credentials{}
-	if err := json.Unmarshal(data, creds); err != nil {
-		return nil, errors.Wrap(err, "cannot unmarshal Azure client secret data")
-	}
+// Account provisioning states.
+const (
+	ProvisioningStateCreating     = string(storage.Creating)
+	ProvisioningStateResolvingDNS = string(storage.ResolvingDNS)
+	ProvisioningStateSucceeded    = string(storage.Succeeded)
+)
 
-	config := auth.NewClientCredentialsConfig(creds.ClientID, creds.ClientSecret, creds.TenantID)
-	config.AADEndpoint = creds.ActiveDirectoryEndpointURL
-	config.Resource = creds.ResourceManagerEndpointURL
+// NewAccountCreateParameters returns storage Account creation parameters
+// suitable for use with the Azure API.
+func NewAccountCreateParameters(p v1beta1.AccountParameters) storage.AccountCreateParameters {
+	return storage.AccountCreateParameters{
+		Sku:      &storage.Sku{Name: storage.SkuName(p.SKU.Name)},
+		Kind:     storage.Kind(p.Kind),
+		Location: azure.ToStringPtr(p.Location),
+		Identity: newIdentity(p.Identity),
+		Tags:     azure.ToStringPtrMap(p.Tags),
+		AccountPropertiesCreateParameters: &storage.AccountPropertiesCreateParameters{
+			CustomDomain:           newCustomDomain(p.CustomDomain),
+			Encryption:             newEncryption(p.Encryption),
+			NetworkRuleSet:         newNetworkRuleSet(p.NetworkRuleSet),
+			AccessTier:             storage.AccessTier(azure.ToString(p.AccessTier)),
+			EnableHTTPSTrafficOnly: p.EnableHTTPSTrafficOnly,
+			IsHnsEnabled:           p.IsHNSEnabled,
+		},
+	}
+}
 
-	authorizer, err := config.Authorizer()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get authorizer from config: %+v", err)
+// NewAccountUpdateParameters returns storage Account update parameters
+// suitable for use with the Azure API.
+func NewAccountUpdateParameters(p v1beta1.AccountParameters) storage.AccountUpdateParameters {
+	return storage.AccountUpdateParameters{
+		Sku:      &storage.Sku{Name: storage.SkuName(p.SKU.Name)},
+		Kind:     storage.Kind(p.Kind),
+		Identity: newIdentity(p.Identity),
+		Tags:     azure.ToStringPtrMap(p.Tags),
+		AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
+			CustomDomain:           newCustomDomain(p.CustomDomain),
+			Encryption:             newEncryption(p.Encryption),
+			NetworkRuleSet:         newNetworkRuleSet(p.NetworkRuleSet),
+			AccessTier:             storage.AccessTier(azure.ToString(p.AccessTier)),
+			EnableHTTPSTrafficOnly: p.EnableHTTPSTrafficOnly,
+		},
 	}
+}
 
-	client := storage.NewAccountsClient(creds.SubscriptionID)
-	client.Authorizer = authorizer
+// GenerateAccountObservation produces an AccountObservation from the
+// storage Account received from Azure.
+func GenerateAccountObservation(az storage.Account) v1beta1.AccountObservation {
+	o := v1beta1.AccountObservation{
+		ID:   azure.ToString(az.ID),
+		Name: azure.ToString(az.Name),
+		Type: azure.ToString(az.Type),
+	}
+	if az.Identity != nil {
+		o.Identity = &v1beta1.IdentityObservation{
+			PrincipalID: azure.ToString(az.Identity.PrincipalID),
+			TenantID:    azure.ToString(az.Identity.TenantID),
+		}
+	}
+	if az.AccountProperties == nil {
+		return o
+	}
+	o.ProvisioningState = string(az.ProvisioningState)
+	o.Encryption = generateEncryptionObservation(az.Encryption)
+	o.CreationTime = toMetaTime(az.CreationTime)
+	o.LastGeoFailoverTime = toMetaTime(az.LastGeoFailoverTime)
+	o.PrimaryEndpoints = generateEndpoints(az.PrimaryEndpoints)
+	o.PrimaryLocation = azure.ToString(az.PrimaryLocation)
+	o.StatusOfPrimary = string(az.StatusOfPrimary)
+	o.SecondaryEndpoints = generateEndpoints(az.SecondaryEndpoints)
+	o.SecondaryLocation = azure.ToString(az.SecondaryLocation)
+	o.StatusOfSecondary = string(az.StatusOfSecondary)
+	return o
+}
 
-	if err := client.AddToUserAgent(azure.UserAgent); err != nil {
-		return nil, errors.Wrap(err, "cannot add to Azure client user agent")
+// LateInitializeAccount fills the empty fields of the supplied
+// AccountParameters with the values of the storage Account received from
+// Azure.
+func LateInitializeAccount(p *v1beta1.AccountParameters, az storage.Account) {
+	o := generateAccountParameters(az)
+	if p.Identity == nil {
+		p.Identity = o.Identity
+	}
+	p.Tags = azure.LateInitializeStringMap(p.Tags, az.Tags)
+	p.AccessTier = azure.LateInitializeStringPtrFromPtr(p.AccessTier, o.AccessTier)
+	p.EnableHTTPSTrafficOnly = azure.LateInitializeBoolPtrFromPtr(p.EnableHTTPSTrafficOnly, o.EnableHTTPSTrafficOnly)
+	p.IsHNSEnabled = azure.LateInitializeBoolPtrFromPtr(p.IsHNSEnabled, o.IsHNSEnabled)
+	if p.CustomDomain == nil {
+		p.CustomDomain = o.CustomDomain
 	}
-	return &client, nil
+	if p.CustomDomain != nil && o.CustomDomain != nil {
+		p.CustomDomain.UseSubDomainName = azure.LateInitializeBoolPtrFromPtr(p.CustomDomain.UseSubDomainName, o.CustomDomain.UseSubDomainName)
+	}
+	p.Encryption = lateInitializeEncryption(p.Encryption, o.Encryption)
+	p.NetworkRuleSet = lateInitializeNetworkRuleSet(p.NetworkRuleSet, o.NetworkRuleSet)
 }
 
-// AccountOperations Azure storate account interface
-type AccountOperations interface {
-	Create(context.Context, storage.AccountCreateParameters) (*storage.Account, error)
-	Update(context.Context, storage.AccountUpdateParameters) (*storage.Account, error)
-	Get(ctx context.Context) (*storage.Account, error)
-	Delete(ctx context.Context) error
-	IsAccountNameAvailable(context.Context, string) error
-	ListKeys(context.Context) ([]storage.AccountKey, error)
+// IsAccountUpToDate returns true if the supplied storage Account received from
+// Azure matches the supplied AccountParameters. Fields that cannot be updated
+// are not considered.
+func IsAccountUpToDate(p v1beta1.AccountParameters, az storage.Account) bool {
+	desired := p.DeepCopy()
+	LateInitializeAccount(desired, az)
+
+	observed := generateAccountParameters(az)
+	observed.ResourceGroupName = desired.ResourceGroupName
+	observed.ResourceGroupNameRef = desired.ResourceGroupNameRef
+	observed.ResourceGroupNameSelector = desired.ResourceGroupNameSelector
+	observed.Location = desired.Location
+	// The hierarchical namespace cannot be enabled or disabled once an
+	// Account has been created.
+	observed.IsHNSEnabled = desired.IsHNSEnabled
+	if desired.Encryption != nil && observed.Encryption != nil {
+		observed.Encryption.KeyVaultKeyID = desired.Encryption.KeyVaultKeyID
+		observed.Encryption.KeyVaultKeyIDRef = desired.Encryption.KeyVaultKeyIDRef
+		observed.Encryption.KeyVaultKeyIDSelector = desired.Encryption.KeyVaultKeyIDSelector
+	}
+	// The static website is a property of the blob service rather than the
+	// Account; see IsStaticWebsiteUpToDate.
+	observed.StaticWebsite = desired.StaticWebsite
+
+	return cmp.Equal(*desired, observed, cmpopts.EquateEmpty(),
+		cmpopts.IgnoreFields(v1beta1.VirtualNetworkRule{}, "VirtualNetworkResourceIDRef", "VirtualNetworkResourceIDSelector"),
+		cmpopts.SortSlices(func(a, b v1beta1.VirtualNetworkRule) bool {
+			return a.VirtualNetworkResourceID < b.VirtualNetworkResourceID
+		}),
+		cmpopts.SortSlices(func(a, b v1beta1.IPRule) bool {
+			return a.IPAddressOrRange < b.IPAddressOrRange
+		}))
 }
 
-// AccountHandle implements AccountOperations interface
-type AccountHandle struct {
-	client      *storage.AccountsClient
-	groupName   string
-	accountName string
+// generateAccountParameters produces the AccountParameters that describe the
+// supplied storage Account received from Azure.
+func generateAccountParameters(az storage.Account) v1beta1.AccountParameters {
+	p := v1beta1.AccountParameters{
+		Location: azure.ToString(az.Location),
+		Kind:     string(az.Kind),
+		Tags:     azure.ToStringMap(az.Tags),
+	}
+	if az.Sku != nil {
+		p.SKU.Name = string(az.Sku.Name)
+	}
+	if az.Identity != nil && az.Identity.Type != nil {
+		p.Identity = &v1beta1.Identity{Type: azure.ToString(az.Identity.Type)}
+	}
+	if az.AccountProperties == nil {
+		return p
+	}
+	if az.AccessTier != "" {
+		p.AccessTier = azure.ToStringPtr(string(az.AccessTier))
+	}
+	p.EnableHTTPSTrafficOnly = az.EnableHTTPSTrafficOnly
+	p.IsHNSEnabled = az.IsHnsEnabled
+	if az.CustomDomain != nil {
+		p.CustomDomain = &v1beta1.CustomDomain{
+			Name:             azure.ToString(az.CustomDomain.Name),
+			UseSubDomainName: az.CustomDomain.UseSubDomainName,
+		}
+	}
+	p.Encryption = generateEncryption(az.Encryption)
+	p.NetworkRuleSet = generateNetworkRuleSet(az.NetworkRuleSet)
+	return p
 }
 
-var _ AccountOperations = &AccountHandle{}
+func newIdentity(i *v1beta1.Identity) *storage.Identity {
+	if i == nil {
+		return nil
+	}
+	return &storage.Identity{Type: azure.ToStringPtr(i.Type)}
+}
 
-// NewAccountHandle creates a new storage account with specific name,
-func NewAccountHandle(client *storage.AccountsClient, groupName, accountName string) *AccountHandle {
-	return &AccountHandle{
-		client:      client,
-		groupName:   groupName,
-		accountName: accountName,
+func newCustomDomain(d *v1beta1.CustomDomain) *storage.CustomDomain {
+	if d == nil {
+		return nil
+	}
+	return &storage.CustomDomain{
+		Name:             azure.ToStringPtr(d.Name, azure.FieldRequired),
+		UseSubDomainName: d.UseSubDomainName,
 	}
 }
 
-// Create create new storage account with given location
-func (a *AccountHandle) Create(ctx context.Context, params storage.AccountCreateParameters) (*storage.Account, error) {
-	if err := a.IsAccountNameAvailable(ctx, a.accountName); err != nil {
-		return nil, errors.Wrapf(err, "failed to check account name availability")
+func newEncryption(e *v1beta1.Encryption) *storage.Encryption {
+	if e == nil {
+		return nil
 	}
+	out := &storage.Encryption{KeySource: storage.KeySource(azure.ToString(e.KeySource))}
+	if s := e.Services; s != nil {
+		out.Services = &storage.EncryptionServices{
+			Blob: newEncryptionService(s.Blob),
+			File: newEncryptionService(s.File),
+		}
+	}
+	if kv := e.KeyVaultProperties; kv != nil {
+		out.KeyVaultProperties = &storage.KeyVaultProperties{
+			KeyName:     kv.KeyName,
+			KeyVersion:  kv.KeyVersion,
+			KeyVaultURI: kv.KeyVaultURI,
+		}
+	}
+	return out
+}
 
-	future, err := a.client.Create(ctx, a.groupName, a.accountName, params)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to start creating storage account")
+func newEncryptionService(enabled *bool) *storage.EncryptionService {
+	if enabled == nil {
+		return nil
 	}
+	return &storage.EncryptionService{Enabled: enabled}
+}
 
-	err = future.WaitForCompletionRef(ctx, a.client.Client)
-	if err != nil {
-		return nil, errors.Wrapf(err, "failed to finish creating storage account")
+func newNetworkRuleSet(n *v1beta1.NetworkRuleSet) *storage.NetworkRuleSet {
+	if n == nil {
+		return nil
+	}
+	vnr := make([]storage.VirtualNetworkRule, len(n.VirtualNetworkRules))
+	for i, r := range n.VirtualNetworkRules {
+		vnr[i] = storage.VirtualNetworkRule{
+			VirtualNetworkResourceID: azure.ToStringPtr(r.VirtualNetworkResourceID),
+			Action:                   storage.Action(azure.ToString(r.Action)),
+		}
+	}
+	ipr := make([]storage.IPRule, len(n.IPRules))
+	for i, r := range n.IPRules {
+		ipr[i] = storage.IPRule{
+			IPAddressOrRange: azure.ToStringPtr(r.IPAddressOrRange),
+			Action:           storage.Action(azure.ToString(r.Action)),
+		}
+	}
+	return &storage.NetworkRuleSet{
+		Bypass:              storage.Bypass(azure.ToString(n.Bypass)),
+		VirtualNetworkRules: &vnr,
+		IPRules:             &ipr,
+		DefaultAction:       storage.DefaultAction(n.DefaultAction),
 	}
+}
 
-	acct, err := future.Result(*a.client)
-	if err != nil {
-		return nil, err
+func generateEncryption(e *storage.Encryption) *v1beta1.Encryption {
+	if e == nil {
+		return nil
+	}
+	out := &v1beta1.Encryption{}
+	if e.KeySource != "" {
+		out.KeySource = azure.ToStringPtr(string(e.KeySource))
+	}
+	if s := e.Services; s != nil {
+		out.Services = &v1beta1.EncryptionServices{
+			Blob: generateEncryptionService(s.Blob),
+			File: generateEncryptionService(s.File),
+		}
 	}
-	return &acct, nil
+	if kv := e.KeyVaultProperties; kv != nil {
+		out.KeyVaultProperties = &v1beta1.KeyVaultProperties{
+			KeyName:     kv.KeyName,
+			KeyVersion:  kv.KeyVersion,
+			KeyVaultURI: kv.KeyVaultURI,
+		}
+	}
+	return out
 }
 
-// Update create new storage account with given location
-func (a *AccountHandle) Update(ctx context.Context, params storage.AccountUpdateParameters) (*storage.Account, error) {
-	acct, err := a.client.Update(ctx, a.groupName, a.accountName, params)
-	if err != nil {
-		return nil, err
+func generateEncryptionService(s *storage.EncryptionService) *bool {
+	if s == nil {
+		return nil
 	}
-	return &acct, nil
+	return s.Enabled
 }
 
-// Get retrieves storage account resource
-func (a *AccountHandle) Get(ctx context.Context) (*storage.Account, error) {
-	acct, err := a.client.GetProperties(ctx, a.groupName, a.accountName)
-	if err != nil {
-		return nil, err
+func generateNetworkRuleSet(n *storage.NetworkRuleSet) *v1beta1.NetworkRuleSet {
+	if n == nil {
+		return nil
+	}
+	out := &v1beta1.NetworkRuleSet{DefaultAction: string(n.DefaultAction)}
+	if n.Bypass != "" {
+		out.Bypass = azure.ToStringPtr(string(n.Bypass))
+	}
+	if n.VirtualNetworkRules != nil {
+		for _, r := range *n.VirtualNetworkRules {
+			out.VirtualNetworkRules = append(out.VirtualNetworkRules, v1beta1.VirtualNetworkRule{
+				VirtualNetworkResourceID: azure.ToString(r.VirtualNetworkResourceID),
+				Action:                   azure.ToStringPtr(string(r.Action)),
+			})
+		}
 	}
-	return &acct, nil
+	if n.IPRules != nil {
+		for _, r := range *n.IPRules {
+			out.IPRules = append(out.IPRules, v1beta1.IPRule{
+				IPAddressOrRange: azure.ToString(r.IPAddressOrRange),
+				Action:           azure.ToStringPtr(string(r.Action)),
+			})
+		}
+	}
+	return out
 }
 
-// Delete deletes storage account resource
-func (a *AccountHandle) Delete(ctx context.Context) error {
-	_, err := a.client.Delete(ctx, a.groupName, a.accountName)
-	return err
+func lateInitializeEncryption(in, from *v1beta1.Encryption) *v1beta1.Encryption {
+	if in == nil {
+		return from
+	}
+	if from == nil {
+		return in
+	}
+	if in.Services == nil {
+		in.Services = from.Services
+	}
+	if in.Services != nil && from.Services != nil {
+		in.Services.Blob = azure.LateInitializeBoolPtrFromPtr(in.Services.Blob, from.Services.Blob)
+		in.Services.File = azure.LateInitializeBoolPtrFromPtr(in.Services.File, from.Services.File)
+	}
+	// The key source and Key Vault properties are managed by the provider
+	// when a Key Vault key ID is supplied.
+	if in.KeyVaultKeyID != nil {
+		return in
+	}
+	in.KeySource = azure.LateInitializeStringPtrFromPtr(in.KeySource, from.KeySource)
+	if in.KeyVaultProperties == nil {
+		in.KeyVaultProperties = from.KeyVaultProperties
+	}
+	return in
 }
 
-// IsAccountNameAvailable checks if AccountHandle name is not being used (Azure requires unique storage account names)
-func (a *AccountHandle) IsAccountNameAvailable(ctx context.Context, name string) error {
-	result, err := a.client.CheckNameAvailability(
-		ctx,
-		storage.AccountCheckNameAvailabilityParameters{
-			Name: to.StringPtr(name),
-			Type: to.StringPtr("Microsoft.Storage/storageAccounts"),
-		})
-	if err != nil {
-		return err
+func lateInitializeNetworkRuleSet(in, from *v1beta1.NetworkRuleSet) *v1beta1.NetworkRuleSet {
+	if in == nil {
+		return from
 	}
+	if from == nil {
+		return in
+	}
+	in.Bypass = azure.LateInitializeStringPtrFromPtr(in.Bypass, from.Bypass)
 
-	if result.NameAvailable == nil || !*result.NameAvailable {
-		return errors.Errorf("%s - %s", result.Reason, to.String(result.Message))
+	// The only possible action of a rule is Allow, which Azure returns even
+	// if it was not supplied.
+	vnr := map[string]*string{}
+	for _, r := range from.VirtualNetworkRules {
+		vnr[r.VirtualNetworkResourceID] = r.Action
+	}
+	for i := range in.VirtualNetworkRules {
+		r := &in.VirtualNetworkRules[i]
+		r.Action = azure.LateInitializeStringPtrFromPtr(r.Action, vnr[r.VirtualNetworkResourceID])
+	}
+	ipr := map[string]*string{}
+	for _, r := range from.IPRules {
+		ipr[r.IPAddressOrRange] = r.Action
 	}
+	for i := range in.IPRules {
+		r := &in.IPRules[i]
+		r.Action = azure.LateInitializeStringPtrFromPtr(r.Action, ipr[r.IPAddressOrRange])
+	}
+	return in
+}
 
-	return nil
+func generateEndpoints(e *storage.Endpoints) *v1beta1.Endpoints {
+	if e == nil {
+		return nil
+	}
+	return &v1beta1.Endpoints{
+		Blob:  azure.ToString(e.Blob),
+		Queue: azure.ToString(e.Queue),
+		Table: azure.ToString(e.Table),
+		File:  azure.ToString(e.File),
+		Web:   azure.ToString(e.Web),
+	}
 }
 
-// ListKeys for this storage account
-func (a *AccountHandle) ListKeys(ctx context.Context) ([]storage.AccountKey, error) {
-	rs, err := a.client.ListKeys(ctx, a.groupName, a.accountName)
-	if err != nil {
-		return nil, err
+func toMetaTime(t *date.Time) *metav1.Time {
+	if t == nil {
+		return nil
 	}
+	return &metav1.Time{Time: t.Time}
+}
 
-	return *rs.Keys, nil
+func toDateTime(t *metav1.Time) *date.Time {
+	if t == nil {
+		return nil
+	}
+	return &date.Time{Time: t.Time}
 }
@@ -18,89 +18,293 @@ package storage
 
 import (
 	"testing"
+	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/google/go-cmp/cmp"
-	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
-	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
-func TestNewStorageAccountClient(t *testing.T) {
-	tests := []struct {
-		name    string
-		args    []byte
-		wantRes *storage.AccountsClient
-		wantErr error
+const (
+	location = "westus2"
+	subnetID = "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Network/virtualNetworks/coolnet/subnets/coolsubnet"
+	ipRange  = "10.0.0.0/24"
+)
+
+func params() v1beta1.AccountParameters {
+	return v1beta1.AccountParameters{
+		ResourceGroupName: "coolgroup",
+		Location:          location,
+		Kind:              string(storage.StorageV2),
+		SKU:               v1beta1.SKU{Name: string(storage.StandardLRS)},
+		Identity:          &v1beta1.Identity{Type: "SystemAssigned"},
+		AccessTier:        azure.ToStringPtr(string(storage.Hot)),
+		CustomDomain:      &v1beta1.CustomDomain{Name: "cool.example.org", UseSubDomainName: azure.ToBoolPtr(true)},
+		Encryption: &v1beta1.Encryption{
+			Services:  &v1beta1.EncryptionServices{Blob: azure.ToBoolPtr(true)},
+			KeySource: azure.ToStringPtr(string(storage.KeySourceMicrosoftStorage)),
+		},
+		EnableHTTPSTrafficOnly: azure.ToBoolPtr(true),
+		NetworkRuleSet: &v1beta1.NetworkRuleSet{
+			Bypass:              azure.ToStringPtr(string(storage.AzureServices)),
+			VirtualNetworkRules: []v1beta1.VirtualNetworkRule{{VirtualNetworkResourceID: subnetID}},
+			IPRules:             []v1beta1.IPRule{{IPAddressOrRange: ipRange}},
+			DefaultAction:       string(storage.DefaultActionDeny),
+		},
+		Tags: map[string]string{"cool": "very"},
+	}
+}
+
+func account() storage.Account {
+	return storage.Account{
+		ID:       azure.ToStringPtr("coolid"),
+		Name:     azure.ToStringPtr("coolaccount"),
+		Location: azure.ToStringPtr(location),
+		Kind:     storage.StorageV2,
+		Sku:      &storage.Sku{Name: storage.StandardLRS},
+		Identity: &storage.Identity{Type: azure.ToStringPtr("SystemAssigned"), PrincipalID: azure.ToStringPtr("coolprincipal")},
+		Tags:     map[string]*string{"cool": azure.ToStringPtr("very")},
+		AccountProperties: &storage.AccountProperties{
+			ProvisioningState: storage.Succeeded,
+			AccessTier:        storage.Hot,
+			CustomDomain:      &storage.CustomDomain{Name: azure.ToStringPtr("cool.example.org"), UseSubDomainName: azure.ToBoolPtr(true)},
+			Encryption: &storage.Encryption{
+				Services:  &storage.EncryptionServices{Blob: &storage.EncryptionService{Enabled: azure.ToBoolPtr(true)}},
+				KeySource: storage.KeySourceMicrosoftStorage,
+			},
+			EnableHTTPSTrafficOnly: azure.ToBoolPtr(true),
+			NetworkRuleSet: &storage.NetworkRuleSet{
+				Bypass:              storage.AzureServices,
+				VirtualNetworkRules: &[]storage.VirtualNetworkRule{{VirtualNetworkResourceID: azure.ToStringPtr(subnetID), Action: storage.Allow}},
+				IPRules:             &[]storage.IPRule{{IPAddressOrRange: azure.ToStringPtr(ipRange), Action: storage.Allow}},
+				DefaultAction:       storage.DefaultActionDeny,
+			},
+		},
+	}
+}
+
+func TestNewAccountCreateParameters(t *testing.T) {
+	want := storage.AccountCreateParameters{
+		Sku:      &storage.Sku{Name: storage.StandardLRS},
+		Kind:     storage.StorageV2,
+		Location: azure.ToStringPtr(location),
+		Identity: &storage.Identity{Type: azure.ToStringPtr("SystemAssigned")},
+		Tags:     map[string]*string{"cool": azure.ToStringPtr("very")},
+		AccountPropertiesCreateParameters: &storage.AccountPropertiesCreateParameters{
+			CustomDomain: &storage.CustomDomain{Name: azure.ToStringPtr("cool.example.org"), UseSubDomainName: azure.ToBoolPtr(true)},
+			Encryption: &storage.Encryption{
+				Services:  &storage.EncryptionServices{Blob: &storage.EncryptionService{Enabled: azure.ToBoolPtr(true)}},
+				KeySource: storage.KeySourceMicrosoftStorage,
+			},
+			NetworkRuleSet: &storage.NetworkRuleSet{
+				Bypass:              storage.AzureServices,
+				VirtualNetworkRules: &[]storage.VirtualNetworkRule{{VirtualNetworkResourceID: azure.ToStringPtr(subnetID)}},
+				IPRules:             &[]storage.IPRule{{IPAddressOrRange: azure.ToStringPtr(ipRange)}},
+				DefaultAction:       storage.DefaultActionDeny,
+			},
+			AccessTier:             storage.Hot,
+			EnableHTTPSTrafficOnly: azure.ToBoolPtr(true),
+		},
+	}
+	if diff := cmp.Diff(want, NewAccountCreateParameters(params())); diff != "" {
+		t.Errorf("NewAccountCreateParameters(...): -want, +got\n%s", diff)
+	}
+}
+
+func TestGenerateAccountObservation(t *testing.T) {
+	created := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		az   storage.Account
+		want v1beta1.AccountObservation
 	}{
-		{
-			name:    "EmptyData",
-			args:    []byte{},
-			wantRes: nil,
-			wantErr: errors.WithStack(errors.New("cannot unmarshal Azure client secret data: unexpected end of JSON input")),
-		},
-		{
-			name: "Success",
-			args: []byte(`{"clientId": "0f32e96b-b9a4-49ce-a857-243a33b20e5c",
-	"clientSecret": "49d8cab5-d47a-4d1a-9133-5c5db29c345d",
-	"subscriptionId": "bf1b0e59-93da-42e0-82c6-5a1d94227911",
-	"tenantId": "302de427-dba9-4452-8583-a4268e46de6b",
-	"activeDirectoryEndpointUrl": "https://login.microsoftonline.com",
-	"resourceManagerEndpointUrl": "https://management.azure.com/",
-	"activeDirectoryGraphResourceId": "https://graph.windows.net/",
-	"sqlManagementEndpointUrl": "https://management.core.windows.net:8443/",
-	"galleryEndpointUrl": "https://gallery.azure.com/",
-	"managementEndpointUrl": "https://management.core.windows.net/"}`),
-			wantRes: &storage.AccountsClient{},
-			wantErr: nil,
+		"NoProperties": {
+			az:   storage.Account{ID: azure.ToStringPtr("coolid")},
+			want: v1beta1.AccountObservation{ID: "coolid"},
+		},
+		"Full": {
+			az: storage.Account{
+				ID:       azure.ToStringPtr("coolid"),
+				Name:     azure.ToStringPtr("coolaccount"),
+				Identity: &storage.Identity{PrincipalID: azure.ToStringPtr("coolprincipal"), TenantID: azure.ToStringPtr("cooltenant")},
+				AccountProperties: &storage.AccountProperties{
+					ProvisioningState: storage.Succeeded,
+					CreationTime:      &date.Time{Time: created},
+					PrimaryEndpoints:  &storage.Endpoints{Blob: azure.ToStringPtr("https://coolaccount.blob.core.windows.net/")},
+					PrimaryLocation:   azure.ToStringPtr(location),
+					StatusOfPrimary:   storage.Available,
+				},
+			},
+			want: v1beta1.AccountObservation{
+				ID:                "coolid",
+				Name:              "coolaccount",
+				ProvisioningState: ProvisioningStateSucceeded,
+				Identity:          &v1beta1.IdentityObservation{PrincipalID: "coolprincipal", TenantID: "cooltenant"},
+				CreationTime:      &metav1.Time{Time: created},
+				PrimaryEndpoints:  &v1beta1.Endpoints{Blob: "https://coolaccount.blob.core.windows.net/"},
+				PrimaryLocation:   location,
+				StatusOfPrimary:   string(storage.Available),
+			},
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got, err := NewStorageAccountClient(tt.args)
-			if diff := cmp.Diff(err, tt.wantErr, test.EquateErrors()); diff != "" {
-				t.Errorf("NewStorageAccountClient() error = %v, wantErr %v\n%s", err, tt.wantErr, diff)
-			}
-			if err != nil && got != nil {
-				t.Errorf("NewStorageAccountClient() %v, want nil", got)
-			}
-			if err == nil && got == nil {
-				t.Errorf("NewStorageAccountClient() %v, want not nil", got)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, GenerateAccountObservation(tc.az)); diff != "" {
+				t.Errorf("GenerateAccountObservation(...): -want, +got\n%s", diff)
 			}
 		})
 	}
 }
 
-func TestNewAccountHandle(t *testing.T) {
-	type args struct {
-		client      *storage.AccountsClient
-		groupName   string
-		accountName string
-	}
-	tests := []struct {
-		name string
-		args args
-		want *AccountHandle
+func TestLateInitializeAccount(t *testing.T) {
+	cases := map[string]struct {
+		p    v1beta1.AccountParameters
+		az   storage.Account
+		want v1beta1.AccountParameters
 	}{
-		{
-			name: "Test",
-			args: args{
-				client:      &storage.AccountsClient{},
-				groupName:   "test-group",
-				accountName: "test-account",
+		"Empty": {
+			p: v1beta1.AccountParameters{
+				ResourceGroupName: "coolgroup",
+				Location:          location,
+				Kind:              string(storage.StorageV2),
+				SKU:               v1beta1.SKU{Name: string(storage.StandardLRS)},
 			},
-			want: &AccountHandle{
-				client:      &storage.AccountsClient{},
-				groupName:   "test-group",
-				accountName: "test-account",
+			az: account(),
+			want: func() v1beta1.AccountParameters {
+				p := params()
+				p.NetworkRuleSet.VirtualNetworkRules[0].Action = azure.ToStringPtr(string(storage.Allow))
+				p.NetworkRuleSet.IPRules[0].Action = azure.ToStringPtr(string(storage.Allow))
+				return p
+			}(),
+		},
+		"RuleActions": {
+			p:  params(),
+			az: account(),
+			want: func() v1beta1.AccountParameters {
+				p := params()
+				p.NetworkRuleSet.VirtualNetworkRules[0].Action = azure.ToStringPtr(string(storage.Allow))
+				p.NetworkRuleSet.IPRules[0].Action = azure.ToStringPtr(string(storage.Allow))
+				return p
+			}(),
+		},
+		"NoOverwrite": {
+			p: func() v1beta1.AccountParameters {
+				p := params()
+				p.AccessTier = azure.ToStringPtr(string(storage.Cool))
+				p.Tags = map[string]string{"cool": "extremely"}
+				return p
+			}(),
+			az: storage.Account{
+				Tags:              map[string]*string{"cool": azure.ToStringPtr("very")},
+				AccountProperties: &storage.AccountProperties{AccessTier: storage.Hot},
 			},
+			want: func() v1beta1.AccountParameters {
+				p := params()
+				p.AccessTier = azure.ToStringPtr(string(storage.Cool))
+				p.Tags = map[string]string{"cool": "extremely"}
+				return p
+			}(),
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			got := NewAccountHandle(tt.args.client, tt.args.groupName, tt.args.accountName)
-			if diff := cmp.Diff(got, tt.want, cmp.AllowUnexported(AccountHandle{})); diff != "" {
-				t.Errorf("NewAccountHandle() = %v, wantErr %v\n%s", got, tt.want, diff)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeAccount(&tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("LateInitializeAccount(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsAccountUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		p    v1beta1.AccountParameters
+		az   storage.Account
+		want bool
+	}{
+		"UpToDate": {
+			p:    params(),
+			az:   account(),
+			want: true,
+		},
+		"UpToDateWithUnsetFields": {
+			p: v1beta1.AccountParameters{
+				ResourceGroupName: "coolgroup",
+				Location:          location,
+				Kind:              string(storage.StorageV2),
+				SKU:               v1beta1.SKU{Name: string(storage.StandardLRS)},
+			},
+			az:   account(),
+			want: true,
+		},
+		"RulesInDifferentOrder": {
+			p: func() v1beta1.AccountParameters {
+				p := params()
+				p.NetworkRuleSet.IPRules = []v1beta1.IPRule{{IPAddressOrRange: "10.0.1.0/24"}, {IPAddressOrRange: ipRange}}
+				return p
+			}(),
+			az: func() storage.Account {
+				a := account()
+				a.NetworkRuleSet.IPRules = &[]storage.IPRule{
+					{IPAddressOrRange: azure.ToStringPtr(ipRange), Action: storage.Allow},
+					{IPAddressOrRange: azure.ToStringPtr("10.0.1.0/24"), Action: storage.Allow},
+				}
+				return a
+			}(),
+			want: true,
+		},
+		"HNSChanged": {
+			p: func() v1beta1.AccountParameters {
+				p := params()
+				p.IsHNSEnabled = azure.ToBoolPtr(true)
+				return p
+			}(),
+			az: func() storage.Account {
+				a := account()
+				a.IsHnsEnabled = azure.ToBoolPtr(false)
+				return a
+			}(),
+			want: true,
+		},
+		"SKUChanged": {
+			p: func() v1beta1.AccountParameters {
+				p := params()
+				p.SKU.Name = string(storage.StandardGRS)
+				return p
+			}(),
+			az:   account(),
+			want: false,
+		},
+		"TagsChanged": {
+			p: func() v1beta1.AccountParameters {
+				p := params()
+				p.Tags = map[string]string{"cool": "extremely"}
+				return p
+			}(),
+			az:   account(),
+			want: false,
+		},
+		"RuleRemoved": {
+			p: func() v1beta1.AccountParameters {
+				p := params()
+				p.NetworkRuleSet.IPRules = nil
+				return p
+			}(),
+			az:   account(),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsAccountUpToDate(tc.p, tc.az); got != tc.want {
+				t.Errorf("IsAccountUpToDate(...): want %t, got %t", tc.want, got)
 			}
 		})
 	}
@@ -0,0 +1,261 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const blobServicePath = "/blobServices/default"
+
+// LastAccessTimeTrackingPolicyName is the name of all last access time
+// tracking policies.
+const LastAccessTimeTrackingPolicyName = "AccessTimeTracking"
+
+// BlobServiceProperties are the properties of the blob service of a storage
+// Account, as represented by the Azure management API. The vendored Azure SDK
+// predates last access time tracking.
+type BlobServiceProperties struct {
+	autorest.Response `json:"-"`
+
+	// ID - READ-ONLY; Fully qualified resource ID of the blob service.
+	ID *string `json:"id,omitempty"`
+
+	// Name - READ-ONLY; The name of the blob service.
+	Name *string `json:"name,omitempty"`
+
+	*BlobServicePropertiesProperties `json:"properties,omitempty"`
+}
+
+// BlobServicePropertiesProperties are the properties of a blob service.
+type BlobServicePropertiesProperties struct {
+	storage.BlobServicePropertiesProperties
+
+	// LastAccessTimeTrackingPolicy - The policy that tracks when blobs were
+	// last accessed.
+	LastAccessTimeTrackingPolicy *LastAccessTimeTrackingPolicy `json:"lastAccessTimeTrackingPolicy,omitempty"`
+}
+
+// A LastAccessTimeTrackingPolicy tracks when the blobs of a blob service were
+// last accessed.
+type LastAccessTimeTrackingPolicy struct {
+	// Enable - Whether last access time tracking is enabled.
+	Enable *bool `json:"enable,omitempty"`
+
+	// Name - The name of the policy, which is always 'AccessTimeTracking'.
+	Name *string `json:"name,omitempty"`
+
+	// TrackingGranularityInDays - READ-ONLY; How often the last access time
+	// of a blob is updated.
+	TrackingGranularityInDays *int32 `json:"trackingGranularityInDays,omitempty"`
+
+	// BlobType - READ-ONLY; The types of blob whose last access time is
+	// tracked.
+	BlobType *[]string `json:"blobType,omitempty"`
+}
+
+// BlobServiceClientAPI manages the blob service of a storage Account.
+type BlobServiceClientAPI interface {
+	GetServiceProperties(ctx context.Context, resourceGroupName string, accountName string) (BlobServiceProperties, error)
+	SetServiceProperties(ctx context.Context, resourceGroupName string, accountName string, parameters BlobServiceProperties) (BlobServiceProperties, error)
+}
+
+var _ BlobServiceClientAPI = BlobServiceClient{}
+
+// BlobServiceClient manages the blob service of a storage Account using the
+// Azure management API.
+type BlobServiceClient struct {
+	servicesClient
+}
+
+// NewBlobServiceClient returns a BlobServiceClient for the supplied
+// subscription.
+func NewBlobServiceClient(subscriptionID string) BlobServiceClient {
+	return BlobServiceClient{servicesClient{BaseClient: storage.New(subscriptionID), name: "storage.BlobServiceClient"}}
+}
+
+// GetServiceProperties gets the properties of the blob service.
+func (c BlobServiceClient) GetServiceProperties(ctx context.Context, resourceGroupName string, accountName string) (BlobServiceProperties, error) {
+	result := BlobServiceProperties{}
+	resp, err := c.send(ctx, "GetServiceProperties", http.MethodGet, resourceGroupName, accountName, blobServicePath, nil, nil, &result)
+	result.Response = resp
+	return result, err
+}
+
+// SetServiceProperties sets the supplied properties of the blob service.
+func (c BlobServiceClient) SetServiceProperties(ctx context.Context, resourceGroupName string, accountName string, parameters BlobServiceProperties) (BlobServiceProperties, error) {
+	result := BlobServiceProperties{}
+	body := BlobServiceProperties{BlobServicePropertiesProperties: parameters.BlobServicePropertiesProperties}
+	resp, err := c.send(ctx, "SetServiceProperties", http.MethodPut, resourceGroupName, accountName, blobServicePath, nil, body, &result)
+	result.Response = resp
+	return result, err
+}
+
+// NewBlobServiceProperties returns blob service properties suitable for use
+// with the Azure API. Properties that are not specified are not changed.
+func NewBlobServiceProperties(p v1beta1.BlobServiceParameters) BlobServiceProperties {
+	props := &BlobServicePropertiesProperties{
+		BlobServicePropertiesProperties: storage.BlobServicePropertiesProperties{
+			DeleteRetentionPolicy:          newDeleteRetentionPolicy(p.DeleteRetentionPolicy),
+			ContainerDeleteRetentionPolicy: newDeleteRetentionPolicy(p.ContainerDeleteRetentionPolicy),
+			IsVersioningEnabled:            p.IsVersioningEnabled,
+		},
+	}
+	if p.ChangeFeed != nil {
+		props.ChangeFeed = &storage.ChangeFeed{Enabled: azure.ToBoolPtr(p.ChangeFeed.Enabled, azure.FieldRequired)}
+	}
+	if t := p.LastAccessTimeTrackingPolicy; t != nil {
+		props.LastAccessTimeTrackingPolicy = &LastAccessTimeTrackingPolicy{
+			Enable: azure.ToBoolPtr(t.Enabled, azure.FieldRequired),
+			Name:   azure.ToStringPtr(LastAccessTimeTrackingPolicyName),
+		}
+	}
+	if p.CORSRules != nil {
+		rules := make([]storage.CorsRule, len(p.CORSRules))
+		for i, r := range p.CORSRules {
+			rules[i] = storage.CorsRule{
+				AllowedOrigins:  azure.ToStringArrayPtr(r.AllowedOrigins),
+				AllowedMethods:  azure.ToStringArrayPtr(r.AllowedMethods),
+				MaxAgeInSeconds: azure.ToInt32Ptr(r.MaxAgeInSeconds, azure.FieldRequired),
+				ExposedHeaders:  azure.ToStringArrayPtr(r.ExposedHeaders),
+				AllowedHeaders:  azure.ToStringArrayPtr(r.AllowedHeaders),
+			}
+		}
+		props.Cors = &storage.CorsRules{CorsRules: &rules}
+	}
+	return BlobServiceProperties{BlobServicePropertiesProperties: props}
+}
+
+// GenerateBlobServiceObservation produces a BlobServiceObservation from the
+// blob service properties received from Azure.
+func GenerateBlobServiceObservation(az BlobServiceProperties) v1beta1.BlobServiceObservation {
+	return v1beta1.BlobServiceObservation{ID: azure.ToString(az.ID)}
+}
+
+// LateInitializeBlobService fills the empty fields of the supplied
+// BlobServiceParameters with the blob service properties received from
+// Azure.
+func LateInitializeBlobService(p *v1beta1.BlobServiceParameters, az BlobServiceProperties) {
+	o := generateBlobServiceParameters(az)
+	p.DeleteRetentionPolicy = lateInitializeDeleteRetentionPolicy(p.DeleteRetentionPolicy, o.DeleteRetentionPolicy)
+	p.ContainerDeleteRetentionPolicy = lateInitializeDeleteRetentionPolicy(p.ContainerDeleteRetentionPolicy, o.ContainerDeleteRetentionPolicy)
+	p.IsVersioningEnabled = azure.LateInitializeBoolPtrFromPtr(p.IsVersioningEnabled, o.IsVersioningEnabled)
+	if p.ChangeFeed == nil {
+		p.ChangeFeed = o.ChangeFeed
+	}
+	if p.LastAccessTimeTrackingPolicy == nil {
+		p.LastAccessTimeTrackingPolicy = o.LastAccessTimeTrackingPolicy
+	}
+	if p.CORSRules == nil {
+		p.CORSRules = o.CORSRules
+	}
+}
+
+// IsBlobServiceUpToDate returns true if the supplied blob service properties
+// received from Azure match the supplied BlobServiceParameters. The number of
+// days of a disabled retention policy is not considered.
+func IsBlobServiceUpToDate(p v1beta1.BlobServiceParameters, az BlobServiceProperties) bool {
+	desired := p.DeepCopy()
+	LateInitializeBlobService(desired, az)
+
+	observed := generateBlobServiceParameters(az)
+	observed.ResourceGroupName = desired.ResourceGroupName
+	observed.ResourceGroupNameRef = desired.ResourceGroupNameRef
+	observed.ResourceGroupNameSelector = desired.ResourceGroupNameSelector
+	observed.AccountName = desired.AccountName
+	observed.AccountNameRef = desired.AccountNameRef
+	observed.AccountNameSelector = desired.AccountNameSelector
+
+	for _, p := range []*v1beta1.BlobServiceParameters{desired, &observed} {
+		for _, rp := range []*v1beta1.DeleteRetentionPolicy{p.DeleteRetentionPolicy, p.ContainerDeleteRetentionPolicy} {
+			if rp != nil && !rp.Enabled {
+				rp.Days = nil
+			}
+		}
+	}
+
+	return cmp.Equal(*desired, observed, cmpopts.EquateEmpty())
+}
+
+// generateBlobServiceParameters produces the BlobServiceParameters that
+// describe the supplied blob service properties received from Azure.
+func generateBlobServiceParameters(az BlobServiceProperties) v1beta1.BlobServiceParameters {
+	p := v1beta1.BlobServiceParameters{}
+	props := az.BlobServicePropertiesProperties
+	if props == nil {
+		return p
+	}
+	p.DeleteRetentionPolicy = generateDeleteRetentionPolicy(props.DeleteRetentionPolicy)
+	p.ContainerDeleteRetentionPolicy = generateDeleteRetentionPolicy(props.ContainerDeleteRetentionPolicy)
+	p.IsVersioningEnabled = props.IsVersioningEnabled
+	if props.ChangeFeed != nil {
+		p.ChangeFeed = &v1beta1.ChangeFeed{Enabled: azure.ToBool(props.ChangeFeed.Enabled)}
+	}
+	if props.LastAccessTimeTrackingPolicy != nil {
+		p.LastAccessTimeTrackingPolicy = &v1beta1.LastAccessTimeTrackingPolicy{Enabled: azure.ToBool(props.LastAccessTimeTrackingPolicy.Enable)}
+	}
+	if props.Cors != nil && props.Cors.CorsRules != nil {
+		for _, r := range *props.Cors.CorsRules {
+			p.CORSRules = append(p.CORSRules, v1beta1.CORSRule{
+				AllowedOrigins:  azure.LateInitializeStringValArrFromArrPtr(nil, r.AllowedOrigins),
+				AllowedMethods:  azure.LateInitializeStringValArrFromArrPtr(nil, r.AllowedMethods),
+				MaxAgeInSeconds: azure.ToInt(r.MaxAgeInSeconds),
+				ExposedHeaders:  azure.LateInitializeStringValArrFromArrPtr(nil, r.ExposedHeaders),
+				AllowedHeaders:  azure.LateInitializeStringValArrFromArrPtr(nil, r.AllowedHeaders),
+			})
+		}
+	}
+	return p
+}
+
+func newDeleteRetentionPolicy(rp *v1beta1.DeleteRetentionPolicy) *storage.DeleteRetentionPolicy {
+	if rp == nil {
+		return nil
+	}
+	return &storage.DeleteRetentionPolicy{
+		Enabled: azure.ToBoolPtr(rp.Enabled, azure.FieldRequired),
+		Days:    azure.ToInt32(rp.Days),
+	}
+}
+
+func generateDeleteRetentionPolicy(rp *storage.DeleteRetentionPolicy) *v1beta1.DeleteRetentionPolicy {
+	if rp == nil {
+		return nil
+	}
+	out := &v1beta1.DeleteRetentionPolicy{Enabled: azure.ToBool(rp.Enabled)}
+	out.Days = azure.LateInitializeIntPtrFromInt32Ptr(nil, rp.Days)
+	return out
+}
+
+func lateInitializeDeleteRetentionPolicy(in, from *v1beta1.DeleteRetentionPolicy) *v1beta1.DeleteRetentionPolicy {
+	if in == nil {
+		return from
+	}
+	if from != nil {
+		in.Days = azure.LateInitializeIntPtrFromInt32Ptr(in.Days, azure.ToInt32(from.Days))
+	}
+	return in
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func intPtr(i int) *int { return &i }
+
+func blobServiceParams() v1beta1.BlobServiceParameters {
+	return v1beta1.BlobServiceParameters{
+		ResourceGroupName:              "coolgroup",
+		AccountName:                    "coolaccount",
+		DeleteRetentionPolicy:          &v1beta1.DeleteRetentionPolicy{Enabled: true, Days: intPtr(14)},
+		ContainerDeleteRetentionPolicy: &v1beta1.DeleteRetentionPolicy{Enabled: false},
+		IsVersioningEnabled:            azure.ToBoolPtr(true),
+		ChangeFeed:                     &v1beta1.ChangeFeed{Enabled: true},
+		LastAccessTimeTrackingPolicy:   &v1beta1.LastAccessTimeTrackingPolicy{Enabled: true},
+		CORSRules: []v1beta1.CORSRule{{
+			AllowedOrigins:  []string{"https://example.com"},
+			AllowedMethods:  []string{"GET"},
+			MaxAgeInSeconds: 60,
+		}},
+	}
+}
+
+func TestLateInitializeBlobService(t *testing.T) {
+	cases := map[string]struct {
+		p    v1beta1.BlobServiceParameters
+		az   BlobServiceProperties
+		want v1beta1.BlobServiceParameters
+	}{
+		"NoProperties": {
+			p:    v1beta1.BlobServiceParameters{AccountName: "coolaccount"},
+			az:   BlobServiceProperties{},
+			want: v1beta1.BlobServiceParameters{AccountName: "coolaccount"},
+		},
+		"AllUnset": {
+			p:    v1beta1.BlobServiceParameters{ResourceGroupName: "coolgroup", AccountName: "coolaccount"},
+			az:   NewBlobServiceProperties(blobServiceParams()),
+			want: blobServiceParams(),
+		},
+		"RetentionDays": {
+			p: v1beta1.BlobServiceParameters{
+				DeleteRetentionPolicy: &v1beta1.DeleteRetentionPolicy{Enabled: true},
+				IsVersioningEnabled:   azure.ToBoolPtr(false, azure.FieldRequired),
+			},
+			az: NewBlobServiceProperties(blobServiceParams()),
+			want: v1beta1.BlobServiceParameters{
+				DeleteRetentionPolicy:          &v1beta1.DeleteRetentionPolicy{Enabled: true, Days: intPtr(14)},
+				ContainerDeleteRetentionPolicy: &v1beta1.DeleteRetentionPolicy{Enabled: false},
+				IsVersioningEnabled:            azure.ToBoolPtr(false, azure.FieldRequired),
+				ChangeFeed:                     &v1beta1.ChangeFeed{Enabled: true},
+				LastAccessTimeTrackingPolicy:   &v1beta1.LastAccessTimeTrackingPolicy{Enabled: true},
+				CORSRules:                      blobServiceParams().CORSRules,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeBlobService(&tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("LateInitializeBlobService(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsBlobServiceUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		p    v1beta1.BlobServiceParameters
+		az   BlobServiceProperties
+		want bool
+	}{
+		"UpToDate": {
+			p:    blobServiceParams(),
+			az:   NewBlobServiceProperties(blobServiceParams()),
+			want: true,
+		},
+		"Unspecified": {
+			p:    v1beta1.BlobServiceParameters{ResourceGroupName: "coolgroup", AccountName: "coolaccount"},
+			az:   NewBlobServiceProperties(blobServiceParams()),
+			want: true,
+		},
+		"DisabledPolicyDaysIgnored": {
+			p: func() v1beta1.BlobServiceParameters {
+				p := blobServiceParams()
+				p.ContainerDeleteRetentionPolicy.Days = intPtr(30)
+				return p
+			}(),
+			az:   NewBlobServiceProperties(blobServiceParams()),
+			want: true,
+		},
+		"LastAccessTimeTrackingDisabled": {
+			p: func() v1beta1.BlobServiceParameters {
+				p := blobServiceParams()
+				p.LastAccessTimeTrackingPolicy.Enabled = false
+				return p
+			}(),
+			az:   NewBlobServiceProperties(blobServiceParams()),
+			want: false,
+		},
+		"RetentionDaysChanged": {
+			p: func() v1beta1.BlobServiceParameters {
+				p := blobServiceParams()
+				p.DeleteRetentionPolicy.Days = intPtr(30)
+				return p
+			}(),
+			az:   NewBlobServiceProperties(blobServiceParams()),
+			want: false,
+		},
+		"CORSRulesRemoved": {
+			p: func() v1beta1.BlobServiceParameters {
+				p := blobServiceParams()
+				p.CORSRules = []v1beta1.CORSRule{}
+				return p
+			}(),
+			az:   NewBlobServiceProperties(blobServiceParams()),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsBlobServiceUpToDate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsBlobServiceUpToDate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,9 +21,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/Azure/azure-storage-blob-go/azblob"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 )
 
@@ -31,14 +35,25 @@ import (
 type ContainerOperations interface {
 	Create(ctx context.Context, publicAccessType azblob.PublicAccessType, metadata azblob.Metadata) error
 	Update(ctx context.Context, publicAccessType azblob.PublicAccessType, metadata azblob.Metadata) error
-	Get(ctx context.Context) (*azblob.PublicAccessType, azblob.Metadata, error)
+	Get(ctx context.Context) (*ContainerProperties, error)
 	Delete(ctx context.Context) error
 }
 
+// ContainerProperties are the observed properties of a Container.
+type ContainerProperties struct {
+	PublicAccessType      azblob.PublicAccessType
+	Metadata              azblob.Metadata
+	ETag                  azblob.ETag
+	LastModified          time.Time
+	LeaseState            azblob.LeaseStateType
+	LeaseStatus           azblob.LeaseStatusType
+	HasImmutabilityPolicy bool
+	HasLegalHold          bool
+}
+
 // ContainerHandle implements ContainerOperations
 type ContainerHandle struct {
 	azblob.ContainerURL
-	PublicAccessType azblob.PublicAccessType
 }
 
 var _ ContainerOperations = &ContainerHandle{}
@@ -51,7 +66,18 @@ func NewContainerHandle(accountName, accountKey, containerName string) (*Contain
 	if err != nil {
 		return nil, err
 	}
+	return newContainerHandle(accountName, containerName, c), nil
+}
 
+// NewContainerHandleWithToken creates a new instance of ContainerHandle for
+// the given storage account and container name that authorizes requests with
+// the supplied Azure AD token. Requests authorized this way succeed even if
+// the storage account does not allow shared key access.
+func NewContainerHandleWithToken(accountName, token, containerName string) *ContainerHandle {
+	return newContainerHandle(accountName, containerName, azblob.NewTokenCredential(token, nil))
+}
+
+func newContainerHandle(accountName, containerName string, c azblob.Credential) *ContainerHandle {
 	p := azblob.NewPipeline(c, azblob.PipelineOptions{
 		Telemetry: azblob.TelemetryOptions{Value: azure.UserAgent},
 	})
@@ -61,12 +87,12 @@ func NewContainerHandle(accountName, accountKey, containerName string) (*Contain
 
 	return &ContainerHandle{
 		ContainerURL: service.NewContainerURL(containerName),
-	}, nil
+	}
 }
 
 // Create container resource
 func (a *ContainerHandle) Create(ctx context.Context, publicAccessType azblob.PublicAccessType, metadata azblob.Metadata) error {
-	_, err := a.ContainerURL.Create(ctx, azblob.Metadata{}, publicAccessType)
+	_, err := a.ContainerURL.Create(ctx, metadata, publicAccessType)
 	return err
 }
 
@@ -80,13 +106,21 @@ func (a *ContainerHandle) Update(ctx context.Context, publicAccessType azblob.Pu
 }
 
 // Get resource information
-func (a *ContainerHandle) Get(ctx context.Context) (*azblob.PublicAccessType, azblob.Metadata, error) {
+func (a *ContainerHandle) Get(ctx context.Context) (*ContainerProperties, error) {
 	rs, err := a.ContainerURL.GetProperties(ctx, azblob.LeaseAccessConditions{})
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	publicAccess := rs.BlobPublicAccess()
-	return &publicAccess, emtpyMetaToNil(rs.NewMetadata()), nil
+	return &ContainerProperties{
+		PublicAccessType:      rs.BlobPublicAccess(),
+		Metadata:              emtpyMetaToNil(rs.NewMetadata()),
+		ETag:                  rs.ETag(),
+		LastModified:          rs.LastModified(),
+		LeaseState:            rs.LeaseState(),
+		LeaseStatus:           rs.LeaseStatus(),
+		HasImmutabilityPolicy: rs.HasImmutabilityPolicy() == "true",
+		HasLegalHold:          rs.HasLegalHold() == "true",
+	}, nil
 }
 
 // Delete deletes the named container.
@@ -95,6 +129,54 @@ func (a *ContainerHandle) Delete(ctx context.Context) error {
 	return err
 }
 
+// NewContainerMetadata returns the metadata of the supplied Container
+// parameters suitable for use with the Azure API. Azure stores metadata keys
+// in lower case.
+func NewContainerMetadata(p v1beta1.ContainerParameters) azblob.Metadata {
+	if len(p.Metadata) == 0 {
+		return nil
+	}
+	md := make(azblob.Metadata, len(p.Metadata))
+	for k, v := range p.Metadata {
+		md[strings.ToLower(k)] = v
+	}
+	return md
+}
+
+// GenerateContainerObservation produces a ContainerObservation from the
+// supplied Container properties received from Azure.
+func GenerateContainerObservation(p ContainerProperties) v1beta1.ContainerObservation {
+	o := v1beta1.ContainerObservation{
+		ETag:                  string(p.ETag),
+		LeaseState:            string(p.LeaseState),
+		LeaseStatus:           string(p.LeaseStatus),
+		HasImmutabilityPolicy: p.HasImmutabilityPolicy,
+		HasLegalHold:          p.HasLegalHold,
+	}
+	if !p.LastModified.IsZero() {
+		o.LastModified = &metav1.Time{Time: p.LastModified}
+	}
+	return o
+}
+
+// IsContainerUpToDate returns true if the supplied Container properties
+// received from Azure match the supplied ContainerParameters.
+func IsContainerUpToDate(p v1beta1.ContainerParameters, az ContainerProperties) bool {
+	if azblob.PublicAccessType(p.PublicAccessType) != az.PublicAccessType {
+		return false
+	}
+	md := NewContainerMetadata(p)
+	if len(md) != len(az.Metadata) {
+		return false
+	}
+	for k, v := range md {
+		if got, ok := az.Metadata[k]; !ok || got != v {
+			return false
+		}
+	}
+	return true
+}
+
 func emtpyMetaToNil(m azblob.Metadata) azblob.Metadata {
 	if len(m) == 0 {
 		return nil
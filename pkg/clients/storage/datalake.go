@@ -0,0 +1,271 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"encoding/base64"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake"
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake/storagedatalakeapi"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const (
+	errParseProperty = "cannot parse filesystem property"
+	errParseACLEntry = "cannot parse access control list entry"
+)
+
+// DataLakeVersion is the version of the Azure Data Lake Storage Gen2 API
+// used by Filesystem and Path clients.
+const DataLakeVersion = "2019-10-31"
+
+// Headers returned by the Azure Data Lake Storage Gen2 API.
+const (
+	HeaderETag         = "ETag"
+	HeaderLastModified = "Last-Modified"
+	HeaderProperties   = "x-ms-properties"
+	HeaderOwner        = "x-ms-owner"
+	HeaderGroup        = "x-ms-group"
+	HeaderPermissions  = "x-ms-permissions"
+	HeaderACL          = "x-ms-acl"
+)
+
+const aclEntryDefault = "default"
+
+// NewFilesystemClient returns a Filesystem client for the supplied storage
+// Account, authorized by the supplied access key.
+func NewFilesystemClient(accountName, accountKey string) (storagedatalakeapi.FilesystemClientAPI, error) {
+	a, err := autorest.NewSharedKeyAuthorizer(accountName, accountKey, autorest.SharedKey)
+	if err != nil {
+		return nil, err
+	}
+	c := storagedatalake.NewFilesystemClient(DataLakeVersion, accountName)
+	c.Authorizer = a
+	_ = c.AddToUserAgent(azure.UserAgent)
+	return c, nil
+}
+
+// NewPathClient returns a Path client for the supplied storage Account,
+// authorized by the supplied access key.
+func NewPathClient(accountName, accountKey string) (storagedatalakeapi.PathClientAPI, error) {
+	a, err := autorest.NewSharedKeyAuthorizer(accountName, accountKey, autorest.SharedKey)
+	if err != nil {
+		return nil, err
+	}
+	c := storagedatalake.NewPathClient(DataLakeVersion, accountName)
+	c.Authorizer = a
+	_ = c.AddToUserAgent(azure.UserAgent)
+	return c, nil
+}
+
+// NewFilesystemProperties returns the supplied Filesystem properties encoded
+// as expected by the x-ms-properties header; a comma separated list of names
+// and base64 encoded values.
+func NewFilesystemProperties(p map[string]string) string {
+	names := make([]string, 0, len(p))
+	for n := range p {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	props := make([]string, len(names))
+	for i, n := range names {
+		props[i] = n + "=" + base64.StdEncoding.EncodeToString([]byte(p[n]))
+	}
+	return strings.Join(props, ",")
+}
+
+// ParseFilesystemProperties parses Filesystem properties encoded as returned
+// by the x-ms-properties header.
+func ParseFilesystemProperties(s string) (map[string]string, error) {
+	if s == "" {
+		return nil, nil
+	}
+	p := map[string]string{}
+	for _, prop := range strings.Split(s, ",") {
+		kv := strings.SplitN(prop, "=", 2)
+		if len(kv) != 2 {
+			return nil, errors.Errorf("%s: %s", errParseProperty, prop)
+		}
+		v, err := base64.StdEncoding.DecodeString(kv[1])
+		if err != nil {
+			return nil, errors.Wrap(err, errParseProperty)
+		}
+		p[kv[0]] = string(v)
+	}
+	return p, nil
+}
+
+// GenerateFilesystemObservation produces a FilesystemObservation from the
+// headers of a Filesystem received from Azure.
+func GenerateFilesystemObservation(h http.Header) v1beta1.FilesystemObservation {
+	return v1beta1.FilesystemObservation{
+		ETag:             h.Get(HeaderETag),
+		LastModifiedTime: lastModified(h),
+	}
+}
+
+// LateInitializeFilesystem fills the empty fields of the supplied
+// FilesystemParameters with the supplied properties received from Azure.
+func LateInitializeFilesystem(p *v1beta1.FilesystemParameters, props map[string]string) {
+	if p.Properties == nil {
+		p.Properties = props
+	}
+}
+
+// IsFilesystemUpToDate returns true if the supplied properties received from
+// Azure match the supplied FilesystemParameters.
+func IsFilesystemUpToDate(p v1beta1.FilesystemParameters, props map[string]string) bool {
+	return cmp.Equal(p.Properties, props, cmpopts.EquateEmpty())
+}
+
+// NewACL returns the supplied ACL entries formatted as expected by the
+// x-ms-acl header.
+func NewACL(acl []v1beta1.ACLEntry) string {
+	entries := make([]string, len(acl))
+	for i, e := range acl {
+		entries[i] = strings.Join([]string{e.Type, e.ID, e.Permissions}, ":")
+		if e.Default {
+			entries[i] = aclEntryDefault + ":" + entries[i]
+		}
+	}
+	return strings.Join(entries, ",")
+}
+
+// ParseACL parses ACL entries formatted as returned by the x-ms-acl header.
+func ParseACL(s string) ([]v1beta1.ACLEntry, error) {
+	if s == "" {
+		return nil, nil
+	}
+	var acl []v1beta1.ACLEntry
+	for _, entry := range strings.Split(s, ",") {
+		e := v1beta1.ACLEntry{}
+		f := strings.Split(entry, ":")
+		if len(f) == 4 && f[0] == aclEntryDefault {
+			e.Default = true
+			f = f[1:]
+		}
+		if len(f) != 3 {
+			return nil, errors.Errorf("%s: %s", errParseACLEntry, entry)
+		}
+		e.Type, e.ID, e.Permissions = f[0], f[1], f[2]
+		acl = append(acl, e)
+	}
+	return acl, nil
+}
+
+// PathAccessControl is the access control of a Path received from Azure.
+type PathAccessControl struct {
+	Owner       string
+	Group       string
+	Permissions string
+	ACL         []v1beta1.ACLEntry
+}
+
+// GetPathAccessControl returns the access control of a Path from the headers
+// of a Path received from Azure.
+func GetPathAccessControl(h http.Header) (PathAccessControl, error) {
+	acl, err := ParseACL(h.Get(HeaderACL))
+	if err != nil {
+		return PathAccessControl{}, err
+	}
+	return PathAccessControl{
+		Owner:       h.Get(HeaderOwner),
+		Group:       h.Get(HeaderGroup),
+		Permissions: h.Get(HeaderPermissions),
+		ACL:         acl,
+	}, nil
+}
+
+// GeneratePathObservation produces a PathObservation from the headers and
+// access control of a Path received from Azure.
+func GeneratePathObservation(h http.Header, ac PathAccessControl) v1beta1.PathObservation {
+	return v1beta1.PathObservation{
+		ETag:             h.Get(HeaderETag),
+		LastModifiedTime: lastModified(h),
+		Permissions:      ac.Permissions,
+	}
+}
+
+// LateInitializePath fills the empty fields of the supplied PathParameters
+// with the supplied access control received from Azure.
+func LateInitializePath(p *v1beta1.PathParameters, ac PathAccessControl) {
+	p.Owner = azure.LateInitializeStringPtrFromVal(p.Owner, ac.Owner)
+	p.Group = azure.LateInitializeStringPtrFromVal(p.Group, ac.Group)
+	if p.ACL == nil {
+		p.ACL = ac.ACL
+	}
+}
+
+// IsPathUpToDate returns true if the supplied access control received from
+// Azure matches the supplied PathParameters. The order of ACL entries is not
+// considered. Azure computes a mask entry when an ACL has named entries, so
+// mask entries are only considered if the ACL specifies them.
+func IsPathUpToDate(p v1beta1.PathParameters, ac PathAccessControl) bool {
+	if p.Owner != nil && *p.Owner != ac.Owner {
+		return false
+	}
+	if p.Group != nil && *p.Group != ac.Group {
+		return false
+	}
+	if p.ACL == nil {
+		return true
+	}
+	masks := map[bool]bool{}
+	for _, e := range p.ACL {
+		if e.Type == v1beta1.ACLEntryTypeMask {
+			masks[e.Default] = true
+		}
+	}
+	observed := make([]v1beta1.ACLEntry, 0, len(ac.ACL))
+	for _, e := range ac.ACL {
+		if e.Type == v1beta1.ACLEntryTypeMask && !masks[e.Default] {
+			continue
+		}
+		observed = append(observed, e)
+	}
+	return cmp.Equal(p.ACL, observed, cmpopts.EquateEmpty(), cmpopts.SortSlices(func(a, b v1beta1.ACLEntry) bool {
+		return NewACL([]v1beta1.ACLEntry{a}) < NewACL([]v1beta1.ACLEntry{b})
+	}))
+}
+
+// ResponseHeader returns the headers of the supplied response received from
+// Azure.
+func ResponseHeader(r autorest.Response) http.Header {
+	if r.Response == nil || r.Header == nil {
+		return http.Header{}
+	}
+	return r.Header
+}
+
+func lastModified(h http.Header) *metav1.Time {
+	t, err := time.Parse(http.TimeFormat, h.Get(HeaderLastModified))
+	if err != nil {
+		return nil
+	}
+	return &metav1.Time{Time: t}
+}
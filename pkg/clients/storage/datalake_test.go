@@ -0,0 +1,149 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestFilesystemProperties(t *testing.T) {
+	props := map[string]string{"team": "data-platform", "cost-center": "42"}
+	encoded := "cost-center=NDI=,team=ZGF0YS1wbGF0Zm9ybQ=="
+
+	if diff := cmp.Diff(encoded, NewFilesystemProperties(props)); diff != "" {
+		t.Errorf("NewFilesystemProperties(...): -want, +got\n%s", diff)
+	}
+	got, err := ParseFilesystemProperties(encoded)
+	if err != nil {
+		t.Errorf("ParseFilesystemProperties(...): %s", err)
+	}
+	if diff := cmp.Diff(props, got); diff != "" {
+		t.Errorf("ParseFilesystemProperties(...): -want, +got\n%s", diff)
+	}
+}
+
+func TestParseACL(t *testing.T) {
+	type want struct {
+		acl []v1beta1.ACLEntry
+		err error
+	}
+
+	cases := map[string]struct {
+		acl string
+		want
+	}{
+		"Empty": {
+			acl:  "",
+			want: want{},
+		},
+		"AccessAndDefault": {
+			acl: "user::rwx,group::r-x,group:coolgroup:r-x,mask::r-x,other::---,default:group:coolgroup:r--",
+			want: want{
+				acl: []v1beta1.ACLEntry{
+					{Type: v1beta1.ACLEntryTypeUser, Permissions: "rwx"},
+					{Type: v1beta1.ACLEntryTypeGroup, Permissions: "r-x"},
+					{Type: v1beta1.ACLEntryTypeGroup, ID: "coolgroup", Permissions: "r-x"},
+					{Type: v1beta1.ACLEntryTypeMask, Permissions: "r-x"},
+					{Type: v1beta1.ACLEntryTypeOther, Permissions: "---"},
+					{Default: true, Type: v1beta1.ACLEntryTypeGroup, ID: "coolgroup", Permissions: "r--"},
+				},
+			},
+		},
+		"Malformed": {
+			acl: "user:rwx",
+			want: want{
+				err: errors.Errorf("%s: %s", errParseACLEntry, "user:rwx"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			acl, err := ParseACL(tc.acl)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseACL(...): -want error, +got error\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.acl, acl); diff != "" {
+				t.Errorf("ParseACL(...): -want, +got\n%s", diff)
+			}
+			if tc.want.err == nil {
+				if diff := cmp.Diff(tc.acl, NewACL(acl)); diff != "" {
+					t.Errorf("NewACL(...): -want, +got\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestIsPathUpToDate(t *testing.T) {
+	base := []v1beta1.ACLEntry{
+		{Type: v1beta1.ACLEntryTypeUser, Permissions: "rwx"},
+		{Type: v1beta1.ACLEntryTypeGroup, Permissions: "r-x"},
+		{Type: v1beta1.ACLEntryTypeOther, Permissions: "---"},
+	}
+	named := append([]v1beta1.ACLEntry{{Type: v1beta1.ACLEntryTypeGroup, ID: "coolgroup", Permissions: "r-x"}}, base...)
+	observed := append([]v1beta1.ACLEntry{{Type: v1beta1.ACLEntryTypeMask, Permissions: "r-x"}}, base...)
+	observed = append(observed, v1beta1.ACLEntry{Type: v1beta1.ACLEntryTypeGroup, ID: "coolgroup", Permissions: "r-x"})
+
+	cases := map[string]struct {
+		p    v1beta1.PathParameters
+		ac   PathAccessControl
+		want bool
+	}{
+		"Unmanaged": {
+			p:    v1beta1.PathParameters{},
+			ac:   PathAccessControl{Owner: "$superuser", ACL: base},
+			want: true,
+		},
+		"OwnerChanged": {
+			p:    v1beta1.PathParameters{Owner: azure.ToStringPtr("coolowner")},
+			ac:   PathAccessControl{Owner: "$superuser"},
+			want: false,
+		},
+		"ComputedMaskIgnored": {
+			p:    v1beta1.PathParameters{ACL: named},
+			ac:   PathAccessControl{ACL: observed},
+			want: true,
+		},
+		"SpecifiedMaskDiffers": {
+			p:    v1beta1.PathParameters{ACL: append([]v1beta1.ACLEntry{{Type: v1beta1.ACLEntryTypeMask, Permissions: "rwx"}}, named...)},
+			ac:   PathAccessControl{ACL: observed},
+			want: false,
+		},
+		"EntryMissing": {
+			p:    v1beta1.PathParameters{ACL: named},
+			ac:   PathAccessControl{ACL: base},
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsPathUpToDate(tc.p, tc.ac)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsPathUpToDate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/keyvault/key"
+)
+
+// IdentityTypeSystemAssigned is the type of a system-assigned identity.
+const IdentityTypeSystemAssigned = "SystemAssigned"
+
+// KeyVaultKeyID returns the Key Vault key with which the supplied
+// AccountParameters want their Account to be encrypted, if any.
+func KeyVaultKeyID(p v1beta1.AccountParameters) string {
+	if p.Encryption == nil {
+		return ""
+	}
+	return azure.ToString(p.Encryption.KeyVaultKeyID)
+}
+
+// WithCustomerManagedKey returns a copy of the supplied AccountParameters,
+// which must have a KeyVaultKeyID, that has a system-assigned identity and is
+// encrypted with the supplied version of its Key Vault key. The copy uses
+// Microsoft-managed keys if the supplied key is nil, which is the case until
+// the identity of the Account has been granted access to the key.
+func WithCustomerManagedKey(p v1beta1.AccountParameters, k *key.VersionedKey) v1beta1.AccountParameters {
+	out := p.DeepCopy()
+	if out.Identity == nil {
+		out.Identity = &v1beta1.Identity{Type: IdentityTypeSystemAssigned}
+	}
+	if k == nil {
+		out.Encryption.KeySource = azure.ToStringPtr(string(storage.KeySourceMicrosoftStorage))
+		out.Encryption.KeyVaultProperties = nil
+		return *out
+	}
+	out.Encryption.KeySource = azure.ToStringPtr(string(storage.KeySourceMicrosoftKeyvault))
+	out.Encryption.KeyVaultProperties = &v1beta1.KeyVaultProperties{
+		KeyName:     azure.ToStringPtr(k.Name),
+		KeyVersion:  azure.ToStringPtr(k.Version),
+		KeyVaultURI: azure.ToStringPtr(k.VaultBaseURL),
+	}
+	return *out
+}
+
+func generateEncryptionObservation(e *storage.Encryption) *v1beta1.EncryptionObservation {
+	if e == nil {
+		return nil
+	}
+	o := &v1beta1.EncryptionObservation{KeySource: string(e.KeySource)}
+	if kv := e.KeyVaultProperties; kv != nil {
+		o.KeyVersion = azure.ToString(kv.KeyVersion)
+		o.LastKeyRotationTime = toMetaTime(kv.LastKeyRotationTimestamp)
+	}
+	return o
+}
@@ -19,73 +19,63 @@ package fake
 import (
 	"context"
 
-	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
-
-	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
+	"github.com/Azure/go-autorest/autorest"
 )
 
-// MockAccountOperations mock implementation of AccountOperations
-type MockAccountOperations struct {
-	MockCreate                 func(context.Context, storage.AccountCreateParameters) (*storage.Account, error)
-	MockUpdate                 func(context.Context, storage.AccountUpdateParameters) (*storage.Account, error)
-	MockGet                    func(ctx context.Context) (*storage.Account, error)
-	MockDelete                 func(ctx context.Context) error
-	MockIsAccountNameAvailable func(context.Context, string) error
-	MockListKeys               func(context.Context) ([]storage.AccountKey, error)
+var _ storageapi.AccountsClientAPI = &MockAccountsClient{}
+
+// MockAccountsClient is a fake implementation of storage.AccountsClient.
+type MockAccountsClient struct {
+	storageapi.AccountsClientAPI
+
+	MockCreate         func(ctx context.Context, resourceGroupName string, accountName string, parameters storage.AccountCreateParameters) (storage.AccountsCreateFuture, error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, accountName string) (autorest.Response, error)
+	MockGetProperties  func(ctx context.Context, resourceGroupName string, accountName string, expand storage.AccountExpand) (storage.Account, error)
+	MockListAccountSAS func(ctx context.Context, resourceGroupName string, accountName string, parameters storage.AccountSasParameters) (storage.ListAccountSasResponse, error)
+	MockListKeys       func(ctx context.Context, resourceGroupName string, accountName string, expand storage.ListKeyExpand) (storage.AccountListKeysResult, error)
+	MockListServiceSAS func(ctx context.Context, resourceGroupName string, accountName string, parameters storage.ServiceSasParameters) (storage.ListServiceSasResponse, error)
+	MockRegenerateKey  func(ctx context.Context, resourceGroupName string, accountName string, regenerateKey storage.AccountRegenerateKeyParameters) (storage.AccountListKeysResult, error)
+	MockUpdate         func(ctx context.Context, resourceGroupName string, accountName string, parameters storage.AccountUpdateParameters) (storage.Account, error)
+}
+
+// Create calls the MockAccountsClient's MockCreate method.
+func (c *MockAccountsClient) Create(ctx context.Context, resourceGroupName string, accountName string, parameters storage.AccountCreateParameters) (storage.AccountsCreateFuture, error) {
+	return c.MockCreate(ctx, resourceGroupName, accountName, parameters)
 }
 
-var _ azurestorage.AccountOperations = &MockAccountOperations{}
-
-// NewMockAccountOperations returns new mock instance with default mocks
-func NewMockAccountOperations() *MockAccountOperations {
-	return &MockAccountOperations{
-		MockCreate: func(i context.Context, parameters storage.AccountCreateParameters) (account *storage.Account, e error) {
-			return nil, nil
-		},
-		MockUpdate: func(i context.Context, parameters storage.AccountUpdateParameters) (account *storage.Account, e error) {
-			return nil, nil
-		},
-		MockGet: func(ctx context.Context) (account *storage.Account, e error) {
-			return nil, nil
-		},
-		MockDelete: func(ctx context.Context) error {
-			return nil
-		},
-		MockIsAccountNameAvailable: func(i context.Context, s string) error {
-			return nil
-		},
-		MockListKeys: func(i context.Context) ([]storage.AccountKey, error) {
-			return nil, nil
-		},
-	}
+// Delete calls the MockAccountsClient's MockDelete method.
+func (c *MockAccountsClient) Delete(ctx context.Context, resourceGroupName string, accountName string) (autorest.Response, error) {
+	return c.MockDelete(ctx, resourceGroupName, accountName)
 }
 
-// Create mock create
-func (m *MockAccountOperations) Create(ctx context.Context, params storage.AccountCreateParameters) (*storage.Account, error) {
-	return m.MockCreate(ctx, params)
+// GetProperties calls the MockAccountsClient's MockGetProperties method.
+func (c *MockAccountsClient) GetProperties(ctx context.Context, resourceGroupName string, accountName string, expand storage.AccountExpand) (storage.Account, error) {
+	return c.MockGetProperties(ctx, resourceGroupName, accountName, expand)
 }
 
-// Update mock update
-func (m *MockAccountOperations) Update(ctx context.Context, params storage.AccountUpdateParameters) (*storage.Account, error) {
-	return m.MockUpdate(ctx, params)
+// ListAccountSAS calls the MockAccountsClient's MockListAccountSAS method.
+func (c *MockAccountsClient) ListAccountSAS(ctx context.Context, resourceGroupName string, accountName string, parameters storage.AccountSasParameters) (storage.ListAccountSasResponse, error) {
+	return c.MockListAccountSAS(ctx, resourceGroupName, accountName, parameters)
 }
 
-// Get mock get
-func (m *MockAccountOperations) Get(ctx context.Context) (*storage.Account, error) {
-	return m.MockGet(ctx)
+// ListKeys calls the MockAccountsClient's MockListKeys method.
+func (c *MockAccountsClient) ListKeys(ctx context.Context, resourceGroupName string, accountName string, expand storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+	return c.MockListKeys(ctx, resourceGroupName, accountName, expand)
 }
 
-// Delete mock delete
-func (m *MockAccountOperations) Delete(ctx context.Context) error {
-	return m.MockDelete(ctx)
+// ListServiceSAS calls the MockAccountsClient's MockListServiceSAS method.
+func (c *MockAccountsClient) ListServiceSAS(ctx context.Context, resourceGroupName string, accountName string, parameters storage.ServiceSasParameters) (storage.ListServiceSasResponse, error) {
+	return c.MockListServiceSAS(ctx, resourceGroupName, accountName, parameters)
 }
 
-// IsAccountNameAvailable mock check
-func (m *MockAccountOperations) IsAccountNameAvailable(ctx context.Context, name string) error {
-	return m.MockIsAccountNameAvailable(ctx, name)
+// RegenerateKey calls the MockAccountsClient's MockRegenerateKey method.
+func (c *MockAccountsClient) RegenerateKey(ctx context.Context, resourceGroupName string, accountName string, regenerateKey storage.AccountRegenerateKeyParameters) (storage.AccountListKeysResult, error) {
+	return c.MockRegenerateKey(ctx, resourceGroupName, accountName, regenerateKey)
 }
 
-// ListKeys mock list keys
-func (m *MockAccountOperations) ListKeys(ctx context.Context) ([]storage.AccountKey, error) {
-	return m.MockListKeys(ctx)
+// Update calls the MockAccountsClient's MockUpdate method.
+func (c *MockAccountsClient) Update(ctx context.Context, resourceGroupName string, accountName string, parameters storage.AccountUpdateParameters) (storage.Account, error) {
+	return c.MockUpdate(ctx, resourceGroupName, accountName, parameters)
 }
@@ -0,0 +1,44 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+)
+
+var _ azurestorage.BlobServiceClientAPI = &MockBlobServicesClient{}
+
+// MockBlobServicesClient is a fake implementation of
+// azurestorage.BlobServiceClient.
+type MockBlobServicesClient struct {
+	MockGetServiceProperties func(ctx context.Context, resourceGroupName string, accountName string) (azurestorage.BlobServiceProperties, error)
+	MockSetServiceProperties func(ctx context.Context, resourceGroupName string, accountName string, parameters azurestorage.BlobServiceProperties) (azurestorage.BlobServiceProperties, error)
+}
+
+// GetServiceProperties calls the MockBlobServicesClient's
+// MockGetServiceProperties method.
+func (c *MockBlobServicesClient) GetServiceProperties(ctx context.Context, resourceGroupName string, accountName string) (azurestorage.BlobServiceProperties, error) {
+	return c.MockGetServiceProperties(ctx, resourceGroupName, accountName)
+}
+
+// SetServiceProperties calls the MockBlobServicesClient's
+// MockSetServiceProperties method.
+func (c *MockBlobServicesClient) SetServiceProperties(ctx context.Context, resourceGroupName string, accountName string, parameters azurestorage.BlobServiceProperties) (azurestorage.BlobServiceProperties, error) {
+	return c.MockSetServiceProperties(ctx, resourceGroupName, accountName, parameters)
+}
@@ -19,6 +19,8 @@ package fake
 import (
 	"context"
 
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
 	"github.com/Azure/azure-storage-blob-go/azblob"
 
 	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
@@ -28,7 +30,7 @@ import (
 type MockContainerOperations struct {
 	MockCreate func(context.Context, azblob.PublicAccessType, azblob.Metadata) error
 	MockUpdate func(context.Context, azblob.PublicAccessType, azblob.Metadata) error
-	MockGet    func(ctx context.Context) (*azblob.PublicAccessType, azblob.Metadata, error)
+	MockGet    func(ctx context.Context) (*azurestorage.ContainerProperties, error)
 	MockDelete func(ctx context.Context) error
 }
 
@@ -43,8 +45,8 @@ func NewMockContainerOperations() *MockContainerOperations {
 		MockUpdate: func(ctx context.Context, pat azblob.PublicAccessType, meta azblob.Metadata) error {
 			return nil
 		},
-		MockGet: func(ctx context.Context) (*azblob.PublicAccessType, azblob.Metadata, error) {
-			return nil, nil, nil
+		MockGet: func(ctx context.Context) (*azurestorage.ContainerProperties, error) {
+			return &azurestorage.ContainerProperties{}, nil
 		},
 		MockDelete: func(ctx context.Context) error {
 			return nil
@@ -63,7 +65,7 @@ func (m *MockContainerOperations) Update(ctx context.Context, pat azblob.PublicA
 }
 
 // Get mock get function
-func (m *MockContainerOperations) Get(ctx context.Context) (*azblob.PublicAccessType, azblob.Metadata, error) {
+func (m *MockContainerOperations) Get(ctx context.Context) (*azurestorage.ContainerProperties, error) {
 	return m.MockGet(ctx)
 }
 
@@ -72,7 +74,51 @@ func (m *MockContainerOperations) Delete(ctx context.Context) error {
 	return m.MockDelete(ctx)
 }
 
-// PublicAccessTypePtr returns pointer of the PublicAccessType value
-func PublicAccessTypePtr(pab azblob.PublicAccessType) *azblob.PublicAccessType {
-	return &pab
+var _ storageapi.BlobContainersClientAPI = &MockBlobContainersClient{}
+
+// MockBlobContainersClient is a fake implementation of
+// storage.BlobContainersClient.
+type MockBlobContainersClient struct {
+	storageapi.BlobContainersClientAPI
+
+	MockGet                              func(ctx context.Context, resourceGroupName string, accountName string, containerName string) (storage.BlobContainer, error)
+	MockCreateOrUpdateImmutabilityPolicy func(ctx context.Context, resourceGroupName string, accountName string, containerName string, parameters *storage.ImmutabilityPolicy, ifMatch string) (storage.ImmutabilityPolicy, error)
+	MockLockImmutabilityPolicy           func(ctx context.Context, resourceGroupName string, accountName string, containerName string, ifMatch string) (storage.ImmutabilityPolicy, error)
+	MockExtendImmutabilityPolicy         func(ctx context.Context, resourceGroupName string, accountName string, containerName string, ifMatch string, parameters *storage.ImmutabilityPolicy) (storage.ImmutabilityPolicy, error)
+	MockSetLegalHold                     func(ctx context.Context, resourceGroupName string, accountName string, containerName string, legalHold storage.LegalHold) (storage.LegalHold, error)
+	MockClearLegalHold                   func(ctx context.Context, resourceGroupName string, accountName string, containerName string, legalHold storage.LegalHold) (storage.LegalHold, error)
+}
+
+// Get calls the MockBlobContainersClient's MockGet method.
+func (c *MockBlobContainersClient) Get(ctx context.Context, resourceGroupName string, accountName string, containerName string) (storage.BlobContainer, error) {
+	return c.MockGet(ctx, resourceGroupName, accountName, containerName)
+}
+
+// CreateOrUpdateImmutabilityPolicy calls the MockBlobContainersClient's
+// MockCreateOrUpdateImmutabilityPolicy method.
+func (c *MockBlobContainersClient) CreateOrUpdateImmutabilityPolicy(ctx context.Context, resourceGroupName string, accountName string, containerName string, parameters *storage.ImmutabilityPolicy, ifMatch string) (storage.ImmutabilityPolicy, error) {
+	return c.MockCreateOrUpdateImmutabilityPolicy(ctx, resourceGroupName, accountName, containerName, parameters, ifMatch)
+}
+
+// LockImmutabilityPolicy calls the MockBlobContainersClient's
+// MockLockImmutabilityPolicy method.
+func (c *MockBlobContainersClient) LockImmutabilityPolicy(ctx context.Context, resourceGroupName string, accountName string, containerName string, ifMatch string) (storage.ImmutabilityPolicy, error) {
+	return c.MockLockImmutabilityPolicy(ctx, resourceGroupName, accountName, containerName, ifMatch)
+}
+
+// ExtendImmutabilityPolicy calls the MockBlobContainersClient's
+// MockExtendImmutabilityPolicy method.
+func (c *MockBlobContainersClient) ExtendImmutabilityPolicy(ctx context.Context, resourceGroupName string, accountName string, containerName string, ifMatch string, parameters *storage.ImmutabilityPolicy) (storage.ImmutabilityPolicy, error) {
+	return c.MockExtendImmutabilityPolicy(ctx, resourceGroupName, accountName, containerName, ifMatch, parameters)
+}
+
+// SetLegalHold calls the MockBlobContainersClient's MockSetLegalHold method.
+func (c *MockBlobContainersClient) SetLegalHold(ctx context.Context, resourceGroupName string, accountName string, containerName string, legalHold storage.LegalHold) (storage.LegalHold, error) {
+	return c.MockSetLegalHold(ctx, resourceGroupName, accountName, containerName, legalHold)
+}
+
+// ClearLegalHold calls the MockBlobContainersClient's MockClearLegalHold
+// method.
+func (c *MockBlobContainersClient) ClearLegalHold(ctx context.Context, resourceGroupName string, accountName string, containerName string, legalHold storage.LegalHold) (storage.LegalHold, error) {
+	return c.MockClearLegalHold(ctx, resourceGroupName, accountName, containerName, legalHold)
 }
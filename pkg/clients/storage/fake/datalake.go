@@ -0,0 +1,92 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+	"io"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake"
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake/storagedatalakeapi"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+var _ storagedatalakeapi.FilesystemClientAPI = &MockFilesystemClient{}
+
+// MockFilesystemClient is a fake implementation of
+// storagedatalake.FilesystemClient.
+type MockFilesystemClient struct {
+	storagedatalakeapi.FilesystemClientAPI
+
+	MockCreate        func(ctx context.Context, filesystem string, xMsProperties string) (autorest.Response, error)
+	MockDelete        func(ctx context.Context, filesystem string) (autorest.Response, error)
+	MockGetProperties func(ctx context.Context, filesystem string) (autorest.Response, error)
+	MockSetProperties func(ctx context.Context, filesystem string, xMsProperties string) (autorest.Response, error)
+}
+
+// Create calls the MockFilesystemClient's MockCreate method.
+func (c *MockFilesystemClient) Create(ctx context.Context, filesystem string, xMsProperties string, _ string, _ *int32, _ string) (autorest.Response, error) {
+	return c.MockCreate(ctx, filesystem, xMsProperties)
+}
+
+// Delete calls the MockFilesystemClient's MockDelete method.
+func (c *MockFilesystemClient) Delete(ctx context.Context, filesystem string, _ string, _ string, _ string, _ *int32, _ string) (autorest.Response, error) {
+	return c.MockDelete(ctx, filesystem)
+}
+
+// GetProperties calls the MockFilesystemClient's MockGetProperties method.
+func (c *MockFilesystemClient) GetProperties(ctx context.Context, filesystem string, _ string, _ *int32, _ string) (autorest.Response, error) {
+	return c.MockGetProperties(ctx, filesystem)
+}
+
+// SetProperties calls the MockFilesystemClient's MockSetProperties method.
+func (c *MockFilesystemClient) SetProperties(ctx context.Context, filesystem string, xMsProperties string, _ string, _ string, _ string, _ *int32, _ string) (autorest.Response, error) {
+	return c.MockSetProperties(ctx, filesystem, xMsProperties)
+}
+
+var _ storagedatalakeapi.PathClientAPI = &MockPathClient{}
+
+// MockPathClient is a fake implementation of storagedatalake.PathClient.
+type MockPathClient struct {
+	storagedatalakeapi.PathClientAPI
+
+	MockCreate           func(ctx context.Context, filesystem string, path string, resource storagedatalake.PathResourceType) (autorest.Response, error)
+	MockDelete           func(ctx context.Context, filesystem string, path string, recursive *bool) (autorest.Response, error)
+	MockGetProperties    func(ctx context.Context, filesystem string, path string, action storagedatalake.PathGetPropertiesAction) (autorest.Response, error)
+	MockSetAccessControl func(ctx context.Context, filesystem string, path string, owner string, group string, acl string) (autorest.Response, error)
+}
+
+// Create calls the MockPathClient's MockCreate method.
+func (c *MockPathClient) Create(ctx context.Context, filesystem string, path string, resource storagedatalake.PathResourceType, _ string, _ storagedatalake.PathRenameMode, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ *int32, _ string) (autorest.Response, error) {
+	return c.MockCreate(ctx, filesystem, path, resource)
+}
+
+// Delete calls the MockPathClient's MockDelete method.
+func (c *MockPathClient) Delete(ctx context.Context, filesystem string, path string, recursive *bool, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ *int32, _ string) (autorest.Response, error) {
+	return c.MockDelete(ctx, filesystem, path, recursive)
+}
+
+// GetProperties calls the MockPathClient's MockGetProperties method.
+func (c *MockPathClient) GetProperties(ctx context.Context, filesystem string, path string, action storagedatalake.PathGetPropertiesAction, _ *bool, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ *int32, _ string) (autorest.Response, error) {
+	return c.MockGetProperties(ctx, filesystem, path, action)
+}
+
+// Update calls the MockPathClient's MockSetAccessControl method. Only the
+// setAccessControl action is supported.
+func (c *MockPathClient) Update(ctx context.Context, action storagedatalake.PathUpdateAction, filesystem string, path string, _ *int64, _ *bool, _ *bool, _ *int64, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, _ string, owner string, group string, _ string, acl string, _ string, _ string, _ string, _ string, _ io.ReadCloser, _ string, _ *int32, _ string) (autorest.Response, error) {
+	return c.MockSetAccessControl(ctx, filesystem, path, owner, group, acl)
+}
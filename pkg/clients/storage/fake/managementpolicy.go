@@ -0,0 +1,51 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+)
+
+var _ azurestorage.ManagementPolicyClientAPI = &MockManagementPoliciesClient{}
+
+// MockManagementPoliciesClient is a fake implementation of
+// azurestorage.ManagementPolicyClient.
+type MockManagementPoliciesClient struct {
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, accountName string, properties azurestorage.ManagementPolicy) (azurestorage.ManagementPolicy, error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, accountName string) (autorest.Response, error)
+	MockGet            func(ctx context.Context, resourceGroupName string, accountName string) (azurestorage.ManagementPolicy, error)
+}
+
+// CreateOrUpdate calls the MockManagementPoliciesClient's MockCreateOrUpdate
+// method.
+func (c *MockManagementPoliciesClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, accountName string, properties azurestorage.ManagementPolicy) (azurestorage.ManagementPolicy, error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, accountName, properties)
+}
+
+// Delete calls the MockManagementPoliciesClient's MockDelete method.
+func (c *MockManagementPoliciesClient) Delete(ctx context.Context, resourceGroupName string, accountName string) (autorest.Response, error) {
+	return c.MockDelete(ctx, resourceGroupName, accountName)
+}
+
+// Get calls the MockManagementPoliciesClient's MockGet method.
+func (c *MockManagementPoliciesClient) Get(ctx context.Context, resourceGroupName string, accountName string) (azurestorage.ManagementPolicy, error) {
+	return c.MockGet(ctx, resourceGroupName, accountName)
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
+	"github.com/Azure/go-autorest/autorest"
+)
+
+var _ storageapi.ObjectReplicationPoliciesClientAPI = &MockObjectReplicationPoliciesClient{}
+
+// MockObjectReplicationPoliciesClient is a fake implementation of
+// storage.ObjectReplicationPoliciesClient.
+type MockObjectReplicationPoliciesClient struct {
+	storageapi.ObjectReplicationPoliciesClientAPI
+
+	MockCreateOrUpdate func(ctx context.Context, resourceGroupName string, accountName string, objectReplicationPolicyID string, properties storage.ObjectReplicationPolicy) (storage.ObjectReplicationPolicy, error)
+	MockDelete         func(ctx context.Context, resourceGroupName string, accountName string, objectReplicationPolicyID string) (autorest.Response, error)
+	MockGet            func(ctx context.Context, resourceGroupName string, accountName string, objectReplicationPolicyID string) (storage.ObjectReplicationPolicy, error)
+}
+
+// CreateOrUpdate calls the MockObjectReplicationPoliciesClient's
+// MockCreateOrUpdate method.
+func (c *MockObjectReplicationPoliciesClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, accountName string, objectReplicationPolicyID string, properties storage.ObjectReplicationPolicy) (storage.ObjectReplicationPolicy, error) {
+	return c.MockCreateOrUpdate(ctx, resourceGroupName, accountName, objectReplicationPolicyID, properties)
+}
+
+// Delete calls the MockObjectReplicationPoliciesClient's MockDelete method.
+func (c *MockObjectReplicationPoliciesClient) Delete(ctx context.Context, resourceGroupName string, accountName string, objectReplicationPolicyID string) (autorest.Response, error) {
+	return c.MockDelete(ctx, resourceGroupName, accountName, objectReplicationPolicyID)
+}
+
+// Get calls the MockObjectReplicationPoliciesClient's MockGet method.
+func (c *MockObjectReplicationPoliciesClient) Get(ctx context.Context, resourceGroupName string, accountName string, objectReplicationPolicyID string) (storage.ObjectReplicationPolicy, error) {
+	return c.MockGet(ctx, resourceGroupName, accountName, objectReplicationPolicyID)
+}
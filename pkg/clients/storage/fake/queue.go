@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+)
+
+var _ azurestorage.QueueClientAPI = &MockQueueClient{}
+
+// MockQueueClient is a fake implementation of azurestorage.QueueClient.
+type MockQueueClient struct {
+	MockCreate func(ctx context.Context, resourceGroupName string, accountName string, queueName string, queue azurestorage.Queue) (azurestorage.Queue, error)
+	MockGet    func(ctx context.Context, resourceGroupName string, accountName string, queueName string) (azurestorage.Queue, error)
+	MockUpdate func(ctx context.Context, resourceGroupName string, accountName string, queueName string, queue azurestorage.Queue) (azurestorage.Queue, error)
+	MockDelete func(ctx context.Context, resourceGroupName string, accountName string, queueName string) (autorest.Response, error)
+}
+
+// Create calls the MockQueueClient's MockCreate method.
+func (c *MockQueueClient) Create(ctx context.Context, resourceGroupName string, accountName string, queueName string, queue azurestorage.Queue) (azurestorage.Queue, error) {
+	return c.MockCreate(ctx, resourceGroupName, accountName, queueName, queue)
+}
+
+// Get calls the MockQueueClient's MockGet method.
+func (c *MockQueueClient) Get(ctx context.Context, resourceGroupName string, accountName string, queueName string) (azurestorage.Queue, error) {
+	return c.MockGet(ctx, resourceGroupName, accountName, queueName)
+}
+
+// Update calls the MockQueueClient's MockUpdate method.
+func (c *MockQueueClient) Update(ctx context.Context, resourceGroupName string, accountName string, queueName string, queue azurestorage.Queue) (azurestorage.Queue, error) {
+	return c.MockUpdate(ctx, resourceGroupName, accountName, queueName, queue)
+}
+
+// Delete calls the MockQueueClient's MockDelete method.
+func (c *MockQueueClient) Delete(ctx context.Context, resourceGroupName string, accountName string, queueName string) (autorest.Response, error) {
+	return c.MockDelete(ctx, resourceGroupName, accountName, queueName)
+}
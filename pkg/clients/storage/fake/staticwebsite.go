@@ -0,0 +1,46 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+)
+
+var _ azurestorage.ServicePropertiesAPI = &MockServicePropertiesClient{}
+
+// MockServicePropertiesClient is a mock implementation of
+// ServicePropertiesAPI.
+type MockServicePropertiesClient struct {
+	MockGetProperties func(ctx context.Context) (*azblob.StorageServiceProperties, error)
+	MockSetProperties func(ctx context.Context, properties azblob.StorageServiceProperties) (*azblob.ServiceSetPropertiesResponse, error)
+}
+
+// GetProperties calls the MockServicePropertiesClient's MockGetProperties
+// method.
+func (c *MockServicePropertiesClient) GetProperties(ctx context.Context) (*azblob.StorageServiceProperties, error) {
+	return c.MockGetProperties(ctx)
+}
+
+// SetProperties calls the MockServicePropertiesClient's MockSetProperties
+// method.
+func (c *MockServicePropertiesClient) SetProperties(ctx context.Context, properties azblob.StorageServiceProperties) (*azblob.ServiceSetPropertiesResponse, error) {
+	return c.MockSetProperties(ctx, properties)
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fake
+
+import (
+	"context"
+
+	"github.com/Azure/go-autorest/autorest"
+
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+)
+
+var _ azurestorage.TableClientAPI = &MockTableClient{}
+
+// MockTableClient is a fake implementation of azurestorage.TableClient.
+type MockTableClient struct {
+	MockCreate func(ctx context.Context, resourceGroupName string, accountName string, tableName string, table azurestorage.Table) (azurestorage.Table, error)
+	MockGet    func(ctx context.Context, resourceGroupName string, accountName string, tableName string) (azurestorage.Table, error)
+	MockUpdate func(ctx context.Context, resourceGroupName string, accountName string, tableName string, table azurestorage.Table) (azurestorage.Table, error)
+	MockDelete func(ctx context.Context, resourceGroupName string, accountName string, tableName string) (autorest.Response, error)
+}
+
+// Create calls the MockTableClient's MockCreate method.
+func (c *MockTableClient) Create(ctx context.Context, resourceGroupName string, accountName string, tableName string, table azurestorage.Table) (azurestorage.Table, error) {
+	return c.MockCreate(ctx, resourceGroupName, accountName, tableName, table)
+}
+
+// Get calls the MockTableClient's MockGet method.
+func (c *MockTableClient) Get(ctx context.Context, resourceGroupName string, accountName string, tableName string) (azurestorage.Table, error) {
+	return c.MockGet(ctx, resourceGroupName, accountName, tableName)
+}
+
+// Update calls the MockTableClient's MockUpdate method.
+func (c *MockTableClient) Update(ctx context.Context, resourceGroupName string, accountName string, tableName string, table azurestorage.Table) (azurestorage.Table, error) {
+	return c.MockUpdate(ctx, resourceGroupName, accountName, tableName, table)
+}
+
+// Delete calls the MockTableClient's MockDelete method.
+func (c *MockTableClient) Delete(ctx context.Context, resourceGroupName string, accountName string, tableName string) (autorest.Response, error) {
+	return c.MockDelete(ctx, resourceGroupName, accountName, tableName)
+}
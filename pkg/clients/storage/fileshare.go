@@ -0,0 +1,102 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Connection secret keys of a FileShare. These are the keys the Azure Files
+// CSI driver expects to find in the secret of a persistent volume.
+const (
+	ConnectionSecretKeyAccountName = "azurestorageaccountname"
+	ConnectionSecretKeyAccountKey  = "azurestorageaccountkey"
+)
+
+// NewFileShare returns a file share suitable for use with the Azure API.
+func NewFileShare(p v1beta1.FileShareParameters) storage.FileShare {
+	return storage.FileShare{
+		FileShareProperties: &storage.FileShareProperties{
+			ShareQuota:       azure.ToInt32(p.ShareQuota),
+			AccessTier:       storage.ShareAccessTier(azure.ToString(p.AccessTier)),
+			EnabledProtocols: storage.EnabledProtocols(azure.ToString(p.EnabledProtocols)),
+			RootSquash:       storage.RootSquashType(azure.ToString(p.RootSquash)),
+		},
+	}
+}
+
+// NewFileShareUpdate returns a file share update suitable for use with the
+// Azure API. The enabled protocols of a share can only be set when it is
+// created, so they are omitted.
+func NewFileShareUpdate(p v1beta1.FileShareParameters) storage.FileShare {
+	fs := NewFileShare(p)
+	fs.EnabledProtocols = ""
+	return fs
+}
+
+// GenerateFileShareObservation produces a FileShareObservation from the file
+// share received from Azure.
+func GenerateFileShareObservation(az storage.FileShare) v1beta1.FileShareObservation {
+	o := v1beta1.FileShareObservation{ID: azure.ToString(az.ID)}
+	if az.FileShareProperties != nil {
+		o.LastModifiedTime = toMetaTime(az.LastModifiedTime)
+		o.AccessTierStatus = azure.ToString(az.AccessTierStatus)
+		o.ShareUsageBytes = azure.ToInt(az.ShareUsageBytes)
+	}
+	return o
+}
+
+// LateInitializeFileShare fills the empty fields of the supplied
+// FileShareParameters with the file share received from Azure.
+func LateInitializeFileShare(p *v1beta1.FileShareParameters, az storage.FileShare) {
+	if az.FileShareProperties == nil {
+		return
+	}
+	p.ShareQuota = azure.LateInitializeIntPtrFromInt32Ptr(p.ShareQuota, az.ShareQuota)
+	p.AccessTier = azure.LateInitializeStringPtrFromPtr(p.AccessTier, azure.ToStringPtr(string(az.AccessTier)))
+	p.EnabledProtocols = azure.LateInitializeStringPtrFromPtr(p.EnabledProtocols, azure.ToStringPtr(string(az.EnabledProtocols)))
+	p.RootSquash = azure.LateInitializeStringPtrFromPtr(p.RootSquash, azure.ToStringPtr(string(az.RootSquash)))
+}
+
+// IsFileShareUpToDate returns true if the file share received from Azure
+// matches the supplied FileShareParameters. Fields that are not specified are
+// considered up to date.
+func IsFileShareUpToDate(p v1beta1.FileShareParameters, az storage.FileShare) bool {
+	if az.FileShareProperties == nil {
+		return false
+	}
+	switch {
+	case p.ShareQuota != nil && *p.ShareQuota != azure.ToInt(az.ShareQuota):
+		return false
+	case p.AccessTier != nil && *p.AccessTier != string(az.AccessTier):
+		return false
+	case p.RootSquash != nil && *p.RootSquash != string(az.RootSquash):
+		return false
+	}
+	return true
+}
+
+// FileShareURL returns the URL of the named file share, given the file
+// endpoint of its storage Account.
+func FileShareURL(fileEndpoint, shareName string) string {
+	return strings.TrimSuffix(fileEndpoint, "/") + "/" + shareName
+}
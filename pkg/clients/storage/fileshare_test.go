@@ -0,0 +1,131 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestLateInitializeFileShare(t *testing.T) {
+	cases := map[string]struct {
+		p    v1beta1.FileShareParameters
+		az   storage.FileShare
+		want v1beta1.FileShareParameters
+	}{
+		"NoProperties": {
+			p:    v1beta1.FileShareParameters{ShareQuota: intPtr(10)},
+			az:   storage.FileShare{},
+			want: v1beta1.FileShareParameters{ShareQuota: intPtr(10)},
+		},
+		"UnsetFields": {
+			p: v1beta1.FileShareParameters{ShareQuota: intPtr(10)},
+			az: storage.FileShare{FileShareProperties: &storage.FileShareProperties{
+				ShareQuota:       azure.ToInt32Ptr(5120),
+				AccessTier:       storage.ShareAccessTierTransactionOptimized,
+				EnabledProtocols: storage.SMB,
+			}},
+			want: v1beta1.FileShareParameters{
+				ShareQuota:       intPtr(10),
+				AccessTier:       azure.ToStringPtr("TransactionOptimized"),
+				EnabledProtocols: azure.ToStringPtr("SMB"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			LateInitializeFileShare(&tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, tc.p); diff != "" {
+				t.Errorf("LateInitializeFileShare(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestIsFileShareUpToDate(t *testing.T) {
+	az := storage.FileShare{FileShareProperties: &storage.FileShareProperties{
+		ShareQuota:       azure.ToInt32Ptr(100),
+		AccessTier:       storage.ShareAccessTierPremium,
+		EnabledProtocols: storage.NFS,
+		RootSquash:       storage.RootSquash,
+	}}
+
+	cases := map[string]struct {
+		p    v1beta1.FileShareParameters
+		az   storage.FileShare
+		want bool
+	}{
+		"UpToDate": {
+			p: v1beta1.FileShareParameters{
+				ShareQuota: intPtr(100),
+				AccessTier: azure.ToStringPtr("Premium"),
+				RootSquash: azure.ToStringPtr("RootSquash"),
+			},
+			az:   az,
+			want: true,
+		},
+		"Unspecified": {
+			p:    v1beta1.FileShareParameters{},
+			az:   az,
+			want: true,
+		},
+		"QuotaChanged": {
+			p:    v1beta1.FileShareParameters{ShareQuota: intPtr(200)},
+			az:   az,
+			want: false,
+		},
+		"RootSquashChanged": {
+			p:    v1beta1.FileShareParameters{RootSquash: azure.ToStringPtr("AllSquash")},
+			az:   az,
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsFileShareUpToDate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsFileShareUpToDate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestFileShareURL(t *testing.T) {
+	cases := map[string]struct {
+		endpoint string
+		want     string
+	}{
+		"TrailingSlash":   {endpoint: "https://coolaccount.file.core.windows.net/", want: "https://coolaccount.file.core.windows.net/coolshare"},
+		"NoTrailingSlash": {endpoint: "https://coolaccount.file.core.windows.net", want: "https://coolaccount.file.core.windows.net/coolshare"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := FileShareURL(tc.endpoint, "coolshare")
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("FileShareURL(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
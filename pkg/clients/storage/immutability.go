@@ -0,0 +1,178 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const (
+	errUnlockPolicy       = "a locked immutability policy cannot be unlocked"
+	errChangeAppendWrites = "allowProtectedAppendWrites cannot be changed once an immutability policy is locked"
+	errFmtShortenPolicy   = "the immutability period of a locked immutability policy cannot be shortened from %d to %d days"
+)
+
+// TypeImmutabilityPolicy indicates whether the desired immutability policy of
+// a Container can be applied.
+const TypeImmutabilityPolicy xpv1.ConditionType = "ImmutabilityPolicy"
+
+// Reasons a Container's immutability policy can or cannot be applied.
+const (
+	ReasonImmutabilityPolicyApplied       xpv1.ConditionReason = "ImmutabilityPolicyApplied"
+	ReasonImmutabilityPolicyChangeIllegal xpv1.ConditionReason = "IllegalImmutabilityPolicyChange"
+)
+
+// ImmutabilityPolicyApplied returns a condition indicating that the desired
+// immutability policy of a Container can be applied.
+func ImmutabilityPolicyApplied() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImmutabilityPolicy,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImmutabilityPolicyApplied,
+	}
+}
+
+// ImmutabilityPolicyChangeIllegal returns a condition indicating that the
+// desired immutability policy of a Container cannot be applied because its
+// current policy is locked.
+func ImmutabilityPolicyChangeIllegal(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeImmutabilityPolicy,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonImmutabilityPolicyChangeIllegal,
+		Message:            err.Error(),
+	}
+}
+
+// An ImmutabilityPolicyAction brings the immutability policy of a Container
+// closer to its desired state.
+type ImmutabilityPolicyAction string
+
+// Immutability policy actions.
+const (
+	ImmutabilityPolicyActionNone   ImmutabilityPolicyAction = ""
+	ImmutabilityPolicyActionUpdate ImmutabilityPolicyAction = "Update"
+	ImmutabilityPolicyActionLock   ImmutabilityPolicyAction = "Lock"
+	ImmutabilityPolicyActionExtend ImmutabilityPolicyAction = "Extend"
+)
+
+// NextImmutabilityPolicyAction returns the action that brings the supplied
+// immutability policy received from Azure closer to the supplied desired
+// policy. An unlocked policy is updated until it matches, then locked if
+// desired. A locked policy may only be extended; an error describes any other
+// change to a locked policy.
+func NextImmutabilityPolicyAction(p v1beta1.ImmutabilityPolicy, az *storage.ImmutabilityPolicyProperties) (ImmutabilityPolicyAction, error) {
+	o := GenerateImmutabilityPolicyObservation(az)
+	if o == nil {
+		return ImmutabilityPolicyActionUpdate, nil
+	}
+	if o.State != v1beta1.ImmutabilityPolicyStateLocked {
+		if p.ImmutabilityPeriodSinceCreationInDays != o.ImmutabilityPeriodSinceCreationInDays || p.AllowProtectedAppendWrites != o.AllowProtectedAppendWrites {
+			return ImmutabilityPolicyActionUpdate, nil
+		}
+		if p.Locked {
+			return ImmutabilityPolicyActionLock, nil
+		}
+		return ImmutabilityPolicyActionNone, nil
+	}
+	switch {
+	case !p.Locked:
+		return ImmutabilityPolicyActionNone, errors.New(errUnlockPolicy)
+	case p.AllowProtectedAppendWrites != o.AllowProtectedAppendWrites:
+		return ImmutabilityPolicyActionNone, errors.New(errChangeAppendWrites)
+	case p.ImmutabilityPeriodSinceCreationInDays < o.ImmutabilityPeriodSinceCreationInDays:
+		return ImmutabilityPolicyActionNone, errors.Errorf(errFmtShortenPolicy, o.ImmutabilityPeriodSinceCreationInDays, p.ImmutabilityPeriodSinceCreationInDays)
+	case p.ImmutabilityPeriodSinceCreationInDays > o.ImmutabilityPeriodSinceCreationInDays:
+		return ImmutabilityPolicyActionExtend, nil
+	}
+	return ImmutabilityPolicyActionNone, nil
+}
+
+// NewImmutabilityPolicy returns an immutability policy suitable for use with
+// the Azure API.
+func NewImmutabilityPolicy(p v1beta1.ImmutabilityPolicy) *storage.ImmutabilityPolicy {
+	return &storage.ImmutabilityPolicy{ImmutabilityPolicyProperty: &storage.ImmutabilityPolicyProperty{
+		ImmutabilityPeriodSinceCreationInDays: azure.ToInt32Ptr(p.ImmutabilityPeriodSinceCreationInDays, azure.FieldRequired),
+		AllowProtectedAppendWrites:            azure.ToBoolPtr(p.AllowProtectedAppendWrites, azure.FieldRequired),
+	}}
+}
+
+// GenerateImmutabilityPolicyObservation produces an
+// ImmutabilityPolicyObservation from the immutability policy of a Container
+// received from Azure. It returns nil if the Container has no policy.
+func GenerateImmutabilityPolicyObservation(az *storage.ImmutabilityPolicyProperties) *v1beta1.ImmutabilityPolicyObservation {
+	if az == nil || az.ImmutabilityPolicyProperty == nil || az.ImmutabilityPeriodSinceCreationInDays == nil {
+		return nil
+	}
+	return &v1beta1.ImmutabilityPolicyObservation{
+		ImmutabilityPeriodSinceCreationInDays: int(*az.ImmutabilityPeriodSinceCreationInDays),
+		AllowProtectedAppendWrites:            azure.ToBool(az.AllowProtectedAppendWrites),
+		State:                                 string(az.State),
+		ETag:                                  azure.ToString(az.Etag),
+	}
+}
+
+// GenerateLegalHoldTags returns the tags of the legal hold of a Container
+// received from Azure.
+func GenerateLegalHoldTags(az *storage.LegalHoldProperties) []string {
+	if az == nil || az.Tags == nil {
+		return nil
+	}
+	var tags []string
+	for _, t := range *az.Tags {
+		tags = append(tags, azure.ToString(t.Tag))
+	}
+	return tags
+}
+
+// LegalHoldTagChanges returns the tags that must be set and cleared for the
+// supplied legal hold tags received from Azure to match the supplied legal
+// hold. Tags are compared case-insensitively.
+func LegalHoldTagChanges(l v1beta1.LegalHold, observed []string) (toSet, toClear []string) {
+	desired := map[string]bool{}
+	for _, t := range l.Tags {
+		desired[strings.ToLower(t)] = true
+	}
+	existing := map[string]bool{}
+	for _, t := range observed {
+		existing[strings.ToLower(t)] = true
+		if !desired[strings.ToLower(t)] {
+			toClear = append(toClear, t)
+		}
+	}
+	for _, t := range l.Tags {
+		if !existing[strings.ToLower(t)] {
+			toSet = append(toSet, t)
+		}
+	}
+	sort.Strings(toSet)
+	sort.Strings(toClear)
+	return toSet, toClear
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func immutabilityPolicy(days int, appendWrites bool, state storage.ImmutabilityPolicyState) *storage.ImmutabilityPolicyProperties {
+	return &storage.ImmutabilityPolicyProperties{
+		ImmutabilityPolicyProperty: &storage.ImmutabilityPolicyProperty{
+			ImmutabilityPeriodSinceCreationInDays: azure.ToInt32Ptr(days, azure.FieldRequired),
+			AllowProtectedAppendWrites:            azure.ToBoolPtr(appendWrites, azure.FieldRequired),
+			State:                                 state,
+		},
+	}
+}
+
+func TestNextImmutabilityPolicyAction(t *testing.T) {
+	type want struct {
+		a   ImmutabilityPolicyAction
+		err error
+	}
+
+	cases := map[string]struct {
+		p  v1beta1.ImmutabilityPolicy
+		az *storage.ImmutabilityPolicyProperties
+		want
+	}{
+		"NotObserved": {
+			p:    v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, Locked: true},
+			want: want{a: ImmutabilityPolicyActionUpdate},
+		},
+		"UnlockedPeriodChanged": {
+			p:    v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 3},
+			az:   immutabilityPolicy(7, false, storage.Unlocked),
+			want: want{a: ImmutabilityPolicyActionUpdate},
+		},
+		"UnlockedNeedsLock": {
+			p:    v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, Locked: true},
+			az:   immutabilityPolicy(7, false, storage.Unlocked),
+			want: want{a: ImmutabilityPolicyActionLock},
+		},
+		"UnlockedUpToDate": {
+			p:    v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, AllowProtectedAppendWrites: true},
+			az:   immutabilityPolicy(7, true, storage.Unlocked),
+			want: want{a: ImmutabilityPolicyActionNone},
+		},
+		"LockedExtended": {
+			p:    v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 14, Locked: true},
+			az:   immutabilityPolicy(7, false, storage.Locked),
+			want: want{a: ImmutabilityPolicyActionExtend},
+		},
+		"LockedUpToDate": {
+			p:    v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, Locked: true},
+			az:   immutabilityPolicy(7, false, storage.Locked),
+			want: want{a: ImmutabilityPolicyActionNone},
+		},
+		"LockedUnlocked": {
+			p:    v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7},
+			az:   immutabilityPolicy(7, false, storage.Locked),
+			want: want{err: errors.New(errUnlockPolicy)},
+		},
+		"LockedAppendWritesChanged": {
+			p:    v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, AllowProtectedAppendWrites: true, Locked: true},
+			az:   immutabilityPolicy(7, false, storage.Locked),
+			want: want{err: errors.New(errChangeAppendWrites)},
+		},
+		"LockedShortened": {
+			p:    v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 3, Locked: true},
+			az:   immutabilityPolicy(7, false, storage.Locked),
+			want: want{err: errors.New(fmt.Sprintf(errFmtShortenPolicy, 7, 3))},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a, err := NextImmutabilityPolicyAction(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want.a, a); diff != "" {
+				t.Errorf("NextImmutabilityPolicyAction(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("NextImmutabilityPolicyAction(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestLegalHoldTagChanges(t *testing.T) {
+	type want struct {
+		toSet   []string
+		toClear []string
+	}
+
+	cases := map[string]struct {
+		l        v1beta1.LegalHold
+		observed []string
+		want
+	}{
+		"NoChanges": {
+			l:        v1beta1.LegalHold{Tags: []string{"audit", "litigation"}},
+			observed: []string{"LITIGATION", "AUDIT"},
+		},
+		"SetAndClear": {
+			l:        v1beta1.LegalHold{Tags: []string{"litigation", "audit"}},
+			observed: []string{"investigation", "AUDIT"},
+			want:     want{toSet: []string{"litigation"}, toClear: []string{"investigation"}},
+		},
+		"ClearAll": {
+			l:        v1beta1.LegalHold{},
+			observed: []string{"LITIGATION", "AUDIT"},
+			want:     want{toClear: []string{"AUDIT", "LITIGATION"}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			toSet, toClear := LegalHoldTagChanges(tc.l, tc.observed)
+			if diff := cmp.Diff(tc.want.toSet, toSet); diff != "" {
+				t.Errorf("LegalHoldTagChanges(...): -want set, +got set\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.toClear, toClear); diff != "" {
+				t.Errorf("LegalHoldTagChanges(...): -want clear, +got clear\n%s", diff)
+			}
+		})
+	}
+}
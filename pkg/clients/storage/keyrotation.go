@@ -0,0 +1,143 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+)
+
+// DefaultKeyRotationGracePeriod is the grace period of a key rotation whose
+// grace period is not specified.
+const DefaultKeyRotationGracePeriod = time.Hour
+
+const (
+	errFmtKeyRotationInterval    = "key rotation interval %s must be positive"
+	errFmtKeyRotationGracePeriod = "key rotation grace period %s must be positive and shorter than the interval %s"
+)
+
+// TypeKeyRotation indicates whether the access keys of an Account are rotated
+// as configured.
+const TypeKeyRotation xpv1.ConditionType = "KeyRotation"
+
+// Reasons the access keys of an Account are or are not rotated.
+const (
+	ReasonKeyRotationScheduled xpv1.ConditionReason = "KeyRotationScheduled"
+	ReasonKeyRotationInvalid   xpv1.ConditionReason = "InvalidKeyRotation"
+)
+
+// KeyRotationScheduled returns a condition indicating that the access keys of
+// an Account are rotated as configured.
+func KeyRotationScheduled() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeKeyRotation,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonKeyRotationScheduled,
+	}
+}
+
+// KeyRotationInvalid returns a condition indicating that the access keys of
+// an Account are not rotated because their rotation is misconfigured.
+func KeyRotationInvalid(err error) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeKeyRotation,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonKeyRotationInvalid,
+		Message:            err.Error(),
+	}
+}
+
+// ValidateKeyRotation returns an error if the supplied key rotation would
+// regenerate keys on every reconcile, or regenerate a key before the grace
+// period of the previous rotation has passed.
+func ValidateKeyRotation(r v1beta1.KeyRotation) error {
+	if r.Interval.Duration <= 0 {
+		return errors.Errorf(errFmtKeyRotationInterval, r.Interval.Duration)
+	}
+	if g := keyRotationGracePeriod(r); g <= 0 || g >= r.Interval.Duration {
+		return errors.Errorf(errFmtKeyRotationGracePeriod, g, r.Interval.Duration)
+	}
+	return nil
+}
+
+// NextKeyRotation returns the name of the access key that should be
+// regenerated at the supplied time, if any, and the key rotation status that
+// results from regenerating it. The first observation of an Account activates
+// its primary key without regenerating anything. The supplied key rotation
+// must be valid per ValidateKeyRotation.
+func NextKeyRotation(r v1beta1.KeyRotation, s *v1beta1.KeyRotationStatus, now time.Time) (string, *v1beta1.KeyRotationStatus) {
+	if s == nil || s.ActiveKeyName == "" || s.LastRotationTime == nil {
+		return "", &v1beta1.KeyRotationStatus{
+			ActiveKeyName:    v1beta1.KeyNamePrimary,
+			LastRotationTime: &metav1.Time{Time: now.Truncate(time.Second)},
+		}
+	}
+
+	if s.RetiringKeyName != "" {
+		if now.Before(s.LastRotationTime.Add(keyRotationGracePeriod(r))) {
+			return "", s
+		}
+		return s.RetiringKeyName, &v1beta1.KeyRotationStatus{
+			ActiveKeyName:    s.ActiveKeyName,
+			LastRotationTime: s.LastRotationTime,
+		}
+	}
+
+	if now.Before(s.LastRotationTime.Add(r.Interval.Duration)) {
+		return "", s
+	}
+	next := InactiveKeyName(s.ActiveKeyName)
+	return next, &v1beta1.KeyRotationStatus{
+		ActiveKeyName:    next,
+		LastRotationTime: &metav1.Time{Time: now.Truncate(time.Second)},
+		RetiringKeyName:  s.ActiveKeyName,
+	}
+}
+
+// ActiveKeyName returns the name of the access key that the supplied Account
+// publishes; the primary key unless the Account's keys are rotated.
+func ActiveKeyName(a *v1beta1.Account) string {
+	if s := a.Status.KeyRotation; a.Spec.KeyRotation != nil && s != nil && s.ActiveKeyName != "" {
+		return s.ActiveKeyName
+	}
+	return v1beta1.KeyNamePrimary
+}
+
+// InactiveKeyName returns the name of the access key that is not the supplied
+// one.
+func InactiveKeyName(active string) string {
+	if active == v1beta1.KeyNameSecondary {
+		return v1beta1.KeyNamePrimary
+	}
+	return v1beta1.KeyNameSecondary
+}
+
+func keyRotationGracePeriod(r v1beta1.KeyRotation) time.Duration {
+	if r.GracePeriod != nil {
+		return r.GracePeriod.Duration
+	}
+	return DefaultKeyRotationGracePeriod
+}
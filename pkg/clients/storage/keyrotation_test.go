@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+)
+
+func TestNextKeyRotation(t *testing.T) {
+	now := time.Unix(100000, 0)
+	rotated := &metav1.Time{Time: now.Add(-2 * time.Hour)}
+	r := v1beta1.KeyRotation{Interval: metav1.Duration{Duration: 24 * time.Hour}}
+
+	type args struct {
+		r v1beta1.KeyRotation
+		s *v1beta1.KeyRotationStatus
+	}
+	type want struct {
+		key string
+		s   *v1beta1.KeyRotationStatus
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"FirstObservation": {
+			args: args{r: r},
+			want: want{
+				s: &v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: now},
+				},
+			},
+		},
+		"NotDue": {
+			args: args{
+				r: r,
+				s: &v1beta1.KeyRotationStatus{ActiveKeyName: v1beta1.KeyNamePrimary, LastRotationTime: rotated},
+			},
+			want: want{
+				s: &v1beta1.KeyRotationStatus{ActiveKeyName: v1beta1.KeyNamePrimary, LastRotationTime: rotated},
+			},
+		},
+		"RotateToSecondary": {
+			args: args{
+				r: v1beta1.KeyRotation{Interval: metav1.Duration{Duration: time.Hour}},
+				s: &v1beta1.KeyRotationStatus{ActiveKeyName: v1beta1.KeyNamePrimary, LastRotationTime: rotated},
+			},
+			want: want{
+				key: v1beta1.KeyNameSecondary,
+				s: &v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNameSecondary,
+					LastRotationTime: &metav1.Time{Time: now},
+					RetiringKeyName:  v1beta1.KeyNamePrimary,
+				},
+			},
+		},
+		"RotateToPrimary": {
+			args: args{
+				r: v1beta1.KeyRotation{Interval: metav1.Duration{Duration: time.Hour}},
+				s: &v1beta1.KeyRotationStatus{ActiveKeyName: v1beta1.KeyNameSecondary, LastRotationTime: rotated},
+			},
+			want: want{
+				key: v1beta1.KeyNamePrimary,
+				s: &v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: now},
+					RetiringKeyName:  v1beta1.KeyNameSecondary,
+				},
+			},
+		},
+		"InGracePeriod": {
+			args: args{
+				r: v1beta1.KeyRotation{
+					Interval:    metav1.Duration{Duration: time.Hour},
+					GracePeriod: &metav1.Duration{Duration: 3 * time.Hour},
+				},
+				s: &v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNameSecondary,
+					LastRotationTime: rotated,
+					RetiringKeyName:  v1beta1.KeyNamePrimary,
+				},
+			},
+			want: want{
+				s: &v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNameSecondary,
+					LastRotationTime: rotated,
+					RetiringKeyName:  v1beta1.KeyNamePrimary,
+				},
+			},
+		},
+		"GracePeriodPassed": {
+			args: args{
+				r: r,
+				s: &v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNameSecondary,
+					LastRotationTime: rotated,
+					RetiringKeyName:  v1beta1.KeyNamePrimary,
+				},
+			},
+			want: want{
+				key: v1beta1.KeyNamePrimary,
+				s:   &v1beta1.KeyRotationStatus{ActiveKeyName: v1beta1.KeyNameSecondary, LastRotationTime: rotated},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			key, s := NextKeyRotation(tc.args.r, tc.args.s, now)
+			if diff := cmp.Diff(tc.want.key, key); diff != "" {
+				t.Errorf("NextKeyRotation(...): -want key, +got key\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.s, s); diff != "" {
+				t.Errorf("NextKeyRotation(...): -want status, +got status\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestActiveKeyName(t *testing.T) {
+	rotated := &v1beta1.KeyRotationStatus{ActiveKeyName: v1beta1.KeyNameSecondary}
+
+	cases := map[string]struct {
+		a    *v1beta1.Account
+		want string
+	}{
+		"NotRotated": {
+			a:    &v1beta1.Account{},
+			want: v1beta1.KeyNamePrimary,
+		},
+		"RotationDisabled": {
+			a:    &v1beta1.Account{Status: v1beta1.AccountStatus{KeyRotation: rotated}},
+			want: v1beta1.KeyNamePrimary,
+		},
+		"Rotated": {
+			a: &v1beta1.Account{
+				Spec:   v1beta1.AccountSpec{KeyRotation: &v1beta1.KeyRotation{}},
+				Status: v1beta1.AccountStatus{KeyRotation: rotated},
+			},
+			want: v1beta1.KeyNameSecondary,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, ActiveKeyName(tc.a)); diff != "" {
+				t.Errorf("ActiveKeyName(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestValidateKeyRotation(t *testing.T) {
+	d := func(d time.Duration) *metav1.Duration { return &metav1.Duration{Duration: d} }
+
+	cases := map[string]struct {
+		r    v1beta1.KeyRotation
+		want error
+	}{
+		"Valid": {
+			r: v1beta1.KeyRotation{Interval: *d(24 * time.Hour), GracePeriod: d(2 * time.Hour)},
+		},
+		"ValidDefaultGracePeriod": {
+			r: v1beta1.KeyRotation{Interval: *d(2 * time.Hour)},
+		},
+		"ZeroInterval": {
+			r:    v1beta1.KeyRotation{},
+			want: errors.Errorf(errFmtKeyRotationInterval, time.Duration(0)),
+		},
+		"NegativeInterval": {
+			r:    v1beta1.KeyRotation{Interval: *d(-time.Hour)},
+			want: errors.Errorf(errFmtKeyRotationInterval, -time.Hour),
+		},
+		"GracePeriodAsLongAsInterval": {
+			r:    v1beta1.KeyRotation{Interval: *d(24 * time.Hour), GracePeriod: d(24 * time.Hour)},
+			want: errors.Errorf(errFmtKeyRotationGracePeriod, 24*time.Hour, 24*time.Hour),
+		},
+		"DefaultGracePeriodLongerThanInterval": {
+			r:    v1beta1.KeyRotation{Interval: *d(30 * time.Minute)},
+			want: errors.Errorf(errFmtKeyRotationGracePeriod, time.Hour, 30*time.Minute),
+		},
+		"ZeroGracePeriod": {
+			r:    v1beta1.KeyRotation{Interval: *d(24 * time.Hour), GracePeriod: d(0)},
+			want: errors.Errorf(errFmtKeyRotationGracePeriod, time.Duration(0), 24*time.Hour),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := ValidateKeyRotation(tc.r)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ValidateKeyRotation(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
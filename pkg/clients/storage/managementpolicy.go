@@ -0,0 +1,363 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const managementPolicyPath = "/managementPolicies/default"
+
+// ManagementPolicyRuleType is the type of all management policy rules.
+const ManagementPolicyRuleType = "Lifecycle"
+
+// A ManagementPolicy of a storage Account, as represented by the Azure
+// management API. The vendored Azure SDK predates actions on blob versions.
+type ManagementPolicy struct {
+	autorest.Response `json:"-"`
+
+	// ID - READ-ONLY; Fully qualified resource ID of the policy.
+	ID *string `json:"id,omitempty"`
+
+	// Name - READ-ONLY; The name of the policy.
+	Name *string `json:"name,omitempty"`
+
+	*ManagementPolicyProperties `json:"properties,omitempty"`
+}
+
+// ManagementPolicyProperties are the properties of a ManagementPolicy.
+type ManagementPolicyProperties struct {
+	// LastModifiedTime - READ-ONLY; The time at which the policy was last
+	// modified.
+	LastModifiedTime *date.Time `json:"lastModifiedTime,omitempty"`
+
+	// Policy - The rules of the policy.
+	Policy *ManagementPolicySchema `json:"policy,omitempty"`
+}
+
+// ManagementPolicySchema contains the rules of a ManagementPolicy.
+type ManagementPolicySchema struct {
+	// Rules - The lifecycle management rules.
+	Rules *[]ManagementPolicyRule `json:"rules,omitempty"`
+}
+
+// A ManagementPolicyRule is a lifecycle management rule.
+type ManagementPolicyRule struct {
+	// Enabled - Whether the rule is enabled.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// Name - The name of the rule.
+	Name *string `json:"name,omitempty"`
+
+	// Type - The type of the rule, which is always 'Lifecycle'.
+	Type *string `json:"type,omitempty"`
+
+	// Definition - The actions and filters of the rule.
+	Definition *ManagementPolicyDefinition `json:"definition,omitempty"`
+}
+
+// A ManagementPolicyDefinition contains the actions and filters of a
+// ManagementPolicyRule.
+type ManagementPolicyDefinition struct {
+	// Actions - The actions taken on matching blobs.
+	Actions *ManagementPolicyAction `json:"actions,omitempty"`
+
+	// Filters - The filters that limit the blobs to which the actions apply.
+	Filters *storage.ManagementPolicyFilter `json:"filters,omitempty"`
+}
+
+// ManagementPolicyAction contains the actions of a ManagementPolicyRule.
+type ManagementPolicyAction struct {
+	// BaseBlob - The actions on base blobs.
+	BaseBlob *storage.ManagementPolicyBaseBlob `json:"baseBlob,omitempty"`
+
+	// Snapshot - The actions on blob snapshots.
+	Snapshot *storage.ManagementPolicySnapShot `json:"snapshot,omitempty"`
+
+	// Version - The actions on previous versions of blobs.
+	Version *ManagementPolicyVersion `json:"version,omitempty"`
+}
+
+// ManagementPolicyVersion contains the actions on previous versions of
+// blobs.
+type ManagementPolicyVersion struct {
+	// TierToCool - Moves versions to the cool tier.
+	TierToCool *storage.DateAfterCreation `json:"tierToCool,omitempty"`
+
+	// TierToArchive - Moves versions to the archive tier.
+	TierToArchive *storage.DateAfterCreation `json:"tierToArchive,omitempty"`
+
+	// Delete - Deletes versions.
+	Delete *storage.DateAfterCreation `json:"delete,omitempty"`
+}
+
+// ManagementPolicyClientAPI manages the management policy of a storage
+// Account.
+type ManagementPolicyClientAPI interface {
+	CreateOrUpdate(ctx context.Context, resourceGroupName string, accountName string, policy ManagementPolicy) (ManagementPolicy, error)
+	Get(ctx context.Context, resourceGroupName string, accountName string) (ManagementPolicy, error)
+	Delete(ctx context.Context, resourceGroupName string, accountName string) (autorest.Response, error)
+}
+
+var _ ManagementPolicyClientAPI = ManagementPolicyClient{}
+
+// ManagementPolicyClient manages the management policy of a storage Account
+// using the Azure management API.
+type ManagementPolicyClient struct {
+	servicesClient
+}
+
+// NewManagementPolicyClient returns a ManagementPolicyClient for the
+// supplied subscription.
+func NewManagementPolicyClient(subscriptionID string) ManagementPolicyClient {
+	return ManagementPolicyClient{servicesClient{BaseClient: storage.New(subscriptionID), name: "storage.ManagementPolicyClient"}}
+}
+
+// CreateOrUpdate sets the supplied management policy.
+func (c ManagementPolicyClient) CreateOrUpdate(ctx context.Context, resourceGroupName string, accountName string, policy ManagementPolicy) (ManagementPolicy, error) {
+	result := ManagementPolicy{}
+	resp, err := c.send(ctx, "CreateOrUpdate", http.MethodPut, resourceGroupName, accountName, managementPolicyPath, nil, newManagementPolicyBody(policy), &result)
+	result.Response = resp
+	return result, err
+}
+
+// Get gets the management policy.
+func (c ManagementPolicyClient) Get(ctx context.Context, resourceGroupName string, accountName string) (ManagementPolicy, error) {
+	result := ManagementPolicy{}
+	resp, err := c.send(ctx, "Get", http.MethodGet, resourceGroupName, accountName, managementPolicyPath, nil, nil, &result)
+	result.Response = resp
+	return result, err
+}
+
+// Delete deletes the management policy.
+func (c ManagementPolicyClient) Delete(ctx context.Context, resourceGroupName string, accountName string) (autorest.Response, error) {
+	return c.send(ctx, "Delete", http.MethodDelete, resourceGroupName, accountName, managementPolicyPath, nil, nil, nil)
+}
+
+// newManagementPolicyBody omits the read-only fields of the supplied
+// management policy.
+func newManagementPolicyBody(p ManagementPolicy) ManagementPolicy {
+	body := ManagementPolicy{ManagementPolicyProperties: &ManagementPolicyProperties{}}
+	if p.ManagementPolicyProperties != nil {
+		body.Policy = p.Policy
+	}
+	return body
+}
+
+// NewManagementPolicy returns a storage management policy suitable for use
+// with the Azure API.
+func NewManagementPolicy(p v1beta1.ManagementPolicyParameters) ManagementPolicy {
+	rules := make([]ManagementPolicyRule, len(p.Rules))
+	for i, r := range p.Rules {
+		rules[i] = ManagementPolicyRule{
+			Name:    azure.ToStringPtr(r.Name),
+			Enabled: r.Enabled,
+			Type:    azure.ToStringPtr(ManagementPolicyRuleType),
+			Definition: &ManagementPolicyDefinition{
+				Actions: newManagementPolicyAction(r.Definition.Actions),
+				Filters: newManagementPolicyFilter(r.Definition.Filters),
+			},
+		}
+	}
+	return ManagementPolicy{
+		ManagementPolicyProperties: &ManagementPolicyProperties{
+			Policy: &ManagementPolicySchema{Rules: &rules},
+		},
+	}
+}
+
+// GenerateManagementPolicyObservation produces a ManagementPolicyObservation
+// from the storage management policy received from Azure.
+func GenerateManagementPolicyObservation(az ManagementPolicy) v1beta1.ManagementPolicyObservation {
+	o := v1beta1.ManagementPolicyObservation{ID: azure.ToString(az.ID)}
+	if az.ManagementPolicyProperties != nil {
+		o.LastModifiedTime = toMetaTime(az.LastModifiedTime)
+	}
+	return o
+}
+
+// IsManagementPolicyUpToDate returns true if the rules of the supplied storage
+// management policy received from Azure match the supplied
+// ManagementPolicyParameters. The order of rules, prefixes and blob types is
+// not significant.
+func IsManagementPolicyUpToDate(p v1beta1.ManagementPolicyParameters, az ManagementPolicy) bool {
+	desired := make([]v1beta1.ManagementPolicyRule, len(p.Rules))
+	for i, r := range p.Rules {
+		desired[i] = *r.DeepCopy()
+		if desired[i].Enabled == nil {
+			desired[i].Enabled = azure.ToBoolPtr(true)
+		}
+	}
+	return cmp.Equal(desired, generateManagementPolicyRules(az), cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b v1beta1.ManagementPolicyRule) bool { return a.Name < b.Name }),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }),
+		cmpopts.SortSlices(func(a, b v1beta1.TagFilter) bool { return a.Name < b.Name }))
+}
+
+func newManagementPolicyAction(a v1beta1.ManagementPolicyActions) *ManagementPolicyAction {
+	out := &ManagementPolicyAction{}
+	if b := a.BaseBlob; b != nil {
+		out.BaseBlob = &storage.ManagementPolicyBaseBlob{
+			TierToCool:    newDateAfterModification(b.TierToCool),
+			TierToArchive: newDateAfterModification(b.TierToArchive),
+			Delete:        newDateAfterModification(b.Delete),
+		}
+	}
+	if s := a.Snapshot; s != nil {
+		out.Snapshot = &storage.ManagementPolicySnapShot{Delete: newDateAfterCreation(s.Delete)}
+	}
+	if v := a.Version; v != nil {
+		out.Version = &ManagementPolicyVersion{
+			TierToCool:    newDateAfterCreation(v.TierToCool),
+			TierToArchive: newDateAfterCreation(v.TierToArchive),
+			Delete:        newDateAfterCreation(v.Delete),
+		}
+	}
+	return out
+}
+
+func newDateAfterCreation(d *v1beta1.DateAfterCreation) *storage.DateAfterCreation {
+	if d == nil {
+		return nil
+	}
+	days := float64(d.DaysAfterCreationGreaterThan)
+	return &storage.DateAfterCreation{DaysAfterCreationGreaterThan: &days}
+}
+
+func newDateAfterModification(d *v1beta1.DateAfterModification) *storage.DateAfterModification {
+	if d == nil {
+		return nil
+	}
+	days := float64(d.DaysAfterModificationGreaterThan)
+	return &storage.DateAfterModification{DaysAfterModificationGreaterThan: &days}
+}
+
+func newManagementPolicyFilter(f *v1beta1.ManagementPolicyFilters) *storage.ManagementPolicyFilter {
+	if f == nil {
+		return nil
+	}
+	out := &storage.ManagementPolicyFilter{BlobTypes: &f.BlobTypes}
+	if len(f.PrefixMatch) > 0 {
+		out.PrefixMatch = &f.PrefixMatch
+	}
+	if len(f.BlobIndexMatch) > 0 {
+		tags := make([]storage.TagFilter, len(f.BlobIndexMatch))
+		for i, t := range f.BlobIndexMatch {
+			tags[i] = storage.TagFilter{
+				Name:  azure.ToStringPtr(t.Name),
+				Op:    azure.ToStringPtr(t.Op),
+				Value: azure.ToStringPtr(t.Value),
+			}
+		}
+		out.BlobIndexMatch = &tags
+	}
+	return out
+}
+
+// generateManagementPolicyRules produces the rules that describe the
+// supplied storage management policy received from Azure.
+func generateManagementPolicyRules(az ManagementPolicy) []v1beta1.ManagementPolicyRule {
+	if az.ManagementPolicyProperties == nil || az.Policy == nil || az.Policy.Rules == nil {
+		return nil
+	}
+	rules := make([]v1beta1.ManagementPolicyRule, len(*az.Policy.Rules))
+	for i, r := range *az.Policy.Rules {
+		rules[i] = v1beta1.ManagementPolicyRule{
+			Name:    azure.ToString(r.Name),
+			Enabled: r.Enabled,
+		}
+		if r.Definition != nil {
+			rules[i].Definition = v1beta1.ManagementPolicyDefinition{
+				Actions: generateManagementPolicyActions(r.Definition.Actions),
+				Filters: generateManagementPolicyFilters(r.Definition.Filters),
+			}
+		}
+	}
+	return rules
+}
+
+func generateManagementPolicyActions(a *ManagementPolicyAction) v1beta1.ManagementPolicyActions {
+	out := v1beta1.ManagementPolicyActions{}
+	if a == nil {
+		return out
+	}
+	if b := a.BaseBlob; b != nil {
+		out.BaseBlob = &v1beta1.ManagementPolicyBaseBlob{
+			TierToCool:    generateDateAfterModification(b.TierToCool),
+			TierToArchive: generateDateAfterModification(b.TierToArchive),
+			Delete:        generateDateAfterModification(b.Delete),
+		}
+	}
+	if s := a.Snapshot; s != nil {
+		out.Snapshot = &v1beta1.ManagementPolicySnapshot{Delete: generateDateAfterCreation(s.Delete)}
+	}
+	if v := a.Version; v != nil {
+		out.Version = &v1beta1.ManagementPolicyVersion{
+			TierToCool:    generateDateAfterCreation(v.TierToCool),
+			TierToArchive: generateDateAfterCreation(v.TierToArchive),
+			Delete:        generateDateAfterCreation(v.Delete),
+		}
+	}
+	return out
+}
+
+func generateDateAfterCreation(d *storage.DateAfterCreation) *v1beta1.DateAfterCreation {
+	if d == nil || d.DaysAfterCreationGreaterThan == nil {
+		return nil
+	}
+	return &v1beta1.DateAfterCreation{DaysAfterCreationGreaterThan: int(*d.DaysAfterCreationGreaterThan)}
+}
+
+func generateDateAfterModification(d *storage.DateAfterModification) *v1beta1.DateAfterModification {
+	if d == nil || d.DaysAfterModificationGreaterThan == nil {
+		return nil
+	}
+	return &v1beta1.DateAfterModification{DaysAfterModificationGreaterThan: int(*d.DaysAfterModificationGreaterThan)}
+}
+
+func generateManagementPolicyFilters(f *storage.ManagementPolicyFilter) *v1beta1.ManagementPolicyFilters {
+	if f == nil {
+		return nil
+	}
+	out := &v1beta1.ManagementPolicyFilters{}
+	if f.PrefixMatch != nil {
+		out.PrefixMatch = *f.PrefixMatch
+	}
+	if f.BlobTypes != nil {
+		out.BlobTypes = *f.BlobTypes
+	}
+	if f.BlobIndexMatch != nil {
+		for _, t := range *f.BlobIndexMatch {
+			out.BlobIndexMatch = append(out.BlobIndexMatch, v1beta1.TagFilter{
+				Name:  azure.ToString(t.Name),
+				Op:    azure.ToString(t.Op),
+				Value: azure.ToString(t.Value),
+			})
+		}
+	}
+	return out
+}
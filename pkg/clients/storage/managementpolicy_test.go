@@ -0,0 +1,236 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func float64Ptr(f float64) *float64 { return &f }
+
+func policyParams() v1beta1.ManagementPolicyParameters {
+	return v1beta1.ManagementPolicyParameters{
+		ResourceGroupName: "coolgroup",
+		AccountName:       "coolaccount",
+		Rules: []v1beta1.ManagementPolicyRule{
+			{
+				Name: "tiering",
+				Definition: v1beta1.ManagementPolicyDefinition{
+					Actions: v1beta1.ManagementPolicyActions{
+						BaseBlob: &v1beta1.ManagementPolicyBaseBlob{
+							TierToCool: &v1beta1.DateAfterModification{DaysAfterModificationGreaterThan: 30},
+							Delete:     &v1beta1.DateAfterModification{DaysAfterModificationGreaterThan: 365},
+						},
+						Snapshot: &v1beta1.ManagementPolicySnapshot{
+							Delete: &v1beta1.DateAfterCreation{DaysAfterCreationGreaterThan: 90},
+						},
+						Version: &v1beta1.ManagementPolicyVersion{
+							TierToArchive: &v1beta1.DateAfterCreation{DaysAfterCreationGreaterThan: 30},
+							Delete:        &v1beta1.DateAfterCreation{DaysAfterCreationGreaterThan: 180},
+						},
+					},
+					Filters: &v1beta1.ManagementPolicyFilters{
+						PrefixMatch: []string{"logs/", "backups/"},
+						BlobTypes:   []string{"blockBlob"},
+					},
+				},
+			},
+			{
+				Name:    "disabled",
+				Enabled: azure.ToBoolPtr(false, azure.FieldRequired),
+				Definition: v1beta1.ManagementPolicyDefinition{
+					Actions: v1beta1.ManagementPolicyActions{
+						BaseBlob: &v1beta1.ManagementPolicyBaseBlob{
+							TierToArchive: &v1beta1.DateAfterModification{DaysAfterModificationGreaterThan: 10},
+						},
+					},
+					Filters: &v1beta1.ManagementPolicyFilters{
+						BlobTypes:      []string{"blockBlob"},
+						BlobIndexMatch: []v1beta1.TagFilter{{Name: "project", Op: "==", Value: "cool"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func policy() ManagementPolicy {
+	return ManagementPolicy{
+		ID: azure.ToStringPtr("coolid"),
+		ManagementPolicyProperties: &ManagementPolicyProperties{
+			Policy: &ManagementPolicySchema{Rules: &[]ManagementPolicyRule{
+				{
+					Name:    azure.ToStringPtr("disabled"),
+					Enabled: azure.ToBoolPtr(false, azure.FieldRequired),
+					Type:    azure.ToStringPtr(ManagementPolicyRuleType),
+					Definition: &ManagementPolicyDefinition{
+						Actions: &ManagementPolicyAction{
+							BaseBlob: &storage.ManagementPolicyBaseBlob{
+								TierToArchive: &storage.DateAfterModification{DaysAfterModificationGreaterThan: float64Ptr(10)},
+							},
+						},
+						Filters: &storage.ManagementPolicyFilter{
+							BlobTypes: &[]string{"blockBlob"},
+							BlobIndexMatch: &[]storage.TagFilter{{
+								Name:  azure.ToStringPtr("project"),
+								Op:    azure.ToStringPtr("=="),
+								Value: azure.ToStringPtr("cool"),
+							}},
+						},
+					},
+				},
+				{
+					Name:    azure.ToStringPtr("tiering"),
+					Enabled: azure.ToBoolPtr(true),
+					Type:    azure.ToStringPtr(ManagementPolicyRuleType),
+					Definition: &ManagementPolicyDefinition{
+						Actions: &ManagementPolicyAction{
+							BaseBlob: &storage.ManagementPolicyBaseBlob{
+								TierToCool: &storage.DateAfterModification{DaysAfterModificationGreaterThan: float64Ptr(30)},
+								Delete:     &storage.DateAfterModification{DaysAfterModificationGreaterThan: float64Ptr(365)},
+							},
+							Snapshot: &storage.ManagementPolicySnapShot{
+								Delete: &storage.DateAfterCreation{DaysAfterCreationGreaterThan: float64Ptr(90)},
+							},
+							Version: &ManagementPolicyVersion{
+								TierToArchive: &storage.DateAfterCreation{DaysAfterCreationGreaterThan: float64Ptr(30)},
+								Delete:        &storage.DateAfterCreation{DaysAfterCreationGreaterThan: float64Ptr(180)},
+							},
+						},
+						Filters: &storage.ManagementPolicyFilter{
+							PrefixMatch: &[]string{"backups/", "logs/"},
+							BlobTypes:   &[]string{"blockBlob"},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func TestNewManagementPolicy(t *testing.T) {
+	p := policyParams()
+	p.Rules = p.Rules[:1]
+	want := ManagementPolicy{
+		ManagementPolicyProperties: &ManagementPolicyProperties{
+			Policy: &ManagementPolicySchema{Rules: &[]ManagementPolicyRule{{
+				Name: azure.ToStringPtr("tiering"),
+				Type: azure.ToStringPtr(ManagementPolicyRuleType),
+				Definition: &ManagementPolicyDefinition{
+					Actions: &ManagementPolicyAction{
+						BaseBlob: &storage.ManagementPolicyBaseBlob{
+							TierToCool: &storage.DateAfterModification{DaysAfterModificationGreaterThan: float64Ptr(30)},
+							Delete:     &storage.DateAfterModification{DaysAfterModificationGreaterThan: float64Ptr(365)},
+						},
+						Snapshot: &storage.ManagementPolicySnapShot{
+							Delete: &storage.DateAfterCreation{DaysAfterCreationGreaterThan: float64Ptr(90)},
+						},
+						Version: &ManagementPolicyVersion{
+							TierToArchive: &storage.DateAfterCreation{DaysAfterCreationGreaterThan: float64Ptr(30)},
+							Delete:        &storage.DateAfterCreation{DaysAfterCreationGreaterThan: float64Ptr(180)},
+						},
+					},
+					Filters: &storage.ManagementPolicyFilter{
+						PrefixMatch: &[]string{"logs/", "backups/"},
+						BlobTypes:   &[]string{"blockBlob"},
+					},
+				},
+			}}},
+		},
+	}
+	if diff := cmp.Diff(want, NewManagementPolicy(p)); diff != "" {
+		t.Errorf("NewManagementPolicy(...): -want, +got\n%s", diff)
+	}
+}
+
+func TestIsManagementPolicyUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		p    v1beta1.ManagementPolicyParameters
+		az   ManagementPolicy
+		want bool
+	}{
+		"UpToDate": {
+			p:    policyParams(),
+			az:   policy(),
+			want: true,
+		},
+		"NoRules": {
+			p:    policyParams(),
+			az:   ManagementPolicy{},
+			want: false,
+		},
+		"RuleRemoved": {
+			p: func() v1beta1.ManagementPolicyParameters {
+				p := policyParams()
+				p.Rules = p.Rules[:1]
+				return p
+			}(),
+			az:   policy(),
+			want: false,
+		},
+		"RuleDisabled": {
+			p: func() v1beta1.ManagementPolicyParameters {
+				p := policyParams()
+				p.Rules[0].Enabled = azure.ToBoolPtr(false, azure.FieldRequired)
+				return p
+			}(),
+			az:   policy(),
+			want: false,
+		},
+		"ActionChanged": {
+			p: func() v1beta1.ManagementPolicyParameters {
+				p := policyParams()
+				p.Rules[0].Definition.Actions.BaseBlob.Delete.DaysAfterModificationGreaterThan = 730
+				return p
+			}(),
+			az:   policy(),
+			want: false,
+		},
+		"VersionActionChanged": {
+			p: func() v1beta1.ManagementPolicyParameters {
+				p := policyParams()
+				p.Rules[0].Definition.Actions.Version.TierToCool = &v1beta1.DateAfterCreation{DaysAfterCreationGreaterThan: 7}
+				return p
+			}(),
+			az:   policy(),
+			want: false,
+		},
+		"FilterChanged": {
+			p: func() v1beta1.ManagementPolicyParameters {
+				p := policyParams()
+				p.Rules[0].Definition.Filters.PrefixMatch = []string{"logs/"}
+				return p
+			}(),
+			az:   policy(),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := IsManagementPolicyUpToDate(tc.p, tc.az); got != tc.want {
+				t.Errorf("IsManagementPolicyUpToDate(...): want %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const errParseSubnetID = "cannot parse subnet ID"
+
+// ServiceEndpointStorage is the service endpoint a subnet must have enabled
+// for a storage Account to allow traffic from it.
+const ServiceEndpointStorage = "Microsoft.Storage"
+
+// TypeServiceEndpoints indicates whether every subnet from which a storage
+// Account allows traffic has the Microsoft.Storage service endpoint enabled.
+const TypeServiceEndpoints xpv1.ConditionType = "ServiceEndpoints"
+
+// Reasons a storage Account's subnets do or do not have service endpoints.
+const (
+	ReasonServiceEndpointsEnabled xpv1.ConditionReason = "ServiceEndpointsEnabled"
+	ReasonServiceEndpointsMissing xpv1.ConditionReason = "ServiceEndpointsMissing"
+)
+
+// ServiceEndpointsEnabled returns a condition indicating that every subnet
+// from which a storage Account allows traffic has the Microsoft.Storage
+// service endpoint enabled.
+func ServiceEndpointsEnabled() xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeServiceEndpoints,
+		Status:             corev1.ConditionTrue,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonServiceEndpointsEnabled,
+	}
+}
+
+// ServiceEndpointsMissing returns a condition indicating that the supplied
+// subnets, from which a storage Account allows traffic, do not have the
+// Microsoft.Storage service endpoint enabled.
+func ServiceEndpointsMissing(subnetIDs ...string) xpv1.Condition {
+	return xpv1.Condition{
+		Type:               TypeServiceEndpoints,
+		Status:             corev1.ConditionFalse,
+		LastTransitionTime: metav1.Now(),
+		Reason:             ReasonServiceEndpointsMissing,
+		Message: fmt.Sprintf("the %s service endpoint must be enabled on subnets %s before they can be allowed by the network rules of this Account",
+			ServiceEndpointStorage, strings.Join(subnetIDs, ", ")),
+	}
+}
+
+// A SubnetID identifies an Azure virtual network subnet.
+type SubnetID struct {
+	SubscriptionID     string
+	ResourceGroupName  string
+	VirtualNetworkName string
+	Name               string
+}
+
+// ParseSubnetID parses the supplied resource ID of a subnet.
+func ParseSubnetID(id string) (SubnetID, error) {
+	p := strings.Split(strings.Trim(id, "/"), "/")
+	if len(p) != 10 || !strings.EqualFold(p[0], "subscriptions") || !strings.EqualFold(p[2], "resourceGroups") ||
+		!strings.EqualFold(p[6], "virtualNetworks") || !strings.EqualFold(p[8], "subnets") {
+		return SubnetID{}, errors.Errorf("%s: %s", errParseSubnetID, id)
+	}
+	return SubnetID{SubscriptionID: p[1], ResourceGroupName: p[3], VirtualNetworkName: p[7], Name: p[9]}, nil
+}
+
+// HasStorageServiceEndpoint returns true if the supplied subnet has the
+// Microsoft.Storage service endpoint enabled.
+func HasStorageServiceEndpoint(s network.Subnet) bool {
+	if s.SubnetPropertiesFormat == nil || s.ServiceEndpoints == nil {
+		return false
+	}
+	for _, e := range *s.ServiceEndpoints {
+		if strings.EqualFold(azure.ToString(e.Service), ServiceEndpointStorage) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestParseSubnetID(t *testing.T) {
+	type want struct {
+		id  SubnetID
+		err error
+	}
+
+	cases := map[string]struct {
+		id string
+		want
+	}{
+		"Valid": {
+			id: "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Network/virtualNetworks/coolnet/subnets/coolsubnet",
+			want: want{
+				id: SubnetID{SubscriptionID: "cool", ResourceGroupName: "coolgroup", VirtualNetworkName: "coolnet", Name: "coolsubnet"},
+			},
+		},
+		"NotASubnet": {
+			id: "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Network/virtualNetworks/coolnet",
+			want: want{
+				err: errors.Errorf("%s: %s", errParseSubnetID, "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Network/virtualNetworks/coolnet"),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			id, err := ParseSubnetID(tc.id)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("ParseSubnetID(...): -want error, +got error\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.id, id); diff != "" {
+				t.Errorf("ParseSubnetID(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestHasStorageServiceEndpoint(t *testing.T) {
+	cases := map[string]struct {
+		s    network.Subnet
+		want bool
+	}{
+		"NoProperties": {
+			s:    network.Subnet{},
+			want: false,
+		},
+		"OtherEndpoint": {
+			s: network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+				ServiceEndpoints: &[]network.ServiceEndpointPropertiesFormat{{Service: azure.ToStringPtr("Microsoft.Sql")}},
+			}},
+			want: false,
+		},
+		"StorageEndpoint": {
+			s: network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{
+				ServiceEndpoints: &[]network.ServiceEndpointPropertiesFormat{{Service: azure.ToStringPtr("microsoft.storage")}},
+			}},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := HasStorageServiceEndpoint(tc.s)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("HasStorageServiceEndpoint(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
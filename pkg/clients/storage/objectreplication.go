@@ -0,0 +1,161 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// ObjectReplicationPolicyIDDefault is the policy ID with which an object
+// replication policy is created on its destination Account, which then
+// generates the actual ID of the policy.
+const ObjectReplicationPolicyIDDefault = "default"
+
+// objectReplicationTimeFormat is the format of the minimum creation time of
+// blobs replicated by an object replication rule.
+const objectReplicationTimeFormat = "2006-01-02T15:04:05Z"
+
+// NewObjectReplicationPolicy returns an object replication policy suitable
+// for use with the Azure API. The IDs of the supplied observed rules are
+// reused for rules that replicate between the same pair of containers.
+func NewObjectReplicationPolicy(p v1beta1.ObjectReplicationPolicyParameters, observed []v1beta1.ObjectReplicationRuleObservation) storage.ObjectReplicationPolicy {
+	ids := make(map[objectReplicationContainers]string, len(observed))
+	for _, r := range observed {
+		ids[objectReplicationContainers{source: r.SourceContainer, destination: r.DestinationContainer}] = r.RuleID
+	}
+	rules := make([]storage.ObjectReplicationPolicyRule, len(p.Rules))
+	for i, r := range p.Rules {
+		rules[i] = storage.ObjectReplicationPolicyRule{
+			RuleID:               azure.ToStringPtr(ids[objectReplicationContainers{source: r.SourceContainer, destination: r.DestinationContainer}]),
+			SourceContainer:      azure.ToStringPtr(r.SourceContainer),
+			DestinationContainer: azure.ToStringPtr(r.DestinationContainer),
+			Filters:              newObjectReplicationPolicyFilter(r.Filters),
+		}
+	}
+	return storage.ObjectReplicationPolicy{
+		ObjectReplicationPolicyProperties: &storage.ObjectReplicationPolicyProperties{
+			SourceAccount:      azure.ToStringPtr(p.SourceAccountName),
+			DestinationAccount: azure.ToStringPtr(p.DestinationAccountName),
+			Rules:              &rules,
+		},
+	}
+}
+
+// GenerateObjectReplicationPolicyObservation produces an
+// ObjectReplicationPolicyObservation from the object replication policy
+// received from the destination Account and, if it exists there, the source
+// Account. Only the source Account reports when the policy was enabled.
+func GenerateObjectReplicationPolicyObservation(dst storage.ObjectReplicationPolicy, src *storage.ObjectReplicationPolicy) v1beta1.ObjectReplicationPolicyObservation {
+	o := v1beta1.ObjectReplicationPolicyObservation{}
+	if dst.ObjectReplicationPolicyProperties != nil {
+		o.PolicyID = azure.ToString(dst.PolicyID)
+		if dst.Rules != nil {
+			for _, r := range *dst.Rules {
+				o.Rules = append(o.Rules, v1beta1.ObjectReplicationRuleObservation{
+					RuleID:               azure.ToString(r.RuleID),
+					SourceContainer:      azure.ToString(r.SourceContainer),
+					DestinationContainer: azure.ToString(r.DestinationContainer),
+				})
+			}
+		}
+	}
+	if src != nil && src.ObjectReplicationPolicyProperties != nil {
+		o.EnabledTime = toMetaTime(src.EnabledTime)
+	}
+	return o
+}
+
+// IsObjectReplicationPolicyUpToDate returns true if the rules of the supplied
+// object replication policy received from Azure match the supplied
+// ObjectReplicationPolicyParameters. The order of rules and prefixes is not
+// significant.
+func IsObjectReplicationPolicyUpToDate(p v1beta1.ObjectReplicationPolicyParameters, az storage.ObjectReplicationPolicy) bool {
+	desired := make([]v1beta1.ObjectReplicationRule, len(p.Rules))
+	for i, r := range p.Rules {
+		desired[i] = *r.DeepCopy()
+		desired[i].Filters = generateObjectReplicationFilters(newObjectReplicationPolicyFilter(r.Filters))
+	}
+	return cmp.Equal(desired, generateObjectReplicationRules(az), cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b v1beta1.ObjectReplicationRule) bool {
+			if a.SourceContainer != b.SourceContainer {
+				return a.SourceContainer < b.SourceContainer
+			}
+			return a.DestinationContainer < b.DestinationContainer
+		}),
+		cmpopts.SortSlices(func(a, b string) bool { return a < b }))
+}
+
+type objectReplicationContainers struct {
+	source      string
+	destination string
+}
+
+func newObjectReplicationPolicyFilter(f *v1beta1.ObjectReplicationFilters) *storage.ObjectReplicationPolicyFilter {
+	if f == nil || (len(f.PrefixMatch) == 0 && f.MinCreationTime == nil) {
+		return nil
+	}
+	az := &storage.ObjectReplicationPolicyFilter{}
+	if len(f.PrefixMatch) > 0 {
+		prefixes := make([]string, len(f.PrefixMatch))
+		copy(prefixes, f.PrefixMatch)
+		az.PrefixMatch = &prefixes
+	}
+	if f.MinCreationTime != nil {
+		az.MinCreationTime = azure.ToStringPtr(f.MinCreationTime.UTC().Format(objectReplicationTimeFormat))
+	}
+	return az
+}
+
+func generateObjectReplicationRules(az storage.ObjectReplicationPolicy) []v1beta1.ObjectReplicationRule {
+	if az.ObjectReplicationPolicyProperties == nil || az.Rules == nil {
+		return nil
+	}
+	rules := make([]v1beta1.ObjectReplicationRule, len(*az.Rules))
+	for i, r := range *az.Rules {
+		rules[i] = v1beta1.ObjectReplicationRule{
+			SourceContainer:      azure.ToString(r.SourceContainer),
+			DestinationContainer: azure.ToString(r.DestinationContainer),
+			Filters:              generateObjectReplicationFilters(r.Filters),
+		}
+	}
+	return rules
+}
+
+func generateObjectReplicationFilters(f *storage.ObjectReplicationPolicyFilter) *v1beta1.ObjectReplicationFilters {
+	if f == nil {
+		return nil
+	}
+	o := &v1beta1.ObjectReplicationFilters{}
+	if f.PrefixMatch != nil {
+		o.PrefixMatch = *f.PrefixMatch
+	}
+	if t, err := time.Parse(time.RFC3339, azure.ToString(f.MinCreationTime)); err == nil {
+		o.MinCreationTime = &metav1.Time{Time: t.UTC()}
+	}
+	if len(o.PrefixMatch) == 0 && o.MinCreationTime == nil {
+		return nil
+	}
+	return o
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestNewObjectReplicationPolicy(t *testing.T) {
+	p := v1beta1.ObjectReplicationPolicyParameters{
+		SourceAccountName:      "coolsource",
+		DestinationAccountName: "cooldestination",
+		Rules: []v1beta1.ObjectReplicationRule{
+			{SourceContainer: "reports", DestinationContainer: "reports"},
+			{
+				SourceContainer:      "logs",
+				DestinationContainer: "archive",
+				Filters: &v1beta1.ObjectReplicationFilters{
+					PrefixMatch:     []string{"2021/"},
+					MinCreationTime: &metav1.Time{Time: time.Date(2021, 2, 19, 16, 5, 0, 0, time.UTC)},
+				},
+			},
+		},
+	}
+	observed := []v1beta1.ObjectReplicationRuleObservation{
+		{RuleID: "cool-rule", SourceContainer: "reports", DestinationContainer: "reports"},
+		{RuleID: "stale-rule", SourceContainer: "logs", DestinationContainer: "logs"},
+	}
+	want := storage.ObjectReplicationPolicy{
+		ObjectReplicationPolicyProperties: &storage.ObjectReplicationPolicyProperties{
+			SourceAccount:      azure.ToStringPtr("coolsource"),
+			DestinationAccount: azure.ToStringPtr("cooldestination"),
+			Rules: &[]storage.ObjectReplicationPolicyRule{
+				{
+					RuleID:               azure.ToStringPtr("cool-rule"),
+					SourceContainer:      azure.ToStringPtr("reports"),
+					DestinationContainer: azure.ToStringPtr("reports"),
+				},
+				{
+					SourceContainer:      azure.ToStringPtr("logs"),
+					DestinationContainer: azure.ToStringPtr("archive"),
+					Filters: &storage.ObjectReplicationPolicyFilter{
+						PrefixMatch:     &[]string{"2021/"},
+						MinCreationTime: azure.ToStringPtr("2021-02-19T16:05:00Z"),
+					},
+				},
+			},
+		},
+	}
+	if diff := cmp.Diff(want, NewObjectReplicationPolicy(p, observed)); diff != "" {
+		t.Errorf("NewObjectReplicationPolicy(...): -want, +got\n%s", diff)
+	}
+}
+
+func TestIsObjectReplicationPolicyUpToDate(t *testing.T) {
+	rule := func(src, dst string, f *storage.ObjectReplicationPolicyFilter) storage.ObjectReplicationPolicyRule {
+		return storage.ObjectReplicationPolicyRule{
+			RuleID:               azure.ToStringPtr(src + "-rule"),
+			SourceContainer:      azure.ToStringPtr(src),
+			DestinationContainer: azure.ToStringPtr(dst),
+			Filters:              f,
+		}
+	}
+	az := func(rules ...storage.ObjectReplicationPolicyRule) storage.ObjectReplicationPolicy {
+		return storage.ObjectReplicationPolicy{ObjectReplicationPolicyProperties: &storage.ObjectReplicationPolicyProperties{Rules: &rules}}
+	}
+	p := v1beta1.ObjectReplicationPolicyParameters{
+		Rules: []v1beta1.ObjectReplicationRule{
+			{SourceContainer: "reports", DestinationContainer: "reports"},
+			{
+				SourceContainer:      "logs",
+				DestinationContainer: "archive",
+				Filters: &v1beta1.ObjectReplicationFilters{
+					PrefixMatch:     []string{"2021/", "2020/"},
+					MinCreationTime: &metav1.Time{Time: time.Date(2021, 2, 19, 16, 5, 0, 0, time.UTC)},
+				},
+			},
+		},
+	}
+
+	cases := map[string]struct {
+		az   storage.ObjectReplicationPolicy
+		want bool
+	}{
+		"UpToDate": {
+			az: az(
+				rule("logs", "archive", &storage.ObjectReplicationPolicyFilter{
+					PrefixMatch:     &[]string{"2020/", "2021/"},
+					MinCreationTime: azure.ToStringPtr("2021-02-19T16:05:00Z"),
+				}),
+				rule("reports", "reports", &storage.ObjectReplicationPolicyFilter{}),
+			),
+			want: true,
+		},
+		"MinCreationTimeChanged": {
+			az: az(
+				rule("reports", "reports", nil),
+				rule("logs", "archive", &storage.ObjectReplicationPolicyFilter{
+					PrefixMatch:     &[]string{"2020/", "2021/"},
+					MinCreationTime: azure.ToStringPtr("2021-01-01T00:00:00Z"),
+				}),
+			),
+			want: false,
+		},
+		"RuleMissing": {
+			az:   az(rule("reports", "reports", nil)),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsObjectReplicationPolicyUpToDate(p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsObjectReplicationPolicyUpToDate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
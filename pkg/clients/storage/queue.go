@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const queuePath = "/queueServices/default/queues/{queueName}"
+
+// A Queue of a storage Account, as represented by the Azure management API.
+type Queue struct {
+	autorest.Response `json:"-"`
+
+	// ID - READ-ONLY; Fully qualified resource ID of the queue.
+	ID *string `json:"id,omitempty"`
+
+	// Name - READ-ONLY; The name of the queue.
+	Name *string `json:"name,omitempty"`
+
+	*QueueProperties `json:"properties,omitempty"`
+}
+
+// QueueProperties are the properties of a Queue.
+type QueueProperties struct {
+	// Metadata - A name-value pair that represents queue metadata.
+	Metadata map[string]*string `json:"metadata"`
+
+	// ApproximateMessageCount - READ-ONLY; The approximate number of
+	// messages in the queue.
+	ApproximateMessageCount *int32 `json:"approximateMessageCount,omitempty"`
+}
+
+// QueueClientAPI manages the queues of a storage Account.
+type QueueClientAPI interface {
+	Create(ctx context.Context, resourceGroupName string, accountName string, queueName string, queue Queue) (Queue, error)
+	Get(ctx context.Context, resourceGroupName string, accountName string, queueName string) (Queue, error)
+	Update(ctx context.Context, resourceGroupName string, accountName string, queueName string, queue Queue) (Queue, error)
+	Delete(ctx context.Context, resourceGroupName string, accountName string, queueName string) (autorest.Response, error)
+}
+
+var _ QueueClientAPI = QueueClient{}
+
+// QueueClient manages the queues of a storage Account using the Azure
+// management API.
+type QueueClient struct {
+	servicesClient
+}
+
+// NewQueueClient returns a QueueClient for the supplied subscription.
+func NewQueueClient(subscriptionID string) QueueClient {
+	return QueueClient{servicesClient{BaseClient: storage.New(subscriptionID), name: "storage.QueueClient"}}
+}
+
+// Create creates the supplied queue.
+func (c QueueClient) Create(ctx context.Context, resourceGroupName string, accountName string, queueName string, queue Queue) (Queue, error) {
+	result := Queue{}
+	resp, err := c.send(ctx, "Create", http.MethodPut, resourceGroupName, accountName, queuePath, map[string]interface{}{"queueName": queueName}, newQueueBody(queue), &result)
+	result.Response = resp
+	return result, err
+}
+
+// Get gets the supplied queue.
+func (c QueueClient) Get(ctx context.Context, resourceGroupName string, accountName string, queueName string) (Queue, error) {
+	result := Queue{}
+	resp, err := c.send(ctx, "Get", http.MethodGet, resourceGroupName, accountName, queuePath, map[string]interface{}{"queueName": queueName}, nil, &result)
+	result.Response = resp
+	return result, err
+}
+
+// Update updates the supplied queue.
+func (c QueueClient) Update(ctx context.Context, resourceGroupName string, accountName string, queueName string, queue Queue) (Queue, error) {
+	result := Queue{}
+	resp, err := c.send(ctx, "Update", http.MethodPatch, resourceGroupName, accountName, queuePath, map[string]interface{}{"queueName": queueName}, newQueueBody(queue), &result)
+	result.Response = resp
+	return result, err
+}
+
+// Delete deletes the supplied queue.
+func (c QueueClient) Delete(ctx context.Context, resourceGroupName string, accountName string, queueName string) (autorest.Response, error) {
+	return c.send(ctx, "Delete", http.MethodDelete, resourceGroupName, accountName, queuePath, map[string]interface{}{"queueName": queueName}, nil, nil)
+}
+
+// newQueueBody omits the read-only fields of the supplied queue.
+func newQueueBody(q Queue) Queue {
+	body := Queue{QueueProperties: &QueueProperties{}}
+	if q.QueueProperties != nil {
+		body.Metadata = q.Metadata
+	}
+	return body
+}
+
+// NewQueue returns a queue suitable for use with the Azure API.
+func NewQueue(p v1beta1.QueueParameters) Queue {
+	return Queue{QueueProperties: &QueueProperties{Metadata: azure.ToStringPtrMap(p.Metadata)}}
+}
+
+// GenerateQueueObservation produces a QueueObservation from the queue
+// received from Azure.
+func GenerateQueueObservation(az Queue) v1beta1.QueueObservation {
+	o := v1beta1.QueueObservation{ID: azure.ToString(az.ID)}
+	if az.QueueProperties != nil {
+		o.ApproximateMessageCount = azure.ToInt(az.ApproximateMessageCount)
+	}
+	return o
+}
+
+// LateInitializeQueue fills the empty fields of the supplied QueueParameters
+// with the queue received from Azure.
+func LateInitializeQueue(p *v1beta1.QueueParameters, az Queue) {
+	if az.QueueProperties == nil {
+		return
+	}
+	p.Metadata = azure.LateInitializeStringMap(p.Metadata, az.Metadata)
+}
+
+// IsQueueUpToDate returns true if the queue received from Azure matches the
+// supplied QueueParameters.
+func IsQueueUpToDate(p v1beta1.QueueParameters, az Queue) bool {
+	var observed map[string]string
+	if az.QueueProperties != nil {
+		observed = azure.ToStringMap(az.Metadata)
+	}
+	return cmp.Equal(p.Metadata, observed, cmpopts.EquateEmpty())
+}
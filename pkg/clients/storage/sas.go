@@ -0,0 +1,184 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/Azure/go-autorest/autorest/date"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// ConnectionSecretKeySASToken is the connection secret key of a shared access
+// signature (SAS) token.
+const ConnectionSecretKeySASToken = "sasToken"
+
+// DefaultSASLifetime is the lifetime of a SAS whose lifetime is not
+// specified.
+const DefaultSASLifetime = 24 * time.Hour
+
+// SASExpiry returns the expiry time of a SAS with the supplied parameters
+// that is issued at the supplied time.
+func SASExpiry(p v1beta1.SharedAccessSignatureParameters, now time.Time) time.Time {
+	return now.Add(sasLifetime(p)).Truncate(time.Second)
+}
+
+// SASNeedsRenewal returns true if a new SAS with the supplied parameters,
+// whose issuing parameters have the supplied hash, should be issued at the
+// supplied time, given the status of the current SAS.
+func SASNeedsRenewal(p v1beta1.SharedAccessSignatureParameters, hash string, s *v1beta1.SharedAccessSignatureStatus, now time.Time) bool {
+	if s == nil || s.ExpiryTime == nil || s.ParametersHash != hash {
+		return true
+	}
+	renewBefore := sasLifetime(p) / 3
+	if p.RenewBefore != nil {
+		renewBefore = p.RenewBefore.Duration
+	}
+	return !now.Add(renewBefore).Before(s.ExpiryTime.Time)
+}
+
+// NewSASStatus returns the status of a SAS that expires at the supplied time,
+// and whose issuing parameters have the supplied hash.
+func NewSASStatus(expiry time.Time, hash string) *v1beta1.SharedAccessSignatureStatus {
+	return &v1beta1.SharedAccessSignatureStatus{ExpiryTime: &metav1.Time{Time: expiry}, ParametersHash: hash}
+}
+
+// ContainerSASHash returns a hash of the parameters with which the supplied
+// Container SAS is issued. Its lifetime is not among them.
+func ContainerSASHash(s v1beta1.ContainerSharedAccessSignature) string {
+	t := s.Type
+	if t == "" {
+		t = v1beta1.SASTypeService
+	}
+	return sasHash(t, s.Permissions, azure.ToString(s.IPRange))
+}
+
+// AccountSASHash returns a hash of the parameters with which the supplied
+// Account SAS is issued. Its lifetime is not among them.
+func AccountSASHash(s v1beta1.AccountSharedAccessSignature) string {
+	return sasHash(s.Services, s.ResourceTypes, s.Permissions, azure.ToString(s.IPRange))
+}
+
+func sasHash(params ...string) string {
+	h := sha256.New()
+	for _, p := range params {
+		// Quoting keeps the boundaries between parameters unambiguous.
+		fmt.Fprintf(h, "%q", p)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// NewContainerServiceSASParameters returns the parameters of a service SAS
+// for the named Container, suitable for use with the Azure API.
+func NewContainerServiceSASParameters(accountName, containerName string, p v1beta1.SharedAccessSignatureParameters, expiry time.Time) storage.ServiceSasParameters {
+	return storage.ServiceSasParameters{
+		CanonicalizedResource:  azure.ToStringPtr("/blob/" + accountName + "/" + containerName),
+		Resource:               storage.SignedResourceC,
+		Permissions:            storage.Permissions(p.Permissions),
+		IPAddressOrRange:       p.IPRange,
+		Protocols:              storage.HTTPS,
+		SharedAccessExpiryTime: &date.Time{Time: expiry},
+	}
+}
+
+// NewAccountSASParameters returns the parameters of an account SAS, suitable
+// for use with the Azure API.
+func NewAccountSASParameters(s v1beta1.AccountSharedAccessSignature, expiry time.Time) storage.AccountSasParameters {
+	return storage.AccountSasParameters{
+		Services:               storage.Services(s.Services),
+		ResourceTypes:          storage.SignedResourceTypes(s.ResourceTypes),
+		Permissions:            storage.Permissions(s.Permissions),
+		IPAddressOrRange:       s.IPRange,
+		Protocols:              storage.HTTPS,
+		SharedAccessExpiryTime: &date.Time{Time: expiry},
+	}
+}
+
+// A UserDelegationCredentialGetter gets a credential that can sign user
+// delegation SAS tokens that are valid between the supplied times.
+type UserDelegationCredentialGetter func(ctx context.Context, start, expiry time.Time) (azblob.UserDelegationCredential, error)
+
+// NewUserDelegationCredentialGetter returns a UserDelegationCredentialGetter
+// that uses the supplied credentials to obtain user delegation keys for the
+// named storage Account.
+func NewUserDelegationCredentialGetter(creds map[string]string, accountName string) UserDelegationCredentialGetter {
+	return func(ctx context.Context, start, expiry time.Time) (azblob.UserDelegationCredential, error) {
+		token, err := azure.GetToken(ctx, creds, azure.StorageResource)
+		if err != nil {
+			return azblob.UserDelegationCredential{}, err
+		}
+		p := azblob.NewPipeline(azblob.NewTokenCredential(token, nil), azblob.PipelineOptions{
+			Telemetry: azblob.TelemetryOptions{Value: azure.UserAgent},
+		})
+		u, _ := url.Parse(fmt.Sprintf(blobFormatString, accountName))
+		return azblob.NewServiceURL(*u, p).GetUserDelegationCredential(ctx, azblob.NewKeyInfo(start, expiry), nil, nil)
+	}
+}
+
+// NewContainerUserDelegationSAS returns a user delegation SAS token for the
+// named Container, signed with the supplied credential.
+func NewContainerUserDelegationSAS(cred azblob.UserDelegationCredential, containerName string, p v1beta1.SharedAccessSignatureParameters, start, expiry time.Time) (string, error) {
+	v := azblob.BlobSASSignatureValues{
+		Protocol:      azblob.SASProtocolHTTPS,
+		StartTime:     start,
+		ExpiryTime:    expiry,
+		Permissions:   p.Permissions,
+		IPRange:       parseIPRange(azure.ToString(p.IPRange)),
+		ContainerName: containerName,
+	}
+	q, err := v.NewSASQueryParameters(cred)
+	if err != nil {
+		return "", err
+	}
+	return q.Encode(), nil
+}
+
+// ContainerURL returns the URL of the named Container.
+func ContainerURL(accountName, containerName string) string {
+	return fmt.Sprintf(blobFormatString, accountName) + "/" + containerName
+}
+
+func sasLifetime(p v1beta1.SharedAccessSignatureParameters) time.Duration {
+	if p.Lifetime != nil {
+		return p.Lifetime.Duration
+	}
+	return DefaultSASLifetime
+}
+
+func parseIPRange(r string) azblob.IPRange {
+	if r == "" {
+		return azblob.IPRange{}
+	}
+	ips := strings.SplitN(r, "-", 2)
+	ipr := azblob.IPRange{Start: net.ParseIP(ips[0])}
+	if len(ips) == 2 {
+		ipr.End = net.ParseIP(ips[1])
+	}
+	return ipr
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+)
+
+func TestSASNeedsRenewal(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := map[string]struct {
+		p    v1beta1.SharedAccessSignatureParameters
+		hash string
+		s    *v1beta1.SharedAccessSignatureStatus
+		want bool
+	}{
+		"NeverIssued": {
+			s:    nil,
+			want: true,
+		},
+		"DefaultRenewBeforeNotDue": {
+			s:    NewSASStatus(now.Add(9*time.Hour), ""),
+			want: false,
+		},
+		"DefaultRenewBeforeDue": {
+			s:    NewSASStatus(now.Add(8*time.Hour), ""),
+			want: true,
+		},
+		"ParametersChanged": {
+			hash: "cool",
+			s:    NewSASStatus(now.Add(9*time.Hour), "cooler"),
+			want: true,
+		},
+		"ParametersUnchanged": {
+			hash: "cool",
+			s:    NewSASStatus(now.Add(9*time.Hour), "cool"),
+			want: false,
+		},
+		"Expired": {
+			s:    NewSASStatus(now.Add(-time.Hour), ""),
+			want: true,
+		},
+		"RenewBefore": {
+			p:    v1beta1.SharedAccessSignatureParameters{RenewBefore: &metav1.Duration{Duration: time.Hour}},
+			s:    NewSASStatus(now.Add(2*time.Hour), ""),
+			want: false,
+		},
+		"Lifetime": {
+			p:    v1beta1.SharedAccessSignatureParameters{Lifetime: &metav1.Duration{Duration: 3 * time.Hour}},
+			s:    NewSASStatus(now.Add(30*time.Minute), ""),
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SASNeedsRenewal(tc.p, tc.hash, tc.s, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SASNeedsRenewal(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSASHash(t *testing.T) {
+	ip := "168.1.5.60"
+	container := v1beta1.ContainerSharedAccessSignature{
+		SharedAccessSignatureParameters: v1beta1.SharedAccessSignatureParameters{Permissions: "rl"},
+	}
+	account := v1beta1.AccountSharedAccessSignature{
+		SharedAccessSignatureParameters: v1beta1.SharedAccessSignatureParameters{Permissions: "rl"},
+		Services:                        "b",
+		ResourceTypes:                   "co",
+	}
+
+	cases := map[string]struct {
+		a    string
+		b    string
+		want bool
+	}{
+		"ContainerDefaultType": {
+			a:    ContainerSASHash(container),
+			b:    ContainerSASHash(v1beta1.ContainerSharedAccessSignature{SharedAccessSignatureParameters: container.SharedAccessSignatureParameters, Type: v1beta1.SASTypeService}),
+			want: true,
+		},
+		"ContainerLifetime": {
+			a: ContainerSASHash(container),
+			b: ContainerSASHash(v1beta1.ContainerSharedAccessSignature{SharedAccessSignatureParameters: v1beta1.SharedAccessSignatureParameters{
+				Permissions: "rl",
+				Lifetime:    &metav1.Duration{Duration: time.Hour},
+				RenewBefore: &metav1.Duration{Duration: time.Minute},
+			}}),
+			want: true,
+		},
+		"ContainerType": {
+			a:    ContainerSASHash(container),
+			b:    ContainerSASHash(v1beta1.ContainerSharedAccessSignature{SharedAccessSignatureParameters: container.SharedAccessSignatureParameters, Type: v1beta1.SASTypeUserDelegation}),
+			want: false,
+		},
+		"ContainerPermissions": {
+			a:    ContainerSASHash(container),
+			b:    ContainerSASHash(v1beta1.ContainerSharedAccessSignature{SharedAccessSignatureParameters: v1beta1.SharedAccessSignatureParameters{Permissions: "rwl"}}),
+			want: false,
+		},
+		"ContainerIPRange": {
+			a:    ContainerSASHash(container),
+			b:    ContainerSASHash(v1beta1.ContainerSharedAccessSignature{SharedAccessSignatureParameters: v1beta1.SharedAccessSignatureParameters{Permissions: "rl", IPRange: &ip}}),
+			want: false,
+		},
+		"AccountServices": {
+			a:    AccountSASHash(account),
+			b:    AccountSASHash(v1beta1.AccountSharedAccessSignature{SharedAccessSignatureParameters: account.SharedAccessSignatureParameters, Services: "bq", ResourceTypes: "co"}),
+			want: false,
+		},
+		"AccountResourceTypes": {
+			a:    AccountSASHash(account),
+			b:    AccountSASHash(v1beta1.AccountSharedAccessSignature{SharedAccessSignatureParameters: account.SharedAccessSignatureParameters, Services: "b", ResourceTypes: "sco"}),
+			want: false,
+		},
+		"AccountParameterBoundaries": {
+			a:    AccountSASHash(account),
+			b:    AccountSASHash(v1beta1.AccountSharedAccessSignature{SharedAccessSignatureParameters: account.SharedAccessSignatureParameters, Services: "bc", ResourceTypes: "o"}),
+			want: false,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, tc.a == tc.b); diff != "" {
+				t.Errorf("-want equal, +got equal\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSASExpiry(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 500, time.UTC)
+
+	cases := map[string]struct {
+		p    v1beta1.SharedAccessSignatureParameters
+		want time.Time
+	}{
+		"DefaultLifetime": {
+			want: time.Date(2021, 1, 2, 12, 0, 0, 0, time.UTC),
+		},
+		"Lifetime": {
+			p:    v1beta1.SharedAccessSignatureParameters{Lifetime: &metav1.Duration{Duration: time.Hour}},
+			want: time.Date(2021, 1, 1, 13, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := SASExpiry(tc.p, now)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("SASExpiry(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestParseIPRange(t *testing.T) {
+	cases := map[string]struct {
+		r    string
+		want azblob.IPRange
+	}{
+		"Empty":    {r: "", want: azblob.IPRange{}},
+		"SingleIP": {r: "168.1.5.60", want: azblob.IPRange{Start: net.ParseIP("168.1.5.60")}},
+		"Range":    {r: "168.1.5.60-168.1.5.70", want: azblob.IPRange{Start: net.ParseIP("168.1.5.60"), End: net.ParseIP("168.1.5.70")}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := parseIPRange(tc.r)
+			if diff := cmp.Diff(tc.want.String(), got.String()); diff != "" {
+				t.Errorf("parseIPRange(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+)
+
+// ServicesAPIVersion is the version of the Azure storage management API used
+// to manage queues, tables, management policies and blob service properties.
+// The vendored Azure SDK predates management API support for queues and
+// tables, for the actions of management policies on blob versions, and for
+// last access time tracking, so their clients are implemented here.
+const ServicesAPIVersion = "2021-09-01"
+
+// A servicesClient sends requests for the resources of a storage Account that
+// the vendored Azure SDK does not support to the Azure management API.
+type servicesClient struct {
+	storage.BaseClient
+	name string
+}
+
+// send sends a request with the supplied method and optional JSON body to the
+// supplied path, which is relative to the storage Account. The response body
+// is unmarshalled into result, if result is not nil. Like the Azure SDK,
+// errors are returned as an autorest.DetailedError.
+func (c servicesClient) send(ctx context.Context, op, method, resourceGroupName, accountName, path string, pathParameters map[string]interface{}, body, result interface{}) (autorest.Response, error) {
+	params := map[string]interface{}{
+		"subscriptionId":    autorest.Encode("path", c.SubscriptionID),
+		"resourceGroupName": autorest.Encode("path", resourceGroupName),
+		"accountName":       autorest.Encode("path", accountName),
+	}
+	for k, v := range pathParameters {
+		params[k] = autorest.Encode("path", v)
+	}
+
+	decorators := []autorest.PrepareDecorator{
+		autorest.WithMethod(method),
+		autorest.WithBaseURL(c.BaseURI),
+		autorest.WithPathParameters("/subscriptions/{subscriptionId}/resourceGroups/{resourceGroupName}/providers/Microsoft.Storage/storageAccounts/{accountName}"+path, params),
+		autorest.WithQueryParameters(map[string]interface{}{"api-version": ServicesAPIVersion}),
+	}
+	if body != nil {
+		decorators = append(decorators, autorest.AsContentType("application/json; charset=utf-8"), autorest.WithJSON(body))
+	}
+	req, err := autorest.Prepare((&http.Request{}).WithContext(ctx), decorators...)
+	if err != nil {
+		return autorest.Response{}, autorest.NewErrorWithError(err, c.name, op, nil, "Failure preparing request")
+	}
+
+	resp, err := c.Send(req, autorestazure.DoRetryWithRegistration(c.Client))
+	if err != nil {
+		return autorest.Response{Response: resp}, autorest.NewErrorWithError(err, c.name, op, resp, "Failure sending request")
+	}
+
+	responders := []autorest.RespondDecorator{c.ByInspecting(), autorestazure.WithErrorUnlessStatusCode(http.StatusOK, http.StatusNoContent)}
+	if result != nil {
+		responders = append(responders, autorest.ByUnmarshallingJSON(result))
+	}
+	responders = append(responders, autorest.ByClosing())
+	if err := autorest.Respond(resp, responders...); err != nil {
+		return autorest.Response{Response: resp}, autorest.NewErrorWithError(err, c.name, op, resp, "Failure responding to request")
+	}
+	return autorest.Response{Response: resp}, nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/google/go-cmp/cmp"
+
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestQueueClient(t *testing.T) {
+	path := "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount/queueServices/default/queues/coolqueue"
+
+	type want struct {
+		method   string
+		body     string
+		q        Queue
+		notFound bool
+	}
+
+	cases := map[string]struct {
+		status int
+		resp   string
+		call   func(c QueueClient) (Queue, error)
+		want   want
+	}{
+		"Create": {
+			status: http.StatusOK,
+			resp:   `{"id":"` + path + `","properties":{"metadata":{"team":"cool"}}}`,
+			call: func(c QueueClient) (Queue, error) {
+				return c.Create(context.Background(), "coolgroup", "coolaccount", "coolqueue", Queue{
+					QueueProperties: &QueueProperties{
+						Metadata:                map[string]*string{"team": azure.ToStringPtr("cool")},
+						ApproximateMessageCount: azure.ToInt32Ptr(3),
+					},
+				})
+			},
+			want: want{
+				method: http.MethodPut,
+				body:   `{"properties":{"metadata":{"team":"cool"}}}`,
+				q: Queue{
+					ID:              azure.ToStringPtr(path),
+					QueueProperties: &QueueProperties{Metadata: map[string]*string{"team": azure.ToStringPtr("cool")}},
+				},
+			},
+		},
+		"GetNotFound": {
+			status: http.StatusNotFound,
+			resp:   `{"error":{"code":"QueueNotFound","message":"The specified queue does not exist."}}`,
+			call: func(c QueueClient) (Queue, error) {
+				return c.Get(context.Background(), "coolgroup", "coolaccount", "coolqueue")
+			},
+			want: want{
+				method:   http.MethodGet,
+				notFound: true,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var method, body string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path != path || r.URL.Query().Get("api-version") != ServicesAPIVersion {
+					t.Errorf("unexpected request %s", r.URL)
+				}
+				method = r.Method
+				b, _ := ioutil.ReadAll(r.Body)
+				body = string(b)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tc.status)
+				_, _ = w.Write([]byte(tc.resp))
+			}))
+			defer srv.Close()
+
+			c := QueueClient{servicesClient{BaseClient: storage.NewWithBaseURI(srv.URL, "cool"), name: "storage.QueueClient"}}
+			q, err := tc.call(c)
+
+			if diff := cmp.Diff(tc.want.notFound, azure.IsNotFound(err)); diff != "" {
+				t.Errorf("IsNotFound(...): -want, +got\n%s", diff)
+			}
+			if !tc.want.notFound && err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if diff := cmp.Diff(tc.want.method, method); diff != "" {
+				t.Errorf("method: -want, +got\n%s", diff)
+			}
+			if tc.want.body != "" {
+				var w, g interface{}
+				_ = json.Unmarshal([]byte(tc.want.body), &w)
+				_ = json.Unmarshal([]byte(body), &g)
+				if diff := cmp.Diff(w, g); diff != "" {
+					t.Errorf("body: -want, +got\n%s", diff)
+				}
+			}
+			q.Response = tc.want.q.Response
+			if diff := cmp.Diff(tc.want.q, q); diff != "" {
+				t.Errorf("queue: -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestManagementPolicyClient(t *testing.T) {
+	path := "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount/managementPolicies/default"
+	rules := `{"rules":[{"name":"versions","type":"Lifecycle","definition":{"actions":{"version":{"delete":{"daysAfterCreationGreaterThan":90}}}}}]}`
+
+	var method, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path || r.URL.Query().Get("api-version") != ServicesAPIVersion {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		method = r.Method
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"` + path + `","properties":{"policy":` + rules + `}}`))
+	}))
+	defer srv.Close()
+
+	c := ManagementPolicyClient{servicesClient{BaseClient: storage.NewWithBaseURI(srv.URL, "cool"), name: "storage.ManagementPolicyClient"}}
+	p := ManagementPolicy{
+		ID: azure.ToStringPtr(path),
+		ManagementPolicyProperties: &ManagementPolicyProperties{
+			Policy: &ManagementPolicySchema{Rules: &[]ManagementPolicyRule{{
+				Name: azure.ToStringPtr("versions"),
+				Type: azure.ToStringPtr(ManagementPolicyRuleType),
+				Definition: &ManagementPolicyDefinition{
+					Actions: &ManagementPolicyAction{
+						Version: &ManagementPolicyVersion{
+							Delete: &storage.DateAfterCreation{DaysAfterCreationGreaterThan: float64Ptr(90)},
+						},
+					},
+				},
+			}}},
+		},
+	}
+	got, err := c.CreateOrUpdate(context.Background(), "coolgroup", "coolaccount", p)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(http.MethodPut, method); diff != "" {
+		t.Errorf("method: -want, +got\n%s", diff)
+	}
+	var w, g interface{}
+	_ = json.Unmarshal([]byte(`{"properties":{"policy":`+rules+`}}`), &w)
+	_ = json.Unmarshal([]byte(body), &g)
+	if diff := cmp.Diff(w, g); diff != "" {
+		t.Errorf("body: -want, +got\n%s", diff)
+	}
+	got.Response = p.Response
+	if diff := cmp.Diff(p, got); diff != "" {
+		t.Errorf("policy: -want, +got\n%s", diff)
+	}
+}
+
+func TestBlobServiceClient(t *testing.T) {
+	path := "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount/blobServices/default"
+	props := `{"isVersioningEnabled":true,"lastAccessTimeTrackingPolicy":{"enable":true,"name":"AccessTimeTracking"}}`
+
+	var method, body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path || r.URL.Query().Get("api-version") != ServicesAPIVersion {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		method = r.Method
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"id":"` + path + `","properties":` + props + `}`))
+	}))
+	defer srv.Close()
+
+	c := BlobServiceClient{servicesClient{BaseClient: storage.NewWithBaseURI(srv.URL, "cool"), name: "storage.BlobServiceClient"}}
+	p := BlobServiceProperties{
+		ID: azure.ToStringPtr(path),
+		BlobServicePropertiesProperties: &BlobServicePropertiesProperties{
+			BlobServicePropertiesProperties: storage.BlobServicePropertiesProperties{IsVersioningEnabled: azure.ToBoolPtr(true)},
+			LastAccessTimeTrackingPolicy: &LastAccessTimeTrackingPolicy{
+				Enable: azure.ToBoolPtr(true),
+				Name:   azure.ToStringPtr(LastAccessTimeTrackingPolicyName),
+			},
+		},
+	}
+	got, err := c.SetServiceProperties(context.Background(), "coolgroup", "coolaccount", p)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(http.MethodPut, method); diff != "" {
+		t.Errorf("method: -want, +got\n%s", diff)
+	}
+	var w, g interface{}
+	_ = json.Unmarshal([]byte(`{"properties":`+props+`}`), &w)
+	_ = json.Unmarshal([]byte(body), &g)
+	if diff := cmp.Diff(w, g); diff != "" {
+		t.Errorf("body: -want, +got\n%s", diff)
+	}
+	got.Response = p.Response
+	if diff := cmp.Diff(p, got); diff != "" {
+		t.Errorf("properties: -want, +got\n%s", diff)
+	}
+}
@@ -0,0 +1,95 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// ConnectionSecretKeyWebEndpoint is the connection secret key of the endpoint
+// from which an Account serves its static website.
+const ConnectionSecretKeyWebEndpoint = "webEndpoint"
+
+// ServicePropertiesAPI gets and sets the properties of the blob service of a
+// storage Account. It is satisfied by azblob.ServiceURL.
+type ServicePropertiesAPI interface {
+	GetProperties(ctx context.Context) (*azblob.StorageServiceProperties, error)
+	SetProperties(ctx context.Context, properties azblob.StorageServiceProperties) (*azblob.ServiceSetPropertiesResponse, error)
+}
+
+var _ ServicePropertiesAPI = azblob.ServiceURL{}
+
+// NewServicePropertiesClient returns a ServicePropertiesAPI for the blob
+// service of the supplied storage Account.
+func NewServicePropertiesClient(accountName, accountKey string) (ServicePropertiesAPI, error) {
+	c, err := azblob.NewSharedKeyCredential(accountName, accountKey)
+	if err != nil {
+		return nil, err
+	}
+
+	p := azblob.NewPipeline(c, azblob.PipelineOptions{
+		Telemetry: azblob.TelemetryOptions{Value: azure.UserAgent},
+	})
+
+	u, _ := url.Parse(fmt.Sprintf(blobFormatString, accountName))
+	return azblob.NewServiceURL(*u, p), nil
+}
+
+// NewStaticWebsiteProperties returns blob service properties that configure
+// the supplied static website. Blob service properties that are omitted are
+// not changed by Azure.
+func NewStaticWebsiteProperties(w v1beta1.StaticWebsite) azblob.StorageServiceProperties {
+	return azblob.StorageServiceProperties{StaticWebsite: &azblob.StaticWebsite{
+		Enabled:              w.Enabled,
+		IndexDocument:        w.IndexDocument,
+		ErrorDocument404Path: w.ErrorDocument404Path,
+	}}
+}
+
+// LateInitializeStaticWebsite fills the empty fields of the supplied
+// StaticWebsite with the static website received from Azure.
+func LateInitializeStaticWebsite(w *v1beta1.StaticWebsite, az *azblob.StaticWebsite) {
+	if w == nil || az == nil {
+		return
+	}
+	w.IndexDocument = azure.LateInitializeStringPtrFromPtr(w.IndexDocument, az.IndexDocument)
+	w.ErrorDocument404Path = azure.LateInitializeStringPtrFromPtr(w.ErrorDocument404Path, az.ErrorDocument404Path)
+}
+
+// IsStaticWebsiteUpToDate returns true if the supplied static website
+// received from Azure matches the supplied StaticWebsite. The documents of a
+// disabled static website are not considered.
+func IsStaticWebsiteUpToDate(w v1beta1.StaticWebsite, az *azblob.StaticWebsite) bool {
+	if az == nil {
+		return !w.Enabled
+	}
+	if w.Enabled != az.Enabled {
+		return false
+	}
+	if !w.Enabled {
+		return true
+	}
+	return azure.ToString(w.IndexDocument) == azure.ToString(az.IndexDocument) &&
+		azure.ToString(w.ErrorDocument404Path) == azure.ToString(az.ErrorDocument404Path)
+}
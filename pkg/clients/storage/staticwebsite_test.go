@@ -0,0 +1,76 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestIsStaticWebsiteUpToDate(t *testing.T) {
+	cases := map[string]struct {
+		w    v1beta1.StaticWebsite
+		az   *azblob.StaticWebsite
+		want bool
+	}{
+		"NotObservedDisabled": {
+			w:    v1beta1.StaticWebsite{},
+			want: true,
+		},
+		"NotObservedEnabled": {
+			w:    v1beta1.StaticWebsite{Enabled: true},
+			want: false,
+		},
+		"DisabledWithDocuments": {
+			w:    v1beta1.StaticWebsite{IndexDocument: azure.ToStringPtr("index.html")},
+			az:   &azblob.StaticWebsite{},
+			want: true,
+		},
+		"IndexDocumentChanged": {
+			w:    v1beta1.StaticWebsite{Enabled: true, IndexDocument: azure.ToStringPtr("index.html")},
+			az:   &azblob.StaticWebsite{Enabled: true, IndexDocument: azure.ToStringPtr("default.html")},
+			want: false,
+		},
+		"UpToDate": {
+			w: v1beta1.StaticWebsite{
+				Enabled:              true,
+				IndexDocument:        azure.ToStringPtr("index.html"),
+				ErrorDocument404Path: azure.ToStringPtr("404.html"),
+			},
+			az: &azblob.StaticWebsite{
+				Enabled:              true,
+				IndexDocument:        azure.ToStringPtr("index.html"),
+				ErrorDocument404Path: azure.ToStringPtr("404.html"),
+			},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsStaticWebsiteUpToDate(tc.w, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsStaticWebsiteUpToDate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
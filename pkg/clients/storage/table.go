@@ -0,0 +1,200 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+const tablePath = "/tableServices/default/tables/{tableName}"
+
+// A Table of a storage Account, as represented by the Azure management API.
+type Table struct {
+	autorest.Response `json:"-"`
+
+	// ID - READ-ONLY; Fully qualified resource ID of the table.
+	ID *string `json:"id,omitempty"`
+
+	// Name - READ-ONLY; The name of the table.
+	Name *string `json:"name,omitempty"`
+
+	*TableProperties `json:"properties,omitempty"`
+}
+
+// TableProperties are the properties of a Table.
+type TableProperties struct {
+	// TableName - READ-ONLY; The name of the table.
+	TableName *string `json:"tableName,omitempty"`
+
+	// SignedIdentifiers - The stored access policies of the table.
+	SignedIdentifiers *[]TableSignedIdentifier `json:"signedIdentifiers,omitempty"`
+}
+
+// A TableSignedIdentifier is a stored access policy of a Table.
+type TableSignedIdentifier struct {
+	// ID - The unique ID of the policy.
+	ID *string `json:"id,omitempty"`
+
+	// AccessPolicy - The access policy.
+	AccessPolicy *TableAccessPolicy `json:"accessPolicy,omitempty"`
+}
+
+// A TableAccessPolicy grants access to a Table.
+type TableAccessPolicy struct {
+	// StartTime - The time at which the policy becomes valid.
+	StartTime *date.Time `json:"startTime,omitempty"`
+
+	// ExpiryTime - The time at which the policy ceases to be valid.
+	ExpiryTime *date.Time `json:"expiryTime,omitempty"`
+
+	// Permission - The permissions granted by the policy.
+	Permission *string `json:"permission,omitempty"`
+}
+
+// TableClientAPI manages the tables of a storage Account.
+type TableClientAPI interface {
+	Create(ctx context.Context, resourceGroupName string, accountName string, tableName string, table Table) (Table, error)
+	Get(ctx context.Context, resourceGroupName string, accountName string, tableName string) (Table, error)
+	Update(ctx context.Context, resourceGroupName string, accountName string, tableName string, table Table) (Table, error)
+	Delete(ctx context.Context, resourceGroupName string, accountName string, tableName string) (autorest.Response, error)
+}
+
+var _ TableClientAPI = TableClient{}
+
+// TableClient manages the tables of a storage Account using the Azure
+// management API.
+type TableClient struct {
+	servicesClient
+}
+
+// NewTableClient returns a TableClient for the supplied subscription.
+func NewTableClient(subscriptionID string) TableClient {
+	return TableClient{servicesClient{BaseClient: storage.New(subscriptionID), name: "storage.TableClient"}}
+}
+
+// Create creates the supplied table.
+func (c TableClient) Create(ctx context.Context, resourceGroupName string, accountName string, tableName string, table Table) (Table, error) {
+	result := Table{}
+	resp, err := c.send(ctx, "Create", http.MethodPut, resourceGroupName, accountName, tablePath, map[string]interface{}{"tableName": tableName}, newTableBody(table), &result)
+	result.Response = resp
+	return result, err
+}
+
+// Get gets the supplied table.
+func (c TableClient) Get(ctx context.Context, resourceGroupName string, accountName string, tableName string) (Table, error) {
+	result := Table{}
+	resp, err := c.send(ctx, "Get", http.MethodGet, resourceGroupName, accountName, tablePath, map[string]interface{}{"tableName": tableName}, nil, &result)
+	result.Response = resp
+	return result, err
+}
+
+// Update updates the supplied table.
+func (c TableClient) Update(ctx context.Context, resourceGroupName string, accountName string, tableName string, table Table) (Table, error) {
+	result := Table{}
+	resp, err := c.send(ctx, "Update", http.MethodPatch, resourceGroupName, accountName, tablePath, map[string]interface{}{"tableName": tableName}, newTableBody(table), &result)
+	result.Response = resp
+	return result, err
+}
+
+// Delete deletes the supplied table.
+func (c TableClient) Delete(ctx context.Context, resourceGroupName string, accountName string, tableName string) (autorest.Response, error) {
+	return c.send(ctx, "Delete", http.MethodDelete, resourceGroupName, accountName, tablePath, map[string]interface{}{"tableName": tableName}, nil, nil)
+}
+
+// newTableBody omits the read-only fields of the supplied table. An empty
+// list of signed identifiers is sent explicitly, so that it clears the stored
+// access policies of the table.
+func newTableBody(t Table) Table {
+	ids := []TableSignedIdentifier{}
+	if t.TableProperties != nil && t.SignedIdentifiers != nil {
+		ids = *t.SignedIdentifiers
+	}
+	return Table{TableProperties: &TableProperties{SignedIdentifiers: &ids}}
+}
+
+// NewTable returns a table suitable for use with the Azure API.
+func NewTable(p v1beta1.TableParameters) Table {
+	ids := make([]TableSignedIdentifier, len(p.SignedIdentifiers))
+	for i, si := range p.SignedIdentifiers {
+		ids[i] = TableSignedIdentifier{ID: azure.ToStringPtr(si.ID)}
+		if ap := si.AccessPolicy; ap != nil {
+			ids[i].AccessPolicy = &TableAccessPolicy{
+				StartTime:  toDateTime(ap.StartTime),
+				ExpiryTime: toDateTime(ap.ExpiryTime),
+				Permission: azure.ToStringPtr(ap.Permission),
+			}
+		}
+	}
+	return Table{TableProperties: &TableProperties{SignedIdentifiers: &ids}}
+}
+
+// GenerateTableObservation produces a TableObservation from the table
+// received from Azure.
+func GenerateTableObservation(az Table) v1beta1.TableObservation {
+	o := v1beta1.TableObservation{ID: azure.ToString(az.ID)}
+	if az.TableProperties != nil {
+		o.TableName = azure.ToString(az.TableName)
+	}
+	return o
+}
+
+// LateInitializeTable fills the empty fields of the supplied TableParameters
+// with the table received from Azure.
+func LateInitializeTable(p *v1beta1.TableParameters, az Table) {
+	if p.SignedIdentifiers == nil {
+		p.SignedIdentifiers = generateTableSignedIdentifiers(az)
+	}
+}
+
+// IsTableUpToDate returns true if the table received from Azure matches the
+// supplied TableParameters. The order of signed identifiers is not
+// considered.
+func IsTableUpToDate(p v1beta1.TableParameters, az Table) bool {
+	return cmp.Equal(p.SignedIdentifiers, generateTableSignedIdentifiers(az),
+		cmpopts.EquateEmpty(),
+		cmpopts.SortSlices(func(a, b v1beta1.TableSignedIdentifier) bool { return a.ID < b.ID }),
+		cmp.Comparer(func(a, b metav1.Time) bool { return a.Equal(&b) }))
+}
+
+func generateTableSignedIdentifiers(az Table) []v1beta1.TableSignedIdentifier {
+	if az.TableProperties == nil || az.SignedIdentifiers == nil {
+		return nil
+	}
+	ids := make([]v1beta1.TableSignedIdentifier, len(*az.SignedIdentifiers))
+	for i, si := range *az.SignedIdentifiers {
+		ids[i] = v1beta1.TableSignedIdentifier{ID: azure.ToString(si.ID)}
+		if ap := si.AccessPolicy; ap != nil {
+			ids[i].AccessPolicy = &v1beta1.TableAccessPolicy{
+				StartTime:  toMetaTime(ap.StartTime),
+				ExpiryTime: toMetaTime(ap.ExpiryTime),
+				Permission: azure.ToString(ap.Permission),
+			}
+		}
+	}
+	return ids
+}
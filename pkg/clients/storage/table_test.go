@@ -0,0 +1,100 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest/date"
+	"github.com/google/go-cmp/cmp"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+func TestIsTableUpToDate(t *testing.T) {
+	expiry := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	az := Table{TableProperties: &TableProperties{SignedIdentifiers: &[]TableSignedIdentifier{
+		{ID: azure.ToStringPtr("readers"), AccessPolicy: &TableAccessPolicy{ExpiryTime: &date.Time{Time: expiry}, Permission: azure.ToStringPtr("r")}},
+		{ID: azure.ToStringPtr("writers"), AccessPolicy: &TableAccessPolicy{Permission: azure.ToStringPtr("au")}},
+	}}}
+	readers := v1beta1.TableSignedIdentifier{ID: "readers", AccessPolicy: &v1beta1.TableAccessPolicy{ExpiryTime: &metav1.Time{Time: expiry.In(time.Local)}, Permission: "r"}}
+	writers := v1beta1.TableSignedIdentifier{ID: "writers", AccessPolicy: &v1beta1.TableAccessPolicy{Permission: "au"}}
+
+	cases := map[string]struct {
+		p    v1beta1.TableParameters
+		az   Table
+		want bool
+	}{
+		"UpToDate": {
+			p:    v1beta1.TableParameters{SignedIdentifiers: []v1beta1.TableSignedIdentifier{readers, writers}},
+			az:   az,
+			want: true,
+		},
+		"DifferentOrder": {
+			p:    v1beta1.TableParameters{SignedIdentifiers: []v1beta1.TableSignedIdentifier{writers, readers}},
+			az:   az,
+			want: true,
+		},
+		"PolicyRemoved": {
+			p:    v1beta1.TableParameters{SignedIdentifiers: []v1beta1.TableSignedIdentifier{readers}},
+			az:   az,
+			want: false,
+		},
+		"NoPolicies": {
+			p:    v1beta1.TableParameters{SignedIdentifiers: []v1beta1.TableSignedIdentifier{}},
+			az:   Table{TableProperties: &TableProperties{}},
+			want: true,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := IsTableUpToDate(tc.p, tc.az)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("IsTableUpToDate(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNewTableBody(t *testing.T) {
+	cases := map[string]struct {
+		t    Table
+		want Table
+	}{
+		"ReadOnlyFieldsOmitted": {
+			t:    Table{ID: azure.ToStringPtr("id"), TableProperties: &TableProperties{TableName: azure.ToStringPtr("cooltable")}},
+			want: Table{TableProperties: &TableProperties{SignedIdentifiers: &[]TableSignedIdentifier{}}},
+		},
+		"SignedIdentifiersKept": {
+			t:    NewTable(v1beta1.TableParameters{SignedIdentifiers: []v1beta1.TableSignedIdentifier{{ID: "readers"}}}),
+			want: Table{TableProperties: &TableProperties{SignedIdentifiers: &[]TableSignedIdentifier{{ID: azure.ToStringPtr("readers")}}}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := newTableBody(tc.t)
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Errorf("newTableBody(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -21,9 +21,18 @@ import (
 
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 
+	cachev1beta1 "github.com/crossplane/provider-azure/apis/cache/v1beta1"
+	computev1alpha3 "github.com/crossplane/provider-azure/apis/compute/v1alpha3"
+	databasev1alpha3 "github.com/crossplane/provider-azure/apis/database/v1alpha3"
+	databasev1beta1 "github.com/crossplane/provider-azure/apis/database/v1beta1"
+	keyvaultv1alpha1 "github.com/crossplane/provider-azure/apis/keyvault/v1alpha1"
+	networkv1alpha3 "github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	storagev1beta1 "github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	"github.com/crossplane/provider-azure/apis/v1alpha3"
 	"github.com/crossplane/provider-azure/pkg/controller/cache"
 	"github.com/crossplane/provider-azure/pkg/controller/compute"
 	"github.com/crossplane/provider-azure/pkg/controller/config"
@@ -35,36 +44,213 @@ import (
 	"github.com/crossplane/provider-azure/pkg/controller/database/postgresqlserverconfiguration"
 	"github.com/crossplane/provider-azure/pkg/controller/database/postgresqlserverfirewallrule"
 	"github.com/crossplane/provider-azure/pkg/controller/database/postgresqlservervirtualnetworkrule"
+	"github.com/crossplane/provider-azure/pkg/controller/keyvault/key"
 	"github.com/crossplane/provider-azure/pkg/controller/keyvault/secret"
+	"github.com/crossplane/provider-azure/pkg/controller/network/natgateway"
+	"github.com/crossplane/provider-azure/pkg/controller/network/publicipaddress"
+	"github.com/crossplane/provider-azure/pkg/controller/network/publicipprefix"
+	"github.com/crossplane/provider-azure/pkg/controller/network/route"
+	"github.com/crossplane/provider-azure/pkg/controller/network/routetable"
+	"github.com/crossplane/provider-azure/pkg/controller/network/securitygroup"
+	"github.com/crossplane/provider-azure/pkg/controller/network/securityrule"
 	"github.com/crossplane/provider-azure/pkg/controller/network/subnet"
 	"github.com/crossplane/provider-azure/pkg/controller/network/virtualnetwork"
+	"github.com/crossplane/provider-azure/pkg/controller/network/virtualnetworkpeering"
 	"github.com/crossplane/provider-azure/pkg/controller/resourcegroup"
 	"github.com/crossplane/provider-azure/pkg/controller/storage/account"
+	"github.com/crossplane/provider-azure/pkg/controller/storage/blobservice"
 	"github.com/crossplane/provider-azure/pkg/controller/storage/container"
+	"github.com/crossplane/provider-azure/pkg/controller/storage/fileshare"
+	"github.com/crossplane/provider-azure/pkg/controller/storage/filesystem"
+	"github.com/crossplane/provider-azure/pkg/controller/storage/managementpolicy"
+	"github.com/crossplane/provider-azure/pkg/controller/storage/objectreplicationpolicy"
+	"github.com/crossplane/provider-azure/pkg/controller/storage/path"
+	"github.com/crossplane/provider-azure/pkg/controller/storage/queue"
+	"github.com/crossplane/provider-azure/pkg/controller/storage/table"
 )
 
+// DefaultMaxConcurrentReconciles is the number of concurrent reconciles each
+// controller runs unless configured otherwise.
+const DefaultMaxConcurrentReconciles = 1
+
+// A Group of controllers that may be enabled or disabled together.
+type Group string
+
+// Controller groups.
+const (
+	GroupResourceGroup Group = "resourcegroup"
+	GroupCache         Group = "cache"
+	GroupCompute       Group = "compute"
+	GroupDatabase      Group = "database"
+	GroupNetwork       Group = "network"
+	GroupStorage       Group = "storage"
+	GroupKeyVault      Group = "keyvault"
+)
+
+// Groups returns all controller groups, in the order they are set up.
+func Groups() []Group {
+	return []Group{
+		GroupCache,
+		GroupCompute,
+		GroupDatabase,
+		GroupNetwork,
+		GroupResourceGroup,
+		GroupStorage,
+		GroupKeyVault,
+	}
+}
+
+type setupFn func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, time.Duration, int) error
+
+// A group of controllers and the managed resource kinds they reconcile.
+type group struct {
+	setup []setupFn
+	kinds []client.Object
+}
+
+var groups = map[Group]group{
+	GroupCache: {
+		setup: []setupFn{cache.SetupRedis},
+		kinds: []client.Object{&cachev1beta1.Redis{}},
+	},
+	GroupCompute: {
+		setup: []setupFn{compute.SetupAKSCluster},
+		kinds: []client.Object{&computev1alpha3.AKSCluster{}},
+	},
+	GroupDatabase: {
+		setup: []setupFn{
+			mysqlserver.Setup,
+			mysqlserverfirewallrule.Setup,
+			mysqlservervirtualnetworkrule.Setup,
+			postgresqlserver.Setup,
+			postgresqlserverfirewallrule.Setup,
+			postgresqlservervirtualnetworkrule.Setup,
+			postgresqlserverconfiguration.Setup,
+			cosmosdb.Setup,
+		},
+		kinds: []client.Object{
+			&databasev1beta1.MySQLServer{},
+			&databasev1alpha3.MySQLServerFirewallRule{},
+			&databasev1alpha3.MySQLServerVirtualNetworkRule{},
+			&databasev1beta1.PostgreSQLServer{},
+			&databasev1alpha3.PostgreSQLServerFirewallRule{},
+			&databasev1alpha3.PostgreSQLServerVirtualNetworkRule{},
+			&databasev1beta1.PostgreSQLServerConfiguration{},
+			&databasev1alpha3.CosmosDBAccount{},
+		},
+	},
+	GroupNetwork: {
+		setup: []setupFn{
+			virtualnetwork.Setup,
+			subnet.Setup,
+			securitygroup.Setup,
+			securityrule.Setup,
+			routetable.Setup,
+			route.Setup,
+			virtualnetworkpeering.Setup,
+			publicipprefix.Setup,
+			publicipaddress.Setup,
+			natgateway.Setup,
+		},
+		kinds: []client.Object{
+			&networkv1alpha3.VirtualNetwork{},
+			&networkv1alpha3.Subnet{},
+			&networkv1alpha3.SecurityGroup{},
+			&networkv1alpha3.SecurityRule{},
+			&networkv1alpha3.RouteTable{},
+			&networkv1alpha3.Route{},
+			&networkv1alpha3.VirtualNetworkPeering{},
+			&networkv1alpha3.PublicIPPrefix{},
+			&networkv1alpha3.PublicIPAddress{},
+			&networkv1alpha3.NATGateway{},
+		},
+	},
+	GroupResourceGroup: {
+		setup: []setupFn{resourcegroup.Setup},
+		kinds: []client.Object{&v1alpha3.ResourceGroup{}},
+	},
+	GroupStorage: {
+		setup: []setupFn{
+			account.Setup,
+			container.Setup,
+			managementpolicy.Setup,
+			blobservice.Setup,
+			fileshare.Setup,
+			queue.Setup,
+			table.Setup,
+			filesystem.Setup,
+			path.Setup,
+			objectreplicationpolicy.Setup,
+		},
+		kinds: []client.Object{
+			&storagev1beta1.Account{},
+			&storagev1beta1.Container{},
+			&storagev1beta1.ManagementPolicy{},
+			&storagev1beta1.BlobService{},
+			&storagev1beta1.FileShare{},
+			&storagev1beta1.Queue{},
+			&storagev1beta1.Table{},
+			&storagev1beta1.Filesystem{},
+			&storagev1beta1.Path{},
+			&storagev1beta1.ObjectReplicationPolicy{},
+		},
+	},
+	GroupKeyVault: {
+		setup: []setupFn{secret.SetupSecret, key.Setup},
+		kinds: []client.Object{&keyvaultv1alpha1.KeyVaultSecret{}, &keyvaultv1alpha1.KeyVaultKey{}},
+	},
+}
+
+// Options configure which Azure controllers are set up, and how.
+type Options struct {
+	// Disabled groups of controllers. Controllers in a disabled group are not
+	// set up, and thus do not watch the kinds they would otherwise reconcile.
+	Disabled map[Group]bool
+
+	// MaxConcurrentReconciles for the controllers in each group. Groups that
+	// are not configured use DefaultMaxConcurrentReconciles.
+	MaxConcurrentReconciles map[Group]int
+}
+
+// Enabled returns true if the supplied group of controllers is enabled.
+func (o Options) Enabled(g Group) bool {
+	return !o.Disabled[g]
+}
+
+// concurrency returns the maximum number of concurrent reconciles for the
+// supplied group of controllers.
+func (o Options) concurrency(g Group) int {
+	if n, ok := o.MaxConcurrentReconciles[g]; ok && n > 0 {
+		return n
+	}
+	return DefaultMaxConcurrentReconciles
+}
+
+// DisabledKinds returns the managed resource kinds reconciled by disabled
+// groups of controllers. Reads of these kinds, for example when resolving a
+// cross resource reference, should bypass the cache so that no watch is
+// started for them.
+func (o Options) DisabledKinds() []client.Object {
+	var kinds []client.Object
+	for _, g := range Groups() {
+		if !o.Enabled(g) {
+			kinds = append(kinds, groups[g].kinds...)
+		}
+	}
+	return kinds
+}
+
 // Setup Azure controllers.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
-	for _, setup := range []func(ctrl.Manager, logging.Logger, workqueue.RateLimiter, time.Duration) error{
-		cache.SetupRedis,
-		compute.SetupAKSCluster,
-		mysqlserver.Setup,
-		mysqlserverfirewallrule.Setup,
-		mysqlservervirtualnetworkrule.Setup,
-		postgresqlserver.Setup,
-		postgresqlserverfirewallrule.Setup,
-		postgresqlservervirtualnetworkrule.Setup,
-		postgresqlserverconfiguration.Setup,
-		cosmosdb.Setup,
-		virtualnetwork.Setup,
-		subnet.Setup,
-		resourcegroup.Setup,
-		account.Setup,
-		container.Setup,
-		secret.SetupSecret,
-	} {
-		if err := setup(mgr, l, rl, poll); err != nil {
-			return err
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, o Options) error {
+	for _, g := range Groups() {
+		if !o.Enabled(g) {
+			l.Debug("Skipping disabled controller group", "group", g)
+			continue
+		}
+		for _, setup := range groups[g].setup {
+			if err := setup(mgr, l, rl, poll, o.concurrency(g)); err != nil {
+				return err
+			}
 		}
 	}
 	return config.Setup(mgr, l, rl)
@@ -39,7 +39,9 @@ import (
 
 	"github.com/crossplane/provider-azure/apis/cache/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
 	redisclients "github.com/crossplane/provider-azure/pkg/clients/redis"
+	"github.com/crossplane/provider-azure/pkg/tracing"
 )
 
 const (
@@ -55,22 +57,23 @@ const (
 )
 
 // SetupRedis adds a controller that reconciles Redis resources.
-func SetupRedis(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupRedis(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
 	name := managed.ControllerName(v1beta1.RedisGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
 		}).
 		For(&v1beta1.Redis{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(tracing.Reconciler(name, v1beta1.RedisGroupVersionKind, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1beta1.RedisGroupVersionKind),
-			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connector{kube: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.atProvider.id"))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
 }
 
 type connector struct {
@@ -40,6 +40,8 @@ import (
 	"github.com/crossplane/provider-azure/apis/compute/v1alpha3"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 	"github.com/crossplane/provider-azure/pkg/clients/compute"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	"github.com/crossplane/provider-azure/pkg/tracing"
 )
 
 // Error strings.
@@ -53,22 +55,23 @@ const (
 )
 
 // SetupAKSCluster adds a controller that reconciles AKSClusters.
-func SetupAKSCluster(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupAKSCluster(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
 	name := managed.ControllerName(v1alpha3.AKSClusterGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
 		}).
 		For(&v1alpha3.AKSCluster{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(tracing.Reconciler(name, v1alpha3.AKSClusterGroupVersionKind, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha3.AKSClusterGroupVersionKind),
-			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{client: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.providerID"))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
 }
 
 type connecter struct {
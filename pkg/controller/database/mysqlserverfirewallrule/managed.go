@@ -39,6 +39,8 @@ import (
 	"github.com/crossplane/provider-azure/apis/database/v1alpha3"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 	"github.com/crossplane/provider-azure/pkg/clients/database"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	"github.com/crossplane/provider-azure/pkg/tracing"
 )
 
 // Error strings.
@@ -51,23 +53,24 @@ const (
 )
 
 // Setup adds a controller that reconciles MySQLServerFirewallRules.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
 	name := managed.ControllerName(v1alpha3.MySQLServerFirewallRuleGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
 		}).
 		For(&v1alpha3.MySQLServerFirewallRule{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(tracing.Reconciler(name, v1alpha3.MySQLServerFirewallRuleGroupVersionKind, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha3.MySQLServerFirewallRuleGroupVersionKind),
 			managed.WithConnectionPublishers(),
-			managed.WithExternalConnecter(&connecter{client: mgr.GetClient()}),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{client: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.atProvider.id"))),
 			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
 }
 
 type connecter struct {
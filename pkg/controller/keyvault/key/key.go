@@ -0,0 +1,146 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package key
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault/keyvaultapi"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/keyvault/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	keyclients "github.com/crossplane/provider-azure/pkg/clients/keyvault/key"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotKey        = "managed resource is not a KeyVaultKey"
+	errConnectFailed = "cannot connect to Azure API"
+	errGetFailed     = "cannot get vault key"
+	errCreateFailed  = "cannot create vault key"
+	errUpdateFailed  = "cannot update vault key"
+	errDeleteFailed  = "cannot delete vault key"
+)
+
+// Setup adds a controller that reconciles KeyVaultKeys.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1alpha1.KeyVaultKeyGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1alpha1.KeyVaultKey{}).
+		Complete(tracing.Reconciler(name, v1alpha1.KeyVaultKeyGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha1.KeyVaultKeyGroupVersionKind),
+			managed.WithExternalConnecter(&connecter{kube: mgr.GetClient()}),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	kube client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, _, err := azure.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
+	}
+	auth, err := azure.GetAuthorizer(creds, azure.KeyVaultResource)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
+	}
+	cl := keyvault.New()
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client keyvaultapi.BaseClientAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1alpha1.KeyVaultKey)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotKey)
+	}
+
+	az, err := e.client.GetKey(ctx, cr.Spec.ForProvider.VaultBaseURL, cr.Spec.ForProvider.Name, "")
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azure.IsNotFound, err), errGetFailed)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	keyclients.LateInitialize(&cr.Spec.ForProvider, az)
+	cr.Status.AtProvider = keyclients.GenerateObservation(az)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        keyclients.IsUpToDate(cr.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1alpha1.KeyVaultKey)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotKey)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+	_, err := e.client.CreateKey(ctx, cr.Spec.ForProvider.VaultBaseURL, cr.Spec.ForProvider.Name, keyclients.NewKeyCreateParameters(cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1alpha1.KeyVaultKey)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotKey)
+	}
+	_, err := e.client.UpdateKey(ctx, cr.Spec.ForProvider.VaultBaseURL, cr.Spec.ForProvider.Name, cr.Status.AtProvider.Version, keyclients.NewKeyUpdateParameters(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1alpha1.KeyVaultKey)
+	if !ok {
+		return errors.New(errNotKey)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.client.DeleteKey(ctx, cr.Spec.ForProvider.VaultBaseURL, cr.Spec.ForProvider.Name)
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteFailed)
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package key
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault/keyvaultapi"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/keyvault/v1alpha1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/keyvault/key/fake"
+)
+
+const (
+	vaultBaseURL = "https://myvault.vault.azure.net"
+	name         = "coolkey"
+	version      = "0123456789abcdef"
+	id           = vaultBaseURL + "/keys/" + name + "/" + version
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type keyModifier func(*v1alpha1.KeyVaultKey)
+
+func withConditions(c ...xpv1.Condition) keyModifier {
+	return func(k *v1alpha1.KeyVaultKey) { k.Status.ConditionedStatus.Conditions = c }
+}
+
+func withObservation() keyModifier {
+	return func(k *v1alpha1.KeyVaultKey) {
+		k.Status.AtProvider = v1alpha1.KeyVaultKeyObservation{ID: id, Version: version}
+	}
+}
+
+func withTags(t map[string]string) keyModifier {
+	return func(k *v1alpha1.KeyVaultKey) { k.Spec.ForProvider.Tags = t }
+}
+
+func withKeyOperations(ops ...string) keyModifier {
+	return func(k *v1alpha1.KeyVaultKey) { k.Spec.ForProvider.KeyOperations = ops }
+}
+
+func key(m ...keyModifier) *v1alpha1.KeyVaultKey {
+	k := &v1alpha1.KeyVaultKey{
+		Spec: v1alpha1.KeyVaultKeySpec{
+			ForProvider: v1alpha1.KeyVaultKeyParameters{
+				VaultBaseURL:  vaultBaseURL,
+				Name:          name,
+				KeyType:       string(keyvault.RSA),
+				KeyOperations: []string{"unwrapKey", "wrapKey"},
+				Tags:          map[string]string{"cool": "very"},
+			},
+		},
+	}
+	for _, f := range m {
+		f(k)
+	}
+	return k
+}
+
+func keyBundle() keyvault.KeyBundle {
+	return keyvault.KeyBundle{
+		Key: &keyvault.JSONWebKey{
+			Kid:    azure.ToStringPtr(id),
+			Kty:    keyvault.RSA,
+			KeyOps: &[]string{"wrapKey", "unwrapKey"},
+		},
+		Tags: map[string]*string{"cool": azure.ToStringPtr("very")},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr  *v1alpha1.KeyVaultKey
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		cr *v1alpha1.KeyVaultKey
+		c  keyvaultapi.BaseClientAPI
+		want
+	}{
+		"NotFound": {
+			cr: key(),
+			c: &fake.MockClient{
+				MockGetKey: func(_ context.Context, _ string, _ string, _ string) (keyvault.KeyBundle, error) {
+					return keyvault.KeyBundle{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+			want: want{
+				cr: key(),
+			},
+		},
+		"GetFailed": {
+			cr: key(),
+			c: &fake.MockClient{
+				MockGetKey: func(_ context.Context, _ string, _ string, _ string) (keyvault.KeyBundle, error) {
+					return keyvault.KeyBundle{}, errorBoom
+				},
+			},
+			want: want{
+				cr:  key(),
+				err: errors.Wrap(errorBoom, errGetFailed),
+			},
+		},
+		"Successful": {
+			cr: key(),
+			c: &fake.MockClient{
+				MockGetKey: func(_ context.Context, _ string, _ string, _ string) (keyvault.KeyBundle, error) {
+					return keyBundle(), nil
+				},
+			},
+			want: want{
+				cr: key(withObservation(), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"LateInitialized": {
+			cr: key(withKeyOperations()),
+			c: &fake.MockClient{
+				MockGetKey: func(_ context.Context, _ string, _ string, _ string) (keyvault.KeyBundle, error) {
+					return keyBundle(), nil
+				},
+			},
+			want: want{
+				cr: key(withKeyOperations("wrapKey", "unwrapKey"), withObservation(), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"NeedsUpdate": {
+			cr: key(withTags(map[string]string{"cool": "extremely"})),
+			c: &fake.MockClient{
+				MockGetKey: func(_ context.Context, _ string, _ string, _ string) (keyvault.KeyBundle, error) {
+					return keyBundle(), nil
+				},
+			},
+			want: want{
+				cr: key(withTags(map[string]string{"cool": "extremely"}), withObservation(), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		c   keyvaultapi.BaseClientAPI
+		err error
+	}{
+		"Successful": {
+			c: &fake.MockClient{
+				MockCreateKey: func(_ context.Context, _ string, _ string, p keyvault.KeyCreateParameters) (keyvault.KeyBundle, error) {
+					if p.Kty != keyvault.RSA {
+						return keyvault.KeyBundle{}, errors.Errorf("unexpected key type %s", p.Kty)
+					}
+					return keyBundle(), nil
+				},
+			},
+		},
+		"Failed": {
+			c: &fake.MockClient{
+				MockCreateKey: func(_ context.Context, _ string, _ string, _ keyvault.KeyCreateParameters) (keyvault.KeyBundle, error) {
+					return keyvault.KeyBundle{}, errorBoom
+				},
+			},
+			err: errors.Wrap(errorBoom, errCreateFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			cr := key()
+			_, err := e.Create(context.Background(), cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(key(withConditions(xpv1.Creating())), cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		c   keyvaultapi.BaseClientAPI
+		err error
+	}{
+		"Successful": {
+			c: &fake.MockClient{
+				MockUpdateKey: func(_ context.Context, _ string, _ string, v string, _ keyvault.KeyUpdateParameters) (keyvault.KeyBundle, error) {
+					if v != version {
+						return keyvault.KeyBundle{}, errors.Errorf("unexpected version %s", v)
+					}
+					return keyBundle(), nil
+				},
+			},
+		},
+		"Failed": {
+			c: &fake.MockClient{
+				MockUpdateKey: func(_ context.Context, _ string, _ string, _ string, _ keyvault.KeyUpdateParameters) (keyvault.KeyBundle, error) {
+					return keyvault.KeyBundle{}, errorBoom
+				},
+			},
+			err: errors.Wrap(errorBoom, errUpdateFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			_, err := e.Update(context.Background(), key(withObservation()))
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		c   keyvaultapi.BaseClientAPI
+		err error
+	}{
+		"Successful": {
+			c: &fake.MockClient{
+				MockDeleteKey: func(_ context.Context, _ string, _ string) (keyvault.DeletedKeyBundle, error) {
+					return keyvault.DeletedKeyBundle{}, nil
+				},
+			},
+		},
+		"NotFound": {
+			c: &fake.MockClient{
+				MockDeleteKey: func(_ context.Context, _ string, _ string) (keyvault.DeletedKeyBundle, error) {
+					return keyvault.DeletedKeyBundle{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+		},
+		"Failed": {
+			c: &fake.MockClient{
+				MockDeleteKey: func(_ context.Context, _ string, _ string) (keyvault.DeletedKeyBundle, error) {
+					return keyvault.DeletedKeyBundle{}, errorBoom
+				},
+			},
+			err: errors.Wrap(errorBoom, errDeleteFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			cr := key()
+			err := e.Delete(context.Background(), cr)
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(key(withConditions(xpv1.Deleting())), cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/crossplane/provider-azure/apis/keyvault/v1alpha1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
 	secretclients "github.com/crossplane/provider-azure/pkg/clients/keyvault/secret"
+	"github.com/crossplane/provider-azure/pkg/tracing"
 )
 
 const (
@@ -53,21 +54,22 @@ const (
 )
 
 // SetupSecret adds a controller that reconciles KeyVaultSecret resources.
-func SetupSecret(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
+func SetupSecret(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
 	name := managed.ControllerName(v1alpha1.KeyVaultSecretGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
 		}).
 		For(&v1alpha1.KeyVaultSecret{}).
-		Complete(managed.NewReconciler(mgr,
+		Complete(tracing.Reconciler(name, v1alpha1.KeyVaultSecretGroupVersionKind, managed.NewReconciler(mgr,
 			resource.ManagedKind(v1alpha1.KeyVaultSecretGroupVersionKind),
 			managed.WithExternalConnecter(&connector{kube: mgr.GetClient()}),
 			managed.WithPollInterval(poll),
 			managed.WithLogger(l.WithValues("controller", name)),
-			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name)))))
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
 }
 
 type connector struct {
@@ -0,0 +1,379 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package natgateway
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
+)
+
+const (
+	name              = "coolNATGateway"
+	uid               = types.UID("definitely-a-uuid")
+	resourceGroupName = "coolRG"
+	location          = "coolplace"
+	id                = "a-very-cool-id"
+	publicIPAddressID = "a-very-cool-ip-id"
+)
+
+var (
+	ctx       = context.Background()
+	errorBoom = errors.New("boom")
+	tags      = map[string]string{"one": "test"}
+)
+
+type testCase struct {
+	name    string
+	e       managed.ExternalClient
+	r       resource.Managed
+	want    resource.Managed
+	wantErr error
+}
+
+type natGatewayModifier func(*v1alpha3.NATGateway)
+
+func withConditions(c ...xpv1.Condition) natGatewayModifier {
+	return func(r *v1alpha3.NATGateway) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withState(s string) natGatewayModifier {
+	return func(r *v1alpha3.NATGateway) { r.Status.State = s }
+}
+
+func withID(id string) natGatewayModifier {
+	return func(r *v1alpha3.NATGateway) { r.Status.ID = id }
+}
+
+func natGateway(sm ...natGatewayModifier) *v1alpha3.NATGateway {
+	r := &v1alpha3.NATGateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			UID:        uid,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha3.NATGatewaySpec{
+			ResourceGroupName: resourceGroupName,
+			Location:          location,
+			Tags:              tags,
+			NATGatewayPropertiesFormat: v1alpha3.NATGatewayPropertiesFormat{
+				PublicIPAddressIDs: []string{publicIPAddressID},
+			},
+		},
+		Status: v1alpha3.NATGatewayStatus{},
+	}
+
+	meta.SetExternalName(r, name)
+
+	for _, m := range sm {
+		m(r)
+	}
+
+	return r
+}
+
+// Test that our Reconciler implementation satisfies the Reconciler interface.
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestCreate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotNATGateway",
+			e:       &external{client: &fake.MockNatGatewaysClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotNATGateway),
+		},
+		{
+			name: "SuccessfulCreate",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.NatGateway) (network.NatGatewaysCreateOrUpdateFuture, error) {
+					return network.NatGatewaysCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r: natGateway(),
+			want: natGateway(
+				withConditions(xpv1.Creating()),
+			),
+		},
+		{
+			name: "FailedCreate",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.NatGateway) (network.NatGatewaysCreateOrUpdateFuture, error) {
+					return network.NatGatewaysCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r: natGateway(),
+			want: natGateway(
+				withConditions(xpv1.Creating()),
+			),
+			wantErr: errors.Wrap(errorBoom, errCreateNATGateway),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Create(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotNATGateway",
+			e:       &external{client: &fake.MockNatGatewaysClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotNATGateway),
+		},
+		{
+			name: "SuccessfulObserveNotExist",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.NatGateway, error) {
+					return network.NatGateway{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r:    natGateway(),
+			want: natGateway(),
+		},
+		{
+			name: "SuccessfulObserveExists",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.NatGateway, error) {
+					return network.NatGateway{
+						ID:   azure.ToStringPtr(id),
+						Tags: azure.ToStringPtrMap(tags),
+						NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+							ProvisioningState: azure.ToStringPtr(string(network.Available)),
+						},
+					}, nil
+				},
+			}},
+			r: natGateway(),
+			want: natGateway(
+				withConditions(xpv1.Available()),
+				withState(string(network.Available)),
+				withID(id),
+			),
+		},
+		{
+			name: "FailedObserve",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.NatGateway, error) {
+					return network.NatGateway{}, errorBoom
+				},
+			}},
+			r:       natGateway(),
+			want:    natGateway(),
+			wantErr: errors.Wrap(errorBoom, errGetNATGateway),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Observe(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Observe(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotNATGateway",
+			e:       &external{client: &fake.MockNatGatewaysClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotNATGateway),
+		},
+		{
+			name: "SuccessfulDoesNotNeedUpdate",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.NatGateway, error) {
+					return network.NatGateway{
+						Tags: azure.ToStringPtrMap(tags),
+						NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{
+							PublicIPAddresses: &[]network.SubResource{{ID: azure.ToStringPtr(publicIPAddressID)}},
+						},
+					}, nil
+				},
+			}},
+			r:    natGateway(),
+			want: natGateway(),
+		},
+		{
+			name: "SuccessfulNeedsUpdate",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.NatGateway, error) {
+					return network.NatGateway{
+						Tags:                       azure.ToStringPtrMap(tags),
+						NatGatewayPropertiesFormat: &network.NatGatewayPropertiesFormat{},
+					}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, p network.NatGateway) (network.NatGatewaysCreateOrUpdateFuture, error) {
+					want := &[]network.SubResource{{ID: azure.ToStringPtr(publicIPAddressID)}}
+					if diff := cmp.Diff(want, p.PublicIPAddresses); diff != "" {
+						t.Errorf("CreateOrUpdate(...): -want public IP addresses, +got:\n%s", diff)
+					}
+					return network.NatGatewaysCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r:    natGateway(),
+			want: natGateway(),
+		},
+		{
+			name: "UnsuccessfulGet",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.NatGateway, error) {
+					return network.NatGateway{}, errorBoom
+				},
+			}},
+			r:       natGateway(),
+			want:    natGateway(),
+			wantErr: errors.Wrap(errorBoom, errGetNATGateway),
+		},
+		{
+			name: "UnsuccessfulUpdate",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.NatGateway, error) {
+					return network.NatGateway{}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.NatGateway) (network.NatGatewaysCreateOrUpdateFuture, error) {
+					return network.NatGatewaysCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r:       natGateway(),
+			want:    natGateway(),
+			wantErr: errors.Wrap(errorBoom, errUpdateNATGateway),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Update(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Update(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotNATGateway",
+			e:       &external{client: &fake.MockNatGatewaysClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotNATGateway),
+		},
+		{
+			name: "Successful",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.NatGatewaysDeleteFuture, error) {
+					return network.NatGatewaysDeleteFuture{}, nil
+				},
+			}},
+			r: natGateway(),
+			want: natGateway(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "SuccessfulNotFound",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.NatGatewaysDeleteFuture, error) {
+					return network.NatGatewaysDeleteFuture{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r: natGateway(),
+			want: natGateway(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "Failed",
+			e: &external{client: &fake.MockNatGatewaysClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.NatGatewaysDeleteFuture, error) {
+					return network.NatGatewaysDeleteFuture{}, errorBoom
+				},
+			}},
+			r: natGateway(),
+			want: natGateway(
+				withConditions(xpv1.Deleting()),
+			),
+			wantErr: errors.Wrap(errorBoom, errDeleteNATGateway),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.e.Delete(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Delete(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipaddress
+
+import (
+	"context"
+	"time"
+
+	azurenetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotPublicIPAddress    = "managed resource is not a PublicIPAddress"
+	errCreatePublicIPAddress = "cannot create PublicIPAddress"
+	errUpdatePublicIPAddress = "cannot update PublicIPAddress"
+	errGetPublicIPAddress    = "cannot get PublicIPAddress"
+	errDeletePublicIPAddress = "cannot delete PublicIPAddress"
+)
+
+// Setup adds a controller that reconciles PublicIPAddresses.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1alpha3.PublicIPAddressGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1alpha3.PublicIPAddress{}).
+		Complete(tracing.Reconciler(name, v1alpha3.PublicIPAddressGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha3.PublicIPAddressGroupVersionKind),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{client: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.id"))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := azurenetwork.NewPublicIPAddressesClient(creds[azureclients.CredentialsKeySubscriptionID])
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client networkapi.PublicIPAddressesClientAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	p, ok := mg.(*v1alpha3.PublicIPAddress)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPublicIPAddress)
+	}
+
+	az, err := e.client.Get(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p), "")
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetPublicIPAddress)
+	}
+
+	network.UpdatePublicIPAddressStatusFromAzure(p, az)
+
+	p.SetConditions(xpv1.Available())
+
+	o := managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: !network.PublicIPAddressNeedsUpdate(p, az),
+		ConnectionDetails: managed.ConnectionDetails{
+			network.ConnectionSecretKeyIPAddress: []byte(p.Status.IPAddress),
+			network.ConnectionSecretKeyFQDN:      []byte(p.Status.FQDN),
+		},
+	}
+
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	p, ok := mg.(*v1alpha3.PublicIPAddress)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPublicIPAddress)
+	}
+
+	p.Status.SetConditions(xpv1.Creating())
+
+	params := network.NewPublicIPAddressParameters(p)
+	if _, err := e.client.CreateOrUpdate(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p), params); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreatePublicIPAddress)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	p, ok := mg.(*v1alpha3.PublicIPAddress)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPublicIPAddress)
+	}
+
+	az, err := e.client.Get(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p), "")
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetPublicIPAddress)
+	}
+
+	if network.PublicIPAddressNeedsUpdate(p, az) {
+		params := network.NewPublicIPAddressParameters(p)
+		if _, err := e.client.CreateOrUpdate(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p), params); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdatePublicIPAddress)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	p, ok := mg.(*v1alpha3.PublicIPAddress)
+	if !ok {
+		return errors.New(errNotPublicIPAddress)
+	}
+
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := e.client.Delete(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeletePublicIPAddress)
+}
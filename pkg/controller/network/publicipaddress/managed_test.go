@@ -0,0 +1,422 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipaddress
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	networkclient "github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
+)
+
+const (
+	name              = "coolPublicIP"
+	uid               = types.UID("definitely-a-uuid")
+	resourceGroupName = "coolRG"
+	location          = "coolplace"
+	id                = "a-very-cool-id"
+	ipAddress         = "20.0.0.1"
+	domainNameLabel   = "cool-label"
+	fqdn              = "cool-label.coolplace.cloudapp.azure.com"
+)
+
+var (
+	ctx       = context.Background()
+	errorBoom = errors.New("boom")
+	tags      = map[string]string{"one": "test"}
+)
+
+type testCase struct {
+	name    string
+	e       managed.ExternalClient
+	r       resource.Managed
+	want    resource.Managed
+	wantErr error
+}
+
+type publicIPAddressModifier func(*v1alpha3.PublicIPAddress)
+
+func withConditions(c ...xpv1.Condition) publicIPAddressModifier {
+	return func(r *v1alpha3.PublicIPAddress) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withState(s string) publicIPAddressModifier {
+	return func(r *v1alpha3.PublicIPAddress) { r.Status.State = s }
+}
+
+func withID(id string) publicIPAddressModifier {
+	return func(r *v1alpha3.PublicIPAddress) { r.Status.ID = id }
+}
+
+func withIPAddress(ip string) publicIPAddressModifier {
+	return func(r *v1alpha3.PublicIPAddress) { r.Status.IPAddress = ip }
+}
+
+func withFQDN(fqdn string) publicIPAddressModifier {
+	return func(r *v1alpha3.PublicIPAddress) { r.Status.FQDN = fqdn }
+}
+
+func withDomainNameLabel(l string) publicIPAddressModifier {
+	return func(r *v1alpha3.PublicIPAddress) {
+		r.Spec.DNSSettings = &v1alpha3.PublicIPAddressDNSSettings{DomainNameLabel: l}
+	}
+}
+
+func publicIPAddress(sm ...publicIPAddressModifier) *v1alpha3.PublicIPAddress {
+	r := &v1alpha3.PublicIPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			UID:        uid,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha3.PublicIPAddressSpec{
+			ResourceGroupName: resourceGroupName,
+			Location:          location,
+			Tags:              tags,
+		},
+		Status: v1alpha3.PublicIPAddressStatus{},
+	}
+
+	meta.SetExternalName(r, name)
+
+	for _, m := range sm {
+		m(r)
+	}
+
+	return r
+}
+
+// Test that our Reconciler implementation satisfies the Reconciler interface.
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestCreate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotPublicIPAddress",
+			e:       &external{client: &fake.MockPublicIPAddressesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotPublicIPAddress),
+		},
+		{
+			name: "SuccessfulCreate",
+			e: &external{client: &fake.MockPublicIPAddressesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.PublicIPAddress) (network.PublicIPAddressesCreateOrUpdateFuture, error) {
+					return network.PublicIPAddressesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r: publicIPAddress(),
+			want: publicIPAddress(
+				withConditions(xpv1.Creating()),
+			),
+		},
+		{
+			name: "FailedCreate",
+			e: &external{client: &fake.MockPublicIPAddressesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.PublicIPAddress) (network.PublicIPAddressesCreateOrUpdateFuture, error) {
+					return network.PublicIPAddressesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r: publicIPAddress(),
+			want: publicIPAddress(
+				withConditions(xpv1.Creating()),
+			),
+			wantErr: errors.Wrap(errorBoom, errCreatePublicIPAddress),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Create(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := []struct {
+		testCase
+		wantObs managed.ExternalObservation
+	}{
+		{
+			testCase: testCase{
+				name:    "NotPublicIPAddress",
+				e:       &external{client: &fake.MockPublicIPAddressesClient{}},
+				r:       &v1alpha3.Subnet{},
+				want:    &v1alpha3.Subnet{},
+				wantErr: errors.New(errNotPublicIPAddress),
+			},
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveNotExist",
+				e: &external{client: &fake.MockPublicIPAddressesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPAddress, error) {
+						return network.PublicIPAddress{}, autorest.DetailedError{
+							StatusCode: http.StatusNotFound,
+						}
+					},
+				}},
+				r:    publicIPAddress(),
+				want: publicIPAddress(),
+			},
+			wantObs: managed.ExternalObservation{ResourceExists: false},
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveExists",
+				e: &external{client: &fake.MockPublicIPAddressesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPAddress, error) {
+						return network.PublicIPAddress{
+							ID:   azure.ToStringPtr(id),
+							Tags: azure.ToStringPtrMap(tags),
+							PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{
+								ProvisioningState: azure.ToStringPtr(string(network.Available)),
+								IPAddress:         azure.ToStringPtr(ipAddress),
+								DNSSettings: &network.PublicIPAddressDNSSettings{
+									DomainNameLabel: azure.ToStringPtr(domainNameLabel),
+									Fqdn:            azure.ToStringPtr(fqdn),
+								},
+							},
+						}, nil
+					},
+				}},
+				r: publicIPAddress(withDomainNameLabel(domainNameLabel)),
+				want: publicIPAddress(
+					withDomainNameLabel(domainNameLabel),
+					withConditions(xpv1.Available()),
+					withState(string(network.Available)),
+					withID(id),
+					withIPAddress(ipAddress),
+					withFQDN(fqdn),
+				),
+			},
+			wantObs: managed.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
+				ConnectionDetails: managed.ConnectionDetails{
+					networkclient.ConnectionSecretKeyIPAddress: []byte(ipAddress),
+					networkclient.ConnectionSecretKeyFQDN:      []byte(fqdn),
+				},
+			},
+		},
+		{
+			testCase: testCase{
+				name: "FailedObserve",
+				e: &external{client: &fake.MockPublicIPAddressesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPAddress, error) {
+						return network.PublicIPAddress{}, errorBoom
+					},
+				}},
+				r:       publicIPAddress(),
+				want:    publicIPAddress(),
+				wantErr: errors.Wrap(errorBoom, errGetPublicIPAddress),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			obs, err := tc.e.Observe(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Observe(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantObs, obs); diff != "" {
+				t.Errorf("tc.e.Observe(...): -want, +got:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotPublicIPAddress",
+			e:       &external{client: &fake.MockPublicIPAddressesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotPublicIPAddress),
+		},
+		{
+			name: "SuccessfulDoesNotNeedUpdate",
+			e: &external{client: &fake.MockPublicIPAddressesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPAddress, error) {
+					return network.PublicIPAddress{
+						Tags:                            azure.ToStringPtrMap(tags),
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{},
+					}, nil
+				},
+			}},
+			r:    publicIPAddress(),
+			want: publicIPAddress(),
+		},
+		{
+			name: "SuccessfulNeedsUpdate",
+			e: &external{client: &fake.MockPublicIPAddressesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPAddress, error) {
+					return network.PublicIPAddress{
+						Tags:                            azure.ToStringPtrMap(tags),
+						PublicIPAddressPropertiesFormat: &network.PublicIPAddressPropertiesFormat{},
+					}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, p network.PublicIPAddress) (network.PublicIPAddressesCreateOrUpdateFuture, error) {
+					if diff := cmp.Diff(domainNameLabel, azure.ToString(p.DNSSettings.DomainNameLabel)); diff != "" {
+						t.Errorf("CreateOrUpdate(...): -want label, +got:\n%s", diff)
+					}
+					return network.PublicIPAddressesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r:    publicIPAddress(withDomainNameLabel(domainNameLabel)),
+			want: publicIPAddress(withDomainNameLabel(domainNameLabel)),
+		},
+		{
+			name: "UnsuccessfulGet",
+			e: &external{client: &fake.MockPublicIPAddressesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPAddress, error) {
+					return network.PublicIPAddress{}, errorBoom
+				},
+			}},
+			r:       publicIPAddress(),
+			want:    publicIPAddress(),
+			wantErr: errors.Wrap(errorBoom, errGetPublicIPAddress),
+		},
+		{
+			name: "UnsuccessfulUpdate",
+			e: &external{client: &fake.MockPublicIPAddressesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPAddress, error) {
+					return network.PublicIPAddress{}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.PublicIPAddress) (network.PublicIPAddressesCreateOrUpdateFuture, error) {
+					return network.PublicIPAddressesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r:       publicIPAddress(),
+			want:    publicIPAddress(),
+			wantErr: errors.Wrap(errorBoom, errUpdatePublicIPAddress),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Update(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Update(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotPublicIPAddress",
+			e:       &external{client: &fake.MockPublicIPAddressesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotPublicIPAddress),
+		},
+		{
+			name: "Successful",
+			e: &external{client: &fake.MockPublicIPAddressesClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.PublicIPAddressesDeleteFuture, error) {
+					return network.PublicIPAddressesDeleteFuture{}, nil
+				},
+			}},
+			r: publicIPAddress(),
+			want: publicIPAddress(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "SuccessfulNotFound",
+			e: &external{client: &fake.MockPublicIPAddressesClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.PublicIPAddressesDeleteFuture, error) {
+					return network.PublicIPAddressesDeleteFuture{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r: publicIPAddress(),
+			want: publicIPAddress(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "Failed",
+			e: &external{client: &fake.MockPublicIPAddressesClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.PublicIPAddressesDeleteFuture, error) {
+					return network.PublicIPAddressesDeleteFuture{}, errorBoom
+				},
+			}},
+			r: publicIPAddress(),
+			want: publicIPAddress(
+				withConditions(xpv1.Deleting()),
+			),
+			wantErr: errors.Wrap(errorBoom, errDeletePublicIPAddress),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.e.Delete(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Delete(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefix
+
+import (
+	"context"
+	"time"
+
+	azurenetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotPublicIPPrefix    = "managed resource is not a PublicIPPrefix"
+	errCreatePublicIPPrefix = "cannot create PublicIPPrefix"
+	errUpdatePublicIPPrefix = "cannot update PublicIPPrefix"
+	errGetPublicIPPrefix    = "cannot get PublicIPPrefix"
+	errDeletePublicIPPrefix = "cannot delete PublicIPPrefix"
+)
+
+// Setup adds a controller that reconciles PublicIPPrefixes.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1alpha3.PublicIPPrefixGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1alpha3.PublicIPPrefix{}).
+		Complete(tracing.Reconciler(name, v1alpha3.PublicIPPrefixGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha3.PublicIPPrefixGroupVersionKind),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{client: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.id"))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := azurenetwork.NewPublicIPPrefixesClient(creds[azureclients.CredentialsKeySubscriptionID])
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client networkapi.PublicIPPrefixesClientAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	p, ok := mg.(*v1alpha3.PublicIPPrefix)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPublicIPPrefix)
+	}
+
+	az, err := e.client.Get(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p), "")
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetPublicIPPrefix)
+	}
+
+	network.UpdatePublicIPPrefixStatusFromAzure(p, az)
+
+	p.SetConditions(xpv1.Available())
+
+	o := managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: !network.PublicIPPrefixNeedsUpdate(p, az),
+		ConnectionDetails: managed.ConnectionDetails{
+			network.ConnectionSecretKeyIPPrefix: []byte(p.Status.IPPrefix),
+		},
+	}
+
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	p, ok := mg.(*v1alpha3.PublicIPPrefix)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPublicIPPrefix)
+	}
+
+	p.Status.SetConditions(xpv1.Creating())
+
+	params := network.NewPublicIPPrefixParameters(p)
+	if _, err := e.client.CreateOrUpdate(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p), params); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreatePublicIPPrefix)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	p, ok := mg.(*v1alpha3.PublicIPPrefix)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPublicIPPrefix)
+	}
+
+	az, err := e.client.Get(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p), "")
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetPublicIPPrefix)
+	}
+
+	if network.PublicIPPrefixNeedsUpdate(p, az) {
+		params := network.NewPublicIPPrefixParameters(p)
+		if _, err := e.client.CreateOrUpdate(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p), params); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdatePublicIPPrefix)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	p, ok := mg.(*v1alpha3.PublicIPPrefix)
+	if !ok {
+		return errors.New(errNotPublicIPPrefix)
+	}
+
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := e.client.Delete(ctx, p.Spec.ResourceGroupName, meta.GetExternalName(p))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeletePublicIPPrefix)
+}
@@ -0,0 +1,402 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package publicipprefix
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	networkclient "github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
+)
+
+const (
+	name              = "coolPublicIPPrefix"
+	uid               = types.UID("definitely-a-uuid")
+	resourceGroupName = "coolRG"
+	location          = "coolplace"
+	id                = "a-very-cool-id"
+	ipPrefix          = "20.0.0.0/30"
+)
+
+var (
+	ctx       = context.Background()
+	errorBoom = errors.New("boom")
+	tags      = map[string]string{"one": "test"}
+)
+
+type testCase struct {
+	name    string
+	e       managed.ExternalClient
+	r       resource.Managed
+	want    resource.Managed
+	wantErr error
+}
+
+type publicIPPrefixModifier func(*v1alpha3.PublicIPPrefix)
+
+func withConditions(c ...xpv1.Condition) publicIPPrefixModifier {
+	return func(r *v1alpha3.PublicIPPrefix) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withState(s string) publicIPPrefixModifier {
+	return func(r *v1alpha3.PublicIPPrefix) { r.Status.State = s }
+}
+
+func withID(id string) publicIPPrefixModifier {
+	return func(r *v1alpha3.PublicIPPrefix) { r.Status.ID = id }
+}
+
+func withIPPrefix(prefix string) publicIPPrefixModifier {
+	return func(r *v1alpha3.PublicIPPrefix) { r.Status.IPPrefix = prefix }
+}
+
+func publicIPPrefix(sm ...publicIPPrefixModifier) *v1alpha3.PublicIPPrefix {
+	r := &v1alpha3.PublicIPPrefix{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			UID:        uid,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha3.PublicIPPrefixSpec{
+			ResourceGroupName: resourceGroupName,
+			Location:          location,
+			Tags:              tags,
+		},
+		Status: v1alpha3.PublicIPPrefixStatus{},
+	}
+
+	meta.SetExternalName(r, name)
+
+	for _, m := range sm {
+		m(r)
+	}
+
+	return r
+}
+
+// Test that our Reconciler implementation satisfies the Reconciler interface.
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestCreate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotPublicIPPrefix",
+			e:       &external{client: &fake.MockPublicIPPrefixesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotPublicIPPrefix),
+		},
+		{
+			name: "SuccessfulCreate",
+			e: &external{client: &fake.MockPublicIPPrefixesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.PublicIPPrefix) (network.PublicIPPrefixesCreateOrUpdateFuture, error) {
+					return network.PublicIPPrefixesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r: publicIPPrefix(),
+			want: publicIPPrefix(
+				withConditions(xpv1.Creating()),
+			),
+		},
+		{
+			name: "FailedCreate",
+			e: &external{client: &fake.MockPublicIPPrefixesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.PublicIPPrefix) (network.PublicIPPrefixesCreateOrUpdateFuture, error) {
+					return network.PublicIPPrefixesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r: publicIPPrefix(),
+			want: publicIPPrefix(
+				withConditions(xpv1.Creating()),
+			),
+			wantErr: errors.Wrap(errorBoom, errCreatePublicIPPrefix),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Create(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := []struct {
+		testCase
+		wantObs managed.ExternalObservation
+	}{
+		{
+			testCase: testCase{
+				name:    "NotPublicIPPrefix",
+				e:       &external{client: &fake.MockPublicIPPrefixesClient{}},
+				r:       &v1alpha3.Subnet{},
+				want:    &v1alpha3.Subnet{},
+				wantErr: errors.New(errNotPublicIPPrefix),
+			},
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveNotExist",
+				e: &external{client: &fake.MockPublicIPPrefixesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPPrefix, error) {
+						return network.PublicIPPrefix{}, autorest.DetailedError{
+							StatusCode: http.StatusNotFound,
+						}
+					},
+				}},
+				r:    publicIPPrefix(),
+				want: publicIPPrefix(),
+			},
+			wantObs: managed.ExternalObservation{ResourceExists: false},
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveExists",
+				e: &external{client: &fake.MockPublicIPPrefixesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPPrefix, error) {
+						return network.PublicIPPrefix{
+							ID:   azure.ToStringPtr(id),
+							Tags: azure.ToStringPtrMap(tags),
+							PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{
+								ProvisioningState: azure.ToStringPtr(string(network.Available)),
+								IPPrefix:          azure.ToStringPtr(ipPrefix),
+							},
+						}, nil
+					},
+				}},
+				r: publicIPPrefix(),
+				want: publicIPPrefix(
+					withConditions(xpv1.Available()),
+					withState(string(network.Available)),
+					withID(id),
+					withIPPrefix(ipPrefix),
+				),
+			},
+			wantObs: managed.ExternalObservation{
+				ResourceExists:   true,
+				ResourceUpToDate: true,
+				ConnectionDetails: managed.ConnectionDetails{
+					networkclient.ConnectionSecretKeyIPPrefix: []byte(ipPrefix),
+				},
+			},
+		},
+		{
+			testCase: testCase{
+				name: "FailedObserve",
+				e: &external{client: &fake.MockPublicIPPrefixesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPPrefix, error) {
+						return network.PublicIPPrefix{}, errorBoom
+					},
+				}},
+				r:       publicIPPrefix(),
+				want:    publicIPPrefix(),
+				wantErr: errors.Wrap(errorBoom, errGetPublicIPPrefix),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			obs, err := tc.e.Observe(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Observe(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.wantObs, obs); diff != "" {
+				t.Errorf("tc.e.Observe(...): -want, +got:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotPublicIPPrefix",
+			e:       &external{client: &fake.MockPublicIPPrefixesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotPublicIPPrefix),
+		},
+		{
+			name: "SuccessfulDoesNotNeedUpdate",
+			e: &external{client: &fake.MockPublicIPPrefixesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPPrefix, error) {
+					return network.PublicIPPrefix{
+						Tags:                           azure.ToStringPtrMap(tags),
+						PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{},
+					}, nil
+				},
+			}},
+			r:    publicIPPrefix(),
+			want: publicIPPrefix(),
+		},
+		{
+			name: "SuccessfulNeedsUpdate",
+			e: &external{client: &fake.MockPublicIPPrefixesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPPrefix, error) {
+					return network.PublicIPPrefix{
+						PublicIPPrefixPropertiesFormat: &network.PublicIPPrefixPropertiesFormat{},
+					}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, p network.PublicIPPrefix) (network.PublicIPPrefixesCreateOrUpdateFuture, error) {
+					if diff := cmp.Diff(azure.ToStringPtrMap(tags), p.Tags); diff != "" {
+						t.Errorf("CreateOrUpdate(...): -want tags, +got:\n%s", diff)
+					}
+					return network.PublicIPPrefixesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r:    publicIPPrefix(),
+			want: publicIPPrefix(),
+		},
+		{
+			name: "UnsuccessfulGet",
+			e: &external{client: &fake.MockPublicIPPrefixesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPPrefix, error) {
+					return network.PublicIPPrefix{}, errorBoom
+				},
+			}},
+			r:       publicIPPrefix(),
+			want:    publicIPPrefix(),
+			wantErr: errors.Wrap(errorBoom, errGetPublicIPPrefix),
+		},
+		{
+			name: "UnsuccessfulUpdate",
+			e: &external{client: &fake.MockPublicIPPrefixesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.PublicIPPrefix, error) {
+					return network.PublicIPPrefix{}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.PublicIPPrefix) (network.PublicIPPrefixesCreateOrUpdateFuture, error) {
+					return network.PublicIPPrefixesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r:       publicIPPrefix(),
+			want:    publicIPPrefix(),
+			wantErr: errors.Wrap(errorBoom, errUpdatePublicIPPrefix),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Update(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Update(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotPublicIPPrefix",
+			e:       &external{client: &fake.MockPublicIPPrefixesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotPublicIPPrefix),
+		},
+		{
+			name: "Successful",
+			e: &external{client: &fake.MockPublicIPPrefixesClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.PublicIPPrefixesDeleteFuture, error) {
+					return network.PublicIPPrefixesDeleteFuture{}, nil
+				},
+			}},
+			r: publicIPPrefix(),
+			want: publicIPPrefix(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "SuccessfulNotFound",
+			e: &external{client: &fake.MockPublicIPPrefixesClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.PublicIPPrefixesDeleteFuture, error) {
+					return network.PublicIPPrefixesDeleteFuture{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r: publicIPPrefix(),
+			want: publicIPPrefix(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "Failed",
+			e: &external{client: &fake.MockPublicIPPrefixesClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.PublicIPPrefixesDeleteFuture, error) {
+					return network.PublicIPPrefixesDeleteFuture{}, errorBoom
+				},
+			}},
+			r: publicIPPrefix(),
+			want: publicIPPrefix(
+				withConditions(xpv1.Deleting()),
+			),
+			wantErr: errors.Wrap(errorBoom, errDeletePublicIPPrefix),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.e.Delete(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Delete(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
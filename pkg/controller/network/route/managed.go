@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"time"
+
+	azurenetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotRoute    = "managed resource is not a Route"
+	errCreateRoute = "cannot create Route"
+	errUpdateRoute = "cannot update Route"
+	errGetRoute    = "cannot get Route"
+	errDeleteRoute = "cannot delete Route"
+)
+
+// Setup adds a controller that reconciles Routes.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1alpha3.RouteGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1alpha3.Route{}).
+		Complete(tracing.Reconciler(name, v1alpha3.RouteGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha3.RouteGroupVersionKind),
+			managed.WithConnectionPublishers(),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{client: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.id"))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := azurenetwork.NewRoutesClient(creds[azureclients.CredentialsKeySubscriptionID])
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client networkapi.RoutesClientAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	r, ok := mg.(*v1alpha3.Route)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotRoute)
+	}
+
+	az, err := e.client.Get(ctx, r.Spec.ResourceGroupName, r.Spec.RouteTableName, meta.GetExternalName(r))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetRoute)
+	}
+
+	network.UpdateRouteStatusFromAzure(r, az)
+	r.SetConditions(xpv1.Available())
+
+	o := managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  !network.RouteNeedsUpdate(r, az),
+		ConnectionDetails: managed.ConnectionDetails{},
+	}
+
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	r, ok := mg.(*v1alpha3.Route)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotRoute)
+	}
+
+	r.Status.SetConditions(xpv1.Creating())
+
+	params := network.NewRouteParameters(r)
+	if _, err := e.client.CreateOrUpdate(ctx, r.Spec.ResourceGroupName, r.Spec.RouteTableName, meta.GetExternalName(r), params); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateRoute)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	r, ok := mg.(*v1alpha3.Route)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotRoute)
+	}
+
+	az, err := e.client.Get(ctx, r.Spec.ResourceGroupName, r.Spec.RouteTableName, meta.GetExternalName(r))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetRoute)
+	}
+
+	if network.RouteNeedsUpdate(r, az) {
+		params := network.NewRouteParameters(r)
+		if _, err := e.client.CreateOrUpdate(ctx, r.Spec.ResourceGroupName, r.Spec.RouteTableName, meta.GetExternalName(r), params); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateRoute)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	r, ok := mg.(*v1alpha3.Route)
+	if !ok {
+		return errors.New(errNotRoute)
+	}
+
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := e.client.Delete(ctx, r.Spec.ResourceGroupName, r.Spec.RouteTableName, meta.GetExternalName(r))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteRoute)
+}
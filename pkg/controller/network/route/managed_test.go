@@ -0,0 +1,398 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package route
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
+)
+
+const (
+	name              = "coolRoute"
+	uid               = types.UID("definitely-a-uuid")
+	routeTableName    = "coolRouteTable"
+	resourceGroupName = "coolRG"
+	addressPrefix     = "0.0.0.0/0"
+	nextHopIPAddress  = "10.0.0.4"
+)
+
+var (
+	ctx       = context.Background()
+	errorBoom = errors.New("boom")
+)
+
+type testCase struct {
+	name    string
+	e       managed.ExternalClient
+	r       resource.Managed
+	want    resource.Managed
+	wantErr error
+}
+
+type routeModifier func(*v1alpha3.Route)
+
+func withConditions(c ...xpv1.Condition) routeModifier {
+	return func(r *v1alpha3.Route) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withState(s string) routeModifier {
+	return func(r *v1alpha3.Route) { r.Status.State = s }
+}
+
+func route(sm ...routeModifier) *v1alpha3.Route {
+	r := &v1alpha3.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			UID:        uid,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha3.RouteSpec{
+			RouteTableName:    routeTableName,
+			ResourceGroupName: resourceGroupName,
+			RoutePropertiesFormat: v1alpha3.RoutePropertiesFormat{
+				AddressPrefix:    addressPrefix,
+				NextHopType:      string(network.RouteNextHopTypeVirtualAppliance),
+				NextHopIPAddress: nextHopIPAddress,
+			},
+		},
+		Status: v1alpha3.RouteStatus{},
+	}
+
+	meta.SetExternalName(r, name)
+
+	for _, m := range sm {
+		m(r)
+	}
+
+	return r
+}
+
+func azureRoute(nextHop string, state string) network.Route {
+	return network.Route{
+		RoutePropertiesFormat: &network.RoutePropertiesFormat{
+			AddressPrefix:     azure.ToStringPtr(addressPrefix),
+			NextHopType:       network.RouteNextHopTypeVirtualAppliance,
+			NextHopIPAddress:  azure.ToStringPtr(nextHop),
+			ProvisioningState: azure.ToStringPtr(state),
+		},
+	}
+}
+
+// Test that our Reconciler implementation satisfies the Reconciler interface.
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestCreate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotRoute",
+			e:       &external{client: &fake.MockRoutesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotRoute),
+		},
+		{
+			name: "SuccessfulCreate",
+			e: &external{client: &fake.MockRoutesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.Route) (network.RoutesCreateOrUpdateFuture, error) {
+					return network.RoutesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r: route(),
+			want: route(
+				withConditions(xpv1.Creating()),
+			),
+		},
+		{
+			name: "FailedCreate",
+			e: &external{client: &fake.MockRoutesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.Route) (network.RoutesCreateOrUpdateFuture, error) {
+					return network.RoutesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r: route(),
+			want: route(
+				withConditions(xpv1.Creating()),
+			),
+			wantErr: errors.Wrap(errorBoom, errCreateRoute),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Create(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := []struct {
+		testCase
+		upToDate bool
+	}{
+		{
+			testCase: testCase{
+				name:    "NotRoute",
+				e:       &external{client: &fake.MockRoutesClient{}},
+				r:       &v1alpha3.Subnet{},
+				want:    &v1alpha3.Subnet{},
+				wantErr: errors.New(errNotRoute),
+			},
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveNotExist",
+				e: &external{client: &fake.MockRoutesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.Route, error) {
+						return network.Route{}, autorest.DetailedError{
+							StatusCode: http.StatusNotFound,
+						}
+					},
+				}},
+				r:    route(),
+				want: route(),
+			},
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveExists",
+				e: &external{client: &fake.MockRoutesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.Route, error) {
+						return azureRoute(nextHopIPAddress, string(network.Available)), nil
+					},
+				}},
+				r: route(),
+				want: route(
+					withConditions(xpv1.Available()),
+					withState(string(network.Available)),
+				),
+			},
+			upToDate: true,
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveNeedsUpdate",
+				e: &external{client: &fake.MockRoutesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.Route, error) {
+						return azureRoute("10.0.0.5", string(network.Available)), nil
+					},
+				}},
+				r: route(),
+				want: route(
+					withConditions(xpv1.Available()),
+					withState(string(network.Available)),
+				),
+			},
+		},
+		{
+			testCase: testCase{
+				name: "FailedObserve",
+				e: &external{client: &fake.MockRoutesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.Route, error) {
+						return network.Route{}, errorBoom
+					},
+				}},
+				r:       route(),
+				want:    route(),
+				wantErr: errors.Wrap(errorBoom, errGetRoute),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := tc.e.Observe(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Observe(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("tc.e.Observe(...): -want ResourceUpToDate, +got:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotRoute",
+			e:       &external{client: &fake.MockRoutesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotRoute),
+		},
+		{
+			name: "SuccessfulDoesNotNeedUpdate",
+			e: &external{client: &fake.MockRoutesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.Route, error) {
+					return azureRoute(nextHopIPAddress, ""), nil
+				},
+			}},
+			r:    route(),
+			want: route(),
+		},
+		{
+			name: "SuccessfulNeedsUpdate",
+			e: &external{client: &fake.MockRoutesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.Route, error) {
+					return azureRoute("10.0.0.5", ""), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.Route) (network.RoutesCreateOrUpdateFuture, error) {
+					return network.RoutesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r:    route(),
+			want: route(),
+		},
+		{
+			name: "UnsuccessfulGet",
+			e: &external{client: &fake.MockRoutesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.Route, error) {
+					return network.Route{}, errorBoom
+				},
+			}},
+			r:       route(),
+			want:    route(),
+			wantErr: errors.Wrap(errorBoom, errGetRoute),
+		},
+		{
+			name: "UnsuccessfulUpdate",
+			e: &external{client: &fake.MockRoutesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.Route, error) {
+					return azureRoute("10.0.0.5", ""), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.Route) (network.RoutesCreateOrUpdateFuture, error) {
+					return network.RoutesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r:       route(),
+			want:    route(),
+			wantErr: errors.Wrap(errorBoom, errUpdateRoute),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Update(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Update(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotRoute",
+			e:       &external{client: &fake.MockRoutesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotRoute),
+		},
+		{
+			name: "Successful",
+			e: &external{client: &fake.MockRoutesClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (network.RoutesDeleteFuture, error) {
+					return network.RoutesDeleteFuture{}, nil
+				},
+			}},
+			r: route(),
+			want: route(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "SuccessfulNotFound",
+			e: &external{client: &fake.MockRoutesClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (network.RoutesDeleteFuture, error) {
+					return network.RoutesDeleteFuture{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r: route(),
+			want: route(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "Failed",
+			e: &external{client: &fake.MockRoutesClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (network.RoutesDeleteFuture, error) {
+					return network.RoutesDeleteFuture{}, errorBoom
+				},
+			}},
+			r: route(),
+			want: route(
+				withConditions(xpv1.Deleting()),
+			),
+			wantErr: errors.Wrap(errorBoom, errDeleteRoute),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.e.Delete(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Delete(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package routetable
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
+)
+
+const (
+	name              = "coolRouteTable"
+	uid               = types.UID("definitely-a-uuid")
+	resourceGroupName = "coolRG"
+	location          = "coolplace"
+	id                = "a-very-cool-id"
+)
+
+var (
+	ctx       = context.Background()
+	errorBoom = errors.New("boom")
+	tags      = map[string]string{"one": "test"}
+)
+
+type testCase struct {
+	name    string
+	e       managed.ExternalClient
+	r       resource.Managed
+	want    resource.Managed
+	wantErr error
+}
+
+type routeTableModifier func(*v1alpha3.RouteTable)
+
+func withConditions(c ...xpv1.Condition) routeTableModifier {
+	return func(r *v1alpha3.RouteTable) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withState(s string) routeTableModifier {
+	return func(r *v1alpha3.RouteTable) { r.Status.State = s }
+}
+
+func withID(id string) routeTableModifier {
+	return func(r *v1alpha3.RouteTable) { r.Status.ID = id }
+}
+
+func routeTable(sm ...routeTableModifier) *v1alpha3.RouteTable {
+	r := &v1alpha3.RouteTable{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			UID:        uid,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha3.RouteTableSpec{
+			ResourceGroupName: resourceGroupName,
+			Location:          location,
+			Tags:              tags,
+		},
+		Status: v1alpha3.RouteTableStatus{},
+	}
+
+	meta.SetExternalName(r, name)
+
+	for _, m := range sm {
+		m(r)
+	}
+
+	return r
+}
+
+// Test that our Reconciler implementation satisfies the Reconciler interface.
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestCreate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotRouteTable",
+			e:       &external{client: &fake.MockRouteTablesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotRouteTable),
+		},
+		{
+			name: "SuccessfulCreate",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.RouteTable) (network.RouteTablesCreateOrUpdateFuture, error) {
+					return network.RouteTablesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r: routeTable(),
+			want: routeTable(
+				withConditions(xpv1.Creating()),
+			),
+		},
+		{
+			name: "FailedCreate",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.RouteTable) (network.RouteTablesCreateOrUpdateFuture, error) {
+					return network.RouteTablesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r: routeTable(),
+			want: routeTable(
+				withConditions(xpv1.Creating()),
+			),
+			wantErr: errors.Wrap(errorBoom, errCreateRouteTable),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Create(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotRouteTable",
+			e:       &external{client: &fake.MockRouteTablesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotRouteTable),
+		},
+		{
+			name: "SuccessfulObserveNotExist",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.RouteTable, error) {
+					return network.RouteTable{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r:    routeTable(),
+			want: routeTable(),
+		},
+		{
+			name: "SuccessfulObserveExists",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.RouteTable, error) {
+					return network.RouteTable{
+						ID:   azure.ToStringPtr(id),
+						Tags: azure.ToStringPtrMap(tags),
+						RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{
+							ProvisioningState: azure.ToStringPtr(string(network.Available)),
+						},
+					}, nil
+				},
+			}},
+			r: routeTable(),
+			want: routeTable(
+				withConditions(xpv1.Available()),
+				withState(string(network.Available)),
+				withID(id),
+			),
+		},
+		{
+			name: "FailedObserve",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.RouteTable, error) {
+					return network.RouteTable{}, errorBoom
+				},
+			}},
+			r:       routeTable(),
+			want:    routeTable(),
+			wantErr: errors.Wrap(errorBoom, errGetRouteTable),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Observe(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Observe(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	observed := &[]network.Route{{Name: azure.ToStringPtr("managed-elsewhere")}}
+
+	cases := []testCase{
+		{
+			name:    "NotRouteTable",
+			e:       &external{client: &fake.MockRouteTablesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotRouteTable),
+		},
+		{
+			name: "SuccessfulDoesNotNeedUpdate",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.RouteTable, error) {
+					return network.RouteTable{
+						Tags:                       azure.ToStringPtrMap(tags),
+						RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{Routes: observed},
+					}, nil
+				},
+			}},
+			r:    routeTable(),
+			want: routeTable(),
+		},
+		{
+			name: "SuccessfulNeedsUpdateKeepsObservedRoutes",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.RouteTable, error) {
+					return network.RouteTable{
+						RouteTablePropertiesFormat: &network.RouteTablePropertiesFormat{Routes: observed},
+					}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, p network.RouteTable) (network.RouteTablesCreateOrUpdateFuture, error) {
+					if diff := cmp.Diff(observed, p.Routes); diff != "" {
+						t.Errorf("CreateOrUpdate(...): -want rules, +got:\n%s", diff)
+					}
+					return network.RouteTablesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r:    routeTable(),
+			want: routeTable(),
+		},
+		{
+			name: "UnsuccessfulGet",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.RouteTable, error) {
+					return network.RouteTable{}, errorBoom
+				},
+			}},
+			r:       routeTable(),
+			want:    routeTable(),
+			wantErr: errors.Wrap(errorBoom, errGetRouteTable),
+		},
+		{
+			name: "UnsuccessfulUpdate",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.RouteTable, error) {
+					return network.RouteTable{}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.RouteTable) (network.RouteTablesCreateOrUpdateFuture, error) {
+					return network.RouteTablesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r:       routeTable(),
+			want:    routeTable(),
+			wantErr: errors.Wrap(errorBoom, errUpdateRouteTable),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Update(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Update(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotRouteTable",
+			e:       &external{client: &fake.MockRouteTablesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotRouteTable),
+		},
+		{
+			name: "Successful",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.RouteTablesDeleteFuture, error) {
+					return network.RouteTablesDeleteFuture{}, nil
+				},
+			}},
+			r: routeTable(),
+			want: routeTable(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "SuccessfulNotFound",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.RouteTablesDeleteFuture, error) {
+					return network.RouteTablesDeleteFuture{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r: routeTable(),
+			want: routeTable(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "Failed",
+			e: &external{client: &fake.MockRouteTablesClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.RouteTablesDeleteFuture, error) {
+					return network.RouteTablesDeleteFuture{}, errorBoom
+				},
+			}},
+			r: routeTable(),
+			want: routeTable(
+				withConditions(xpv1.Deleting()),
+			),
+			wantErr: errors.Wrap(errorBoom, errDeleteRouteTable),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.e.Delete(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Delete(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
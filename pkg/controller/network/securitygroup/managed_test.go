@@ -0,0 +1,373 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securitygroup
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
+)
+
+const (
+	name              = "coolNSG"
+	uid               = types.UID("definitely-a-uuid")
+	resourceGroupName = "coolRG"
+	location          = "coolplace"
+	id                = "a-very-cool-id"
+)
+
+var (
+	ctx       = context.Background()
+	errorBoom = errors.New("boom")
+	tags      = map[string]string{"one": "test"}
+)
+
+type testCase struct {
+	name    string
+	e       managed.ExternalClient
+	r       resource.Managed
+	want    resource.Managed
+	wantErr error
+}
+
+type securityGroupModifier func(*v1alpha3.SecurityGroup)
+
+func withConditions(c ...xpv1.Condition) securityGroupModifier {
+	return func(r *v1alpha3.SecurityGroup) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withState(s string) securityGroupModifier {
+	return func(r *v1alpha3.SecurityGroup) { r.Status.State = s }
+}
+
+func withID(id string) securityGroupModifier {
+	return func(r *v1alpha3.SecurityGroup) { r.Status.ID = id }
+}
+
+func securityGroup(sm ...securityGroupModifier) *v1alpha3.SecurityGroup {
+	r := &v1alpha3.SecurityGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			UID:        uid,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha3.SecurityGroupSpec{
+			ResourceGroupName: resourceGroupName,
+			Location:          location,
+			Tags:              tags,
+		},
+		Status: v1alpha3.SecurityGroupStatus{},
+	}
+
+	meta.SetExternalName(r, name)
+
+	for _, m := range sm {
+		m(r)
+	}
+
+	return r
+}
+
+// Test that our Reconciler implementation satisfies the Reconciler interface.
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestCreate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotSecurityGroup",
+			e:       &external{client: &fake.MockSecurityGroupsClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotSecurityGroup),
+		},
+		{
+			name: "SuccessfulCreate",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.SecurityGroup) (network.SecurityGroupsCreateOrUpdateFuture, error) {
+					return network.SecurityGroupsCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r: securityGroup(),
+			want: securityGroup(
+				withConditions(xpv1.Creating()),
+			),
+		},
+		{
+			name: "FailedCreate",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.SecurityGroup) (network.SecurityGroupsCreateOrUpdateFuture, error) {
+					return network.SecurityGroupsCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r: securityGroup(),
+			want: securityGroup(
+				withConditions(xpv1.Creating()),
+			),
+			wantErr: errors.Wrap(errorBoom, errCreateSecurityGroup),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Create(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotSecurityGroup",
+			e:       &external{client: &fake.MockSecurityGroupsClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotSecurityGroup),
+		},
+		{
+			name: "SuccessfulObserveNotExist",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityGroup, error) {
+					return network.SecurityGroup{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r:    securityGroup(),
+			want: securityGroup(),
+		},
+		{
+			name: "SuccessfulObserveExists",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityGroup, error) {
+					return network.SecurityGroup{
+						ID:   azure.ToStringPtr(id),
+						Tags: azure.ToStringPtrMap(tags),
+						SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{
+							ProvisioningState: azure.ToStringPtr(string(network.Available)),
+						},
+					}, nil
+				},
+			}},
+			r: securityGroup(),
+			want: securityGroup(
+				withConditions(xpv1.Available()),
+				withState(string(network.Available)),
+				withID(id),
+			),
+		},
+		{
+			name: "FailedObserve",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityGroup, error) {
+					return network.SecurityGroup{}, errorBoom
+				},
+			}},
+			r:       securityGroup(),
+			want:    securityGroup(),
+			wantErr: errors.Wrap(errorBoom, errGetSecurityGroup),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Observe(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Observe(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	observed := &[]network.SecurityRule{{Name: azure.ToStringPtr("managed-elsewhere")}}
+
+	cases := []testCase{
+		{
+			name:    "NotSecurityGroup",
+			e:       &external{client: &fake.MockSecurityGroupsClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotSecurityGroup),
+		},
+		{
+			name: "SuccessfulDoesNotNeedUpdate",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityGroup, error) {
+					return network.SecurityGroup{
+						Tags:                          azure.ToStringPtrMap(tags),
+						SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{SecurityRules: observed},
+					}, nil
+				},
+			}},
+			r:    securityGroup(),
+			want: securityGroup(),
+		},
+		{
+			name: "SuccessfulNeedsUpdateKeepsObservedRules",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityGroup, error) {
+					return network.SecurityGroup{
+						SecurityGroupPropertiesFormat: &network.SecurityGroupPropertiesFormat{SecurityRules: observed},
+					}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, p network.SecurityGroup) (network.SecurityGroupsCreateOrUpdateFuture, error) {
+					if diff := cmp.Diff(observed, p.SecurityRules); diff != "" {
+						t.Errorf("CreateOrUpdate(...): -want rules, +got:\n%s", diff)
+					}
+					return network.SecurityGroupsCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r:    securityGroup(),
+			want: securityGroup(),
+		},
+		{
+			name: "UnsuccessfulGet",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityGroup, error) {
+					return network.SecurityGroup{}, errorBoom
+				},
+			}},
+			r:       securityGroup(),
+			want:    securityGroup(),
+			wantErr: errors.Wrap(errorBoom, errGetSecurityGroup),
+		},
+		{
+			name: "UnsuccessfulUpdate",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityGroup, error) {
+					return network.SecurityGroup{}, nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ network.SecurityGroup) (network.SecurityGroupsCreateOrUpdateFuture, error) {
+					return network.SecurityGroupsCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r:       securityGroup(),
+			want:    securityGroup(),
+			wantErr: errors.Wrap(errorBoom, errUpdateSecurityGroup),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Update(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Update(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotSecurityGroup",
+			e:       &external{client: &fake.MockSecurityGroupsClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotSecurityGroup),
+		},
+		{
+			name: "Successful",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.SecurityGroupsDeleteFuture, error) {
+					return network.SecurityGroupsDeleteFuture{}, nil
+				},
+			}},
+			r: securityGroup(),
+			want: securityGroup(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "SuccessfulNotFound",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.SecurityGroupsDeleteFuture, error) {
+					return network.SecurityGroupsDeleteFuture{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r: securityGroup(),
+			want: securityGroup(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "Failed",
+			e: &external{client: &fake.MockSecurityGroupsClient{
+				MockDelete: func(_ context.Context, _ string, _ string) (network.SecurityGroupsDeleteFuture, error) {
+					return network.SecurityGroupsDeleteFuture{}, errorBoom
+				},
+			}},
+			r: securityGroup(),
+			want: securityGroup(
+				withConditions(xpv1.Deleting()),
+			),
+			wantErr: errors.Wrap(errorBoom, errDeleteSecurityGroup),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.e.Delete(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Delete(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
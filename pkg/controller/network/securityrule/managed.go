@@ -0,0 +1,166 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securityrule
+
+import (
+	"context"
+	"time"
+
+	azurenetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotSecurityRule    = "managed resource is not a SecurityRule"
+	errCreateSecurityRule = "cannot create SecurityRule"
+	errUpdateSecurityRule = "cannot update SecurityRule"
+	errGetSecurityRule    = "cannot get SecurityRule"
+	errDeleteSecurityRule = "cannot delete SecurityRule"
+)
+
+// Setup adds a controller that reconciles SecurityRules.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1alpha3.SecurityRuleGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1alpha3.SecurityRule{}).
+		Complete(tracing.Reconciler(name, v1alpha3.SecurityRuleGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha3.SecurityRuleGroupVersionKind),
+			managed.WithConnectionPublishers(),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{client: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.id"))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	cl := azurenetwork.NewSecurityRulesClient(creds[azureclients.CredentialsKeySubscriptionID])
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client networkapi.SecurityRulesClientAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	sr, ok := mg.(*v1alpha3.SecurityRule)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotSecurityRule)
+	}
+
+	az, err := e.client.Get(ctx, sr.Spec.ResourceGroupName, sr.Spec.SecurityGroupName, meta.GetExternalName(sr))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetSecurityRule)
+	}
+
+	network.UpdateSecurityRuleStatusFromAzure(sr, az)
+	sr.SetConditions(xpv1.Available())
+
+	o := managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  !network.SecurityRuleNeedsUpdate(sr, az),
+		ConnectionDetails: managed.ConnectionDetails{},
+	}
+
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	sr, ok := mg.(*v1alpha3.SecurityRule)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotSecurityRule)
+	}
+
+	sr.Status.SetConditions(xpv1.Creating())
+
+	rule := network.NewSecurityRuleParameters(sr)
+	if _, err := e.client.CreateOrUpdate(ctx, sr.Spec.ResourceGroupName, sr.Spec.SecurityGroupName, meta.GetExternalName(sr), rule); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateSecurityRule)
+	}
+
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	sr, ok := mg.(*v1alpha3.SecurityRule)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotSecurityRule)
+	}
+
+	az, err := e.client.Get(ctx, sr.Spec.ResourceGroupName, sr.Spec.SecurityGroupName, meta.GetExternalName(sr))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetSecurityRule)
+	}
+
+	if network.SecurityRuleNeedsUpdate(sr, az) {
+		rule := network.NewSecurityRuleParameters(sr)
+		if _, err := e.client.CreateOrUpdate(ctx, sr.Spec.ResourceGroupName, sr.Spec.SecurityGroupName, meta.GetExternalName(sr), rule); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSecurityRule)
+		}
+	}
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	sr, ok := mg.(*v1alpha3.SecurityRule)
+	if !ok {
+		return errors.New(errNotSecurityRule)
+	}
+
+	mg.SetConditions(xpv1.Deleting())
+
+	_, err := e.client.Delete(ctx, sr.Spec.ResourceGroupName, sr.Spec.SecurityGroupName, meta.GetExternalName(sr))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteSecurityRule)
+}
@@ -0,0 +1,405 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package securityrule
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
+)
+
+const (
+	name              = "coolRule"
+	uid               = types.UID("definitely-a-uuid")
+	securityGroupName = "coolNSG"
+	resourceGroupName = "coolRG"
+	priority          = 100
+)
+
+var (
+	ctx       = context.Background()
+	errorBoom = errors.New("boom")
+)
+
+type testCase struct {
+	name    string
+	e       managed.ExternalClient
+	r       resource.Managed
+	want    resource.Managed
+	wantErr error
+}
+
+type securityRuleModifier func(*v1alpha3.SecurityRule)
+
+func withConditions(c ...xpv1.Condition) securityRuleModifier {
+	return func(r *v1alpha3.SecurityRule) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withState(s string) securityRuleModifier {
+	return func(r *v1alpha3.SecurityRule) { r.Status.State = s }
+}
+
+func securityRule(sm ...securityRuleModifier) *v1alpha3.SecurityRule {
+	r := &v1alpha3.SecurityRule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			UID:        uid,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha3.SecurityRuleSpec{
+			SecurityGroupName: securityGroupName,
+			ResourceGroupName: resourceGroupName,
+			SecurityRulePropertiesFormat: v1alpha3.SecurityRulePropertiesFormat{
+				Protocol:              "Tcp",
+				SourcePortRanges:      []string{"*"},
+				DestinationPortRanges: []string{"443"},
+				SourceAddressPrefixes: []string{"*"},
+				Access:                "Allow",
+				Priority:              priority,
+				Direction:             "Inbound",
+			},
+		},
+		Status: v1alpha3.SecurityRuleStatus{},
+	}
+
+	meta.SetExternalName(r, name)
+
+	for _, m := range sm {
+		m(r)
+	}
+
+	return r
+}
+
+func azureSecurityRule(p int32, state string) network.SecurityRule {
+	return network.SecurityRule{
+		SecurityRulePropertiesFormat: &network.SecurityRulePropertiesFormat{
+			Protocol:             network.SecurityRuleProtocolTCP,
+			SourcePortRange:      azure.ToStringPtr("*"),
+			DestinationPortRange: azure.ToStringPtr("443"),
+			SourceAddressPrefix:  azure.ToStringPtr("*"),
+			Access:               network.SecurityRuleAccessAllow,
+			Priority:             &p,
+			Direction:            network.SecurityRuleDirectionInbound,
+			ProvisioningState:    azure.ToStringPtr(state),
+		},
+	}
+}
+
+// Test that our Reconciler implementation satisfies the Reconciler interface.
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestCreate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotSecurityRule",
+			e:       &external{client: &fake.MockSecurityRulesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotSecurityRule),
+		},
+		{
+			name: "SuccessfulCreate",
+			e: &external{client: &fake.MockSecurityRulesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.SecurityRule) (network.SecurityRulesCreateOrUpdateFuture, error) {
+					return network.SecurityRulesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r: securityRule(),
+			want: securityRule(
+				withConditions(xpv1.Creating()),
+			),
+		},
+		{
+			name: "FailedCreate",
+			e: &external{client: &fake.MockSecurityRulesClient{
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.SecurityRule) (network.SecurityRulesCreateOrUpdateFuture, error) {
+					return network.SecurityRulesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r: securityRule(),
+			want: securityRule(
+				withConditions(xpv1.Creating()),
+			),
+			wantErr: errors.Wrap(errorBoom, errCreateSecurityRule),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Create(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	cases := []struct {
+		testCase
+		upToDate bool
+	}{
+		{
+			testCase: testCase{
+				name:    "NotSecurityRule",
+				e:       &external{client: &fake.MockSecurityRulesClient{}},
+				r:       &v1alpha3.Subnet{},
+				want:    &v1alpha3.Subnet{},
+				wantErr: errors.New(errNotSecurityRule),
+			},
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveNotExist",
+				e: &external{client: &fake.MockSecurityRulesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRule, error) {
+						return network.SecurityRule{}, autorest.DetailedError{
+							StatusCode: http.StatusNotFound,
+						}
+					},
+				}},
+				r:    securityRule(),
+				want: securityRule(),
+			},
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveExists",
+				e: &external{client: &fake.MockSecurityRulesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRule, error) {
+						return azureSecurityRule(priority, string(network.Available)), nil
+					},
+				}},
+				r: securityRule(),
+				want: securityRule(
+					withConditions(xpv1.Available()),
+					withState(string(network.Available)),
+				),
+			},
+			upToDate: true,
+		},
+		{
+			testCase: testCase{
+				name: "SuccessfulObserveNeedsUpdate",
+				e: &external{client: &fake.MockSecurityRulesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRule, error) {
+						return azureSecurityRule(priority+1, string(network.Available)), nil
+					},
+				}},
+				r: securityRule(),
+				want: securityRule(
+					withConditions(xpv1.Available()),
+					withState(string(network.Available)),
+				),
+			},
+		},
+		{
+			testCase: testCase{
+				name: "FailedObserve",
+				e: &external{client: &fake.MockSecurityRulesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRule, error) {
+						return network.SecurityRule{}, errorBoom
+					},
+				}},
+				r:       securityRule(),
+				want:    securityRule(),
+				wantErr: errors.Wrap(errorBoom, errGetSecurityRule),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			o, err := tc.e.Observe(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Observe(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.upToDate, o.ResourceUpToDate); diff != "" {
+				t.Errorf("tc.e.Observe(...): -want ResourceUpToDate, +got:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotSecurityRule",
+			e:       &external{client: &fake.MockSecurityRulesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotSecurityRule),
+		},
+		{
+			name: "SuccessfulDoesNotNeedUpdate",
+			e: &external{client: &fake.MockSecurityRulesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRule, error) {
+					return azureSecurityRule(priority, ""), nil
+				},
+			}},
+			r:    securityRule(),
+			want: securityRule(),
+		},
+		{
+			name: "SuccessfulNeedsUpdate",
+			e: &external{client: &fake.MockSecurityRulesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRule, error) {
+					return azureSecurityRule(priority+1, ""), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.SecurityRule) (network.SecurityRulesCreateOrUpdateFuture, error) {
+					return network.SecurityRulesCreateOrUpdateFuture{}, nil
+				},
+			}},
+			r:    securityRule(),
+			want: securityRule(),
+		},
+		{
+			name: "UnsuccessfulGet",
+			e: &external{client: &fake.MockSecurityRulesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRule, error) {
+					return network.SecurityRule{}, errorBoom
+				},
+			}},
+			r:       securityRule(),
+			want:    securityRule(),
+			wantErr: errors.Wrap(errorBoom, errGetSecurityRule),
+		},
+		{
+			name: "UnsuccessfulUpdate",
+			e: &external{client: &fake.MockSecurityRulesClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRule, error) {
+					return azureSecurityRule(priority+1, ""), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ string, _ network.SecurityRule) (network.SecurityRulesCreateOrUpdateFuture, error) {
+					return network.SecurityRulesCreateOrUpdateFuture{}, errorBoom
+				},
+			}},
+			r:       securityRule(),
+			want:    securityRule(),
+			wantErr: errors.Wrap(errorBoom, errUpdateSecurityRule),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := tc.e.Update(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Update(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := []testCase{
+		{
+			name:    "NotSecurityRule",
+			e:       &external{client: &fake.MockSecurityRulesClient{}},
+			r:       &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotSecurityRule),
+		},
+		{
+			name: "Successful",
+			e: &external{client: &fake.MockSecurityRulesClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRulesDeleteFuture, error) {
+					return network.SecurityRulesDeleteFuture{}, nil
+				},
+			}},
+			r: securityRule(),
+			want: securityRule(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "SuccessfulNotFound",
+			e: &external{client: &fake.MockSecurityRulesClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRulesDeleteFuture, error) {
+					return network.SecurityRulesDeleteFuture{}, autorest.DetailedError{
+						StatusCode: http.StatusNotFound,
+					}
+				},
+			}},
+			r: securityRule(),
+			want: securityRule(
+				withConditions(xpv1.Deleting()),
+			),
+		},
+		{
+			name: "Failed",
+			e: &external{client: &fake.MockSecurityRulesClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (network.SecurityRulesDeleteFuture, error) {
+					return network.SecurityRulesDeleteFuture{}, errorBoom
+				},
+			}},
+			r: securityRule(),
+			want: securityRule(
+				withConditions(xpv1.Deleting()),
+			),
+			wantErr: errors.Wrap(errorBoom, errDeleteSecurityRule),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.e.Delete(ctx, tc.r)
+
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("tc.e.Delete(...): want error != got error:\n%s", diff)
+			}
+
+			if diff := cmp.Diff(tc.want, tc.r, test.EquateConditions()); diff != "" {
+				t.Errorf("r: -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,359 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworkpeering
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	azurenetwork "github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	autorestazure "github.com/Azure/go-autorest/autorest/azure"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azureclients "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotVirtualNetworkPeering     = "managed resource is not a VirtualNetworkPeering"
+	errCreateVirtualNetworkPeering  = "cannot create VirtualNetworkPeering"
+	errUpdateVirtualNetworkPeering  = "cannot update VirtualNetworkPeering"
+	errGetVirtualNetworkPeering     = "cannot get VirtualNetworkPeering"
+	errDeleteVirtualNetworkPeering  = "cannot delete VirtualNetworkPeering"
+	errParseRemoteID                = "cannot parse remote virtual network ID"
+	errGetReversePeering            = "cannot get reverse VirtualNetworkPeering"
+	errCreateOrUpdateReversePeering = "cannot create or update reverse VirtualNetworkPeering"
+	errDeleteReversePeering         = "cannot delete reverse VirtualNetworkPeering"
+)
+
+// Setup adds a controller that reconciles VirtualNetworkPeerings.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1alpha3.VirtualNetworkPeeringGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1alpha3.VirtualNetworkPeering{}).
+		Complete(tracing.Reconciler(name, v1alpha3.VirtualNetworkPeeringGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1alpha3.VirtualNetworkPeeringGroupVersionKind),
+			managed.WithConnectionPublishers(),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{client: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.id"))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	client client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azureclients.GetAuthInfo(ctx, c.client, mg)
+	if err != nil {
+		return nil, err
+	}
+	newClient := func(subscriptionID string) networkapi.VirtualNetworkPeeringsClientAPI {
+		cl := azurenetwork.NewVirtualNetworkPeeringsClient(subscriptionID)
+		cl.Authorizer = auth
+		return cl
+	}
+	subscriptionID := creds[azureclients.CredentialsKeySubscriptionID]
+	return &external{client: newClient(subscriptionID), subscriptionID: subscriptionID, newClient: newClient}, nil
+}
+
+type external struct {
+	client         networkapi.VirtualNetworkPeeringsClientAPI
+	subscriptionID string
+
+	// newClient returns a client for the subscription of the remote virtual
+	// network, in which the reverse peering is reconciled.
+	newClient func(subscriptionID string) networkapi.VirtualNetworkPeeringsClientAPI
+}
+
+// A reversePeering identifies the peering from the remote virtual network back
+// to the local virtual network.
+type reversePeering struct {
+	client             networkapi.VirtualNetworkPeeringsClientAPI
+	resourceGroupName  string
+	virtualNetworkName string
+	name               string
+	id                 string
+
+	// remoteID is the ID of the local virtual network, as seen from the
+	// remote virtual network.
+	remoteID string
+	options  v1alpha3.VirtualNetworkPeeringOptions
+}
+
+func (e *external) reverse(p *v1alpha3.VirtualNetworkPeering) (reversePeering, error) {
+	remote, err := autorestazure.ParseResourceID(p.Spec.RemoteVirtualNetworkID)
+	if err != nil {
+		return reversePeering{}, errors.Wrap(err, errParseRemoteID)
+	}
+	name := p.Spec.ReversePeering.Name
+	if name == "" {
+		name = meta.GetExternalName(p)
+	}
+	return reversePeering{
+		client:             e.newClient(remote.SubscriptionID),
+		resourceGroupName:  remote.ResourceGroup,
+		virtualNetworkName: remote.ResourceName,
+		name:               name,
+		id: network.VirtualNetworkPeeringID{
+			SubscriptionID:     remote.SubscriptionID,
+			ResourceGroupName:  remote.ResourceGroup,
+			VirtualNetworkName: remote.ResourceName,
+			Name:               name,
+		}.String(),
+		remoteID: network.VirtualNetworkID(e.subscriptionID, p.Spec.ResourceGroupName, p.Spec.VirtualNetworkName),
+		options:  p.Spec.ReversePeering.VirtualNetworkPeeringOptions,
+	}, nil
+}
+
+// staleReversePeeringID returns the ID of the reverse peering recorded in the
+// status of the supplied peering if it is no longer specified, either because
+// the reverse peering was removed or because it was renamed.
+func (e *external) staleReversePeeringID(p *v1alpha3.VirtualNetworkPeering) (string, error) {
+	id := p.Status.ReversePeeringID
+	if id == "" || p.Spec.ReversePeering == nil {
+		return id, nil
+	}
+	r, err := e.reverse(p)
+	if err != nil {
+		return "", err
+	}
+	if strings.EqualFold(id, r.id) {
+		return "", nil
+	}
+	return id, nil
+}
+
+// deleteReverse deletes the reverse peering with the supplied ID, if it
+// exists.
+func (e *external) deleteReverse(ctx context.Context, id string) error {
+	r, err := network.ParseVirtualNetworkPeeringID(id)
+	if err != nil {
+		return err
+	}
+	_, err = e.newClient(r.SubscriptionID).Delete(ctx, r.ResourceGroupName, r.VirtualNetworkName, r.Name)
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteReversePeering)
+}
+
+// disconnected returns true if the supplied peering is disconnected, which it
+// becomes once the peering in the remote virtual network is deleted. It must
+// be deleted and recreated to connect again.
+func disconnected(az azurenetwork.VirtualNetworkPeering) bool {
+	return az.VirtualNetworkPeeringPropertiesFormat != nil && az.PeeringState == azurenetwork.VirtualNetworkPeeringStateDisconnected
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	p, ok := mg.(*v1alpha3.VirtualNetworkPeering)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotVirtualNetworkPeering)
+	}
+
+	az, err := e.client.Get(ctx, p.Spec.ResourceGroupName, p.Spec.VirtualNetworkName, meta.GetExternalName(p))
+	if azureclients.IsNotFound(err) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetVirtualNetworkPeering)
+	}
+
+	network.UpdateVirtualNetworkPeeringStatusFromAzure(p, az)
+	upToDate := !disconnected(az) && !network.VirtualNetworkPeeringNeedsUpdate(p.Spec.RemoteVirtualNetworkID, p.Spec.VirtualNetworkPeeringOptions, az)
+
+	if p.Spec.ReversePeering != nil {
+		r, err := e.reverse(p)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		raz, err := r.client.Get(ctx, r.resourceGroupName, r.virtualNetworkName, r.name)
+		if resource.Ignore(azureclients.IsNotFound, err) != nil {
+			return managed.ExternalObservation{}, errors.Wrap(err, errGetReversePeering)
+		}
+		p.Status.ReversePeeringState = ""
+		if err == nil && raz.VirtualNetworkPeeringPropertiesFormat != nil {
+			p.Status.ReversePeeringState = string(raz.PeeringState)
+		}
+		// The ID of a previously reconciled reverse peering is kept until
+		// that peering is deleted.
+		if err == nil && p.Status.ReversePeeringID == "" {
+			p.Status.ReversePeeringID = azureclients.ToString(raz.ID)
+		}
+		upToDate = upToDate && err == nil && !disconnected(raz) && !network.VirtualNetworkPeeringNeedsUpdate(r.remoteID, r.options, raz)
+	}
+
+	stale, err := e.staleReversePeeringID(p)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	upToDate = upToDate && stale == ""
+
+	// A peering only carries traffic once the peering in the remote virtual
+	// network exists too.
+	switch azurenetwork.VirtualNetworkPeeringState(p.Status.PeeringState) {
+	case azurenetwork.VirtualNetworkPeeringStateConnected:
+		p.SetConditions(xpv1.Available())
+	default:
+		p.SetConditions(xpv1.Unavailable())
+	}
+
+	o := managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  upToDate,
+		ConnectionDetails: managed.ConnectionDetails{},
+	}
+
+	return o, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	p, ok := mg.(*v1alpha3.VirtualNetworkPeering)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotVirtualNetworkPeering)
+	}
+
+	p.Status.SetConditions(xpv1.Creating())
+
+	params := network.NewVirtualNetworkPeeringParameters(p.Spec.RemoteVirtualNetworkID, p.Spec.VirtualNetworkPeeringOptions)
+	if _, err := e.client.CreateOrUpdate(ctx, p.Spec.ResourceGroupName, p.Spec.VirtualNetworkName, meta.GetExternalName(p), params); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateVirtualNetworkPeering)
+	}
+
+	// The reverse peering is created by Update once this peering is observed.
+	return managed.ExternalCreation{}, nil
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	p, ok := mg.(*v1alpha3.VirtualNetworkPeering)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotVirtualNetworkPeering)
+	}
+
+	az, err := e.client.Get(ctx, p.Spec.ResourceGroupName, p.Spec.VirtualNetworkName, meta.GetExternalName(p))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetVirtualNetworkPeering)
+	}
+
+	// A disconnected peering is recreated by Create once its deletion is
+	// observed.
+	if disconnected(az) {
+		_, err := e.client.Delete(ctx, p.Spec.ResourceGroupName, p.Spec.VirtualNetworkName, meta.GetExternalName(p))
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDeleteVirtualNetworkPeering)
+	}
+
+	if network.VirtualNetworkPeeringNeedsUpdate(p.Spec.RemoteVirtualNetworkID, p.Spec.VirtualNetworkPeeringOptions, az) {
+		params := network.NewVirtualNetworkPeeringParameters(p.Spec.RemoteVirtualNetworkID, p.Spec.VirtualNetworkPeeringOptions)
+		if _, err := e.client.CreateOrUpdate(ctx, p.Spec.ResourceGroupName, p.Spec.VirtualNetworkName, meta.GetExternalName(p), params); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateVirtualNetworkPeering)
+		}
+	}
+
+	stale, err := e.staleReversePeeringID(p)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	if stale != "" {
+		if err := e.deleteReverse(ctx, stale); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		p.Status.ReversePeeringID = ""
+		p.Status.ReversePeeringState = ""
+	}
+
+	if p.Spec.ReversePeering == nil {
+		return managed.ExternalUpdate{}, nil
+	}
+
+	r, err := e.reverse(p)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	raz, err := r.client.Get(ctx, r.resourceGroupName, r.virtualNetworkName, r.name)
+	if resource.Ignore(azureclients.IsNotFound, err) != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errGetReversePeering)
+	}
+	// A disconnected reverse peering is recreated by a later Update once its
+	// deletion is observed.
+	if err == nil && disconnected(raz) {
+		_, err := r.client.Delete(ctx, r.resourceGroupName, r.virtualNetworkName, r.name)
+		return managed.ExternalUpdate{}, errors.Wrap(err, errDeleteReversePeering)
+	}
+	if err == nil && !network.VirtualNetworkPeeringNeedsUpdate(r.remoteID, r.options, raz) {
+		return managed.ExternalUpdate{}, nil
+	}
+	params := network.NewVirtualNetworkPeeringParameters(r.remoteID, r.options)
+	if _, err := r.client.CreateOrUpdate(ctx, r.resourceGroupName, r.virtualNetworkName, r.name, params); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errCreateOrUpdateReversePeering)
+	}
+	p.Status.ReversePeeringID = r.id
+	return managed.ExternalUpdate{}, nil
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	p, ok := mg.(*v1alpha3.VirtualNetworkPeering)
+	if !ok {
+		return errors.New(errNotVirtualNetworkPeering)
+	}
+
+	mg.SetConditions(xpv1.Deleting())
+
+	if p.Spec.ReversePeering != nil {
+		r, err := e.reverse(p)
+		if err != nil {
+			return err
+		}
+		if err := e.deleteReverse(ctx, r.id); err != nil {
+			return err
+		}
+	}
+	stale, err := e.staleReversePeeringID(p)
+	if err != nil {
+		return err
+	}
+	if stale != "" {
+		if err := e.deleteReverse(ctx, stale); err != nil {
+			return err
+		}
+	}
+
+	_, err = e.client.Delete(ctx, p.Spec.ResourceGroupName, p.Spec.VirtualNetworkName, meta.GetExternalName(p))
+	return errors.Wrap(resource.Ignore(azureclients.IsNotFound, err), errDeleteVirtualNetworkPeering)
+}
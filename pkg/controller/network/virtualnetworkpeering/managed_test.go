@@ -0,0 +1,688 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package virtualnetworkpeering
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/network/v1alpha3"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/network/fake"
+)
+
+const (
+	name                 = "spoke-to-hub"
+	uid                  = types.UID("definitely-a-uuid")
+	subscriptionID       = "spoke-sub"
+	resourceGroupName    = "spokeRG"
+	virtualNetworkName   = "spoke"
+	remoteSubscriptionID = "hub-sub"
+	remoteID             = "/subscriptions/hub-sub/resourceGroups/hubRG/providers/Microsoft.Network/virtualNetworks/hub"
+	localID              = "/subscriptions/spoke-sub/resourceGroups/spokeRG/providers/Microsoft.Network/virtualNetworks/spoke"
+	reverseID            = remoteID + "/virtualNetworkPeerings/" + name
+)
+
+var (
+	ctx       = context.Background()
+	errorBoom = errors.New("boom")
+	notFound  = autorest.DetailedError{StatusCode: http.StatusNotFound}
+)
+
+type peeringModifier func(*v1alpha3.VirtualNetworkPeering)
+
+func withConditions(c ...xpv1.Condition) peeringModifier {
+	return func(r *v1alpha3.VirtualNetworkPeering) { r.Status.ConditionedStatus.Conditions = c }
+}
+
+func withPeeringState(s network.VirtualNetworkPeeringState) peeringModifier {
+	return func(r *v1alpha3.VirtualNetworkPeering) { r.Status.PeeringState = string(s) }
+}
+
+func withReversePeeringState(s network.VirtualNetworkPeeringState) peeringModifier {
+	return func(r *v1alpha3.VirtualNetworkPeering) { r.Status.ReversePeeringState = string(s) }
+}
+
+func withReversePeeringID(id string) peeringModifier {
+	return func(r *v1alpha3.VirtualNetworkPeering) { r.Status.ReversePeeringID = id }
+}
+
+func withReversePeering() peeringModifier {
+	return func(r *v1alpha3.VirtualNetworkPeering) {
+		r.Spec.ReversePeering = &v1alpha3.ReverseVirtualNetworkPeering{
+			VirtualNetworkPeeringOptions: v1alpha3.VirtualNetworkPeeringOptions{AllowGatewayTransit: true},
+		}
+	}
+}
+
+func peering(pm ...peeringModifier) *v1alpha3.VirtualNetworkPeering {
+	r := &v1alpha3.VirtualNetworkPeering{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       name,
+			UID:        uid,
+			Finalizers: []string{},
+		},
+		Spec: v1alpha3.VirtualNetworkPeeringSpec{
+			ResourceGroupName:  resourceGroupName,
+			VirtualNetworkName: virtualNetworkName,
+			VirtualNetworkPeeringPropertiesFormat: v1alpha3.VirtualNetworkPeeringPropertiesFormat{
+				RemoteVirtualNetworkID: remoteID,
+				VirtualNetworkPeeringOptions: v1alpha3.VirtualNetworkPeeringOptions{
+					AllowForwardedTraffic: true,
+					UseRemoteGateways:     true,
+				},
+			},
+		},
+	}
+
+	meta.SetExternalName(r, name)
+
+	for _, m := range pm {
+		m(r)
+	}
+
+	return r
+}
+
+func azurePeering(remote string, forwarded, transit, remoteGateways bool, s network.VirtualNetworkPeeringState) network.VirtualNetworkPeering {
+	return network.VirtualNetworkPeering{
+		VirtualNetworkPeeringPropertiesFormat: &network.VirtualNetworkPeeringPropertiesFormat{
+			RemoteVirtualNetwork:  &network.SubResource{ID: azure.ToStringPtr(remote)},
+			AllowForwardedTraffic: &forwarded,
+			AllowGatewayTransit:   &transit,
+			UseRemoteGateways:     &remoteGateways,
+			PeeringState:          s,
+		},
+	}
+}
+
+// newExternal returns an external client whose reverse peerings are
+// reconciled using the supplied client, which must be for the remote
+// subscription.
+func newExternal(t *testing.T, local, remote networkapi.VirtualNetworkPeeringsClientAPI) *external {
+	return &external{
+		client:         local,
+		subscriptionID: subscriptionID,
+		newClient: func(s string) networkapi.VirtualNetworkPeeringsClientAPI {
+			if s != remoteSubscriptionID {
+				t.Errorf("newClient(...): want subscription %q, got %q", remoteSubscriptionID, s)
+			}
+			return remote
+		},
+	}
+}
+
+// Test that our Reconciler implementation satisfies the Reconciler interface.
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+func TestObserve(t *testing.T) {
+	connected := func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+		return azurePeering(remoteID, true, false, true, network.VirtualNetworkPeeringStateConnected), nil
+	}
+
+	type want struct {
+		o   managed.ExternalObservation
+		cr  resource.Managed
+		err error
+	}
+
+	cases := map[string]struct {
+		e    *external
+		cr   resource.Managed
+		want want
+	}{
+		"NotVirtualNetworkPeering": {
+			e:  newExternal(t, &fake.MockVirtualNetworkPeeringsClient{}, nil),
+			cr: &v1alpha3.Subnet{},
+			want: want{
+				cr:  &v1alpha3.Subnet{},
+				err: errors.New(errNotVirtualNetworkPeering),
+			},
+		},
+		"NotFound": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return network.VirtualNetworkPeering{}, notFound
+				},
+			}, nil),
+			cr: peering(),
+			want: want{
+				cr: peering(),
+			},
+		},
+		"GetFailed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return network.VirtualNetworkPeering{}, errorBoom
+				},
+			}, nil),
+			cr: peering(),
+			want: want{
+				cr:  peering(),
+				err: errors.Wrap(errorBoom, errGetVirtualNetworkPeering),
+			},
+		},
+		"Initiated": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return azurePeering(remoteID, true, false, true, network.VirtualNetworkPeeringStateInitiated), nil
+				},
+			}, nil),
+			cr: peering(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				cr: peering(
+					withPeeringState(network.VirtualNetworkPeeringStateInitiated),
+					withConditions(xpv1.Unavailable()),
+				),
+			},
+		},
+		"Connected": {
+			e:  newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: connected}, nil),
+			cr: peering(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				cr: peering(
+					withPeeringState(network.VirtualNetworkPeeringStateConnected),
+					withConditions(xpv1.Available()),
+				),
+			},
+		},
+		"Disconnected": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return azurePeering(remoteID, true, false, true, network.VirtualNetworkPeeringStateDisconnected), nil
+				},
+			}, nil),
+			cr: peering(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				cr: peering(
+					withPeeringState(network.VirtualNetworkPeeringStateDisconnected),
+					withConditions(xpv1.Unavailable()),
+				),
+			},
+		},
+		"NeedsUpdate": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return azurePeering(remoteID, false, false, true, network.VirtualNetworkPeeringStateConnected), nil
+				},
+			}, nil),
+			cr: peering(),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				cr: peering(
+					withPeeringState(network.VirtualNetworkPeeringStateConnected),
+					withConditions(xpv1.Available()),
+				),
+			},
+		},
+		"ReversePeeringNotFound": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: connected}, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return network.VirtualNetworkPeering{}, notFound
+				},
+			}),
+			cr: peering(withReversePeering()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				cr: peering(
+					withReversePeering(),
+					withPeeringState(network.VirtualNetworkPeeringStateConnected),
+					withConditions(xpv1.Available()),
+				),
+			},
+		},
+		"ReversePeeringUpToDate": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: connected}, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, rg, vnet, n string) (network.VirtualNetworkPeering, error) {
+					if diff := cmp.Diff([]string{"hubRG", "hub", name}, []string{rg, vnet, n}); diff != "" {
+						t.Errorf("Get(...): -want, +got:\n%s", diff)
+					}
+					return azurePeering(localID, false, true, false, network.VirtualNetworkPeeringStateConnected), nil
+				},
+			}),
+			cr: peering(withReversePeering()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				cr: peering(
+					withReversePeering(),
+					withPeeringState(network.VirtualNetworkPeeringStateConnected),
+					withReversePeeringState(network.VirtualNetworkPeeringStateConnected),
+					withConditions(xpv1.Available()),
+				),
+			},
+		},
+		"ReversePeeringIDRecorded": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: connected}, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					p := azurePeering(localID, false, true, false, network.VirtualNetworkPeeringStateConnected)
+					p.ID = azure.ToStringPtr(reverseID)
+					return p, nil
+				},
+			}),
+			cr: peering(withReversePeering()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ConnectionDetails: managed.ConnectionDetails{}},
+				cr: peering(
+					withReversePeering(),
+					withPeeringState(network.VirtualNetworkPeeringStateConnected),
+					withReversePeeringState(network.VirtualNetworkPeeringStateConnected),
+					withReversePeeringID(reverseID),
+					withConditions(xpv1.Available()),
+				),
+			},
+		},
+		"ReversePeeringDisconnected": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: connected}, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return azurePeering(localID, false, true, false, network.VirtualNetworkPeeringStateDisconnected), nil
+				},
+			}),
+			cr: peering(withReversePeering()),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				cr: peering(
+					withReversePeering(),
+					withPeeringState(network.VirtualNetworkPeeringStateConnected),
+					withReversePeeringState(network.VirtualNetworkPeeringStateDisconnected),
+					withConditions(xpv1.Available()),
+				),
+			},
+		},
+		"ReversePeeringRemoved": {
+			e:  newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: connected}, nil),
+			cr: peering(withReversePeeringID(reverseID)),
+			want: want{
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: false, ConnectionDetails: managed.ConnectionDetails{}},
+				cr: peering(
+					withReversePeeringID(reverseID),
+					withPeeringState(network.VirtualNetworkPeeringStateConnected),
+					withConditions(xpv1.Available()),
+				),
+			},
+		},
+		"ReversePeeringGetFailed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: connected}, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return network.VirtualNetworkPeering{}, errorBoom
+				},
+			}),
+			cr: peering(withReversePeering()),
+			want: want{
+				cr: peering(
+					withReversePeering(),
+					withPeeringState(network.VirtualNetworkPeeringStateConnected),
+				),
+				err: errors.Wrap(errorBoom, errGetReversePeering),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			o, err := tc.e.Observe(ctx, tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		e       *external
+		cr      resource.Managed
+		want    resource.Managed
+		wantErr error
+	}{
+		"NotVirtualNetworkPeering": {
+			e:       newExternal(t, &fake.MockVirtualNetworkPeeringsClient{}, nil),
+			cr:      &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotVirtualNetworkPeering),
+		},
+		"Successful": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, p network.VirtualNetworkPeering) (network.VirtualNetworkPeeringsCreateOrUpdateFuture, error) {
+					if diff := cmp.Diff(remoteID, azure.ToString(p.RemoteVirtualNetwork.ID)); diff != "" {
+						t.Errorf("CreateOrUpdate(...): -want remote ID, +got:\n%s", diff)
+					}
+					return network.VirtualNetworkPeeringsCreateOrUpdateFuture{}, nil
+				},
+			}, nil),
+			cr:   peering(),
+			want: peering(withConditions(xpv1.Creating())),
+		},
+		"Failed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ network.VirtualNetworkPeering) (network.VirtualNetworkPeeringsCreateOrUpdateFuture, error) {
+					return network.VirtualNetworkPeeringsCreateOrUpdateFuture{}, errorBoom
+				},
+			}, nil),
+			cr:      peering(),
+			want:    peering(withConditions(xpv1.Creating())),
+			wantErr: errors.Wrap(errorBoom, errCreateVirtualNetworkPeering),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Create(ctx, tc.cr)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	upToDate := func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+		return azurePeering(remoteID, true, false, true, network.VirtualNetworkPeeringStateInitiated), nil
+	}
+
+	cases := map[string]struct {
+		e       *external
+		cr      resource.Managed
+		want    resource.Managed
+		wantErr error
+	}{
+		"NotVirtualNetworkPeering": {
+			e:       newExternal(t, &fake.MockVirtualNetworkPeeringsClient{}, nil),
+			cr:      &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotVirtualNetworkPeering),
+		},
+		"GetFailed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return network.VirtualNetworkPeering{}, errorBoom
+				},
+			}, nil),
+			cr:      peering(),
+			want:    peering(),
+			wantErr: errors.Wrap(errorBoom, errGetVirtualNetworkPeering),
+		},
+		"UpdateFailed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return azurePeering(remoteID, false, false, false, network.VirtualNetworkPeeringStateInitiated), nil
+				},
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ network.VirtualNetworkPeering) (network.VirtualNetworkPeeringsCreateOrUpdateFuture, error) {
+					return network.VirtualNetworkPeeringsCreateOrUpdateFuture{}, errorBoom
+				},
+			}, nil),
+			cr:      peering(),
+			want:    peering(),
+			wantErr: errors.Wrap(errorBoom, errUpdateVirtualNetworkPeering),
+		},
+		"UpToDate": {
+			e:    newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: upToDate}, nil),
+			cr:   peering(),
+			want: peering(),
+		},
+		"DeleteDisconnected": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return azurePeering(remoteID, true, false, true, network.VirtualNetworkPeeringStateDisconnected), nil
+				},
+				MockDelete: func(_ context.Context, rg, vnet, n string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					if diff := cmp.Diff([]string{resourceGroupName, virtualNetworkName, name}, []string{rg, vnet, n}); diff != "" {
+						t.Errorf("Delete(...): -want, +got:\n%s", diff)
+					}
+					return network.VirtualNetworkPeeringsDeleteFuture{}, nil
+				},
+			}, nil),
+			cr:   peering(),
+			want: peering(),
+		},
+		"DeleteDisconnectedFailed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return azurePeering(remoteID, true, false, true, network.VirtualNetworkPeeringStateDisconnected), nil
+				},
+				MockDelete: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					return network.VirtualNetworkPeeringsDeleteFuture{}, errorBoom
+				},
+			}, nil),
+			cr:      peering(),
+			want:    peering(),
+			wantErr: errors.Wrap(errorBoom, errDeleteVirtualNetworkPeering),
+		},
+		"CreateReversePeering": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: upToDate}, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return network.VirtualNetworkPeering{}, notFound
+				},
+				MockCreateOrUpdate: func(_ context.Context, rg, vnet, n string, p network.VirtualNetworkPeering) (network.VirtualNetworkPeeringsCreateOrUpdateFuture, error) {
+					if diff := cmp.Diff([]string{"hubRG", "hub", name}, []string{rg, vnet, n}); diff != "" {
+						t.Errorf("CreateOrUpdate(...): -want, +got:\n%s", diff)
+					}
+					want := azurePeering(localID, false, true, false, "")
+					if diff := cmp.Diff(want, p); diff != "" {
+						t.Errorf("CreateOrUpdate(...): -want, +got:\n%s", diff)
+					}
+					return network.VirtualNetworkPeeringsCreateOrUpdateFuture{}, nil
+				},
+			}),
+			cr:   peering(withReversePeering()),
+			want: peering(withReversePeering(), withReversePeeringID(reverseID)),
+		},
+		"CreateReversePeeringFailed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: upToDate}, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return network.VirtualNetworkPeering{}, notFound
+				},
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ network.VirtualNetworkPeering) (network.VirtualNetworkPeeringsCreateOrUpdateFuture, error) {
+					return network.VirtualNetworkPeeringsCreateOrUpdateFuture{}, errorBoom
+				},
+			}),
+			cr:      peering(withReversePeering()),
+			want:    peering(withReversePeering()),
+			wantErr: errors.Wrap(errorBoom, errCreateOrUpdateReversePeering),
+		},
+		"ReversePeeringUpToDate": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: upToDate}, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return azurePeering(localID, false, true, false, network.VirtualNetworkPeeringStateConnected), nil
+				},
+			}),
+			cr:   peering(withReversePeering()),
+			want: peering(withReversePeering()),
+		},
+		"DeleteDisconnectedReversePeering": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: upToDate}, &fake.MockVirtualNetworkPeeringsClient{
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return azurePeering(localID, false, true, false, network.VirtualNetworkPeeringStateDisconnected), nil
+				},
+				MockDelete: func(_ context.Context, rg, vnet, n string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					if diff := cmp.Diff([]string{"hubRG", "hub", name}, []string{rg, vnet, n}); diff != "" {
+						t.Errorf("Delete(...): -want, +got:\n%s", diff)
+					}
+					return network.VirtualNetworkPeeringsDeleteFuture{}, nil
+				},
+			}),
+			cr:   peering(withReversePeering(), withReversePeeringID(reverseID)),
+			want: peering(withReversePeering(), withReversePeeringID(reverseID)),
+		},
+		"DeleteRemovedReversePeering": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: upToDate}, &fake.MockVirtualNetworkPeeringsClient{
+				MockDelete: func(_ context.Context, rg, vnet, n string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					if diff := cmp.Diff([]string{"hubRG", "hub", name}, []string{rg, vnet, n}); diff != "" {
+						t.Errorf("Delete(...): -want, +got:\n%s", diff)
+					}
+					return network.VirtualNetworkPeeringsDeleteFuture{}, nil
+				},
+			}),
+			cr:   peering(withReversePeeringID(reverseID), withReversePeeringState(network.VirtualNetworkPeeringStateConnected)),
+			want: peering(),
+		},
+		"DeleteRenamedReversePeering": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: upToDate}, &fake.MockVirtualNetworkPeeringsClient{
+				MockDelete: func(_ context.Context, rg, vnet, n string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					if diff := cmp.Diff([]string{"hubRG", "hub", "old"}, []string{rg, vnet, n}); diff != "" {
+						t.Errorf("Delete(...): -want, +got:\n%s", diff)
+					}
+					return network.VirtualNetworkPeeringsDeleteFuture{}, nil
+				},
+				MockGet: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeering, error) {
+					return network.VirtualNetworkPeering{}, notFound
+				},
+				MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ network.VirtualNetworkPeering) (network.VirtualNetworkPeeringsCreateOrUpdateFuture, error) {
+					return network.VirtualNetworkPeeringsCreateOrUpdateFuture{}, nil
+				},
+			}),
+			cr:   peering(withReversePeering(), withReversePeeringID(remoteID+"/virtualNetworkPeerings/old")),
+			want: peering(withReversePeering(), withReversePeeringID(reverseID)),
+		},
+		"DeleteRemovedReversePeeringFailed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockGet: upToDate}, &fake.MockVirtualNetworkPeeringsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					return network.VirtualNetworkPeeringsDeleteFuture{}, errorBoom
+				},
+			}),
+			cr:      peering(withReversePeeringID(reverseID)),
+			want:    peering(withReversePeeringID(reverseID)),
+			wantErr: errors.Wrap(errorBoom, errDeleteReversePeering),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := tc.e.Update(ctx, tc.cr)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	deleted := func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+		return network.VirtualNetworkPeeringsDeleteFuture{}, nil
+	}
+
+	cases := map[string]struct {
+		e       *external
+		cr      resource.Managed
+		want    resource.Managed
+		wantErr error
+	}{
+		"NotVirtualNetworkPeering": {
+			e:       newExternal(t, &fake.MockVirtualNetworkPeeringsClient{}, nil),
+			cr:      &v1alpha3.Subnet{},
+			want:    &v1alpha3.Subnet{},
+			wantErr: errors.New(errNotVirtualNetworkPeering),
+		},
+		"Successful": {
+			e:    newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockDelete: deleted}, nil),
+			cr:   peering(),
+			want: peering(withConditions(xpv1.Deleting())),
+		},
+		"NotFound": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					return network.VirtualNetworkPeeringsDeleteFuture{}, notFound
+				},
+			}, nil),
+			cr:   peering(),
+			want: peering(withConditions(xpv1.Deleting())),
+		},
+		"Failed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					return network.VirtualNetworkPeeringsDeleteFuture{}, errorBoom
+				},
+			}, nil),
+			cr:      peering(),
+			want:    peering(withConditions(xpv1.Deleting())),
+			wantErr: errors.Wrap(errorBoom, errDeleteVirtualNetworkPeering),
+		},
+		"ReversePeeringNotFound": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockDelete: deleted}, &fake.MockVirtualNetworkPeeringsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					return network.VirtualNetworkPeeringsDeleteFuture{}, notFound
+				},
+			}),
+			cr:   peering(withReversePeering()),
+			want: peering(withReversePeering(), withConditions(xpv1.Deleting())),
+		},
+		"RemovedReversePeering": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{MockDelete: deleted}, &fake.MockVirtualNetworkPeeringsClient{
+				MockDelete: func(_ context.Context, rg, vnet, n string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					if diff := cmp.Diff([]string{"hubRG", "hub", name}, []string{rg, vnet, n}); diff != "" {
+						t.Errorf("Delete(...): -want, +got:\n%s", diff)
+					}
+					return network.VirtualNetworkPeeringsDeleteFuture{}, nil
+				},
+			}),
+			cr:   peering(withReversePeeringID(reverseID)),
+			want: peering(withReversePeeringID(reverseID), withConditions(xpv1.Deleting())),
+		},
+		"ReversePeeringFailed": {
+			e: newExternal(t, &fake.MockVirtualNetworkPeeringsClient{}, &fake.MockVirtualNetworkPeeringsClient{
+				MockDelete: func(_ context.Context, _, _, _ string) (network.VirtualNetworkPeeringsDeleteFuture, error) {
+					return network.VirtualNetworkPeeringsDeleteFuture{}, errorBoom
+				},
+			}),
+			cr:      peering(withReversePeering()),
+			want:    peering(withReversePeering(), withConditions(xpv1.Deleting())),
+			wantErr: errors.Wrap(errorBoom, errDeleteReversePeering),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := tc.e.Delete(ctx, tc.cr)
+			if diff := cmp.Diff(tc.wantErr, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
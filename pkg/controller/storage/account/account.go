@@ -18,357 +18,458 @@ package account
 
 import (
 	"context"
-	"reflect"
+	"strings"
 	"time"
 
-	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
-	"github.com/Azure/go-autorest/autorest/to"
+	mgmtkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault"
+	mgmtkeyvaultapi "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault/keyvaultapi"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault/keyvaultapi"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
+	"github.com/Azure/azure-storage-blob-go/azblob"
+	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
-	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/keyvault/key"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
 	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/tracing"
 )
 
+// Error strings.
 const (
-	controllerName = "account.storage.azure.crossplane.io"
-	finalizer      = "finalizer." + controllerName
-
-	reconcileTimeout   = 2 * time.Minute
-	requeueAfterOnWait = 30 * time.Second
-)
-
-var (
-	resultRequeue = reconcile.Result{Requeue: true}
-	requeueOnWait = reconcile.Result{RequeueAfter: requeueAfterOnWait}
+	errNotAccount    = "managed resource is not a storage Account"
+	errConnectFailed = "cannot connect to Azure API"
+	errGetFailed     = "cannot get storage Account"
+	errListKeys      = "cannot list storage Account keys"
+	errNoKeys        = "storage Account has no keys"
+	errIssueSAS      = "cannot issue shared access signature"
+	errRegenerateKey = "cannot regenerate storage Account key"
+	errNoActiveKey   = "storage Account has no active key"
+	errGetVaultKey   = "cannot get Key Vault key"
+	errGrantAccess   = "cannot grant storage Account access to Key Vault key"
+	errGetSubnet     = "cannot get subnet"
+	errGetWebsite    = "cannot get static website"
+	errSetWebsite    = "cannot set static website"
+	errCreateFailed  = "cannot create storage Account"
+	errUpdateFailed  = "cannot update storage Account"
+	errDeleteFailed  = "cannot delete storage Account"
+	errMigrate       = "cannot migrate v1alpha3 storage Account to v1beta1"
 )
 
-// Reconciler reconciles an Azure storage account
-type Reconciler struct {
-	client.Client
-	syncdeleterMaker
-	managed.ReferenceResolver
-	managed.Initializer
-
-	poll time.Duration
-
-	log logging.Logger
-}
-
 // Setup adds a controller that reconciles Accounts.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
-	name := managed.ControllerName(v1alpha3.AccountGroupKind)
-
-	r := &Reconciler{
-		Client:           mgr.GetClient(),
-		syncdeleterMaker: &accountSyncdeleterMaker{mgr.GetClient()},
-		Initializer:      managed.NewNameAsExternalName(mgr.GetClient()),
-		poll:             poll,
-		log:              l.WithValues("controller", name),
-	}
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1beta1.AccountGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
 		}).
-		For(&v1alpha3.Account{}).
-		Owns(&corev1.Secret{}).
-		Complete(r)
+		For(&v1beta1.Account{}).
+		Complete(tracing.Reconciler(name, v1beta1.AccountGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.AccountGroupVersionKind),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{kube: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.atProvider.id"))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(&migrator{kube: mgr.GetClient()}, managed.NewNameAsExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
 }
 
-// Reconcile reads that state of the cluster for a Provider acct and makes changes based on the state read
-// and what is in the Provider.Spec
-func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	r.log.Debug("Reconciling", "request", request)
-
-	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
-	defer cancel()
-
-	b := &v1alpha3.Account{}
-	if err := r.Get(ctx, request.NamespacedName, b); err != nil {
-		if kerrors.IsNotFound(err) {
-			return reconcile.Result{}, nil
-		}
-		return reconcile.Result{}, err
-	}
-	if err := r.Initialize(ctx, b); err != nil {
-		return reconcile.Result{}, err
-	}
+// A migrator migrates Accounts that were created or updated as v1alpha3
+// Accounts to v1beta1. Both versions are served, but Accounts are stored as
+// v1beta1 Accounts without conversion, so a v1alpha3 Account is read as a
+// v1beta1 Account whose forProvider is empty and whose deprecated v1alpha3
+// fields are set.
+type migrator struct {
+	kube client.Client
+}
 
-	bh, err := r.newSyncdeleter(ctx, b, r.poll)
-	if err != nil {
-		b.Status.SetConditions(xpv1.ReconcileError(err))
-		return resultRequeue, r.Status().Update(ctx, b)
+// Initialize converts the deprecated v1alpha3 fields of the supplied Account,
+// if any, to its forProvider fields.
+func (m *migrator) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.Account)
+	if !ok {
+		return errors.New(errNotAccount)
 	}
-
-	// Check for deletion
-	if b.DeletionTimestamp != nil {
-		return bh.delete(ctx)
+	if cr.Spec.DeprecatedStorageAccountSpec == nil {
+		return nil
 	}
-
-	return bh.sync(ctx)
-}
-
-type syncdeleterMaker interface {
-	newSyncdeleter(context.Context, *v1alpha3.Account, time.Duration) (syncdeleter, error)
+	cr.Spec.ForProvider = v1beta1.ConvertStorageAccountSpec(cr.Spec.DeprecatedResourceGroupName, cr.Spec.DeprecatedStorageAccountSpec)
+	cr.Spec.DeprecatedResourceGroupName = ""
+	cr.Spec.DeprecatedStorageAccountSpec = nil
+	return errors.Wrap(m.kube.Update(ctx, cr), errMigrate)
 }
 
-type accountSyncdeleterMaker struct {
-	client.Client
+type connecter struct {
+	kube client.Client
 }
 
-func (m *accountSyncdeleterMaker) newSyncdeleter(ctx context.Context, b *v1alpha3.Account, poll time.Duration) (syncdeleter, error) {
-	creds, auth, err := azure.GetAuthInfo(ctx, m.Client, b)
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azure.GetAuthInfo(ctx, c.kube, mg)
 	if err != nil {
-		return nil, errors.Wrap(err, "cannot get auth information")
+		return nil, errors.Wrap(err, errConnectFailed)
 	}
-
 	cl := storage.NewAccountsClient(creds[azure.CredentialsKeySubscriptionID])
 	cl.Authorizer = auth
-
-	return newAccountSyncDeleter(
-		azurestorage.NewAccountHandle(&cl, b.Spec.ResourceGroupName, meta.GetExternalName(b)),
-		m.Client, b, poll), nil
-}
-
-type deleter interface {
-	delete(context.Context) (reconcile.Result, error)
-}
-
-type syncer interface {
-	sync(context.Context) (reconcile.Result, error)
-}
-
-type creator interface {
-	create(context.Context) (reconcile.Result, error)
-}
-
-type updater interface {
-	update(context.Context, *storage.Account) (reconcile.Result, error)
-}
-
-type syncbacker interface {
-	syncback(context.Context, *storage.Account) (reconcile.Result, error)
-}
-
-type secretupdater interface {
-	updatesecret(ctx context.Context, acct *storage.Account) error
+	vaults := mgmtkeyvault.NewVaultsClient(creds[azure.CredentialsKeySubscriptionID])
+	vaults.Authorizer = auth
+	keys := keyvault.New()
+	if keys.Authorizer, err = azure.GetAuthorizer(creds, azure.KeyVaultResource); err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
+	}
+	subnets := network.NewSubnetsClient(creds[azure.CredentialsKeySubscriptionID])
+	subnets.Authorizer = auth
+	return &external{
+		subscriptionID: creds[azure.CredentialsKeySubscriptionID],
+		client:         cl,
+		keys:           keys,
+		vaults:         vaults,
+		subnets:        subnets,
+		newProperties:  azurestorage.NewServicePropertiesClient,
+	}, nil
 }
 
-type syncdeleter interface {
-	deleter
-	syncer
+type external struct {
+	subscriptionID string
+	client         storageapi.AccountsClientAPI
+	keys           keyvaultapi.BaseClientAPI
+	vaults         mgmtkeyvaultapi.VaultsClientAPI
+	subnets        networkapi.SubnetsClientAPI
+	newProperties  func(accountName, accountKey string) (azurestorage.ServicePropertiesAPI, error)
 }
 
-type accountSyncDeleter struct {
-	createupdater
-	azurestorage.AccountOperations
-	kube client.Client
-	acct *v1alpha3.Account
-}
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.Account)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotAccount)
+	}
 
-func newAccountSyncDeleter(ao azurestorage.AccountOperations, kube client.Client, b *v1alpha3.Account, poll time.Duration) *accountSyncDeleter {
-	return &accountSyncDeleter{
-		createupdater:     newAccountCreateUpdater(ao, kube, b, poll),
-		AccountOperations: ao,
-		kube:              kube,
-		acct:              b,
+	az, err := e.client.GetProperties(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr), "")
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azure.IsNotFound, err), errGetFailed)
 	}
-}
 
-func (asd *accountSyncDeleter) delete(ctx context.Context) (reconcile.Result, error) {
-	asd.acct.Status.SetConditions(xpv1.Deleting())
-	switch asd.acct.Spec.DeletionPolicy {
-	case xpv1.DeletionDelete, "":
-		if err := asd.Delete(ctx); err != nil && !azure.IsNotFound(err) {
-			asd.acct.Status.SetConditions(xpv1.ReconcileError(err))
-			return resultRequeue, asd.kube.Status().Update(ctx, asd.acct)
-		}
-	case xpv1.DeletionOrphan:
-		// No need to do anything if we plan to orphan this account.
+	// Nothing but the existence of a deleted Account matters; in particular
+	// its access keys must not be rotated while it is being deleted.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true}, nil
 	}
 
-	// NOTE(negz): We don't update the conditioned status here because assuming
-	// no other finalizers need to be cleaned up the object should cease to
-	// exist after we update it.
-	meta.RemoveFinalizer(asd.acct, finalizer)
-	return reconcile.Result{}, asd.kube.Update(ctx, asd.acct)
-}
+	current := cr.Spec.ForProvider.DeepCopy()
+	azurestorage.LateInitializeAccount(&cr.Spec.ForProvider, az)
+	cr.Status.AtProvider = azurestorage.GenerateAccountObservation(az)
 
-// sync - synchronizes the state of the storage account resource with the state of the
-// account Kubernetes acct
-func (asd *accountSyncDeleter) sync(ctx context.Context) (reconcile.Result, error) {
-	account, err := asd.Get(ctx)
-	if err != nil && !azure.IsNotFound(err) {
-		asd.acct.Status.SetConditions(xpv1.ReconcileError(err))
-		return resultRequeue, asd.kube.Status().Update(ctx, asd.acct)
+	desired := cr.Spec.ForProvider
+	if id := azurestorage.KeyVaultKeyID(desired); id != "" {
+		k, err := e.latestKey(ctx, id)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		desired = azurestorage.WithCustomerManagedKey(desired, &k)
 	}
 
-	if account == nil {
-		return asd.create(ctx)
+	if n := cr.Spec.ForProvider.NetworkRuleSet; n != nil && len(n.VirtualNetworkRules) > 0 {
+		missing, err := e.subnetsWithoutServiceEndpoint(ctx, n.VirtualNetworkRules)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if len(missing) > 0 {
+			cr.Status.SetConditions(azurestorage.ServiceEndpointsMissing(missing...))
+		} else {
+			cr.Status.SetConditions(azurestorage.ServiceEndpointsEnabled())
+		}
 	}
 
-	return asd.update(ctx, account)
-}
-
-// createupdater interface defining create and update operations on/for storage account resource
-type createupdater interface {
-	creator
-	updater
-}
+	upToDate := azurestorage.IsAccountUpToDate(desired, az)
+
+	var conn managed.ConnectionDetails
+	switch cr.Status.AtProvider.ProvisioningState {
+	case azurestorage.ProvisioningStateSucceeded:
+		// Regenerating an access key is an update, so that it happens after
+		// any late initialization has been persisted and its status is
+		// persisted with it. The first observation activates the primary key.
+		if r := cr.Spec.KeyRotation; r != nil {
+			if err := azurestorage.ValidateKeyRotation(*r); err != nil {
+				cr.Status.SetConditions(azurestorage.KeyRotationInvalid(err))
+			} else {
+				cr.Status.SetConditions(azurestorage.KeyRotationScheduled())
+				key, s := azurestorage.NextKeyRotation(*r, cr.Status.KeyRotation, time.Now())
+				if key == "" {
+					cr.Status.KeyRotation = s
+				}
+				upToDate = upToDate && key == ""
+			}
+		}
+		conn, err = e.connectionDetails(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
+		}
+		if w := cr.Spec.ForProvider.StaticWebsite; w != nil {
+			sw, err := e.staticWebsite(ctx, cr)
+			if err != nil {
+				return managed.ExternalObservation{}, err
+			}
+			azurestorage.LateInitializeStaticWebsite(w, sw)
+			upToDate = upToDate && azurestorage.IsStaticWebsiteUpToDate(*w, sw)
+		}
+		cr.Status.SetConditions(xpv1.Available())
+	case azurestorage.ProvisioningStateCreating, azurestorage.ProvisioningStateResolvingDNS:
+		cr.Status.SetConditions(xpv1.Creating())
+	default:
+		cr.Status.SetConditions(xpv1.Unavailable())
+	}
 
-// accountCreateUpdater implementation of createupdater interface
-type accountCreateUpdater struct {
-	syncbacker
-	azurestorage.AccountOperations
-	kube      client.Client
-	acct      *v1alpha3.Account
-	poll      time.Duration
-	projectID string
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        upToDate,
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+		ConnectionDetails:       conn,
+	}, nil
 }
 
-// newAccountCreateUpdater new instance of accountCreateUpdater
-func newAccountCreateUpdater(ao azurestorage.AccountOperations, kube client.Client, acct *v1alpha3.Account, poll time.Duration) *accountCreateUpdater {
-	return &accountCreateUpdater{
-		syncbacker:        newAccountSyncBacker(ao, kube, acct, poll),
-		AccountOperations: ao,
-		kube:              kube,
-		acct:              acct,
-		poll:              poll,
+// subnetsWithoutServiceEndpoint returns the IDs of the subnets allowed by the
+// supplied rules that do not exist or do not have the Microsoft.Storage
+// service endpoint enabled. Subnets in other subscriptions are not checked.
+func (e *external) subnetsWithoutServiceEndpoint(ctx context.Context, rules []v1beta1.VirtualNetworkRule) ([]string, error) {
+	var missing []string
+	for _, r := range rules {
+		id, err := azurestorage.ParseSubnetID(r.VirtualNetworkResourceID)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.EqualFold(id.SubscriptionID, e.subscriptionID) {
+			continue
+		}
+		s, err := e.subnets.Get(ctx, id.ResourceGroupName, id.VirtualNetworkName, id.Name, "")
+		if resource.Ignore(azure.IsNotFound, err) != nil {
+			return nil, errors.Wrap(err, errGetSubnet)
+		}
+		if err != nil || !azurestorage.HasStorageServiceEndpoint(s) {
+			missing = append(missing, r.VirtualNetworkResourceID)
+		}
 	}
+	return missing, nil
 }
 
-// create new storage account resource and save changes back to account specs
-func (acu *accountCreateUpdater) create(ctx context.Context) (reconcile.Result, error) {
-	acu.acct.Status.SetConditions(xpv1.Creating())
-	meta.AddFinalizer(acu.acct, finalizer)
-
-	accountSpec := v1alpha3.ToStorageAccountCreate(acu.acct.Spec.StorageAccountSpec)
-
-	a, err := acu.Create(ctx, accountSpec)
-	if err != nil {
-		acu.acct.Status.SetConditions(xpv1.ReconcileError(err))
-		return resultRequeue, acu.kube.Status().Update(ctx, acu.acct)
+// connectionDetails returns the primary blob and web endpoints of the supplied
+// Account, its name, and its active access key. If the Account wants a shared access
+// signature (SAS), it is published instead of the access key whenever a new
+// SAS is due. The access key is then explicitly removed from the connection
+// secret, which may hold it from before the Account wanted a SAS.
+func (e *external) connectionDetails(ctx context.Context, cr *v1beta1.Account) (managed.ConnectionDetails, error) {
+	conn := managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretUserKey: []byte(meta.GetExternalName(cr)),
+	}
+	if ep := cr.Status.AtProvider.PrimaryEndpoints; ep != nil {
+		conn[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(ep.Blob)
+		if ep.Web != "" {
+			conn[azurestorage.ConnectionSecretKeyWebEndpoint] = []byte(ep.Web)
+		}
 	}
 
-	return acu.syncback(ctx, a)
-}
-
-// update storage account resource if needed
-func (acu *accountCreateUpdater) update(ctx context.Context, account *storage.Account) (reconcile.Result, error) {
-	if account.ProvisioningState == storage.Succeeded {
-		acu.acct.Status.SetConditions(xpv1.Available())
+	active := azurestorage.ActiveKeyName(cr)
 
-		current := v1alpha3.NewStorageAccountSpec(account)
-		if reflect.DeepEqual(current, acu.acct.Spec.StorageAccountSpec) {
-			acu.acct.Status.SetConditions(xpv1.ReconcileSuccess())
-			return reconcile.Result{RequeueAfter: acu.poll}, acu.kube.Status().Update(ctx, acu.acct)
+	if s := cr.Spec.SharedAccessSignature; s != nil {
+		// Connection details are merged into the connection secret, in which
+		// a nil value removes a key.
+		conn[xpv1.ResourceCredentialsSecretPasswordKey] = nil
+		now := time.Now()
+		hash := azurestorage.AccountSASHash(*s)
+		if !azurestorage.SASNeedsRenewal(s.SharedAccessSignatureParameters, hash, cr.Status.SharedAccessSignature, now) {
+			return conn, nil
 		}
-
-		a, err := acu.Update(ctx, v1alpha3.ToStorageAccountUpdate(acu.acct.Spec.StorageAccountSpec))
+		expiry := azurestorage.SASExpiry(s.SharedAccessSignatureParameters, now)
+		p := azurestorage.NewAccountSASParameters(*s, expiry)
+		p.KeyToSign = azure.ToStringPtr(active)
+		rsp, err := e.client.ListAccountSAS(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr), p)
 		if err != nil {
-			acu.acct.Status.SetConditions(xpv1.ReconcileError(err))
-			return resultRequeue, acu.kube.Status().Update(ctx, acu.acct)
+			return nil, errors.Wrap(err, errIssueSAS)
 		}
-		account = a
+		cr.Status.SharedAccessSignature = azurestorage.NewSASStatus(expiry, hash)
+		conn[azurestorage.ConnectionSecretKeySASToken] = []byte(azure.ToString(rsp.AccountSasToken))
+		return conn, nil
 	}
 
-	return acu.syncback(ctx, account)
+	keys, err := e.client.ListKeys(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr), "")
+	if err != nil {
+		return nil, errors.Wrap(err, errListKeys)
+	}
+	if keys.Keys == nil || len(*keys.Keys) == 0 {
+		return nil, errors.New(errNoKeys)
+	}
+	for _, k := range *keys.Keys {
+		if azure.ToString(k.KeyName) == active {
+			conn[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(azure.ToString(k.Value))
+			return conn, nil
+		}
+	}
+	return nil, errors.New(errNoActiveKey)
 }
 
-type accountSyncbacker struct {
-	secretupdater
-	acct *v1alpha3.Account
-	kube client.Client
-	poll time.Duration
+// rotateKeys regenerates an access key of the supplied Account if one is due
+// for rotation, and records the resulting key rotation status. It reports
+// whether a key was regenerated.
+func (e *external) rotateKeys(ctx context.Context, cr *v1beta1.Account) (bool, error) {
+	key, s := azurestorage.NextKeyRotation(*cr.Spec.KeyRotation, cr.Status.KeyRotation, time.Now())
+	if key != "" {
+		p := storage.AccountRegenerateKeyParameters{KeyName: azure.ToStringPtr(key)}
+		if _, err := e.client.RegenerateKey(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr), p); err != nil {
+			return false, errors.Wrap(err, errRegenerateKey)
+		}
+	}
+	// A SAS is signed with the active key, so a new one must be issued when
+	// the active key changes.
+	if cr.Status.KeyRotation != nil && cr.Status.KeyRotation.ActiveKeyName != s.ActiveKeyName {
+		cr.Status.SharedAccessSignature = nil
+	}
+	cr.Status.KeyRotation = s
+	return key != "", nil
 }
 
-func newAccountSyncBacker(ao azurestorage.AccountOperations, kube client.Client, acct *v1alpha3.Account, poll time.Duration) *accountSyncbacker {
-	return &accountSyncbacker{
-		secretupdater: newAccountSecretUpdater(ao, kube, acct),
-		kube:          kube,
-		acct:          acct,
-		poll:          poll,
+// staticWebsite returns the static website of the supplied Account.
+func (e *external) staticWebsite(ctx context.Context, cr *v1beta1.Account) (*azblob.StaticWebsite, error) {
+	c, err := e.serviceProperties(ctx, cr)
+	if err != nil {
+		return nil, err
 	}
+	p, err := c.GetProperties(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetWebsite)
+	}
+	return p.StaticWebsite, nil
 }
 
-func (asb *accountSyncbacker) syncback(ctx context.Context, acct *storage.Account) (reconcile.Result, error) {
-	asb.acct.Spec.StorageAccountSpec = v1alpha3.NewStorageAccountSpec(acct)
-	if err := asb.kube.Update(ctx, asb.acct); err != nil {
-		return resultRequeue, err
+// serviceProperties returns a client for the blob service properties of the
+// supplied Account, authorized by one of its access keys.
+func (e *external) serviceProperties(ctx context.Context, cr *v1beta1.Account) (azurestorage.ServicePropertiesAPI, error) {
+	keys, err := e.client.ListKeys(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr), "")
+	if err != nil {
+		return nil, errors.Wrap(err, errListKeys)
 	}
-
-	asb.acct.Status.StorageAccountStatus = v1alpha3.NewStorageAccountStatus(acct)
-
-	if acct.ProvisioningState != storage.Succeeded {
-		asb.acct.Status.SetConditions(xpv1.ReconcileSuccess())
-		return requeueOnWait, asb.kube.Status().Update(ctx, asb.acct)
+	if keys.Keys == nil || len(*keys.Keys) == 0 {
+		return nil, errors.New(errNoKeys)
 	}
+	return e.newProperties(meta.GetExternalName(cr), azure.ToString((*keys.Keys)[0].Value))
+}
 
-	if err := asb.updatesecret(ctx, acct); err != nil {
-		asb.acct.Status.SetConditions(xpv1.ReconcileError(err))
-		return resultRequeue, asb.kube.Status().Update(ctx, asb.acct)
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.Account)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotAccount)
 	}
-
-	asb.acct.Status.SetConditions(xpv1.ReconcileSuccess())
-	return reconcile.Result{RequeueAfter: asb.poll}, asb.kube.Status().Update(ctx, asb.acct)
+	cr.Status.SetConditions(xpv1.Creating())
+	p := cr.Spec.ForProvider
+	if azurestorage.KeyVaultKeyID(p) != "" {
+		// The Account's identity must be granted access to its Key Vault
+		// key before the key can be used, so it starts off with
+		// Microsoft-managed keys.
+		p = azurestorage.WithCustomerManagedKey(p, nil)
+	}
+	_, err := e.client.Create(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr), azurestorage.NewAccountCreateParameters(p))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
 }
 
-type accountSecretUpdater struct {
-	azurestorage.AccountOperations
-	acct *v1alpha3.Account
-	kube client.Client
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.Account)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotAccount)
+	}
+	// Storage Accounts cannot be updated until they have been provisioned.
+	if cr.Status.AtProvider.ProvisioningState != azurestorage.ProvisioningStateSucceeded {
+		return managed.ExternalUpdate{}, nil
+	}
+	p := cr.Spec.ForProvider
+	if id := azurestorage.KeyVaultKeyID(p); id != "" {
+		var err error
+		if p, err = e.customerManagedKeyParameters(ctx, cr, id); err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+	}
+	if _, err := e.client.Update(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr), azurestorage.NewAccountUpdateParameters(p)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if w := cr.Spec.ForProvider.StaticWebsite; w != nil {
+		c, err := e.serviceProperties(ctx, cr)
+		if err != nil {
+			return managed.ExternalUpdate{}, err
+		}
+		if _, err := c.SetProperties(ctx, azurestorage.NewStaticWebsiteProperties(*w)); err != nil {
+			return managed.ExternalUpdate{}, errors.Wrap(err, errSetWebsite)
+		}
+	}
+	if cr.Spec.KeyRotation == nil || azurestorage.ValidateKeyRotation(*cr.Spec.KeyRotation) != nil {
+		return managed.ExternalUpdate{}, nil
+	}
+	rotated, err := e.rotateKeys(ctx, cr)
+	if err != nil || !rotated {
+		return managed.ExternalUpdate{}, err
+	}
+	// Publish the newly active key, or a SAS signed with it.
+	conn, err := e.connectionDetails(ctx, cr)
+	return managed.ExternalUpdate{ConnectionDetails: conn}, err
 }
 
-func newAccountSecretUpdater(ao azurestorage.AccountOperations, kube client.Client, acct *v1alpha3.Account) *accountSecretUpdater {
-	return &accountSecretUpdater{
-		AccountOperations: ao,
-		acct:              acct,
-		kube:              kube,
+// customerManagedKeyParameters returns the parameters of the supplied Account
+// encrypted with the latest version of the supplied Key Vault key, after
+// granting the Account's identity access to the key. The Account keeps
+// Microsoft-managed keys until it has an identity.
+func (e *external) customerManagedKeyParameters(ctx context.Context, cr *v1beta1.Account, id string) (v1beta1.AccountParameters, error) {
+	i := cr.Status.AtProvider.Identity
+	if i == nil || i.PrincipalID == "" {
+		return azurestorage.WithCustomerManagedKey(cr.Spec.ForProvider, nil), nil
 	}
+	k, err := e.latestKey(ctx, id)
+	if err != nil {
+		return v1beta1.AccountParameters{}, err
+	}
+	if err := key.GrantStorageAccess(ctx, e.vaults, k.VaultBaseURL, i.PrincipalID); err != nil {
+		return v1beta1.AccountParameters{}, errors.Wrap(err, errGrantAccess)
+	}
+	return azurestorage.WithCustomerManagedKey(cr.Spec.ForProvider, &k), nil
 }
 
-func (asu *accountSecretUpdater) updatesecret(ctx context.Context, acct *storage.Account) error {
-	secret := resource.ConnectionSecretFor(asu.acct, v1alpha3.AccountGroupVersionKind)
-	key := types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}
-
-	if acct.PrimaryEndpoints != nil {
-		secret.Data[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(to.String(acct.PrimaryEndpoints.Blob))
+// latestKey returns the latest version of the supplied Key Vault key.
+func (e *external) latestKey(ctx context.Context, id string) (key.VersionedKey, error) {
+	k, err := key.ParseID(id)
+	if err != nil {
+		return key.VersionedKey{}, err
 	}
-
-	keys, err := asu.ListKeys(ctx)
+	kb, err := e.keys.GetKey(ctx, k.VaultBaseURL, k.Name, "")
 	if err != nil {
-		return errors.Wrapf(err, "failed to list account keys")
+		return key.VersionedKey{}, errors.Wrap(err, errGetVaultKey)
 	}
-	if len(keys) == 0 {
-		return errors.New("account keys are empty")
+	if kb.Key == nil {
+		return key.VersionedKey{}, errors.New(errGetVaultKey)
 	}
+	return key.ParseID(azure.ToString(kb.Key.Kid))
+}
 
-	secret.Data[xpv1.ResourceCredentialsSecretUserKey] = []byte(meta.GetExternalName(asu.acct))
-	secret.Data[xpv1.ResourceCredentialsSecretPasswordKey] = []byte(to.String(keys[0].Value))
-
-	if err := asu.kube.Create(ctx, secret); err != nil {
-		if kerrors.IsAlreadyExists(err) {
-			return errors.Wrapf(asu.kube.Update(ctx, secret), "failed to update secret: %s", key)
-		}
-		return errors.Wrapf(err, "failed to create secret: %s", key)
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.Account)
+	if !ok {
+		return errors.New(errNotAccount)
 	}
-
-	return nil
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.client.Delete(ctx, cr.Spec.ForProvider.ResourceGroupName, meta.GetExternalName(cr))
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteFailed)
 }
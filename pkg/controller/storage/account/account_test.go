@@ -1,11 +1,11 @@
 /*
-Copyright 2019 The Crossplane Authors.
+Copyright 2021 The Crossplane Authors.
 
 Licensed under the Apache License, Version 2.0 (the "License");
-you may not use this file except in compliance With the License.
+you may not use this file except in compliance with the License.
 You may obtain a copy of the License at
 
-	http://www.apache.org/licenses/LICENSE-2.0
+    http://www.apache.org/licenses/LICENSE-2.0
 
 Unless required by applicable law or agreed to in writing, software
 distributed under the License is distributed on an "AS IS" BASIS,
@@ -19,1052 +19,1398 @@ package account
 import (
 	"context"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
-	"github.com/crossplane/provider-azure/apis"
-
-	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
+	mgmtkeyvault "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault"
+	mgmtkeyvaultapi "github.com/Azure/azure-sdk-for-go/services/keyvault/mgmt/2018-02-14/keyvault/keyvaultapi"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault"
+	"github.com/Azure/azure-sdk-for-go/services/keyvault/v7.0/keyvault/keyvaultapi"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network"
+	"github.com/Azure/azure-sdk-for-go/services/network/mgmt/2019-06-01/network/networkapi"
+	legacystorage "github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2017-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
+	"github.com/Azure/azure-storage-blob-go/azblob"
 	"github.com/Azure/go-autorest/autorest"
-	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/google/go-cmp/cmp"
 	"github.com/pkg/errors"
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/fake"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	clientfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
-	v1alpha3test "github.com/crossplane/provider-azure/apis/storage/v1alpha3/test"
-	azurev1alpha3 "github.com/crossplane/provider-azure/apis/v1alpha3"
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	kvfake "github.com/crossplane/provider-azure/pkg/clients/keyvault/key/fake"
+	networkfake "github.com/crossplane/provider-azure/pkg/clients/network/fake"
 	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
-	azurestoragefake "github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
 )
 
-func init() {
-	_ = apis.AddToScheme(scheme.Scheme)
+const (
+	name          = "coolaccount"
+	resourceGroup = "coolgroup"
+	location      = "westus2"
+	id            = "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount"
+	blobEndpoint  = "https://coolaccount.blob.core.windows.net/"
+	webEndpoint   = "https://coolaccount.z5.web.core.windows.net/"
+	accessKey     = "secretkey"
+	accessKey2    = "othersecretkey"
+	principalID   = "coolprincipal"
+	vaultBaseURL  = "https://coolvault.vault.azure.net"
+	vaultKeyID    = vaultBaseURL + "/keys/coolkey"
+	subscription  = "cool"
+	uid           = "cooluid"
+	sasToken      = "sv=2019-02-02&sig=cool"
+	subnetID      = "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Network/virtualNetworks/coolnet/subnets/coolsubnet"
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type accountModifier func(*v1beta1.Account)
+
+func withConditions(c ...xpv1.Condition) accountModifier {
+	return func(a *v1beta1.Account) { a.Status.ConditionedStatus.Conditions = c }
 }
 
-type MockAccountSecretupdater struct {
-	MockUpdateSecret func(context.Context, *storage.Account) error
+func withProvisioningState(s string) accountModifier {
+	return func(a *v1beta1.Account) { a.Status.AtProvider.ProvisioningState = s }
 }
 
-func (m *MockAccountSecretupdater) updatesecret(ctx context.Context, a *storage.Account) error {
-	return m.MockUpdateSecret(ctx, a)
+func withObservation(s string) accountModifier {
+	return func(a *v1beta1.Account) {
+		a.Status.AtProvider = v1beta1.AccountObservation{
+			ID:                id,
+			Name:              name,
+			ProvisioningState: s,
+			PrimaryEndpoints:  &v1beta1.Endpoints{Blob: blobEndpoint},
+		}
+	}
 }
 
-var _ secretupdater = &MockAccountSecretupdater{}
+func withTags(t map[string]string) accountModifier {
+	return func(a *v1beta1.Account) { a.Spec.ForProvider.Tags = t }
+}
 
-type MockAccountSyncbacker struct {
-	MockSyncback func(context.Context, *storage.Account) (reconcile.Result, error)
+func withKind(k string) accountModifier {
+	return func(a *v1beta1.Account) { a.Spec.ForProvider.Kind = k }
 }
 
-func (m *MockAccountSyncbacker) syncback(ctx context.Context, a *storage.Account) (reconcile.Result, error) {
-	return m.MockSyncback(ctx, a)
+func withAccessTier(t string) accountModifier {
+	return func(a *v1beta1.Account) { a.Spec.ForProvider.AccessTier = azure.ToStringPtr(t) }
 }
 
-var _ syncbacker = &MockAccountSyncbacker{}
+func withSAS(expiry time.Time) accountModifier {
+	return func(a *v1beta1.Account) {
+		a.Spec.SharedAccessSignature = &v1beta1.AccountSharedAccessSignature{
+			SharedAccessSignatureParameters: v1beta1.SharedAccessSignatureParameters{Permissions: "rl"},
+			Services:                        "b",
+			ResourceTypes:                   "co",
+		}
+		a.Status.SharedAccessSignature = azurestorage.NewSASStatus(expiry, azurestorage.AccountSASHash(*a.Spec.SharedAccessSignature))
+	}
+}
 
-type MockAccountCreateUpdater struct {
-	MockCreate func(context.Context) (reconcile.Result, error)
-	MockUpdate func(context.Context, *storage.Account) (reconcile.Result, error)
+func withSASPermissions(p string) accountModifier {
+	return func(a *v1beta1.Account) { a.Spec.SharedAccessSignature.Permissions = p }
 }
 
-func newMockAccountCreateUpdater(poll time.Duration) *MockAccountCreateUpdater {
-	return &MockAccountCreateUpdater{
-		MockUpdate: func(i context.Context, acct *storage.Account) (result reconcile.Result, e error) {
-			return reconcile.Result{RequeueAfter: poll}, nil
-		},
-		MockCreate: func(i context.Context) (result reconcile.Result, e error) {
-			return reconcile.Result{RequeueAfter: poll}, nil
-		},
+func withKeyRotation(s *v1beta1.KeyRotationStatus) accountModifier {
+	return func(a *v1beta1.Account) {
+		a.Spec.KeyRotation = &v1beta1.KeyRotation{Interval: metav1.Duration{Duration: 720 * time.Hour}}
+		a.Status.KeyRotation = s
 	}
 }
 
-func (m *MockAccountCreateUpdater) create(ctx context.Context) (reconcile.Result, error) {
-	return m.MockCreate(ctx)
+func withKeyRotationInterval(d time.Duration) accountModifier {
+	return func(a *v1beta1.Account) { a.Spec.KeyRotation.Interval = metav1.Duration{Duration: d} }
 }
 
-func (m *MockAccountCreateUpdater) update(ctx context.Context, a *storage.Account) (reconcile.Result, error) {
-	return m.MockUpdate(ctx, a)
+func withConnectionSecret() accountModifier {
+	return func(a *v1beta1.Account) {
+		a.SetName(name)
+		a.SetUID(uid)
+		a.SetWriteConnectionSecretToReference(&xpv1.SecretReference{Namespace: "coolns", Name: "coolsecret"})
+	}
 }
 
-var _ createupdater = &MockAccountCreateUpdater{}
+func withDeletionTimestamp() accountModifier {
+	return func(a *v1beta1.Account) { a.SetDeletionTimestamp(&metav1.Time{Time: time.Unix(1600000000, 0)}) }
+}
 
-type MockAccountSyncDeleter struct {
-	MockDelete func(context.Context) (reconcile.Result, error)
-	MockSync   func(context.Context) (reconcile.Result, error)
+func withWebEndpoint() accountModifier {
+	return func(a *v1beta1.Account) { a.Status.AtProvider.PrimaryEndpoints.Web = webEndpoint }
 }
 
-func newMockAccountSyncDeleter(poll time.Duration) *MockAccountSyncDeleter {
-	return &MockAccountSyncDeleter{
-		MockSync: func(i context.Context) (result reconcile.Result, e error) {
-			return reconcile.Result{RequeueAfter: poll}, nil
-		},
-		MockDelete: func(i context.Context) (result reconcile.Result, e error) {
-			return result, nil
-		},
+func withStaticWebsite(index, errorPath string) accountModifier {
+	return func(a *v1beta1.Account) {
+		a.Spec.ForProvider.StaticWebsite = &v1beta1.StaticWebsite{Enabled: true, IndexDocument: azure.ToStringPtr(index)}
+		if errorPath != "" {
+			a.Spec.ForProvider.StaticWebsite.ErrorDocument404Path = azure.ToStringPtr(errorPath)
+		}
 	}
 }
 
-func (m *MockAccountSyncDeleter) delete(ctx context.Context) (reconcile.Result, error) {
-	return m.MockDelete(ctx)
+func azureAccountWithWebsite() storage.Account {
+	a := azureAccount(storage.Succeeded)
+	a.PrimaryEndpoints.Web = azure.ToStringPtr(webEndpoint)
+	return a
 }
 
-func (m *MockAccountSyncDeleter) sync(ctx context.Context) (reconcile.Result, error) {
-	return m.MockSync(ctx)
+func staticWebsite(w *azblob.StaticWebsite, err error) func(string, string) (azurestorage.ServicePropertiesAPI, error) {
+	return func(_ string, _ string) (azurestorage.ServicePropertiesAPI, error) {
+		return &fake.MockServicePropertiesClient{
+			MockGetProperties: func(_ context.Context) (*azblob.StorageServiceProperties, error) {
+				return &azblob.StorageServiceProperties{StaticWebsite: w}, err
+			},
+			MockSetProperties: func(_ context.Context, p azblob.StorageServiceProperties) (*azblob.ServiceSetPropertiesResponse, error) {
+				if err != nil {
+					return nil, err
+				}
+				if diff := cmp.Diff(w, p.StaticWebsite); diff != "" {
+					return nil, errors.Errorf("SetProperties(...): -want, +got:\n%s", diff)
+				}
+				return &azblob.ServiceSetPropertiesResponse{}, nil
+			},
+		}, nil
+	}
 }
 
-var _ syncdeleter = &MockAccountSyncDeleter{}
-
-type MockAccountHandleMaker struct {
-	MockNew func(context.Context, *v1alpha3.Account) (syncdeleter, error)
+func withVirtualNetworkRule(id string) accountModifier {
+	return func(a *v1beta1.Account) {
+		a.Spec.ForProvider.NetworkRuleSet = &v1beta1.NetworkRuleSet{
+			DefaultAction:       string(storage.DefaultActionDeny),
+			VirtualNetworkRules: []v1beta1.VirtualNetworkRule{{VirtualNetworkResourceID: id}},
+		}
+	}
 }
 
-func newMockAccountHandleMaker(rh syncdeleter, err error, _ time.Duration) *MockAccountHandleMaker {
-	return &MockAccountHandleMaker{
-		MockNew: func(i context.Context, bucket *v1alpha3.Account) (handler syncdeleter, e error) {
-			return rh, err
+func subnet(endpoints ...string) *networkfake.MockSubnetsClient {
+	return &networkfake.MockSubnetsClient{
+		MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (network.Subnet, error) {
+			se := make([]network.ServiceEndpointPropertiesFormat, len(endpoints))
+			for i := range endpoints {
+				se[i].Service = azure.ToStringPtr(endpoints[i])
+			}
+			return network.Subnet{SubnetPropertiesFormat: &network.SubnetPropertiesFormat{ServiceEndpoints: &se}}, nil
 		},
 	}
 }
 
-func (m *MockAccountHandleMaker) newSyncdeleter(ctx context.Context, b *v1alpha3.Account, _ time.Duration) (syncdeleter, error) {
-	return m.MockNew(ctx, b)
-}
-
-func newStorageAccountSpec() *v1alpha3.StorageAccountSpec {
-	return v1alpha3.NewStorageAccountSpec(&storage.Account{})
+func withCustomerManagedKey() accountModifier {
+	return func(a *v1beta1.Account) {
+		a.Spec.ForProvider.Identity = &v1beta1.Identity{Type: azurestorage.IdentityTypeSystemAssigned}
+		a.Spec.ForProvider.Encryption = &v1beta1.Encryption{KeyVaultKeyID: azure.ToStringPtr(vaultKeyID)}
+	}
 }
 
-func newStoragAccountSpecWithProperties() *v1alpha3.StorageAccountSpec {
-	return v1alpha3.NewStorageAccountSpec(&storage.Account{AccountProperties: &storage.AccountProperties{}})
+func withIdentity() accountModifier {
+	return func(a *v1beta1.Account) {
+		a.Status.AtProvider.Identity = &v1beta1.IdentityObservation{PrincipalID: principalID}
+	}
 }
 
-type storageAccount struct {
-	*storage.Account
+func withKeyVersion(v string) accountModifier {
+	return func(a *v1beta1.Account) {
+		a.Status.AtProvider.Encryption = &v1beta1.EncryptionObservation{
+			KeySource:  string(storage.KeySourceMicrosoftKeyvault),
+			KeyVersion: v,
+		}
+	}
 }
 
-func newStorageAccount() *storageAccount {
-	return &storageAccount{
-		Account: &storage.Account{},
+func azureAccountWithKey(version string) storage.Account {
+	az := azureAccount(storage.Creating)
+	az.Identity = &storage.Identity{Type: azure.ToStringPtr(azurestorage.IdentityTypeSystemAssigned), PrincipalID: azure.ToStringPtr(principalID)}
+	az.Encryption = &storage.Encryption{
+		KeySource: storage.KeySourceMicrosoftKeyvault,
+		KeyVaultProperties: &storage.KeyVaultProperties{
+			KeyName:     azure.ToStringPtr("coolkey"),
+			KeyVersion:  azure.ToStringPtr(version),
+			KeyVaultURI: azure.ToStringPtr(vaultBaseURL),
+		},
 	}
+	return az
 }
 
-func (sa *storageAccount) withAccountProperties(ap *storage.AccountProperties) *storageAccount {
-	sa.Account.AccountProperties = ap
-	return sa
+func latestKey(version string) *kvfake.MockClient {
+	return &kvfake.MockClient{
+		MockGetKey: func(_ context.Context, _ string, _ string, _ string) (keyvault.KeyBundle, error) {
+			return keyvault.KeyBundle{Key: &keyvault.JSONWebKey{Kid: azure.ToStringPtr(vaultKeyID + "/" + version)}}, nil
+		},
+	}
 }
 
-type storageAccountProperties struct {
-	*storage.AccountProperties
+func keys() storage.AccountListKeysResult {
+	return storage.AccountListKeysResult{Keys: &[]storage.AccountKey{
+		{KeyName: azure.ToStringPtr(v1beta1.KeyNamePrimary), Value: azure.ToStringPtr(accessKey)},
+		{KeyName: azure.ToStringPtr(v1beta1.KeyNameSecondary), Value: azure.ToStringPtr(accessKey2)},
+	}}
 }
 
-func newStorageAccountProperties() *storageAccountProperties {
-	return &storageAccountProperties{
-		AccountProperties: &storage.AccountProperties{},
+func account(m ...accountModifier) *v1beta1.Account {
+	a := &v1beta1.Account{
+		Spec: v1beta1.AccountSpec{
+			ForProvider: v1beta1.AccountParameters{
+				ResourceGroupName: resourceGroup,
+				Location:          location,
+				Kind:              string(storage.StorageV2),
+				SKU:               v1beta1.SKU{Name: string(storage.StandardLRS)},
+				Tags:              map[string]string{"cool": "very"},
+			},
+		},
 	}
+	meta.SetExternalName(a, name)
+	for _, f := range m {
+		f(a)
+	}
+	return a
 }
 
-func (sap *storageAccountProperties) withProvisioningStage(ps storage.ProvisioningState) *storageAccountProperties {
-	sap.ProvisioningState = ps
-	return sap
+func azureAccount(s storage.ProvisioningState) storage.Account {
+	return storage.Account{
+		ID:       azure.ToStringPtr(id),
+		Name:     azure.ToStringPtr(name),
+		Location: azure.ToStringPtr(location),
+		Kind:     storage.StorageV2,
+		Sku:      &storage.Sku{Name: storage.StandardLRS},
+		Tags:     map[string]*string{"cool": azure.ToStringPtr("very")},
+		AccountProperties: &storage.AccountProperties{
+			ProvisioningState: s,
+			PrimaryEndpoints:  &storage.Endpoints{Blob: azure.ToStringPtr(blobEndpoint)},
+		},
+	}
 }
 
-const (
-	testNamespace   = "default"
-	testAccountName = "testAccount"
-)
-
-func TestReconciler_Reconcile(t *testing.T) {
-	name := testAccountName
-	key := types.NamespacedName{Name: name}
-	req := reconcile.Request{NamespacedName: key}
-	ctx := context.TODO()
-	rsDone := reconcile.Result{}
-	errBoom := errors.New("boom")
+func TestObserve(t *testing.T) {
+	sasExpiry := time.Now().Add(20 * time.Hour).Truncate(time.Second)
+	sasExpired := time.Now().Truncate(time.Second)
+	rotated := time.Now().Add(-1000 * time.Hour).Truncate(time.Second)
 
-	type fields struct {
-		client client.Client
-		maker  syncdeleterMaker
+	type args struct {
+		cr      *v1beta1.Account
+		c       storageapi.AccountsClientAPI
+		keys    keyvaultapi.BaseClientAPI
+		subnets networkapi.SubnetsClientAPI
+		props   func(string, string) (azurestorage.ServicePropertiesAPI, error)
 	}
 	type want struct {
-		res  reconcile.Result
-		err  error
-		acct *v1alpha3.Account
+		cr  *v1beta1.Account
+		o   managed.ExternalObservation
+		err error
 	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   want
+
+	cases := map[string]struct {
+		args
+		want
 	}{
-		{
-			name:   "GetErrNotFound",
-			fields: fields{client: fake.NewClientBuilder().Build(), maker: nil},
-			want:   want{res: rsDone},
+		"NotFound": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return storage.Account{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+					},
+				},
+			},
+			want: want{
+				cr: account(),
+			},
 		},
-		{
-			name: "GetErrorOther",
-			fields: fields{
-				client: &test.MockClient{
-					MockGet: func(context.Context, client.ObjectKey, client.Object) error {
-						return errBoom
+		"GetFailed": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return storage.Account{}, errorBoom
 					},
 				},
 			},
-			want: want{res: rsDone, err: errBoom},
+			want: want{
+				cr:  account(),
+				err: errors.Wrap(errorBoom, errGetFailed),
+			},
 		},
-		{
-			name: "AccountHandlerError",
-			fields: fields{
-				client: fake.NewClientBuilder().WithObjects(v1alpha3test.NewMockAccount(name).WithFinalizer("foo.bar").Account).Build(),
-				maker:  newMockAccountHandleMaker(nil, errBoom, time.Minute),
+		"Successful": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
+					},
+				},
 			},
 			want: want{
-				res: resultRequeue,
-				acct: v1alpha3test.NewMockAccount(name).
-					WithStatusConditions(
-						xpv1.ReconcileError(errBoom),
-					).
-					WithFinalizer("foo.bar").Account,
+				cr: account(
+					withObservation(azurestorage.ProvisioningStateSucceeded),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte(name),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(accessKey),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(blobEndpoint),
+					},
+				},
 			},
 		},
-		{
-			name: "ReconcileDelete",
-			fields: fields{
-				client: fake.NewClientBuilder().WithObjects(v1alpha3test.NewMockAccount(name).
-					WithDeleteTimestamp(metav1.NewTime(time.Now())).Account).Build(),
-				maker: newMockAccountHandleMaker(newMockAccountSyncDeleter(time.Minute), nil, time.Minute),
-			},
-			want: want{res: rsDone},
+		"SharedAccessSignatureNotDue": {
+			args: args{
+				cr: account(withSAS(sasExpiry)),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+				},
+			},
+			want: want{
+				cr: account(
+					withSAS(sasExpiry),
+					withObservation(azurestorage.ProvisioningStateSucceeded),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte(name),
+						xpv1.ResourceCredentialsSecretPasswordKey: nil,
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(blobEndpoint),
+					},
+				},
+			},
 		},
-		{
-			name: "ReconcileSync",
-			fields: fields{
-				client: fake.NewClientBuilder().WithObjects(v1alpha3test.NewMockAccount(name).Account).Build(),
-				maker:  newMockAccountHandleMaker(newMockAccountSyncDeleter(time.Hour), nil, time.Minute),
+		"SharedAccessSignatureFailed": {
+			args: args{
+				cr: account(withSAS(sasExpired)),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+					MockListAccountSAS: func(_ context.Context, _ string, _ string, _ storage.AccountSasParameters) (storage.ListAccountSasResponse, error) {
+						return storage.ListAccountSasResponse{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  account(withSAS(sasExpired), withObservation(azurestorage.ProvisioningStateSucceeded)),
+				err: errors.Wrap(errorBoom, errIssueSAS),
 			},
-			want: want{res: reconcile.Result{RequeueAfter: time.Hour}},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			r := &Reconciler{
-				Client:           tt.fields.client,
-				syncdeleterMaker: tt.fields.maker,
-				Initializer:      managed.NewNameAsExternalName(tt.fields.client),
-				log:              logging.NewNopLogger(),
-			}
-			got, err := r.Reconcile(context.Background(), req)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("Reconciler.Reconcile(): -want error, +got error:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("Reconciler.Reconcile(): -want, +got:\n%s", diff)
-			}
-			if tt.want.acct != nil {
-				b := &v1alpha3.Account{}
-				if err := r.Get(ctx, key, b); err != nil {
-					t.Errorf("Reconciler.Reconcile() account error: %s", err)
-				}
-				// NOTE(muvaf): Get call returns TypeMeta and ResourceVersion
-				// that we are not interested. Since diff shows them, we have to
-				// assign the right values.
-				b.TypeMeta = tt.want.acct.TypeMeta
-				tt.want.acct.ResourceVersion = b.ResourceVersion
-				if diff := cmp.Diff(tt.want.acct, b, test.EquateConditions()); diff != "" {
-					t.Errorf("Reconciler.Reconcile() account: -want, +got\n%s", diff)
-				}
-			}
-		})
-	}
-}
-
-func Test_syncdeleter_delete(t *testing.T) {
-	ctx := context.TODO()
-	bucketName := "test-account"
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		ao   azurestorage.AccountOperations
-		cc   client.Client
-		acct *v1alpha3.Account
-		poll time.Duration
-	}
-	type want struct {
-		err  error
-		res  reconcile.Result
-		acct *v1alpha3.Account
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   want
-	}{
-		{
-			name: "RetainPolicy",
-			fields: fields{
-				acct: v1alpha3test.NewMockAccount(bucketName).WithSpecDeletionPolicy(xpv1.DeletionOrphan).
-					WithFinalizers([]string{finalizer, "test"}).Account,
-				cc: &test.MockClient{
-					MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
-						return nil
+		"KeyRotationIntervalInvalid": {
+			args: args{
+				cr: account(withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				}), withKeyRotationInterval(0)),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
 					},
 				},
 			},
 			want: want{
-				err: nil,
-				res: reconcile.Result{},
-				acct: v1alpha3test.NewMockAccount(bucketName).
-					WithSpecDeletionPolicy(xpv1.DeletionOrphan).
-					WithFinalizer("test").
-					WithStatusConditions(xpv1.Deleting()).
-					Account,
+				cr: account(
+					withKeyRotation(&v1beta1.KeyRotationStatus{
+						ActiveKeyName:    v1beta1.KeyNamePrimary,
+						LastRotationTime: &metav1.Time{Time: rotated},
+					}),
+					withKeyRotationInterval(0),
+					withObservation(azurestorage.ProvisioningStateSucceeded),
+					withConditions(azurestorage.KeyRotationInvalid(errors.New("key rotation interval 0s must be positive")), xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte(name),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(accessKey),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(blobEndpoint),
+					},
+				},
 			},
 		},
-		{
-			name: "DeleteSuccessful",
-			fields: fields{
-				acct: v1alpha3test.NewMockAccount(bucketName).WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithFinalizer(finalizer).Account,
-				cc: &test.MockClient{
-					MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
-						return nil
+		"KeyRotationGracePeriodInvalid": {
+			args: args{
+				cr: account(withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				}), withKeyRotationInterval(time.Hour)),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
 					},
 				},
-				ao: azurestoragefake.NewMockAccountOperations(),
 			},
 			want: want{
-				err: nil,
-				res: reconcile.Result{},
-				acct: v1alpha3test.NewMockAccount(bucketName).
-					WithFinalizers([]string{}).
-					WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithStatusConditions(xpv1.Deleting()).
-					Account,
+				cr: account(
+					withKeyRotation(&v1beta1.KeyRotationStatus{
+						ActiveKeyName:    v1beta1.KeyNamePrimary,
+						LastRotationTime: &metav1.Time{Time: rotated},
+					}),
+					withKeyRotationInterval(time.Hour),
+					withObservation(azurestorage.ProvisioningStateSucceeded),
+					withConditions(azurestorage.KeyRotationInvalid(errors.New("key rotation grace period 1h0m0s must be positive and shorter than the interval 1h0m0s")), xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte(name),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(accessKey),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(blobEndpoint),
+					},
+				},
 			},
 		},
-		{
-			name: "DeleteFailed",
-			fields: fields{
-				acct: v1alpha3test.NewMockAccount(bucketName).WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithFinalizer(finalizer).Account,
-				cc: &test.MockClient{
-					MockStatusUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
-						return nil
+		"KeyRotationDue": {
+			args: args{
+				cr: account(withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				})),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
+					},
+				},
+			},
+			want: want{
+				cr: account(
+					withKeyRotation(&v1beta1.KeyRotationStatus{
+						ActiveKeyName:    v1beta1.KeyNamePrimary,
+						LastRotationTime: &metav1.Time{Time: rotated},
+					}),
+					withObservation(azurestorage.ProvisioningStateSucceeded),
+					withConditions(azurestorage.KeyRotationScheduled(), xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte(name),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(accessKey),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(blobEndpoint),
 					},
 				},
-				ao: &azurestoragefake.MockAccountOperations{
-					MockDelete: func(ctx context.Context) error {
-						return errBoom
+			},
+		},
+		"Deleted": {
+			args: args{
+				cr: account(withDeletionTimestamp(), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				})),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
 					},
 				},
 			},
 			want: want{
-				err: nil,
-				res: resultRequeue,
-				acct: v1alpha3test.NewMockAccount(bucketName).WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithFinalizer(finalizer).
-					WithStatusConditions(xpv1.Deleting(), xpv1.ReconcileError(errBoom)).
-					Account,
+				cr: account(withDeletionTimestamp(), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				})),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
 			},
 		},
-		{
-			name: "DeleteNonExistent",
-			fields: fields{
-				acct: v1alpha3test.NewMockAccount(bucketName).WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithFinalizer(finalizer).Account,
-				cc: &test.MockClient{
-					MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error { return nil },
+		"CustomerManagedKeyCurrent": {
+			args: args{
+				cr: account(withCustomerManagedKey()),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccountWithKey("v1"), nil
+					},
 				},
-				ao: &azurestoragefake.MockAccountOperations{
-					MockDelete: func(ctx context.Context) error {
-						return autorest.DetailedError{
-							StatusCode: http.StatusNotFound,
-						}
+				keys: latestKey("v1"),
+			},
+			want: want{
+				cr: account(
+					withCustomerManagedKey(),
+					withObservation(azurestorage.ProvisioningStateCreating),
+					withIdentity(),
+					withKeyVersion("v1"),
+					withConditions(xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+				},
+			},
+		},
+		"CustomerManagedKeyRollForward": {
+			args: args{
+				cr: account(withCustomerManagedKey()),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccountWithKey("v1"), nil
 					},
 				},
+				keys: latestKey("v2"),
 			},
 			want: want{
-				err: nil,
-				res: reconcile.Result{},
-				acct: v1alpha3test.NewMockAccount(bucketName).
-					WithFinalizers([]string{}).
-					WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithStatusConditions(xpv1.Deleting()).
-					Account,
+				cr: account(
+					withCustomerManagedKey(),
+					withObservation(azurestorage.ProvisioningStateCreating),
+					withIdentity(),
+					withKeyVersion("v1"),
+					withConditions(xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists: true,
+				},
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			bh := newAccountSyncDeleter(tt.fields.ao, tt.fields.cc, tt.fields.acct, tt.fields.poll)
-			got, err := bh.delete(ctx)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("accountSyncDeleter.delete(): -want error, +got error: \n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("accountSyncDeleter.delete(): -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.acct, tt.fields.acct, test.EquateConditions()); diff != "" {
-				t.Errorf("accountSyncDeleter.delete() account: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func Test_syncdeleter_sync(t *testing.T) {
-	ctx := context.TODO()
-	name := testAccountName
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		ao   azurestorage.AccountOperations
-		kube client.Client
-		acct *v1alpha3.Account
-		poll time.Duration
-	}
-	type want struct {
-		err  error
-		res  reconcile.Result
-		acct *v1alpha3.Account
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   want
-	}{
-		{
-			name: "AttrsError",
-			fields: fields{
-				ao: &azurestoragefake.MockAccountOperations{
-					MockGet: func(i context.Context) (attrs *storage.Account, e error) {
-						return nil, errBoom
+		"GetVaultKeyFailed": {
+			args: args{
+				cr: account(withCustomerManagedKey()),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccountWithKey("v1"), nil
 					},
 				},
-				kube: &test.MockClient{
-					MockCreate: func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error { return nil },
-					MockStatusUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
-						return nil
+				keys: &kvfake.MockClient{
+					MockGetKey: func(_ context.Context, _ string, _ string, _ string) (keyvault.KeyBundle, error) {
+						return keyvault.KeyBundle{}, errorBoom
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).WithUID("test-uid").Account,
 			},
 			want: want{
-				res: resultRequeue,
-				acct: v1alpha3test.NewMockAccount(name).
-					WithUID("test-uid").
-					WithStatusConditions(xpv1.ReconcileError(errBoom)).
-					Account,
+				cr: account(
+					withCustomerManagedKey(),
+					withObservation(azurestorage.ProvisioningStateCreating),
+					withIdentity(),
+					withKeyVersion("v1"),
+				),
+				err: errors.Wrap(errorBoom, errGetVaultKey),
 			},
 		},
-		{
-			name: "AttrsNotFoundCreate",
-			fields: fields{
-				kube: &test.MockClient{
-					MockCreate: func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error { return nil },
-				},
-				ao: &azurestoragefake.MockAccountOperations{
-					MockGet: func(i context.Context) (attrs *storage.Account, e error) {
-						return nil, autorest.DetailedError{
-							StatusCode: http.StatusNotFound,
-						}
+		"ServiceEndpointsEnabled": {
+			args: args{
+				cr: account(withVirtualNetworkRule(subnetID)),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Creating), nil
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).WithUID("test-uid").Account,
-				poll: time.Minute,
+				subnets: subnet("Microsoft.Sql", "Microsoft.Storage"),
 			},
 			want: want{
-				res:  reconcile.Result{RequeueAfter: time.Minute},
-				acct: v1alpha3test.NewMockAccount(name).WithUID("test-uid").Account,
+				cr: account(
+					withVirtualNetworkRule(subnetID),
+					withObservation(azurestorage.ProvisioningStateCreating),
+					withConditions(azurestorage.ServiceEndpointsEnabled(), xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists: true,
+				},
 			},
 		},
-		{
-			name: "Update",
-			fields: fields{
-				kube: &test.MockClient{
-					MockCreate: func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error { return nil },
+		"ServiceEndpointsMissing": {
+			args: args{
+				cr: account(withVirtualNetworkRule(subnetID)),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Creating), nil
+					},
+				},
+				subnets: subnet("Microsoft.Sql"),
+			},
+			want: want{
+				cr: account(
+					withVirtualNetworkRule(subnetID),
+					withObservation(azurestorage.ProvisioningStateCreating),
+					withConditions(azurestorage.ServiceEndpointsMissing(subnetID), xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists: true,
 				},
-				ao: &azurestoragefake.MockAccountOperations{
-					MockGet: func(i context.Context) (attrs *storage.Account, e error) {
-						return &storage.Account{}, nil
+			},
+		},
+		"ServiceEndpointsOtherSubscription": {
+			args: args{
+				cr: account(withVirtualNetworkRule(strings.Replace(subnetID, subscription, "other", 1))),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Creating), nil
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).WithUID("test-uid").Account,
-				poll: time.Minute,
 			},
 			want: want{
-				res:  reconcile.Result{RequeueAfter: time.Minute},
-				acct: v1alpha3test.NewMockAccount(name).WithUID("test-uid").Account,
+				cr: account(
+					withVirtualNetworkRule(strings.Replace(subnetID, subscription, "other", 1)),
+					withObservation(azurestorage.ProvisioningStateCreating),
+					withConditions(azurestorage.ServiceEndpointsEnabled(), xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists: true,
+				},
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			bh := &accountSyncDeleter{
-				createupdater:     newMockAccountCreateUpdater(tt.fields.poll),
-				AccountOperations: tt.fields.ao,
-				kube:              tt.fields.kube,
-				acct:              tt.fields.acct,
-			}
-
-			got, err := bh.sync(ctx)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("accountSyncDeleter.delete() -want error, +got error:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("accountSyncDeleter.delete(): -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.acct, tt.fields.acct, test.EquateConditions()); diff != "" {
-				t.Errorf("accountSyncDeleter.delete() account: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func Test_createupdater_create(t *testing.T) {
-	ctx := context.TODO()
-	name := testAccountName
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		sb        syncbacker
-		ao        azurestorage.AccountOperations
-		kube      client.Client
-		acct      *v1alpha3.Account
-		projectID string
-	}
-	type want struct {
-		err error
-		res reconcile.Result
-		obj *v1alpha3.Account
-	}
-
-	tests := []struct {
-		name   string
-		fields fields
-		want   want
-	}{
-		{
-			name: "CreateFailed",
-			fields: fields{
-				ao: &azurestoragefake.MockAccountOperations{
-					MockCreate: func(ctx context.Context, params storage.AccountCreateParameters) (*storage.Account, error) {
-						return nil, errBoom
+		"GetSubnetFailed": {
+			args: args{
+				cr: account(withVirtualNetworkRule(subnetID)),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Creating), nil
 					},
 				},
-				kube: &test.MockClient{
-					MockStatusUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
-						return nil
+				subnets: &networkfake.MockSubnetsClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string, _ string) (network.Subnet, error) {
+						return network.Subnet{}, errorBoom
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).
-					Account,
 			},
 			want: want{
-				res: resultRequeue,
-				obj: v1alpha3test.NewMockAccount(name).
-					WithStatusConditions(xpv1.Creating(), xpv1.ReconcileError(errBoom)).
-					WithFinalizer(finalizer).
-					Account,
+				cr: account(
+					withVirtualNetworkRule(subnetID),
+					withObservation(azurestorage.ProvisioningStateCreating),
+				),
+				err: errors.Wrap(errorBoom, errGetSubnet),
 			},
 		},
-		{
-			name: "CreateSuccessful",
-			fields: fields{
-				sb: &MockAccountSyncbacker{
-					MockSyncback: func(ctx context.Context, a *storage.Account) (result reconcile.Result, e error) {
-						return resultRequeue, errBoom
+		"StaticWebsiteLateInitialized": {
+			args: args{
+				cr: account(withStaticWebsite("index.html", "")),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccountWithWebsite(), nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
 					},
 				},
-				ao:   azurestoragefake.NewMockAccountOperations(),
-				kube: test.NewMockClient(),
-				acct: v1alpha3test.NewMockAccount(name).
-					WithUID("test-uid").
-					Account,
+				props: staticWebsite(&azblob.StaticWebsite{
+					Enabled:              true,
+					IndexDocument:        azure.ToStringPtr("index.html"),
+					ErrorDocument404Path: azure.ToStringPtr("404.html"),
+				}, nil),
 			},
 			want: want{
-				err: errBoom,
-				res: resultRequeue,
-				obj: v1alpha3test.NewMockAccount(name).
-					WithUID("test-uid").
-					WithFinalizer(finalizer).
-					WithStatusConditions(xpv1.Creating()).
-					Account,
+				cr: account(
+					withStaticWebsite("index.html", "404.html"),
+					withObservation(azurestorage.ProvisioningStateSucceeded),
+					withWebEndpoint(),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:       []byte(name),
+						xpv1.ResourceCredentialsSecretPasswordKey:   []byte(accessKey),
+						xpv1.ResourceCredentialsSecretEndpointKey:   []byte(blobEndpoint),
+						azurestorage.ConnectionSecretKeyWebEndpoint: []byte(webEndpoint),
+					},
+				},
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			bh := &accountCreateUpdater{
-				syncbacker:        tt.fields.sb,
-				AccountOperations: tt.fields.ao,
-				kube:              tt.fields.kube,
-				acct:              tt.fields.acct,
-				projectID:         tt.fields.projectID,
-			}
-			got, err := bh.create(ctx)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("accountCreateUpdater.create(): -want error, +got error:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("accountCreateUpdater.create(): -want, +got:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.obj, tt.fields.acct, test.EquateConditions()); diff != "" {
-				t.Errorf("accountCreateUpdater.create() account: -want, +got:\n%s", diff)
-			}
-		})
-	}
-}
-
-func Test_bucketCreateUpdater_update(t *testing.T) {
-	ctx := context.TODO()
-	name := testAccountName
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		sb   syncbacker
-		ao   azurestorage.AccountOperations
-		kube client.Client
-		acct *v1alpha3.Account
-		poll time.Duration
-	}
-	type want struct {
-		res  reconcile.Result
-		err  error
-		acct *v1alpha3.Account
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		attrs  *storage.Account
-		want   want
-	}{
-		{
-			name: "NotReady",
-			attrs: newStorageAccount().
-				withAccountProperties(newStorageAccountProperties().
-					withProvisioningStage(storage.Creating).AccountProperties).Account,
-			fields: fields{
-				sb: &MockAccountSyncbacker{
-					MockSyncback: func(ctx context.Context, a *storage.Account) (result reconcile.Result, e error) {
-						return reconcile.Result{RequeueAfter: time.Minute}, nil
+		"StaticWebsiteNeedsUpdate": {
+			args: args{
+				cr: account(withStaticWebsite("index.html", "404.html")),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
 					},
 				},
-				poll: time.Minute,
+				props: staticWebsite(&azblob.StaticWebsite{Enabled: false}, nil),
 			},
 			want: want{
-				res: reconcile.Result{RequeueAfter: time.Minute},
+				cr: account(
+					withStaticWebsite("index.html", "404.html"),
+					withObservation(azurestorage.ProvisioningStateSucceeded),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte(name),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(accessKey),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(blobEndpoint),
+					},
+				},
 			},
 		},
-		{
-			name: "NoChanges",
-			attrs: &storage.Account{
-				AccountProperties: &storage.AccountProperties{ProvisioningState: storage.Succeeded},
-			},
-			fields: fields{
-				acct: v1alpha3test.NewMockAccount(name).
-					WithSpecStorageAccountSpec(newStoragAccountSpecWithProperties()).
-					Account,
-				kube: test.NewMockClient(),
-				poll: time.Minute,
+		"GetStaticWebsiteFailed": {
+			args: args{
+				cr: account(withStaticWebsite("index.html", "")),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
+					},
+				},
+				props: staticWebsite(nil, errorBoom),
 			},
 			want: want{
-				res: reconcile.Result{RequeueAfter: time.Minute},
-				acct: v1alpha3test.NewMockAccount(name).
-					WithSpecStorageAccountSpec(newStoragAccountSpecWithProperties()).
-					WithStatusConditions(xpv1.Available(), xpv1.ReconcileSuccess()).
-					Account,
+				cr: account(
+					withStaticWebsite("index.html", ""),
+					withObservation(azurestorage.ProvisioningStateSucceeded),
+				),
+				err: errors.Wrap(errorBoom, errGetWebsite),
 			},
 		},
-		{
-			name: "UpdateFailed",
-			attrs: &storage.Account{
-				AccountProperties: &storage.AccountProperties{ProvisioningState: storage.Succeeded},
-				Location:          to.StringPtr("test-location"),
-			},
-			fields: fields{
-				acct: v1alpha3test.NewMockAccount(name).WithSpecStorageAccountSpec(newStoragAccountSpecWithProperties()).Account,
-				ao: &azurestoragefake.MockAccountOperations{
-					MockUpdate: func(ctx context.Context, update storage.AccountUpdateParameters) (attrs *storage.Account, e error) {
-						return nil, errBoom
+		"LateInitialized": {
+			args: args{
+				cr: account(withTags(nil)),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						az := azureAccount(storage.Creating)
+						az.AccessTier = storage.Hot
+						return az, nil
 					},
 				},
-				kube: &test.MockClient{
-					MockStatusUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error { return nil },
-				},
 			},
 			want: want{
-				res: resultRequeue,
-				acct: v1alpha3test.NewMockAccount(name).
-					WithSpecStorageAccountSpec(newStoragAccountSpecWithProperties()).
-					WithStatusConditions(xpv1.Available(), xpv1.ReconcileError(errBoom)).
-					Account,
+				cr: account(
+					withAccessTier(string(storage.Hot)),
+					withObservation(azurestorage.ProvisioningStateCreating),
+					withConditions(xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
 			},
 		},
-		{
-			name: "UpdateSuccess",
-			attrs: &storage.Account{
-				AccountProperties: &storage.AccountProperties{ProvisioningState: storage.Succeeded},
-				Location:          to.StringPtr("test-location"),
+		"NeedsUpdate": {
+			args: args{
+				cr: account(withTags(map[string]string{"cool": "extremely"})),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.ResolvingDNS), nil
+					},
+				},
 			},
-			fields: fields{
-				sb: &MockAccountSyncbacker{
-					MockSyncback: func(ctx context.Context, a *storage.Account) (result reconcile.Result, e error) {
-						return reconcile.Result{RequeueAfter: time.Minute}, nil
+			want: want{
+				cr: account(
+					withTags(map[string]string{"cool": "extremely"}),
+					withObservation(azurestorage.ProvisioningStateResolvingDNS),
+					withConditions(xpv1.Creating()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists: true,
+				},
+			},
+		},
+		"ListKeysFailed": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return storage.AccountListKeysResult{}, errorBoom
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).WithSpecStorageAccountSpec(newStoragAccountSpecWithProperties()).Account,
-				ao: &azurestoragefake.MockAccountOperations{
-					MockUpdate: func(ctx context.Context, update storage.AccountUpdateParameters) (attrs *storage.Account, e error) {
-						return &storage.Account{Location: to.StringPtr("test-location")}, nil
+			},
+			want: want{
+				cr:  account(withObservation(azurestorage.ProvisioningStateSucceeded)),
+				err: errors.Wrap(errorBoom, errListKeys),
+			},
+		},
+		"NoKeys": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+						return azureAccount(storage.Succeeded), nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return storage.AccountListKeysResult{}, nil
 					},
 				},
-				kube: test.NewMockClient(),
-				poll: time.Minute,
 			},
 			want: want{
-				res: reconcile.Result{RequeueAfter: time.Minute},
-				acct: v1alpha3test.NewMockAccount(name).
-					WithSpecStorageAccountSpec(newStoragAccountSpecWithProperties()).
-					WithStatusConditions(xpv1.Available()).
-					Account,
+				cr:  account(withObservation(azurestorage.ProvisioningStateSucceeded)),
+				err: errors.New(errNoKeys),
 			},
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			bh := &accountCreateUpdater{
-				syncbacker:        tt.fields.sb,
-				AccountOperations: tt.fields.ao,
-				kube:              tt.fields.kube,
-				acct:              tt.fields.acct,
-				poll:              tt.fields.poll,
-			}
-			got, err := bh.update(ctx, tt.attrs)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("accountCreateUpdater.update() -want error, +got error:\n%s", diff)
-				return
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{subscriptionID: subscription, client: tc.c, keys: tc.keys, subnets: tc.subnets, newProperties: tc.props}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
 			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("accountCreateUpdater.update(): -want, +got:\n%s", diff)
-				return
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
 			}
-			if diff := cmp.Diff(tt.want.acct, tt.fields.acct, test.EquateConditions()); diff != "" {
-				t.Errorf("accountCreateUpdater.update() account: -want, +got:\n%s", diff)
-				return
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
 			}
 		})
 	}
 }
 
-func Test_accountSyncBacker_syncback(t *testing.T) {
-	ctx := context.TODO()
-	name := testAccountName
-	errBoom := errors.New("boom")
+func TestSharedAccessSignatureConnectionSecret(t *testing.T) {
+	cr := account(withSAS(time.Now().Truncate(time.Second)), withConnectionSecret())
+	s := resource.ConnectionSecretFor(cr, v1beta1.AccountGroupVersionKind)
+	s.Data = map[string][]byte{
+		xpv1.ResourceCredentialsSecretUserKey:     []byte(name),
+		xpv1.ResourceCredentialsSecretPasswordKey: []byte(accessKey),
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte(blobEndpoint),
+	}
+
+	sc := runtime.NewScheme()
+	if err := corev1.AddToScheme(sc); err != nil {
+		t.Fatal(err)
+	}
+	if err := v1beta1.SchemeBuilder.AddToScheme(sc); err != nil {
+		t.Fatal(err)
+	}
+	kube := clientfake.NewClientBuilder().WithScheme(sc).WithObjects(s).Build()
+
+	e := external{client: &fake.MockAccountsClient{
+		MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+			return azureAccount(storage.Succeeded), nil
+		},
+		MockListAccountSAS: func(_ context.Context, _ string, _ string, _ storage.AccountSasParameters) (storage.ListAccountSasResponse, error) {
+			return storage.ListAccountSasResponse{AccountSasToken: azure.ToStringPtr(sasToken)}, nil
+		},
+	}}
+	o, err := e.Observe(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("Observe(...): %s", err)
+	}
+	if err := managed.NewAPISecretPublisher(kube, sc).PublishConnection(context.Background(), cr, o.ConnectionDetails); err != nil {
+		t.Fatalf("PublishConnection(...): %s", err)
+	}
+
+	got := &corev1.Secret{}
+	if err := kube.Get(context.Background(), types.NamespacedName{Namespace: s.GetNamespace(), Name: s.GetName()}, got); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string][]byte{
+		xpv1.ResourceCredentialsSecretUserKey:     []byte(name),
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte(blobEndpoint),
+		azurestorage.ConnectionSecretKeySASToken:  []byte(sasToken),
+	}
+	if diff := cmp.Diff(want, got.Data); diff != "" {
+		t.Errorf("A connection secret that held the access key should hold only the SAS once one is issued: -want, +got:\n%s", diff)
+	}
+}
+
+func TestSharedAccessSignatureParametersChanged(t *testing.T) {
+	// The current SAS does not expire for a day, but grants fewer permissions
+	// than are now desired.
+	cr := account(withSAS(time.Now().Add(24*time.Hour).Truncate(time.Second)), withSASPermissions("rwl"))
+	e := external{client: &fake.MockAccountsClient{
+		MockListAccountSAS: func(_ context.Context, _ string, _ string, p storage.AccountSasParameters) (storage.ListAccountSasResponse, error) {
+			if p.Permissions != "rwl" {
+				return storage.ListAccountSasResponse{}, errors.Errorf("unexpected permissions %q", p.Permissions)
+			}
+			return storage.ListAccountSasResponse{AccountSasToken: azure.ToStringPtr(sasToken)}, nil
+		},
+	}}
+	conn, err := e.connectionDetails(context.Background(), cr)
+	if err != nil {
+		t.Fatalf("connectionDetails(...): %s", err)
+	}
+	if diff := cmp.Diff(sasToken, string(conn[azurestorage.ConnectionSecretKeySASToken])); diff != "" {
+		t.Errorf("connectionDetails(...): -want token, +got token\n%s", diff)
+	}
+	if diff := cmp.Diff(azurestorage.AccountSASHash(*cr.Spec.SharedAccessSignature), cr.Status.SharedAccessSignature.ParametersHash); diff != "" {
+		t.Errorf("connectionDetails(...): -want hash, +got hash\n%s", diff)
+	}
+}
 
-	type fields struct {
-		secretupdater secretupdater
-		kube          client.Client
-		acct          *v1alpha3.Account
-		poll          time.Duration
+func TestCreate(t *testing.T) {
+	type args struct {
+		cr *v1beta1.Account
+		c  storageapi.AccountsClientAPI
 	}
 	type want struct {
-		res  reconcile.Result
-		err  error
-		acct *v1alpha3.Account
+		cr  *v1beta1.Account
+		err error
 	}
-	tests := []struct {
-		name   string
-		fields fields
-		acct   *storage.Account
-		want   want
+
+	cases := map[string]struct {
+		args
+		want
 	}{
-		{
-			name: "UpdateDailed",
-			fields: fields{
-				secretupdater: &MockAccountSecretupdater{},
-				acct:          v1alpha3test.NewMockAccount(name).Account,
-				kube: &test.MockClient{
-					MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
-						return errBoom
+		"Successful": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockCreate: func(_ context.Context, _ string, _ string, _ storage.AccountCreateParameters) (storage.AccountsCreateFuture, error) {
+						return storage.AccountsCreateFuture{}, nil
 					},
 				},
 			},
-			acct: &storage.Account{},
 			want: want{
-				err:  errBoom,
-				res:  resultRequeue,
-				acct: v1alpha3test.NewMockAccount(name).WithSpecStorageAccountSpec(newStorageAccountSpec()).Account,
+				cr: account(withConditions(xpv1.Creating())),
 			},
 		},
-		{
-			name: "ProvisionStatusIsNotSucceeded",
-			fields: fields{
-				acct: v1alpha3test.NewMockAccount(name).Account,
-				kube: test.NewMockClient(),
-			},
-			acct: newStorageAccount().
-				withAccountProperties(newStorageAccountProperties().
-					withProvisioningStage(storage.Creating).AccountProperties).Account,
-			want: want{
-				res: requeueOnWait,
-				acct: v1alpha3test.NewMockAccount(name).
-					WithSpecStatusFromProperties(&storage.AccountProperties{ProvisioningState: storage.Creating}).
-					WithStatusConditions(xpv1.ReconcileSuccess()).
-					Account,
-			},
-		},
-		{
-			name: "UpdateSecretFailed",
-			fields: fields{
-				secretupdater: &MockAccountSecretupdater{
-					MockUpdateSecret: func(ctx context.Context, a *storage.Account) error {
-						return errBoom
+		"Failed": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockCreate: func(_ context.Context, _ string, _ string, _ storage.AccountCreateParameters) (storage.AccountsCreateFuture, error) {
+						return storage.AccountsCreateFuture{}, errorBoom
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).Account,
-				kube: test.NewMockClient(),
-			},
-			acct: &storage.Account{AccountProperties: &storage.AccountProperties{ProvisioningState: storage.Succeeded}},
-			want: want{
-				res: resultRequeue,
-				acct: v1alpha3test.NewMockAccount(name).
-					WithSpecStatusFromProperties(&storage.AccountProperties{ProvisioningState: storage.Succeeded}).
-					WithStatusConditions(xpv1.ReconcileError(errBoom)).Account,
-			},
-		},
-		{
-			name: "Success",
-			fields: fields{
-				secretupdater: &MockAccountSecretupdater{
-					MockUpdateSecret: func(ctx context.Context, a *storage.Account) error { return nil },
-				},
-				acct: v1alpha3test.NewMockAccount(name).
-					WithSpecStorageAccountSpec(v1alpha3.NewStorageAccountSpec(&storage.Account{})).
-					Account,
-				kube: test.NewMockClient(),
-				poll: time.Minute,
 			},
-			acct: &storage.Account{AccountProperties: &storage.AccountProperties{ProvisioningState: storage.Succeeded}},
 			want: want{
-				res: reconcile.Result{RequeueAfter: time.Minute},
-				acct: v1alpha3test.NewMockAccount(name).
-					WithSpecStatusFromProperties(&storage.AccountProperties{ProvisioningState: storage.Succeeded}).
-					WithStatusConditions(xpv1.ReconcileSuccess()).
-					Account,
+				cr:  account(withConditions(xpv1.Creating())),
+				err: errors.Wrap(errorBoom, errCreateFailed),
 			},
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			acu := &accountSyncbacker{
-				secretupdater: tt.fields.secretupdater,
-				kube:          tt.fields.kube,
-				acct:          tt.fields.acct,
-				poll:          tt.fields.poll,
-			}
-			got, err := acu.syncback(ctx, tt.acct)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("accountSyncBackSecretUpdater.syncback() -want error, +got error:\n%s", diff)
-				return
-			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("accountSyncBackSecretUpdater.syncback(): -want, +got:\n%s", diff)
-				return
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			_, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
 			}
-			if diff := cmp.Diff(tt.want.acct, tt.fields.acct, test.EquateConditions()); diff != "" {
-				t.Errorf("accountSyncBackSecretUpdater.syncback() account: -want, +got:\n%s", diff)
-				return
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
 			}
 		})
 	}
 }
 
-func Test_accountSecretUpdater_updatesecret(t *testing.T) {
-	ctx := context.TODO()
-	ns := testNamespace
-	name := testAccountName
-	csName := "connectionsecret"
+func TestUpdate(t *testing.T) {
+	recent := time.Now().Truncate(time.Second)
+	rotated := time.Now().Add(-1000 * time.Hour).Truncate(time.Second)
 
-	type fields struct {
-		ops  azurestorage.AccountOperations
-		kube client.Client
-		acct *v1alpha3.Account
+	type args struct {
+		cr     *v1beta1.Account
+		c      storageapi.AccountsClientAPI
+		keys   keyvaultapi.BaseClientAPI
+		vaults mgmtkeyvaultapi.VaultsClientAPI
+		props  func(string, string) (azurestorage.ServicePropertiesAPI, error)
+	}
+	type want struct {
+		cr  *v1beta1.Account
+		u   managed.ExternalUpdate
+		err error
 	}
 
-	tests := []struct {
-		name    string
-		fields  fields
-		acct    *storage.Account
-		wantErr error
+	cases := map[string]struct {
+		args
+		want
 	}{
-		{
-			name: "FailedListKeys",
-			fields: fields{
-				ops: &azurestoragefake.MockAccountOperations{
-					MockListKeys: func(ctx context.Context) (keys []storage.AccountKey, e error) {
-						return nil, errors.New("test-list-keys-error")
+		"NotProvisioned": {
+			args: args{
+				cr: account(withProvisioningState(azurestorage.ProvisioningStateCreating)),
+			},
+			want: want{
+				cr: account(withProvisioningState(azurestorage.ProvisioningStateCreating)),
+			},
+		},
+		"Successful": {
+			args: args{
+				cr: account(withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, _ storage.AccountUpdateParameters) (storage.Account, error) {
+						return storage.Account{}, nil
 					},
 				},
-				kube: &test.MockClient{
-					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						return kerrors.NewNotFound(schema.GroupResource{Group: azurev1alpha3.Group, Resource: "secret"}, name)
+			},
+			want: want{
+				cr: account(withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
+			},
+		},
+		"StaticWebsite": {
+			args: args{
+				cr: account(withStaticWebsite("index.html", "404.html"), withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, _ storage.AccountUpdateParameters) (storage.Account, error) {
+						return storage.Account{}, nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).WithSpecWriteConnectionSecretToReference(ns, csName).Account,
+				props: staticWebsite(&azblob.StaticWebsite{
+					Enabled:              true,
+					IndexDocument:        azure.ToStringPtr("index.html"),
+					ErrorDocument404Path: azure.ToStringPtr("404.html"),
+				}, nil),
+			},
+			want: want{
+				cr: account(withStaticWebsite("index.html", "404.html"), withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
 			},
-			acct: &storage.Account{
-				AccountProperties: &storage.AccountProperties{
-					PrimaryEndpoints: &storage.Endpoints{
-						Blob: to.StringPtr("test-blob-endpoint"),
+		},
+		"SetStaticWebsiteFailed": {
+			args: args{
+				cr: account(withStaticWebsite("index.html", ""), withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, _ storage.AccountUpdateParameters) (storage.Account, error) {
+						return storage.Account{}, nil
+					},
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
 					},
 				},
+				props: staticWebsite(nil, errorBoom),
+			},
+			want: want{
+				cr:  account(withStaticWebsite("index.html", ""), withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
+				err: errors.Wrap(errorBoom, errSetWebsite),
 			},
-			wantErr: errors.Wrapf(errors.New("test-list-keys-error"), "failed to list account keys"),
 		},
-		{
-			name: "AccountKeysListEmpty",
-			fields: fields{
-				ops: &azurestoragefake.MockAccountOperations{
-					MockListKeys: func(ctx context.Context) (keys []storage.AccountKey, e error) {
-						return []storage.AccountKey{}, nil
+		"CustomerManagedKeyWithoutIdentity": {
+			args: args{
+				cr: account(withCustomerManagedKey(), withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, p storage.AccountUpdateParameters) (storage.Account, error) {
+						if p.Encryption.KeySource != storage.KeySourceMicrosoftStorage || p.Encryption.KeyVaultProperties != nil {
+							return storage.Account{}, errors.Errorf("unexpected key source %s", p.Encryption.KeySource)
+						}
+						return storage.Account{}, nil
+					},
+				},
+			},
+			want: want{
+				cr: account(withCustomerManagedKey(), withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
+			},
+		},
+		"CustomerManagedKey": {
+			args: args{
+				cr: account(withCustomerManagedKey(), withProvisioningState(azurestorage.ProvisioningStateSucceeded), withIdentity()),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, p storage.AccountUpdateParameters) (storage.Account, error) {
+						want := &storage.KeyVaultProperties{
+							KeyName:     azure.ToStringPtr("coolkey"),
+							KeyVersion:  azure.ToStringPtr("v2"),
+							KeyVaultURI: azure.ToStringPtr(vaultBaseURL),
+						}
+						if diff := cmp.Diff(want, p.Encryption.KeyVaultProperties); diff != "" || p.Encryption.KeySource != storage.KeySourceMicrosoftKeyvault {
+							return storage.Account{}, errors.Errorf("unexpected encryption: %s", diff)
+						}
+						return storage.Account{}, nil
 					},
 				},
-				kube: &test.MockClient{
-					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						return kerrors.NewNotFound(schema.GroupResource{Group: azurev1alpha3.Group, Resource: "secret"}, name)
+				keys: latestKey("v2"),
+				vaults: &kvfake.MockVaultsClient{
+					MockListBySubscriptionComplete: func(ctx context.Context, _ *int32) (mgmtkeyvault.VaultListResultIterator, error) {
+						return kvfake.NewVaultListResultIterator(ctx, mgmtkeyvault.Vault{
+							ID:         azure.ToStringPtr("/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.KeyVault/vaults/coolvault"),
+							Name:       azure.ToStringPtr("coolvault"),
+							Properties: &mgmtkeyvault.VaultProperties{},
+						}), nil
+					},
+					MockUpdateAccessPolicy: func(_ context.Context, _ string, _ string, _ mgmtkeyvault.AccessPolicyUpdateKind, p mgmtkeyvault.VaultAccessPolicyParameters) (mgmtkeyvault.VaultAccessPolicyParameters, error) {
+						return p, nil
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).WithSpecWriteConnectionSecretToReference(ns, csName).Account,
 			},
-			acct: &storage.Account{
-				AccountProperties: &storage.AccountProperties{
-					PrimaryEndpoints: &storage.Endpoints{
-						Blob: to.StringPtr("test-blob-endpoint"),
+			want: want{
+				cr: account(withCustomerManagedKey(), withProvisioningState(azurestorage.ProvisioningStateSucceeded), withIdentity()),
+			},
+		},
+		"GrantAccessFailed": {
+			args: args{
+				cr:   account(withCustomerManagedKey(), withProvisioningState(azurestorage.ProvisioningStateSucceeded), withIdentity()),
+				keys: latestKey("v2"),
+				vaults: &kvfake.MockVaultsClient{
+					MockListBySubscriptionComplete: func(_ context.Context, _ *int32) (mgmtkeyvault.VaultListResultIterator, error) {
+						return mgmtkeyvault.VaultListResultIterator{}, errorBoom
 					},
 				},
 			},
-			wantErr: errors.New("account keys are empty"),
+			want: want{
+				cr:  account(withCustomerManagedKey(), withProvisioningState(azurestorage.ProvisioningStateSucceeded), withIdentity()),
+				err: errors.Wrap(errors.Wrap(errorBoom, "cannot list Key Vaults"), errGrantAccess),
+			},
 		},
-		{
-			name: "CreateNewSecret",
-			fields: fields{
-				ops: &azurestoragefake.MockAccountOperations{
-					MockListKeys: func(ctx context.Context) (keys []storage.AccountKey, e error) {
-						return []storage.AccountKey{
-							{
-								KeyName: to.StringPtr("test-key"),
-								Value:   to.StringPtr("test-value"),
-							},
-						}, nil
+		"KeyRotationNotDue": {
+			args: args{
+				cr: account(withProvisioningState(azurestorage.ProvisioningStateSucceeded), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: recent},
+				})),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, _ storage.AccountUpdateParameters) (storage.Account, error) {
+						return storage.Account{}, nil
 					},
 				},
-				kube: &test.MockClient{
-					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						return kerrors.NewNotFound(schema.GroupResource{Group: azurev1alpha3.Group, Resource: "secret"}, name)
+			},
+			want: want{
+				cr: account(withProvisioningState(azurestorage.ProvisioningStateSucceeded), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: recent},
+				})),
+			},
+		},
+		"KeyRotationRetiredKey": {
+			args: args{
+				cr: account(withObservation(azurestorage.ProvisioningStateSucceeded), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNameSecondary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+					RetiringKeyName:  v1beta1.KeyNamePrimary,
+				})),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, _ storage.AccountUpdateParameters) (storage.Account, error) {
+						return storage.Account{}, nil
+					},
+					MockRegenerateKey: func(_ context.Context, _ string, _ string, p storage.AccountRegenerateKeyParameters) (storage.AccountListKeysResult, error) {
+						if azure.ToString(p.KeyName) != v1beta1.KeyNamePrimary {
+							return storage.AccountListKeysResult{}, errors.Errorf("regenerated %s", azure.ToString(p.KeyName))
+						}
+						return keys(), nil
 					},
-					MockCreate: func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error {
-						return nil
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return keys(), nil
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).WithSpecWriteConnectionSecretToReference(ns, csName).Account,
 			},
-			acct: &storage.Account{
-				AccountProperties: &storage.AccountProperties{
-					PrimaryEndpoints: &storage.Endpoints{
-						Blob: to.StringPtr("test-blob-endpoint"),
+			want: want{
+				cr: account(withObservation(azurestorage.ProvisioningStateSucceeded), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNameSecondary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				})),
+				u: managed.ExternalUpdate{
+					ConnectionDetails: managed.ConnectionDetails{
+						xpv1.ResourceCredentialsSecretUserKey:     []byte(name),
+						xpv1.ResourceCredentialsSecretPasswordKey: []byte(accessKey2),
+						xpv1.ResourceCredentialsSecretEndpointKey: []byte(blobEndpoint),
 					},
 				},
 			},
 		},
-		{
-			name: "CreateNewSecretFailed",
-			fields: fields{
-				ops: &azurestoragefake.MockAccountOperations{
-					MockListKeys: func(ctx context.Context) (keys []storage.AccountKey, e error) {
-						return []storage.AccountKey{
-							{
-								KeyName: to.StringPtr("test-key"),
-								Value:   to.StringPtr("test-value"),
-							},
-						}, nil
+		"KeyRotationInvalid": {
+			args: args{
+				cr: account(withObservation(azurestorage.ProvisioningStateSucceeded), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				}), withKeyRotationInterval(0)),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, _ storage.AccountUpdateParameters) (storage.Account, error) {
+						return storage.Account{}, nil
 					},
 				},
-				kube: &test.MockClient{
-					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						return kerrors.NewNotFound(schema.GroupResource{Group: azurev1alpha3.Group, Resource: "secret"}, name)
+			},
+			want: want{
+				cr: account(withObservation(azurestorage.ProvisioningStateSucceeded), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				}), withKeyRotationInterval(0)),
+			},
+		},
+		"RegenerateKeyFailed": {
+			args: args{
+				cr: account(withObservation(azurestorage.ProvisioningStateSucceeded), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				})),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, _ storage.AccountUpdateParameters) (storage.Account, error) {
+						return storage.Account{}, nil
 					},
-					MockCreate: func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error {
-						return errors.New("test-create-secret-error")
+					MockRegenerateKey: func(_ context.Context, _ string, _ string, _ storage.AccountRegenerateKeyParameters) (storage.AccountListKeysResult, error) {
+						return storage.AccountListKeysResult{}, errorBoom
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).WithSpecWriteConnectionSecretToReference(ns, csName).Account,
 			},
-			acct: &storage.Account{
-				AccountProperties: &storage.AccountProperties{
-					PrimaryEndpoints: &storage.Endpoints{
-						Blob: to.StringPtr("test-blob-endpoint"),
+			want: want{
+				cr: account(withObservation(azurestorage.ProvisioningStateSucceeded), withKeyRotation(&v1beta1.KeyRotationStatus{
+					ActiveKeyName:    v1beta1.KeyNamePrimary,
+					LastRotationTime: &metav1.Time{Time: rotated},
+				})),
+				err: errors.Wrap(errorBoom, errRegenerateKey),
+			},
+		},
+		"Failed": {
+			args: args{
+				cr: account(withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
+				c: &fake.MockAccountsClient{
+					MockUpdate: func(_ context.Context, _ string, _ string, _ storage.AccountUpdateParameters) (storage.Account, error) {
+						return storage.Account{}, errorBoom
 					},
 				},
 			},
-			wantErr: errors.Wrapf(errors.New("test-create-secret-error"), "failed to create secret: %s/%s", ns, csName),
+			want: want{
+				cr:  account(withProvisioningState(azurestorage.ProvisioningStateSucceeded)),
+				err: errors.Wrap(errorBoom, errUpdateFailed),
+			},
 		},
-		{
-			name: "UpdateExistingSecret",
-			fields: fields{
-				ops: &azurestoragefake.MockAccountOperations{
-					MockListKeys: func(ctx context.Context) (keys []storage.AccountKey, e error) {
-						return []storage.AccountKey{
-							{
-								KeyName: to.StringPtr("test-key"),
-								Value:   to.StringPtr("test-value"),
-							},
-						}, nil
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c, keys: tc.keys, vaults: tc.vaults, newProperties: tc.props}
+			u, err := e.Update(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.u, u); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		cr *v1beta1.Account
+		c  storageapi.AccountsClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.Account
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockDelete: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+						return autorest.Response{}, nil
 					},
 				},
-				kube: &test.MockClient{
-					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						return nil
-					},
-					MockCreate: func(ctx context.Context, obj client.Object, _ ...client.CreateOption) error {
-						return kerrors.NewAlreadyExists(schema.GroupResource{Group: azurev1alpha3.Group, Resource: "secret"}, name)
-					},
-					MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
-						return nil
+			},
+			want: want{
+				cr: account(withConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyDeleted": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockDelete: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+						return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
 					},
 				},
-				acct: v1alpha3test.NewMockAccount(name).WithSpecWriteConnectionSecretToReference(ns, csName).Account,
 			},
-			acct: &storage.Account{
-				AccountProperties: &storage.AccountProperties{
-					PrimaryEndpoints: &storage.Endpoints{
-						Blob: to.StringPtr("test-blob-endpoint"),
+			want: want{
+				cr: account(withConditions(xpv1.Deleting())),
+			},
+		},
+		"Failed": {
+			args: args{
+				cr: account(),
+				c: &fake.MockAccountsClient{
+					MockDelete: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+						return autorest.Response{}, errorBoom
 					},
 				},
 			},
+			want: want{
+				cr:  account(withConditions(xpv1.Deleting())),
+				err: errors.Wrap(errorBoom, errDeleteFailed),
+			},
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			asu := &accountSecretUpdater{
-				AccountOperations: tt.fields.ops,
-				acct:              tt.fields.acct,
-				kube:              tt.fields.kube,
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestMigratorInitialize(t *testing.T) {
+	legacy := func() *v1beta1.Account {
+		a := account()
+		a.Spec.ForProvider = v1beta1.AccountParameters{}
+		a.Spec.DeprecatedResourceGroupName = resourceGroup
+		a.Spec.DeprecatedStorageAccountSpec = &v1alpha3.StorageAccountSpec{
+			Kind:     legacystorage.Storage,
+			Location: location,
+			Sku:      &v1alpha3.Sku{Name: legacystorage.StandardLRS},
+			Tags:     map[string]string{"cool": "very"},
+		}
+		return a
+	}
+
+	type want struct {
+		cr  *v1beta1.Account
+		err error
+	}
+
+	cases := map[string]struct {
+		kube client.Client
+		cr   *v1beta1.Account
+		want want
+	}{
+		"NotLegacy": {
+			kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errors.New("unexpected update"))},
+			cr:   account(),
+			want: want{cr: account()},
+		},
+		"Migrated": {
+			kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(nil)},
+			cr:   legacy(),
+			want: want{cr: account(withKind(string(legacystorage.Storage)))},
+		},
+		"UpdateError": {
+			kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errorBoom)},
+			cr:   legacy(),
+			want: want{
+				cr:  account(withKind(string(legacystorage.Storage))),
+				err: errors.Wrap(errorBoom, errMigrate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &migrator{kube: tc.kube}
+			err := m.Initialize(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Initialize(...): -want error, +got error\n%s", diff)
 			}
-			err := asu.updatesecret(ctx, tt.acct)
-			if diff := cmp.Diff(tt.wantErr, err, test.EquateErrors()); diff != "" {
-				t.Errorf("accountSyncBackSecretUpdater.syncback() -want error, +got error:\n%s", diff)
+			if diff := cmp.Diff(tc.want.cr, tc.cr); diff != "" {
+				t.Errorf("Initialize(...): -want, +got\n%s", diff)
 			}
 		})
 	}
@@ -0,0 +1,148 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobservice
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotBlobService = "managed resource is not a storage BlobService"
+	errConnectFailed  = "cannot connect to Azure API"
+	errGetFailed      = "cannot get blob service properties"
+	errSetFailed      = "cannot set blob service properties"
+)
+
+// Setup adds a controller that reconciles BlobServices.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1beta1.BlobServiceGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1beta1.BlobService{}).
+		Complete(tracing.Reconciler(name, v1beta1.BlobServiceGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.BlobServiceGroupVersionKind),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{kube: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.atProvider.id"))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	kube client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azure.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
+	}
+	cl := azurestorage.NewBlobServiceClient(creds[azure.CredentialsKeySubscriptionID])
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client azurestorage.BlobServiceClientAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.BlobService)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotBlobService)
+	}
+
+	// The blob service of an Account cannot be deleted, so we consider it
+	// gone as soon as the BlobService is.
+	if meta.WasDeleted(cr) {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	az, err := e.client.GetServiceProperties(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName)
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azure.IsNotFound, err), errGetFailed)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	azurestorage.LateInitializeBlobService(&cr.Spec.ForProvider, az)
+	cr.Status.AtProvider = azurestorage.GenerateBlobServiceObservation(az)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        azurestorage.IsBlobServiceUpToDate(cr.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.BlobService)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotBlobService)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+	_, err := e.client.SetServiceProperties(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, azurestorage.NewBlobServiceProperties(cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errSetFailed)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.BlobService)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotBlobService)
+	}
+	_, err := e.client.SetServiceProperties(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, azurestorage.NewBlobServiceProperties(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errSetFailed)
+}
+
+// Delete does nothing, because the blob service of an Account cannot be
+// deleted.
+func (e *external) Delete(_ context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.BlobService)
+	if !ok {
+		return errors.New(errNotBlobService)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	return nil
+}
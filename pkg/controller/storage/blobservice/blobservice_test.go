@@ -0,0 +1,305 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package blobservice
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+)
+
+const (
+	resourceGroup = "coolgroup"
+	accountName   = "coolaccount"
+	id            = "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount/blobServices/default"
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type blobServiceModifier func(*v1beta1.BlobService)
+
+func withConditions(c ...xpv1.Condition) blobServiceModifier {
+	return func(cr *v1beta1.BlobService) { cr.Status.ConditionedStatus.Conditions = c }
+}
+
+func withID(id string) blobServiceModifier {
+	return func(cr *v1beta1.BlobService) { cr.Status.AtProvider.ID = id }
+}
+
+func withVersioning(v bool) blobServiceModifier {
+	return func(cr *v1beta1.BlobService) { cr.Spec.ForProvider.IsVersioningEnabled = &v }
+}
+
+func withChangeFeed(enabled bool) blobServiceModifier {
+	return func(cr *v1beta1.BlobService) { cr.Spec.ForProvider.ChangeFeed = &v1beta1.ChangeFeed{Enabled: enabled} }
+}
+
+func withDeletionTimestamp() blobServiceModifier {
+	return func(cr *v1beta1.BlobService) { cr.SetDeletionTimestamp(&metav1.Time{Time: time.Unix(1, 0)}) }
+}
+
+func blobService(m ...blobServiceModifier) *v1beta1.BlobService {
+	days := 7
+	cr := &v1beta1.BlobService{
+		Spec: v1beta1.BlobServiceSpec{
+			ForProvider: v1beta1.BlobServiceParameters{
+				ResourceGroupName:     resourceGroup,
+				AccountName:           accountName,
+				DeleteRetentionPolicy: &v1beta1.DeleteRetentionPolicy{Enabled: true, Days: &days},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func azureBlobService() azurestorage.BlobServiceProperties {
+	return azurestorage.BlobServiceProperties{
+		ID: azure.ToStringPtr(id),
+		BlobServicePropertiesProperties: &azurestorage.BlobServicePropertiesProperties{
+			BlobServicePropertiesProperties: storage.BlobServicePropertiesProperties{
+				DeleteRetentionPolicy: &storage.DeleteRetentionPolicy{Enabled: azure.ToBoolPtr(true), Days: azure.ToInt32Ptr(7)},
+				IsVersioningEnabled:   azure.ToBoolPtr(false, azure.FieldRequired),
+			},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		cr *v1beta1.BlobService
+		c  azurestorage.BlobServiceClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.BlobService
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Deleted": {
+			args: args{
+				cr: blobService(withDeletionTimestamp()),
+				c:  &fake.MockBlobServicesClient{},
+			},
+			want: want{
+				cr: blobService(withDeletionTimestamp()),
+			},
+		},
+		"NotFound": {
+			args: args{
+				cr: blobService(),
+				c: &fake.MockBlobServicesClient{
+					MockGetServiceProperties: func(_ context.Context, _ string, _ string) (azurestorage.BlobServiceProperties, error) {
+						return azurestorage.BlobServiceProperties{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+					},
+				},
+			},
+			want: want{
+				cr: blobService(),
+			},
+		},
+		"GetFailed": {
+			args: args{
+				cr: blobService(),
+				c: &fake.MockBlobServicesClient{
+					MockGetServiceProperties: func(_ context.Context, _ string, _ string) (azurestorage.BlobServiceProperties, error) {
+						return azurestorage.BlobServiceProperties{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  blobService(),
+				err: errors.Wrap(errorBoom, errGetFailed),
+			},
+		},
+		"UpToDateAndLateInitialized": {
+			args: args{
+				cr: blobService(),
+				c: &fake.MockBlobServicesClient{
+					MockGetServiceProperties: func(_ context.Context, _ string, _ string) (azurestorage.BlobServiceProperties, error) {
+						return azureBlobService(), nil
+					},
+				},
+			},
+			want: want{
+				cr: blobService(withVersioning(false), withID(id), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"NeedsUpdate": {
+			args: args{
+				cr: blobService(withVersioning(false), withChangeFeed(true)),
+				c: &fake.MockBlobServicesClient{
+					MockGetServiceProperties: func(_ context.Context, _ string, _ string) (azurestorage.BlobServiceProperties, error) {
+						return azureBlobService(), nil
+					},
+				},
+			},
+			want: want{
+				cr: blobService(withVersioning(false), withChangeFeed(true), withID(id), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type args struct {
+		cr *v1beta1.BlobService
+		c  azurestorage.BlobServiceClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.BlobService
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SetFailed": {
+			args: args{
+				cr: blobService(),
+				c: &fake.MockBlobServicesClient{
+					MockSetServiceProperties: func(_ context.Context, _ string, _ string, _ azurestorage.BlobServiceProperties) (azurestorage.BlobServiceProperties, error) {
+						return azurestorage.BlobServiceProperties{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  blobService(withConditions(xpv1.Creating())),
+				err: errors.Wrap(errorBoom, errSetFailed),
+			},
+		},
+		"Successful": {
+			args: args{
+				cr: blobService(),
+				c: &fake.MockBlobServicesClient{
+					MockSetServiceProperties: func(_ context.Context, _ string, _ string, _ azurestorage.BlobServiceProperties) (azurestorage.BlobServiceProperties, error) {
+						return azureBlobService(), nil
+					},
+				},
+			},
+			want: want{
+				cr: blobService(withConditions(xpv1.Creating())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			_, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type args struct {
+		cr *v1beta1.BlobService
+		c  azurestorage.BlobServiceClientAPI
+	}
+	type want struct {
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"SetFailed": {
+			args: args{
+				cr: blobService(),
+				c: &fake.MockBlobServicesClient{
+					MockSetServiceProperties: func(_ context.Context, _ string, _ string, _ azurestorage.BlobServiceProperties) (azurestorage.BlobServiceProperties, error) {
+						return azurestorage.BlobServiceProperties{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errorBoom, errSetFailed),
+			},
+		},
+		"Successful": {
+			args: args{
+				cr: blobService(),
+				c: &fake.MockBlobServicesClient{
+					MockSetServiceProperties: func(_ context.Context, _ string, _ string, _ azurestorage.BlobServiceProperties) (azurestorage.BlobServiceProperties, error) {
+						return azureBlobService(), nil
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			_, err := e.Update(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -18,291 +18,442 @@ package container
 
 import (
 	"context"
-	"reflect"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
 	"github.com/Azure/azure-storage-blob-go/azblob"
-	"github.com/Azure/go-autorest/autorest/to"
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
 	"github.com/crossplane/crossplane-runtime/pkg/logging"
 	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/resource"
 
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
 	azure "github.com/crossplane/provider-azure/pkg/clients"
-
-	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
-	"github.com/crossplane/provider-azure/pkg/clients/storage"
-)
-
-const (
-	controllerName = "container.storage.azure.crossplane.io"
-	finalizer      = "finalizer." + controllerName
-
-	reconcileTimeout = 2 * time.Minute
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/tracing"
 )
 
-// Error strings
+// Error strings.
 const (
-	errAcctSecretNil = "account does not have a connection secret"
+	errNotContainer  = "managed resource is not a storage Container"
+	errConnectFailed = "cannot connect to Azure API"
+	errListKeys      = "cannot list storage Account keys"
+	errNoKeys        = "storage Account has no keys"
+	errNewClient     = "cannot create storage Container client"
+	errGetFailed     = "cannot get storage Container"
+	errCreateFailed  = "cannot create storage Container"
+	errUpdateFailed  = "cannot update storage Container"
+	errDeleteFailed  = "cannot delete storage Container"
+	errIssueSAS      = "cannot issue shared access signature"
+	errGetUDK        = "cannot get user delegation key"
+	errGetAccount    = "cannot get storage Account"
+	errGetToken      = "cannot get Azure AD token for storage Account"
+	errGetProtection = "cannot get storage Container immutability policy and legal hold"
+	errUpdatePolicy  = "cannot update storage Container immutability policy"
+	errLockPolicy    = "cannot lock storage Container immutability policy"
+	errExtendPolicy  = "cannot extend storage Container immutability policy"
+	errSetLegalHold  = "cannot set storage Container legal hold"
+	errClearLegal    = "cannot clear storage Container legal hold"
+	errMigrate       = "cannot migrate v1alpha3 storage Container to v1beta1"
 )
 
-var (
-	resultRequeue = reconcile.Result{Requeue: true}
-)
-
-// Reconciler reconciles an Azure storage container
-type Reconciler struct {
-	client.Client
-	syncdeleterMaker
-	managed.ReferenceResolver
-	managed.Initializer
-
-	poll time.Duration
-
-	log logging.Logger
-}
-
 // Setup adds a controller that reconciles Containers.
-func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration) error {
-	name := managed.ControllerName(v1alpha3.ContainerGroupKind)
-
-	r := &Reconciler{
-		Client:           mgr.GetClient(),
-		syncdeleterMaker: &containerSyncdeleterMaker{mgr.GetClient()},
-		Initializer:      managed.NewNameAsExternalName(mgr.GetClient()),
-		poll:             poll,
-		log:              l.WithValues("controller", name),
-	}
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1beta1.ContainerGroupKind)
 
 	return ctrl.NewControllerManagedBy(mgr).
 		Named(name).
 		WithOptions(controller.Options{
-			RateLimiter: ratelimiter.NewDefaultManagedRateLimiter(rl),
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
 		}).
-		For(&v1alpha3.Container{}).
-		Complete(r)
+		For(&v1beta1.Container{}).
+		Complete(tracing.Reconciler(name, v1beta1.ContainerGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.ContainerGroupVersionKind),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{kube: mgr.GetClient()}, mgr.GetClient(), containerID)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithInitializers(&migrator{kube: mgr.GetClient()}, managed.NewNameAsExternalName(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
 }
 
-// Reconcile reads that state of the cluster for a Provider acct and makes changes based on the state read
-// and what is in the Provider.Spec
-func (r *Reconciler) Reconcile(ctx context.Context, request reconcile.Request) (reconcile.Result, error) {
-	r.log.Debug("Reconciling", "request", request)
+// containerID returns the Azure resource ID of a Container, which is a child
+// of the blob service of its storage Account.
+func containerID(mg resource.Managed, subscriptionID string) string {
+	cr, ok := mg.(*v1beta1.Container)
+	if !ok {
+		return ""
+	}
+	return "/subscriptions/" + subscriptionID +
+		"/resourceGroups/" + cr.Spec.ForProvider.ResourceGroupName +
+		"/providers/Microsoft.Storage/storageAccounts/" + cr.Spec.ForProvider.AccountName +
+		"/blobServices/default/containers/" + meta.GetExternalName(cr)
+}
 
-	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
-	defer cancel()
+// A migrator migrates Containers that were created or updated as v1alpha3
+// Containers to v1beta1. Both versions are served, but Containers are stored
+// as v1beta1 Containers without conversion, so a v1alpha3 Container is read as
+// a v1beta1 Container whose forProvider does not name a storage Account, and
+// whose provider config reference instead names the Account.
+type migrator struct {
+	kube client.Client
+}
 
-	c := &v1alpha3.Container{}
-	if err := r.Get(ctx, request.NamespacedName, c); err != nil {
-		return reconcile.Result{}, resource.Ignore(kerrors.IsNotFound, err)
+// Initialize converts the deprecated v1alpha3 fields of the supplied
+// Container, if any, to its forProvider fields. The migrated Container
+// references its storage Account, and uses the provider config of the Account.
+func (m *migrator) Initialize(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.Container)
+	if !ok {
+		return errors.New(errNotContainer)
 	}
-	if err := r.Initialize(ctx, c); err != nil {
-		return reconcile.Result{}, err
+	p := cr.Spec.ForProvider
+	if p.AccountName != "" || p.AccountNameRef != nil || p.AccountNameSelector != nil {
+		return nil
 	}
 
-	sd, err := r.newSyncdeleter(ctx, c, r.poll)
-	if err != nil {
-		c.Status.SetConditions(xpv1.ReconcileError(err))
-		return resultRequeue, r.Status().Update(ctx, c)
+	var name string
+	switch {
+	case cr.GetProviderConfigReference() != nil:
+		name = cr.GetProviderConfigReference().Name
+	case cr.GetProviderReference() != nil:
+		name = cr.GetProviderReference().Name
+	default:
+		return nil
 	}
-
-	// Check for deletion
-	if c.DeletionTimestamp != nil {
-		return sd.delete(ctx)
+	acct := &v1beta1.Account{}
+	if err := m.kube.Get(ctx, types.NamespacedName{Name: name}, acct); err != nil {
+		return errors.Wrap(err, errGetAccount)
 	}
 
-	return sd.sync(ctx)
-}
-
-type syncdeleterMaker interface {
-	newSyncdeleter(context.Context, *v1alpha3.Container, time.Duration) (syncdeleter, error)
+	cr.Spec.ForProvider.ResourceGroupName = acct.Spec.ForProvider.ResourceGroupName
+	cr.Spec.ForProvider.ResourceGroupNameRef = acct.Spec.ForProvider.ResourceGroupNameRef
+	if acct.Spec.DeprecatedStorageAccountSpec != nil {
+		// The Account has not yet been migrated either.
+		cr.Spec.ForProvider.ResourceGroupName = acct.Spec.DeprecatedResourceGroupName
+	}
+	cr.Spec.ForProvider.AccountNameRef = &xpv1.Reference{Name: acct.GetName()}
+	cr.Spec.ForProvider.Metadata = cr.Spec.DeprecatedMetadata
+	cr.Spec.ForProvider.PublicAccessType = cr.Spec.DeprecatedPublicAccessType
+	cr.SetProviderConfigReference(acct.GetProviderConfigReference())
+	cr.SetProviderReference(acct.GetProviderReference())
+	cr.Spec.DeprecatedMetadata = nil
+	cr.Spec.DeprecatedPublicAccessType = ""
+	return errors.Wrap(m.kube.Update(ctx, cr), errMigrate)
 }
 
-type containerSyncdeleterMaker struct {
-	client.Client
+type connecter struct {
+	kube client.Client
 }
 
-func (m *containerSyncdeleterMaker) newSyncdeleter(ctx context.Context, c *v1alpha3.Container, poll time.Duration) (syncdeleter, error) { // nolint:gocyclo
-	nn := types.NamespacedName{}
-	switch {
-	case c.GetProviderConfigReference() != nil && c.GetProviderConfigReference().Name != "":
-		nn.Name = c.GetProviderConfigReference().Name
-	case c.GetProviderReference() != nil && c.GetProviderReference().Name != "":
-		nn.Name = c.GetProviderReference().Name
-	default:
-		return nil, errors.New("neither providerConfigRef nor providerRef is given")
-	}
-	// Storage containers use a storage account as their 'provider', not a
-	// typical Azure provider.
-	acct := &v1alpha3.Account{}
-	if err := m.Get(ctx, nn, acct); err != nil {
-		// For storage account not found errors - check if we are on deletion path
-		// if so - remove finalizer from this container object
-		if kerrors.IsNotFound(err) && c.DeletionTimestamp != nil {
-			meta.RemoveFinalizer(c, finalizer)
-			if err := m.Client.Update(ctx, c); err != nil {
-				return nil, errors.Wrapf(err, "failed to update after removing finalizer")
-			}
-		}
-		return nil, errors.Wrapf(err, "failed to retrieve storage account: %s", nn.Name)
+// Connect to the blob service of the storage Account of the supplied
+// Container, using either the first access key of the Account or an Azure AD
+// token, depending on the Container's authorization mode.
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	cr, ok := mg.(*v1beta1.Container)
+	if !ok {
+		return nil, errors.New(errNotContainer)
 	}
-
-	if acct.GetWriteConnectionSecretToReference() == nil {
-		return nil, errors.New(errAcctSecretNil)
+	creds, auth, err := azure.GetAuthInfo(ctx, c.kube, cr)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
 	}
-
-	// Retrieve storage account secret
-	s := &corev1.Secret{}
-	n := types.NamespacedName{
-		Namespace: acct.Spec.WriteConnectionSecretToReference.Namespace,
-		Name:      acct.Spec.WriteConnectionSecretToReference.Name,
+	ac := storage.NewAccountsClient(creds[azure.CredentialsKeySubscriptionID])
+	ac.Authorizer = auth
+	bc := storage.NewBlobContainersClient(creds[azure.CredentialsKeySubscriptionID])
+	bc.Authorizer = auth
+
+	var ch azurestorage.ContainerOperations
+	if azure.ToString(cr.Spec.ForProvider.AuthorizationMode) == v1beta1.AuthorizationModeAzureAD {
+		ch, err = tokenContainerHandle(ctx, creds, ac, cr)
+	} else {
+		ch, err = sharedKeyContainerHandle(ctx, ac, cr)
 	}
-	if err := m.Get(ctx, n, s); err != nil {
-		return nil, errors.Wrapf(err, "failed to retrieve storage account secret: %s", n)
+	if azure.IsNotFound(errors.Cause(err)) && meta.WasDeleted(cr) {
+		// The Container was deleted along with its storage Account.
+		return &orphaned{}, nil
 	}
-
-	accountName := string(s.Data[xpv1.ResourceCredentialsSecretUserKey])
-	accountPassword := string(s.Data[xpv1.ResourceCredentialsSecretPasswordKey])
-	containerName := meta.GetExternalName(c)
-
-	ch, err := storage.NewContainerHandle(accountName, accountPassword, containerName)
 	if err != nil {
-		return nil, errors.Wrapf(err, "failed to create client handle: %s, storage account: %s", containerName, accountName)
-	}
-
-	// set owner reference on the container to storage account, thus
-	// if the account is delete - container is garbage collected as well
-	or := meta.AsOwner(meta.TypedReferenceTo(acct, v1alpha3.AccountGroupVersionKind))
-	or.BlockOwnerDeletion = to.BoolPtr(true)
-	meta.AddOwnerReference(c, or)
-
-	return &containerSyncdeleter{
-		createupdater: &containerCreateUpdater{
-			ContainerOperations: ch,
-			kube:                m.Client,
-			container:           c,
-			poll:                poll,
-		},
-		ContainerOperations: ch,
-		kube:                m.Client,
-		container:           c,
+		return nil, err
+	}
+
+	return &external{
+		client:     ch,
+		accounts:   ac,
+		containers: bc,
+		delegate:   azurestorage.NewUserDelegationCredentialGetter(creds, cr.Spec.ForProvider.AccountName),
 	}, nil
 }
 
-type deleter interface {
-	delete(context.Context) (reconcile.Result, error)
+// sharedKeyContainerHandle returns a handle to the supplied Container that
+// authorizes requests with the first access key of its storage Account.
+func sharedKeyContainerHandle(ctx context.Context, ac storageapi.AccountsClientAPI, cr *v1beta1.Container) (azurestorage.ContainerOperations, error) {
+	keys, err := ac.ListKeys(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, "")
+	if err != nil {
+		return nil, errors.Wrap(err, errListKeys)
+	}
+	if keys.Keys == nil || len(*keys.Keys) == 0 {
+		return nil, errors.New(errNoKeys)
+	}
+	ch, err := azurestorage.NewContainerHandle(cr.Spec.ForProvider.AccountName, azure.ToString((*keys.Keys)[0].Value), meta.GetExternalName(cr))
+	return ch, errors.Wrap(err, errNewClient)
 }
 
-type syncer interface {
-	sync(context.Context) (reconcile.Result, error)
+// tokenContainerHandle returns a handle to the supplied Container that
+// authorizes requests with an Azure AD token obtained using the supplied
+// credentials. The storage Account is read first so that a Container whose
+// Account was deleted can be recognised.
+func tokenContainerHandle(ctx context.Context, creds map[string]string, ac storageapi.AccountsClientAPI, cr *v1beta1.Container) (azurestorage.ContainerOperations, error) {
+	if _, err := ac.GetProperties(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, ""); err != nil {
+		return nil, errors.Wrap(err, errGetAccount)
+	}
+	token, err := azure.GetToken(ctx, creds, azure.StorageResource)
+	if err != nil {
+		return nil, errors.Wrap(err, errGetToken)
+	}
+	return azurestorage.NewContainerHandleWithToken(cr.Spec.ForProvider.AccountName, token, meta.GetExternalName(cr)), nil
 }
 
-type creator interface {
-	create(context.Context) (reconcile.Result, error)
+type external struct {
+	client     azurestorage.ContainerOperations
+	accounts   storageapi.AccountsClientAPI
+	containers storageapi.BlobContainersClientAPI
+	delegate   azurestorage.UserDelegationCredentialGetter
 }
 
-type updater interface {
-	update(context.Context, *azblob.PublicAccessType, azblob.Metadata) (reconcile.Result, error)
-}
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.Container)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotContainer)
+	}
 
-type syncdeleter interface {
-	deleter
-	syncer
-}
+	az, err := e.client.Get(ctx)
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azurestorage.IsNotFoundError, err), errGetFailed)
+	}
 
-type containerSyncdeleter struct {
-	createupdater
-	storage.ContainerOperations
-	kube      client.Client
-	container *v1alpha3.Container
-}
+	cr.Status.AtProvider = azurestorage.GenerateContainerObservation(*az)
+	upToDate := azurestorage.IsContainerUpToDate(cr.Spec.ForProvider, *az)
 
-func (csd *containerSyncdeleter) delete(ctx context.Context) (reconcile.Result, error) {
-	csd.container.Status.SetConditions(xpv1.Deleting())
-	if csd.container.Spec.DeletionPolicy == xpv1.DeletionDelete {
-		if err := csd.Delete(ctx); err != nil && !azure.IsNotFound(err) {
-			csd.container.Status.SetConditions(xpv1.ReconcileError(err))
-			return resultRequeue, csd.kube.Status().Update(ctx, csd.container)
+	p := cr.Spec.ForProvider
+	if p.ImmutabilityPolicy != nil || p.LegalHold != nil {
+		protected, err := e.isProtectionUpToDate(ctx, cr)
+		if err != nil {
+			return managed.ExternalObservation{}, err
 		}
+		upToDate = upToDate && protected
+	}
+
+	conn, err := e.sharedAccessSignature(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
 	}
+	cr.Status.SetConditions(xpv1.Available())
 
-	// NOTE(negz): We don't update the conditioned status here because assuming
-	// no other finalizers need to be cleaned up the object should cease to
-	// exist after we update it.
-	meta.RemoveFinalizer(csd.container, finalizer)
-	return reconcile.Result{}, csd.kube.Update(ctx, csd.container)
+	return managed.ExternalObservation{
+		ResourceExists:    true,
+		ResourceUpToDate:  upToDate,
+		ConnectionDetails: conn,
+	}, nil
 }
 
-func (csd *containerSyncdeleter) sync(ctx context.Context) (reconcile.Result, error) {
-	access, meta, err := csd.Get(ctx)
-	if err != nil && !storage.IsNotFoundError(err) {
-		csd.container.Status.SetConditions(xpv1.ReconcileError(err))
-		return resultRequeue, csd.kube.Status().Update(ctx, csd.container)
+// isProtectionUpToDate returns true if the immutability policy and legal hold
+// of the supplied Container are up to date, and records them in its status.
+// Changes that cannot be made to a locked immutability policy are reported as
+// a condition rather than attempted.
+func (e *external) isProtectionUpToDate(ctx context.Context, cr *v1beta1.Container) (bool, error) {
+	p := cr.Spec.ForProvider
+	bc, err := e.containers.Get(ctx, p.ResourceGroupName, p.AccountName, meta.GetExternalName(cr))
+	if err != nil {
+		return false, errors.Wrap(err, errGetProtection)
+	}
+	if bc.ContainerProperties == nil {
+		bc.ContainerProperties = &storage.ContainerProperties{}
+	}
+	cr.Status.AtProvider.ImmutabilityPolicy = azurestorage.GenerateImmutabilityPolicyObservation(bc.ImmutabilityPolicy)
+	cr.Status.AtProvider.LegalHoldTags = azurestorage.GenerateLegalHoldTags(bc.LegalHold)
+
+	upToDate := true
+	if p.ImmutabilityPolicy != nil {
+		a, err := azurestorage.NextImmutabilityPolicyAction(*p.ImmutabilityPolicy, bc.ImmutabilityPolicy)
+		if err != nil {
+			cr.Status.SetConditions(azurestorage.ImmutabilityPolicyChangeIllegal(err))
+		} else {
+			cr.Status.SetConditions(azurestorage.ImmutabilityPolicyApplied())
+		}
+		upToDate = a == azurestorage.ImmutabilityPolicyActionNone
 	}
+	if p.LegalHold != nil {
+		toSet, toClear := azurestorage.LegalHoldTagChanges(*p.LegalHold, cr.Status.AtProvider.LegalHoldTags)
+		upToDate = upToDate && len(toSet) == 0 && len(toClear) == 0
+	}
+	return upToDate, nil
+}
 
-	if access == nil {
-		return csd.create(ctx)
+// sharedAccessSignature issues a new SAS for the supplied Container if it
+// wants one and its current SAS is due for renewal. It returns the connection
+// details of the new SAS, if any.
+func (e *external) sharedAccessSignature(ctx context.Context, cr *v1beta1.Container) (managed.ConnectionDetails, error) {
+	s := cr.Spec.SharedAccessSignature
+	now := time.Now()
+	if s == nil {
+		return nil, nil
+	}
+	hash := azurestorage.ContainerSASHash(*s)
+	if !azurestorage.SASNeedsRenewal(s.SharedAccessSignatureParameters, hash, cr.Status.SharedAccessSignature, now) {
+		return nil, nil
+	}
+	expiry := azurestorage.SASExpiry(s.SharedAccessSignatureParameters, now)
+
+	var token string
+	switch s.Type {
+	case v1beta1.SASTypeUserDelegation:
+		cred, err := e.delegate(ctx, now, expiry)
+		if err != nil {
+			return nil, errors.Wrap(err, errGetUDK)
+		}
+		token, err = azurestorage.NewContainerUserDelegationSAS(cred, meta.GetExternalName(cr), s.SharedAccessSignatureParameters, now, expiry)
+		if err != nil {
+			return nil, errors.Wrap(err, errIssueSAS)
+		}
+	default:
+		rsp, err := e.accounts.ListServiceSAS(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName,
+			azurestorage.NewContainerServiceSASParameters(cr.Spec.ForProvider.AccountName, meta.GetExternalName(cr), s.SharedAccessSignatureParameters, expiry))
+		if err != nil {
+			return nil, errors.Wrap(err, errIssueSAS)
+		}
+		token = azure.ToString(rsp.ServiceSasToken)
 	}
 
-	return csd.update(ctx, access, meta)
+	cr.Status.SharedAccessSignature = azurestorage.NewSASStatus(expiry, hash)
+	return managed.ConnectionDetails{
+		xpv1.ResourceCredentialsSecretEndpointKey: []byte(azurestorage.ContainerURL(cr.Spec.ForProvider.AccountName, meta.GetExternalName(cr))),
+		azurestorage.ConnectionSecretKeySASToken:  []byte(token),
+	}, nil
 }
 
-type createupdater interface {
-	creator
-	updater
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.Container)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotContainer)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+	err := e.client.Create(ctx, azblob.PublicAccessType(cr.Spec.ForProvider.PublicAccessType), azurestorage.NewContainerMetadata(cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
 }
 
-// containerCreateUpdater implementation of createupdater interface
-type containerCreateUpdater struct {
-	storage.ContainerOperations
-	kube      client.Client
-	container *v1alpha3.Container
-	poll      time.Duration
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.Container)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotContainer)
+	}
+	if err := e.client.Update(ctx, azblob.PublicAccessType(cr.Spec.ForProvider.PublicAccessType), azurestorage.NewContainerMetadata(cr.Spec.ForProvider)); err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+	}
+	if err := e.updateImmutabilityPolicy(ctx, cr); err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	return managed.ExternalUpdate{}, e.updateLegalHold(ctx, cr)
 }
 
-var _ createupdater = &containerCreateUpdater{}
-
-func (ccu *containerCreateUpdater) create(ctx context.Context) (reconcile.Result, error) {
-	container := ccu.container
-	container.Status.SetConditions(xpv1.Creating())
+// updateImmutabilityPolicy takes the next action toward the desired
+// immutability policy of the supplied Container, using the policy observed
+// by the most recent observation. Illegal changes to a locked policy are not
+// attempted.
+func (e *external) updateImmutabilityPolicy(ctx context.Context, cr *v1beta1.Container) error {
+	p := cr.Spec.ForProvider
+	if p.ImmutabilityPolicy == nil {
+		return nil
+	}
+	o := cr.Status.AtProvider.ImmutabilityPolicy
+	var az *storage.ImmutabilityPolicyProperties
+	etag := ""
+	if o != nil {
+		az = &storage.ImmutabilityPolicyProperties{ImmutabilityPolicyProperty: &storage.ImmutabilityPolicyProperty{
+			ImmutabilityPeriodSinceCreationInDays: azure.ToInt32Ptr(o.ImmutabilityPeriodSinceCreationInDays, azure.FieldRequired),
+			AllowProtectedAppendWrites:            azure.ToBoolPtr(o.AllowProtectedAppendWrites, azure.FieldRequired),
+			State:                                 storage.ImmutabilityPolicyState(o.State),
+		}}
+		etag = o.ETag
+	}
+	a, err := azurestorage.NextImmutabilityPolicyAction(*p.ImmutabilityPolicy, az)
+	if err != nil {
+		return nil
+	}
+	name := meta.GetExternalName(cr)
+	switch a {
+	case azurestorage.ImmutabilityPolicyActionUpdate:
+		_, err = e.containers.CreateOrUpdateImmutabilityPolicy(ctx, p.ResourceGroupName, p.AccountName, name, azurestorage.NewImmutabilityPolicy(*p.ImmutabilityPolicy), etag)
+		return errors.Wrap(err, errUpdatePolicy)
+	case azurestorage.ImmutabilityPolicyActionLock:
+		_, err = e.containers.LockImmutabilityPolicy(ctx, p.ResourceGroupName, p.AccountName, name, etag)
+		return errors.Wrap(err, errLockPolicy)
+	case azurestorage.ImmutabilityPolicyActionExtend:
+		_, err = e.containers.ExtendImmutabilityPolicy(ctx, p.ResourceGroupName, p.AccountName, name, etag, azurestorage.NewImmutabilityPolicy(*p.ImmutabilityPolicy))
+		return errors.Wrap(err, errExtendPolicy)
+	}
+	return nil
+}
 
-	meta.AddFinalizer(container, finalizer)
-	if err := ccu.kube.Update(ctx, container); err != nil {
-		return resultRequeue, errors.Wrapf(err, "failed to update container spec")
+// updateLegalHold sets and clears the legal hold tags of the supplied
+// Container so that they match its desired legal hold.
+func (e *external) updateLegalHold(ctx context.Context, cr *v1beta1.Container) error {
+	p := cr.Spec.ForProvider
+	if p.LegalHold == nil {
+		return nil
 	}
+	toSet, toClear := azurestorage.LegalHoldTagChanges(*p.LegalHold, cr.Status.AtProvider.LegalHoldTags)
+	if len(toSet) > 0 {
+		if _, err := e.containers.SetLegalHold(ctx, p.ResourceGroupName, p.AccountName, meta.GetExternalName(cr), storage.LegalHold{Tags: &toSet}); err != nil {
+			return errors.Wrap(err, errSetLegalHold)
+		}
+	}
+	if len(toClear) > 0 {
+		if _, err := e.containers.ClearLegalHold(ctx, p.ResourceGroupName, p.AccountName, meta.GetExternalName(cr), storage.LegalHold{Tags: &toClear}); err != nil {
+			return errors.Wrap(err, errClearLegal)
+		}
+	}
+	return nil
+}
 
-	spec := container.Spec
-	if err := ccu.Create(ctx, spec.PublicAccessType, spec.Metadata); err != nil {
-		container.Status.SetConditions(xpv1.ReconcileError(err))
-		return resultRequeue, ccu.kube.Status().Update(ctx, container)
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.Container)
+	if !ok {
+		return errors.New(errNotContainer)
 	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	return errors.Wrap(resource.Ignore(azurestorage.IsNotFoundError, e.client.Delete(ctx)), errDeleteFailed)
+}
 
-	container.Status.SetConditions(xpv1.Available(), xpv1.ReconcileSuccess())
-	return reconcile.Result{}, ccu.kube.Status().Update(ctx, ccu.container)
+// An orphaned Container belonged to a storage Account that no longer exists,
+// and thus no longer exists itself.
+type orphaned struct{}
+
+func (o *orphaned) Observe(_ context.Context, _ resource.Managed) (managed.ExternalObservation, error) {
+	return managed.ExternalObservation{ResourceExists: false}, nil
 }
 
-func (ccu *containerCreateUpdater) update(ctx context.Context, accessType *azblob.PublicAccessType, meta azblob.Metadata) (reconcile.Result, error) {
-	container := ccu.container
-	spec := container.Spec
+func (o *orphaned) Create(_ context.Context, _ resource.Managed) (managed.ExternalCreation, error) {
+	return managed.ExternalCreation{}, nil
+}
 
-	if !reflect.DeepEqual(*accessType, spec.PublicAccessType) || !reflect.DeepEqual(meta, spec.Metadata) {
-		if err := ccu.Update(ctx, spec.PublicAccessType, spec.Metadata); err != nil {
-			container.Status.SetConditions(xpv1.ReconcileError(err))
-			return resultRequeue, ccu.kube.Status().Update(ctx, container)
-		}
-	}
+func (o *orphaned) Update(_ context.Context, _ resource.Managed) (managed.ExternalUpdate, error) {
+	return managed.ExternalUpdate{}, nil
+}
 
-	container.Status.SetConditions(xpv1.Available(), xpv1.ReconcileSuccess())
-	return reconcile.Result{RequeueAfter: ccu.poll}, ccu.kube.Status().Update(ctx, ccu.container)
+func (o *orphaned) Delete(_ context.Context, _ resource.Managed) error {
+	return nil
 }
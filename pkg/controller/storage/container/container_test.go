@@ -19,929 +19,999 @@ package container
 import (
 	"context"
 	"net/http"
-	"strings"
 	"testing"
 	"time"
 
-	"github.com/crossplane/provider-azure/apis"
-
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
 	"github.com/Azure/azure-storage-blob-go/azblob"
-	"github.com/Azure/go-autorest/autorest"
 	"github.com/google/go-cmp/cmp"
-	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/pkg/errors"
-	v1 "k8s.io/api/core/v1"
-	kerrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
-	"k8s.io/apimachinery/pkg/runtime/schema"
-	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/client-go/kubernetes/scheme"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/client/fake"
-	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
-	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
 	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
 	"github.com/crossplane/crossplane-runtime/pkg/test"
 
 	"github.com/crossplane/provider-azure/apis/storage/v1alpha3"
-	v1alpha3test "github.com/crossplane/provider-azure/apis/storage/v1alpha3/test"
-	"github.com/crossplane/provider-azure/pkg/clients/storage"
-	azurestoragefake "github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+)
+
+const (
+	name          = "coolcontainer"
+	resourceGroup = "coolgroup"
+	accountName   = "coolaccount"
+	etag          = "0x8D8F0E0E0E0E0E0"
 )
 
-func init() {
-	_ = apis.AddToScheme(scheme.Scheme)
+var (
+	errorBoom    = errors.New("boom")
+	lastModified = time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+// notFoundError is a storage error with a 404 response.
+type notFoundError struct{ azblob.StorageError }
+
+func (notFoundError) Error() string { return "not found" }
+
+func (notFoundError) Response() *http.Response {
+	return &http.Response{StatusCode: http.StatusNotFound}
 }
 
-type mockCreateUpdater struct {
-	mockCreate func(context.Context) (reconcile.Result, error)
-	mockUpdate func(context.Context, *azblob.PublicAccessType, azblob.Metadata) (reconcile.Result, error)
+type containerModifier func(*v1beta1.Container)
+
+func withConditions(c ...xpv1.Condition) containerModifier {
+	return func(cr *v1beta1.Container) { cr.Status.ConditionedStatus.Conditions = c }
 }
 
-func (m *mockCreateUpdater) create(ctx context.Context) (reconcile.Result, error) {
-	return m.mockCreate(ctx)
+func withObservation(o v1beta1.ContainerObservation) containerModifier {
+	return func(cr *v1beta1.Container) { cr.Status.AtProvider = o }
 }
 
-func (m *mockCreateUpdater) update(ctx context.Context, pat *azblob.PublicAccessType, meta azblob.Metadata) (reconcile.Result, error) {
-	return m.mockUpdate(ctx, pat, meta)
+func withMetadata(m map[string]string) containerModifier {
+	return func(cr *v1beta1.Container) { cr.Spec.ForProvider.Metadata = m }
 }
 
-func newMockCreateUpdater() *mockCreateUpdater {
-	return &mockCreateUpdater{
-		mockCreate: func(context.Context) (result reconcile.Result, e error) {
-			return reconcile.Result{}, nil
-		},
-		mockUpdate: func(context.Context, *azblob.PublicAccessType, azblob.Metadata) (reconcile.Result, error) {
-			return reconcile.Result{}, nil
-		},
+func withPublicAccessType(t string) containerModifier {
+	return func(cr *v1beta1.Container) { cr.Spec.ForProvider.PublicAccessType = t }
+}
+
+func withSAS(sasType string) containerModifier {
+	return func(cr *v1beta1.Container) {
+		cr.Spec.SharedAccessSignature = &v1beta1.ContainerSharedAccessSignature{
+			SharedAccessSignatureParameters: v1beta1.SharedAccessSignatureParameters{Permissions: "rl"},
+			Type:                            sasType,
+		}
 	}
 }
 
-var _ createupdater = &mockCreateUpdater{}
+func withSASExpiry(t time.Time) containerModifier {
+	return func(cr *v1beta1.Container) {
+		cr.Status.SharedAccessSignature = azurestorage.NewSASStatus(t, azurestorage.ContainerSASHash(*cr.Spec.SharedAccessSignature))
+	}
+}
 
-type mockSyncdeleter struct {
-	mockDelete func(context.Context) (reconcile.Result, error)
-	mockSync   func(context.Context) (reconcile.Result, error)
+func withSASPermissions(p string) containerModifier {
+	return func(cr *v1beta1.Container) { cr.Spec.SharedAccessSignature.Permissions = p }
 }
 
-func (m *mockSyncdeleter) delete(ctx context.Context) (reconcile.Result, error) {
-	return m.mockDelete(ctx)
+func withSASType(sasType string) containerModifier {
+	return func(cr *v1beta1.Container) { cr.Spec.SharedAccessSignature.Type = sasType }
 }
 
-func (m *mockSyncdeleter) sync(ctx context.Context) (reconcile.Result, error) {
-	return m.mockSync(ctx)
+func withImmutabilityPolicy(p v1beta1.ImmutabilityPolicy) containerModifier {
+	return func(cr *v1beta1.Container) { cr.Spec.ForProvider.ImmutabilityPolicy = &p }
 }
 
-var _ syncdeleter = &mockSyncdeleter{}
+func withAuthorizationMode(m string) containerModifier {
+	return func(cr *v1beta1.Container) { cr.Spec.ForProvider.AuthorizationMode = &m }
+}
 
-type mockSyncdeleteMaker struct {
-	mockNewSyncdeleter func(context.Context, *v1alpha3.Container, time.Duration) (syncdeleter, error)
+func withLegalHold(tags ...string) containerModifier {
+	return func(cr *v1beta1.Container) { cr.Spec.ForProvider.LegalHold = &v1beta1.LegalHold{Tags: tags} }
 }
 
-func (m *mockSyncdeleteMaker) newSyncdeleter(ctx context.Context, c *v1alpha3.Container, poll time.Duration) (syncdeleter, error) {
-	return m.mockNewSyncdeleter(ctx, c, poll)
+func container(m ...containerModifier) *v1beta1.Container {
+	cr := &v1beta1.Container{
+		Spec: v1beta1.ContainerSpec{
+			ForProvider: v1beta1.ContainerParameters{
+				ResourceGroupName: resourceGroup,
+				AccountName:       accountName,
+				Metadata:          map[string]string{"Cool": "very"},
+				PublicAccessType:  v1beta1.PublicAccessBlob,
+			},
+		},
+	}
+	meta.SetExternalName(cr, name)
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
 }
 
-var _ syncdeleterMaker = &mockSyncdeleteMaker{}
+func properties() *azurestorage.ContainerProperties {
+	return &azurestorage.ContainerProperties{
+		PublicAccessType: azblob.PublicAccessBlob,
+		Metadata:         azblob.Metadata{"cool": "very"},
+		ETag:             etag,
+		LastModified:     lastModified,
+		LeaseState:       azblob.LeaseStateAvailable,
+		LeaseStatus:      azblob.LeaseStatusUnlocked,
+	}
+}
 
-func newContainerNotFoundError(name string) error {
-	return kerrors.NewNotFound(
-		schema.GroupResource{Group: v1alpha3.Group, Resource: v1alpha3.ContainerKind}, name)
+func observation() v1beta1.ContainerObservation {
+	return v1beta1.ContainerObservation{
+		ETag:         etag,
+		LastModified: &metav1.Time{Time: lastModified},
+		LeaseState:   string(azblob.LeaseStateAvailable),
+		LeaseStatus:  string(azblob.LeaseStatusUnlocked),
+	}
 }
 
-func newAccountNotFoundError(name string) error {
-	return kerrors.NewNotFound(
-		schema.GroupResource{Group: v1alpha3.Group, Resource: strings.ToLower(v1alpha3.AccountKind) + "s"}, name)
+func blobContainer(p *storage.ImmutabilityPolicyProperties, tags ...string) storage.BlobContainer {
+	lh := &storage.LegalHoldProperties{Tags: &[]storage.TagProperty{}}
+	for _, t := range tags {
+		*lh.Tags = append(*lh.Tags, storage.TagProperty{Tag: azure.ToStringPtr(t)})
+	}
+	return storage.BlobContainer{ContainerProperties: &storage.ContainerProperties{ImmutabilityPolicy: p, LegalHold: lh}}
 }
 
-func newSecret(ns, name string, data map[string][]byte) *v1.Secret {
-	return &v1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Namespace: ns,
-			Name:      name,
+func unlockedPolicy(days int) *storage.ImmutabilityPolicyProperties {
+	return &storage.ImmutabilityPolicyProperties{
+		Etag: azure.ToStringPtr(etag),
+		ImmutabilityPolicyProperty: &storage.ImmutabilityPolicyProperty{
+			ImmutabilityPeriodSinceCreationInDays: azure.ToInt32Ptr(days, azure.FieldRequired),
+			AllowProtectedAppendWrites:            azure.ToBoolPtr(false, azure.FieldRequired),
+			State:                                 storage.Unlocked,
 		},
-		Data: data,
 	}
 }
 
-func newSecretNotFoundError(name string) error {
-	return kerrors.NewNotFound(
-		schema.GroupResource{Group: v1.GroupName, Resource: "secrets"}, name)
+func lockedPolicy(days int) *storage.ImmutabilityPolicyProperties {
+	p := unlockedPolicy(days)
+	p.State = storage.Locked
+	return p
 }
 
-func newStorageNotFoundError() error {
-	return azblob.NewResponseError(nil, &http.Response{StatusCode: http.StatusNotFound}, "")
+func TestContainerID(t *testing.T) {
+	want := "/subscriptions/coolsub/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount/blobServices/default/containers/coolcontainer"
+	if diff := cmp.Diff(want, containerID(container(), "coolsub")); diff != "" {
+		t.Errorf("containerID(...): -want, +got\n%s", diff)
+	}
 }
 
-const (
-	testNamespace     = "default"
-	testContainerName = "testContainer"
-	testAccountName   = "testAccount"
-)
-
-func TestReconciler_Reconcile(t *testing.T) {
-	key := types.NamespacedName{Name: testContainerName}
-	req := reconcile.Request{NamespacedName: key}
-	ctx := context.TODO()
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		Client           client.Client
-		syncdeleterMaker syncdeleterMaker
+func TestObserve(t *testing.T) {
+	type args struct {
+		cr         *v1beta1.Container
+		c          azurestorage.ContainerOperations
+		containers storageapi.BlobContainersClientAPI
 	}
 	type want struct {
+		cr  *v1beta1.Container
+		o   managed.ExternalObservation
 		err error
-		res reconcile.Result
-		con *v1alpha3.Container
 	}
-	tests := []struct {
-		name   string
-		fields fields
-		want   want
+
+	cases := map[string]struct {
+		args
+		want
 	}{
-		{
-			name: "GetErrNotFound",
-			fields: fields{
-				Client: &test.MockClient{
-					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						return newContainerNotFoundError(testContainerName)
+		"NotFound": {
+			args: args{
+				cr: container(),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return nil, notFoundError{}
 					},
 				},
-				syncdeleterMaker: nil,
 			},
 			want: want{
-				res: reconcile.Result{},
+				cr: container(),
 			},
 		},
-		{
-			name: "GetErrOther",
-			fields: fields{
-				Client: &test.MockClient{
-					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						return errors.New("test-get-error")
-					},
-				},
-				syncdeleterMaker: nil,
-			},
-			want: want{
-				res: reconcile.Result{},
-				err: errors.New("test-get-error"),
-			},
-		},
-		{
-			name: "SyncdeleteMakerError",
-			fields: fields{
-				Client: fake.NewClientBuilder().WithObjects(v1alpha3test.NewMockContainer(testContainerName).
-					WithFinalizer("foo.bar").Container).Build(),
-				syncdeleterMaker: &mockSyncdeleteMaker{
-					mockNewSyncdeleter: func(ctx context.Context, c *v1alpha3.Container, _ time.Duration) (syncdeleter, error) {
-						return nil, errBoom
+		"GetFailed": {
+			args: args{
+				cr: container(),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return nil, errorBoom
 					},
 				},
 			},
 			want: want{
-				res: resultRequeue,
-				con: v1alpha3test.NewMockContainer(testContainerName).
-					WithFinalizer("foo.bar").
-					WithStatusConditions(
-						xpv1.ReconcileError(errBoom),
-					).
-					Container,
-			},
-		},
-		{
-			name: "Delete",
-			fields: fields{
-				Client: fake.NewClientBuilder().WithObjects(v1alpha3test.NewMockContainer(testContainerName).
-					WithDeleteTimestamp(time.Now()).Container).Build(),
-				syncdeleterMaker: &mockSyncdeleteMaker{
-					mockNewSyncdeleter: func(ctx context.Context, c *v1alpha3.Container, _ time.Duration) (syncdeleter, error) {
-						return &mockSyncdeleter{
-							mockDelete: func(ctx context.Context) (reconcile.Result, error) {
-								return reconcile.Result{}, nil
-							},
-						}, nil
-					},
-				},
+				cr:  container(),
+				err: errors.Wrap(errorBoom, errGetFailed),
 			},
-			want: want{
-				res: reconcile.Result{},
-			},
-		},
-		{
-			name: "Sync",
-			fields: fields{
-				Client: fake.NewClientBuilder().WithObjects(v1alpha3test.NewMockContainer(testContainerName).Container).Build(),
-				syncdeleterMaker: &mockSyncdeleteMaker{
-					mockNewSyncdeleter: func(ctx context.Context, c *v1alpha3.Container, _ time.Duration) (syncdeleter, error) {
-						return &mockSyncdeleter{
-							mockSync: func(ctx context.Context) (reconcile.Result, error) {
-								return reconcile.Result{}, nil
-							},
-						}, nil
+		},
+		"UpToDate": {
+			args: args{
+				cr: container(),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return properties(), nil
 					},
 				},
 			},
 			want: want{
-				res: reconcile.Result{},
+				cr: container(withObservation(observation()), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
 			},
 		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			r := &Reconciler{
-				Client:           tt.fields.Client,
-				syncdeleterMaker: tt.fields.syncdeleterMaker,
-				Initializer:      managed.NewNameAsExternalName(tt.fields.Client),
-				log:              logging.NewNopLogger(),
-			}
-			got, err := r.Reconcile(context.Background(), req)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("Reconciler.Reconcile(): -want error, +got error:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("Reconciler.Reconcile(): -want, +got\n%s", diff)
-			}
-			if tt.want.con != nil {
-				c := &v1alpha3.Container{}
-				if err := tt.fields.Client.Get(ctx, key, c); err != nil {
-					t.Errorf("Reconciler.Reconcile() container error: %s", err)
-				}
-				// NOTE(muvaf): Get call returns TypeMeta and ResourceVersion
-				// that we are not interested. Since diff shows them, we have to
-				// assign the right values.
-				c.TypeMeta = tt.want.con.TypeMeta
-				tt.want.con.ResourceVersion = c.ResourceVersion
-				if diff := cmp.Diff(tt.want.con, c, test.EquateConditions()); diff != "" {
-					t.Errorf("Reconciler.Reconcile() container: -want, +got\n%s", diff)
-				}
-			}
-		})
-	}
-}
-
-func Test_containerSyncdeleterMaker_newSyncdeleter(t *testing.T) {
-	key := types.NamespacedName{Name: testContainerName}
-	newCont := func() *v1alpha3test.MockContainer {
-		return v1alpha3test.NewMockContainer(testContainerName)
-	}
-	ctx := context.TODO()
-	testAccountKey := "dGVzdC1rZXkK"
-
-	ch, err := storage.NewContainerHandle(testAccountName, testAccountKey, testContainerName)
-	if err != nil {
-		t.Errorf("containerSyncdeleterMaker.newSyncdeleter() unexpected error %v", err)
-	}
-
-	type fields struct {
-		Client client.Client
-	}
-	type args struct {
-		ctx context.Context
-		c   *v1alpha3.Container
-	}
-	type want struct {
-		err    error
-		syndel syncdeleter
-		cont   *v1alpha3.Container
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   want
-	}{
-		{
-			name: "FailedToGetAccountNotFoundNoDeleteProviderRef",
-			fields: fields{
-				Client: &test.MockClient{
-					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						return newAccountNotFoundError(testAccountName)
+		"MetadataChanged": {
+			args: args{
+				cr: container(withMetadata(map[string]string{"cool": "extremely"})),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return properties(), nil
 					},
 				},
 			},
-			args: args{
-				ctx: ctx,
-				c:   newCont().WithSpecProviderRef(testAccountName).WithFinalizer(finalizer).Container,
-			},
 			want: want{
-				err: errors.Wrapf(newAccountNotFoundError(testAccountName),
-					"failed to retrieve storage account: %s", testAccountName),
+				cr: container(
+					withMetadata(map[string]string{"cool": "extremely"}),
+					withObservation(observation()),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true},
 			},
 		},
-		{
-			name: "FailedToGetAccountNotFoundNoDeleteProviderConfigRef",
-			fields: fields{
-				Client: &test.MockClient{
-					MockGet: func(ctx context.Context, key client.ObjectKey, obj client.Object) error {
-						return newAccountNotFoundError(testAccountName)
+		"PublicAccessChanged": {
+			args: args{
+				cr: container(withPublicAccessType("")),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return properties(), nil
 					},
 				},
 			},
-			args: args{
-				ctx: ctx,
-				c:   newCont().WithSpecProviderConfigRef(testAccountName).WithFinalizer(finalizer).Container,
-			},
 			want: want{
-				err: errors.Wrapf(newAccountNotFoundError(testAccountName),
-					"failed to retrieve storage account: %s", testAccountName),
+				cr: container(
+					withPublicAccessType(""),
+					withObservation(observation()),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true},
 			},
 		},
-		{
-			name: "FailedToGetAccountNotFoundYesDelete",
-			fields: fields{
-				Client: fake.NewClientBuilder().WithObjects(newCont().WithSpecProviderRef(testAccountName).
-					WithFinalizer(finalizer).
-					WithResourceVersion("1").Container).Build(),
-			},
+		"GetProtectionFailed": {
 			args: args{
-				ctx: ctx,
-				c: newCont().WithSpecProviderRef(testAccountName).WithFinalizer(finalizer).
-					WithResourceVersion("1").
-					WithDeleteTimestamp(time.Now()).
-					Container,
+				cr: container(withLegalHold("audit")),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return properties(), nil
+					},
+				},
+				containers: &fake.MockBlobContainersClient{
+					MockGet: func(_ context.Context, _, _, _ string) (storage.BlobContainer, error) {
+						return storage.BlobContainer{}, errorBoom
+					},
+				},
 			},
 			want: want{
-				err: errors.Wrapf(newAccountNotFoundError(testAccountName),
-					"failed to retrieve storage account: %s", testAccountName),
+				cr:  container(withLegalHold("audit"), withObservation(observation())),
+				err: errors.Wrap(errorBoom, errGetProtection),
 			},
 		},
-		{
-			name: "AccountReferenceSecretNotFound",
-			fields: fields{
-				Client: fake.NewClientBuilder().WithObjects(
-					v1alpha3test.NewMockAccount(testAccountName).
-						WithSpecWriteConnectionSecretToReference(testNamespace, testAccountName).
-						Account,
-					newCont().WithSpecProviderRef(testAccountName).WithFinalizer(finalizer).Container).Build(),
-			},
+		"ProtectionUpToDate": {
 			args: args{
-				ctx: ctx,
-				c: newCont().WithSpecProviderRef(testAccountName).WithFinalizer(finalizer).
-					Container,
+				cr: container(
+					withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, Locked: true}),
+					withLegalHold("audit"),
+				),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return properties(), nil
+					},
+				},
+				containers: &fake.MockBlobContainersClient{
+					MockGet: func(_ context.Context, _, _, _ string) (storage.BlobContainer, error) {
+						return blobContainer(lockedPolicy(7), "AUDIT"), nil
+					},
+				},
 			},
 			want: want{
-				err: errors.Wrapf(newSecretNotFoundError(testAccountName),
-					"failed to retrieve storage account secret: %s/%s", testNamespace, testAccountName),
+				cr: container(
+					withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, Locked: true}),
+					withLegalHold("audit"),
+					withObservation(func() v1beta1.ContainerObservation {
+						o := observation()
+						o.ImmutabilityPolicy = &v1beta1.ImmutabilityPolicyObservation{
+							ImmutabilityPeriodSinceCreationInDays: 7,
+							State:                                 v1beta1.ImmutabilityPolicyStateLocked,
+							ETag:                                  etag,
+						}
+						o.LegalHoldTags = []string{"AUDIT"}
+						return o
+					}()),
+					withConditions(xpv1.Available(), azurestorage.ImmutabilityPolicyApplied()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
 			},
 		},
-		{
-			name: "AccountReferenceSecretNil",
-			fields: fields{
-				Client: fake.NewClientBuilder().WithObjects(
-					v1alpha3test.NewMockAccount(testAccountName).Account,
-					newCont().WithSpecProviderRef(testAccountName).WithFinalizer(finalizer).Container).Build(),
-			},
+		"ImmutabilityPolicyNeedsLock": {
 			args: args{
-				ctx: ctx,
-				c: newCont().WithSpecProviderRef(testAccountName).WithFinalizer(finalizer).
-					Container,
+				cr: container(withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, Locked: true})),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return properties(), nil
+					},
+				},
+				containers: &fake.MockBlobContainersClient{
+					MockGet: func(_ context.Context, _, _, _ string) (storage.BlobContainer, error) {
+						return blobContainer(unlockedPolicy(7)), nil
+					},
+				},
 			},
 			want: want{
-				err: errors.New(errAcctSecretNil),
-			},
-		},
-		{
-			name: "FailedToCreateContainerHandle",
-			fields: fields{
-				Client: fake.NewClientBuilder().WithObjects(
-					newCont().WithSpecProviderRef(testAccountName).WithFinalizer(finalizer).Container,
-					newSecret(testNamespace, testAccountName, map[string][]byte{
-						xpv1.ResourceCredentialsSecretUserKey:     []byte(testAccountName),
-						xpv1.ResourceCredentialsSecretPasswordKey: []byte("test-key"),
-					}),
-					v1alpha3test.NewMockAccount(testAccountName).
-						WithSpecWriteConnectionSecretToReference(testNamespace, testAccountName).
-						Account).Build(),
+				cr: container(
+					withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, Locked: true}),
+					withObservation(func() v1beta1.ContainerObservation {
+						o := observation()
+						o.ImmutabilityPolicy = &v1beta1.ImmutabilityPolicyObservation{
+							ImmutabilityPeriodSinceCreationInDays: 7,
+							State:                                 v1beta1.ImmutabilityPolicyStateUnlocked,
+							ETag:                                  etag,
+						}
+						return o
+					}()),
+					withConditions(xpv1.Available(), azurestorage.ImmutabilityPolicyApplied()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true},
 			},
+		},
+		"LockedImmutabilityPolicyShortened": {
 			args: args{
-				ctx: ctx,
-				c: newCont().WithSpecProviderRef(testAccountName).
-					WithFinalizer(finalizer).
-					Container,
+				cr: container(withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 3, Locked: true})),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return properties(), nil
+					},
+				},
+				containers: &fake.MockBlobContainersClient{
+					MockGet: func(_ context.Context, _, _, _ string) (storage.BlobContainer, error) {
+						return blobContainer(lockedPolicy(7)), nil
+					},
+				},
 			},
 			want: want{
-				err: errors.Wrapf(errors.New("illegal base64 data at input byte 4"),
-					"failed to create client handle: %s, storage account: %s", testContainerName, testAccountName),
-			},
-		},
-		{
-			name: "Success",
-			fields: fields{
-				Client: fake.NewClientBuilder().WithObjects(
-					newCont().WithSpecProviderRef(testAccountName).WithFinalizer(finalizer).Container,
-					newSecret(testNamespace, testAccountName, map[string][]byte{
-						xpv1.ResourceCredentialsSecretUserKey:     []byte(testAccountName),
-						xpv1.ResourceCredentialsSecretPasswordKey: []byte("dGVzdC1rZXkK"),
-					}),
-					v1alpha3test.NewMockAccount(testAccountName).
-						WithSpecWriteConnectionSecretToReference(testNamespace, testAccountName).
-						Account).Build(),
+				cr: container(
+					withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 3, Locked: true}),
+					withObservation(func() v1beta1.ContainerObservation {
+						o := observation()
+						o.ImmutabilityPolicy = &v1beta1.ImmutabilityPolicyObservation{
+							ImmutabilityPeriodSinceCreationInDays: 7,
+							State:                                 v1beta1.ImmutabilityPolicyStateLocked,
+							ETag:                                  etag,
+						}
+						return o
+					}()),
+					withConditions(xpv1.Available(), azurestorage.ImmutabilityPolicyChangeIllegal(errors.New("the immutability period of a locked immutability policy cannot be shortened from 7 to 3 days"))),
+				),
+				o: managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
 			},
+		},
+		"LegalHoldChanged": {
 			args: args{
-				ctx: ctx,
-				c: newCont().WithSpecProviderRef(testAccountName).
-					WithFinalizer(finalizer).
-					Container,
+				cr: container(withLegalHold("audit")),
+				c: &fake.MockContainerOperations{
+					MockGet: func(_ context.Context) (*azurestorage.ContainerProperties, error) {
+						return properties(), nil
+					},
+				},
+				containers: &fake.MockBlobContainersClient{
+					MockGet: func(_ context.Context, _, _, _ string) (storage.BlobContainer, error) {
+						return blobContainer(nil), nil
+					},
+				},
 			},
 			want: want{
-				syndel: &containerSyncdeleter{},
+				cr: container(
+					withLegalHold("audit"),
+					withObservation(observation()),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{ResourceExists: true},
 			},
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			m := &containerSyncdeleterMaker{
-				Client: tt.fields.Client,
-			}
-			got, err := m.newSyncdeleter(tt.args.ctx, tt.args.c, time.Minute)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("containerSyncdeleterMaker.newSyncdeleter(): -got error, +want error: \n%s", diff)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c, containers: tc.containers}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
 			}
-			if tt.want.syndel != nil {
-				tt.want.syndel = &containerSyncdeleter{
-					createupdater: &containerCreateUpdater{
-						ContainerOperations: ch,
-						kube:                tt.fields.Client,
-						container:           tt.args.c,
-					},
-					ContainerOperations: ch,
-					kube:                tt.fields.Client,
-					container:           tt.args.c,
-				}
-				// BUG(negz): This test is broken. It appears to intend to compare
-				// unexported fields, but does not. This behaviour was maintained
-				// when porting the test from https://github.com/go-test/deep to cmp.
-				if diff := cmp.Diff(tt.want.syndel, got,
-					cmpopts.IgnoreUnexported(containerSyncdeleter{}),
-					cmpopts.IgnoreUnexported(azblob.ContainerURL{}),
-				); diff != "" {
-					t.Errorf("containerSyncdeleterMaker.newSyncdeleter(): -want, +got:\n%s", diff)
-				}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
 			}
-			if tt.want.cont != nil {
-				cont := &v1alpha3.Container{}
-				if err := tt.fields.Client.Get(tt.args.ctx, key, cont); err != nil {
-					t.Errorf("containerSyncdeleterMaker.newSyncdeleter() error validating continer: %v, expected nil", err)
-				}
-				if diff := cmp.Diff(tt.want.cont, got); diff != "" {
-					t.Errorf("containerSyncdeleterMaker.newSyncdeleter() container: -want, +got:\n%s", diff)
-				}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
 			}
 		})
 	}
 }
 
-func Test_containerSyncdeleter_delete(t *testing.T) {
-	ctx := context.TODO()
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		createupdater       createupdater
-		ContainerOperations storage.ContainerOperations
-		kube                client.Client
-		container           *v1alpha3.Container
-	}
+func TestCreate(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		cr *v1beta1.Container
+		c  azurestorage.ContainerOperations
 	}
 	type want struct {
-		res  reconcile.Result
-		err  error
-		cont *v1alpha3.Container
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   want
+		cr  *v1beta1.Container
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
 	}{
-		{
-			name: "DeletionOrphan",
-			fields: fields{
-				kube: test.NewMockClient(),
-				container: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecDeletionPolicy(xpv1.DeletionOrphan).
-					WithFinalizer(finalizer).Container,
-			},
-			args: args{ctx: ctx},
-			want: want{
-				res: reconcile.Result{},
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecDeletionPolicy(xpv1.DeletionOrphan).
-					WithFinalizers([]string{}).
-					WithStatusConditions(xpv1.Deleting()).
-					Container,
-			},
-		},
-		{
-			name: "DeleteErrorNotFound",
-			fields: fields{
-				kube: test.NewMockClient(),
-				ContainerOperations: &azurestoragefake.MockContainerOperations{
-					MockDelete: func(ctx context.Context) error {
-						return autorest.DetailedError{StatusCode: http.StatusNotFound}
+		"Successful": {
+			args: args{
+				cr: container(),
+				c: &fake.MockContainerOperations{
+					MockCreate: func(_ context.Context, pat azblob.PublicAccessType, md azblob.Metadata) error {
+						if diff := cmp.Diff(azblob.PublicAccessBlob, pat); diff != "" {
+							t.Errorf("Create(...): -want, +got\n%s", diff)
+						}
+						if diff := cmp.Diff(azblob.Metadata{"cool": "very"}, md); diff != "" {
+							t.Errorf("Create(...): -want, +got\n%s", diff)
+						}
+						return nil
 					},
 				},
-				container: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithFinalizer(finalizer).
-					Container,
 			},
-			args: args{ctx: ctx},
 			want: want{
-				res: reconcile.Result{},
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithFinalizers([]string{}).
-					WithStatusConditions(xpv1.Deleting()).
-					Container,
-			},
-		},
-		{
-			name: "DeleteErrorOther",
-			fields: fields{
-				kube: test.NewMockClient(),
-				ContainerOperations: &azurestoragefake.MockContainerOperations{
-					MockDelete: func(ctx context.Context) error {
-						return errBoom
+				cr: container(withConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			args: args{
+				cr: container(),
+				c: &fake.MockContainerOperations{
+					MockCreate: func(_ context.Context, _ azblob.PublicAccessType, _ azblob.Metadata) error {
+						return errorBoom
 					},
 				},
-				container: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithFinalizer(finalizer).Container,
 			},
-			args: args{ctx: ctx},
 			want: want{
-				res: resultRequeue,
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecDeletionPolicy(xpv1.DeletionDelete).
-					WithFinalizer(finalizer).
-					WithStatusConditions(xpv1.Deleting(), xpv1.ReconcileError(errBoom)).
-					Container,
-			},
-		},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			csd := &containerSyncdeleter{
-				createupdater:       tt.fields.createupdater,
-				ContainerOperations: tt.fields.ContainerOperations,
-				kube:                tt.fields.kube,
-				container:           tt.fields.container,
-			}
-			got, err := csd.delete(tt.args.ctx)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("containerSyncdeleter.delete(): -want error, +got error:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("containerSyncdeleter.delete(): -want, +got:\n%s", diff)
+				cr:  container(withConditions(xpv1.Creating())),
+				err: errors.Wrap(errorBoom, errCreateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			_, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
 			}
-			if diff := cmp.Diff(tt.want.cont, tt.fields.container, test.EquateConditions()); diff != "" {
-				t.Errorf("containerSyncdeleter.delete() container: -want, +got:\n%s", diff)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
 			}
 		})
 	}
 }
 
-func Test_containerSyncdeleter_sync(t *testing.T) {
-	ctx := context.TODO()
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		createupdater       createupdater
-		ContainerOperations storage.ContainerOperations
-		kube                client.Client
-		container           *v1alpha3.Container
-	}
+func TestUpdate(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		cr         *v1beta1.Container
+		c          azurestorage.ContainerOperations
+		containers storageapi.BlobContainersClientAPI
 	}
 	type want struct {
-		res  reconcile.Result
-		err  error
-		cont *v1alpha3.Container
+		cr  *v1beta1.Container
+		err error
 	}
 
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   want
+	cases := map[string]struct {
+		args
+		want
 	}{
-		{
-			name: "GetErrorNotFound",
-			fields: fields{
-				createupdater: newMockCreateUpdater(),
-				ContainerOperations: &azurestoragefake.MockContainerOperations{
-					MockGet: func(ctx context.Context) (*azblob.PublicAccessType, azblob.Metadata, error) {
-						return nil, nil, newStorageNotFoundError()
+		"Successful": {
+			args: args{
+				cr: container(withPublicAccessType("")),
+				c: &fake.MockContainerOperations{
+					MockUpdate: func(_ context.Context, pat azblob.PublicAccessType, md azblob.Metadata) error {
+						if diff := cmp.Diff(azblob.PublicAccessNone, pat); diff != "" {
+							t.Errorf("Update(...): -want, +got\n%s", diff)
+						}
+						if diff := cmp.Diff(azblob.Metadata{"cool": "very"}, md); diff != "" {
+							t.Errorf("Update(...): -want, +got\n%s", diff)
+						}
+						return nil
+					},
+				},
+			},
+			want: want{
+				cr: container(withPublicAccessType("")),
+			},
+		},
+		"Failed": {
+			args: args{
+				cr: container(),
+				c: &fake.MockContainerOperations{
+					MockUpdate: func(_ context.Context, _ azblob.PublicAccessType, _ azblob.Metadata) error {
+						return errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  container(),
+				err: errors.Wrap(errorBoom, errUpdateFailed),
+			},
+		},
+		"CreateImmutabilityPolicy": {
+			args: args{
+				cr: container(withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7})),
+				c: &fake.MockContainerOperations{
+					MockUpdate: func(_ context.Context, _ azblob.PublicAccessType, _ azblob.Metadata) error { return nil },
+				},
+				containers: &fake.MockBlobContainersClient{
+					MockCreateOrUpdateImmutabilityPolicy: func(_ context.Context, _, _, _ string, p *storage.ImmutabilityPolicy, ifMatch string) (storage.ImmutabilityPolicy, error) {
+						if diff := cmp.Diff(azurestorage.NewImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7}), p); diff != "" {
+							t.Errorf("CreateOrUpdateImmutabilityPolicy(...): -want, +got\n%s", diff)
+						}
+						if ifMatch != "" {
+							t.Errorf("CreateOrUpdateImmutabilityPolicy(...): want no ifMatch, got %q", ifMatch)
+						}
+						return storage.ImmutabilityPolicy{}, nil
 					},
 				},
 			},
-			args: args{ctx: ctx},
-			want: want{},
+			want: want{
+				cr: container(withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7})),
+			},
 		},
-		{
-			name: "GetErrorOther",
-			fields: fields{
-				createupdater: newMockCreateUpdater(),
-				ContainerOperations: &azurestoragefake.MockContainerOperations{
-					MockGet: func(ctx context.Context) (*azblob.PublicAccessType, azblob.Metadata, error) {
-						return nil, nil, errBoom
+		"LockImmutabilityPolicyFailed": {
+			args: args{
+				cr: container(
+					withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, Locked: true}),
+					withObservation(v1beta1.ContainerObservation{ImmutabilityPolicy: &v1beta1.ImmutabilityPolicyObservation{
+						ImmutabilityPeriodSinceCreationInDays: 7,
+						State:                                 v1beta1.ImmutabilityPolicyStateUnlocked,
+						ETag:                                  etag,
+					}}),
+				),
+				c: &fake.MockContainerOperations{
+					MockUpdate: func(_ context.Context, _ azblob.PublicAccessType, _ azblob.Metadata) error { return nil },
+				},
+				containers: &fake.MockBlobContainersClient{
+					MockLockImmutabilityPolicy: func(_ context.Context, _, _, _ string, ifMatch string) (storage.ImmutabilityPolicy, error) {
+						if diff := cmp.Diff(etag, ifMatch); diff != "" {
+							t.Errorf("LockImmutabilityPolicy(...): -want, +got\n%s", diff)
+						}
+						return storage.ImmutabilityPolicy{}, errorBoom
 					},
 				},
-				container: v1alpha3test.NewMockContainer(testContainerName).Container,
-				kube:      test.NewMockClient(),
 			},
-			args: args{ctx: ctx},
 			want: want{
-				res: resultRequeue,
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithStatusConditions(xpv1.ReconcileError(errBoom)).
-					Container,
-			},
-		},
-		{
-			name: "Create",
-			fields: fields{
-				createupdater: newMockCreateUpdater(),
-				ContainerOperations: &azurestoragefake.MockContainerOperations{
-					MockGet: func(ctx context.Context) (*azblob.PublicAccessType, azblob.Metadata, error) {
-						return nil, nil, nil
+				cr: container(
+					withImmutabilityPolicy(v1beta1.ImmutabilityPolicy{ImmutabilityPeriodSinceCreationInDays: 7, Locked: true}),
+					withObservation(v1beta1.ContainerObservation{ImmutabilityPolicy: &v1beta1.ImmutabilityPolicyObservation{
+						ImmutabilityPeriodSinceCreationInDays: 7,
+						State:                                 v1beta1.ImmutabilityPolicyStateUnlocked,
+						ETag:                                  etag,
+					}}),
+				),
+				err: errors.Wrap(errorBoom, errLockPolicy),
+			},
+		},
+		"LegalHold": {
+			args: args{
+				cr: container(
+					withLegalHold("audit", "litigation"),
+					withObservation(v1beta1.ContainerObservation{LegalHoldTags: []string{"AUDIT", "INVESTIGATION"}}),
+				),
+				c: &fake.MockContainerOperations{
+					MockUpdate: func(_ context.Context, _ azblob.PublicAccessType, _ azblob.Metadata) error { return nil },
+				},
+				containers: &fake.MockBlobContainersClient{
+					MockSetLegalHold: func(_ context.Context, _, _, _ string, l storage.LegalHold) (storage.LegalHold, error) {
+						if diff := cmp.Diff([]string{"litigation"}, *l.Tags); diff != "" {
+							t.Errorf("SetLegalHold(...): -want, +got\n%s", diff)
+						}
+						return l, nil
+					},
+					MockClearLegalHold: func(_ context.Context, _, _, _ string, l storage.LegalHold) (storage.LegalHold, error) {
+						if diff := cmp.Diff([]string{"INVESTIGATION"}, *l.Tags); diff != "" {
+							t.Errorf("ClearLegalHold(...): -want, +got\n%s", diff)
+						}
+						return l, nil
 					},
 				},
-				container: v1alpha3test.NewMockContainer(testContainerName).Container,
-				kube:      test.NewMockClient(),
 			},
-			args: args{ctx: ctx},
 			want: want{
-				res:  reconcile.Result{},
-				cont: v1alpha3test.NewMockContainer(testContainerName).Container,
+				cr: container(
+					withLegalHold("audit", "litigation"),
+					withObservation(v1beta1.ContainerObservation{LegalHoldTags: []string{"AUDIT", "INVESTIGATION"}}),
+				),
 			},
 		},
-		{
-			name: "Update",
-			fields: fields{
-				createupdater: newMockCreateUpdater(),
-				ContainerOperations: &azurestoragefake.MockContainerOperations{
-					MockGet: func(ctx context.Context) (*azblob.PublicAccessType, azblob.Metadata, error) {
-						return azurestoragefake.PublicAccessTypePtr(azblob.PublicAccessContainer), nil, nil
+		"SetLegalHoldFailed": {
+			args: args{
+				cr: container(withLegalHold("audit")),
+				c: &fake.MockContainerOperations{
+					MockUpdate: func(_ context.Context, _ azblob.PublicAccessType, _ azblob.Metadata) error { return nil },
+				},
+				containers: &fake.MockBlobContainersClient{
+					MockSetLegalHold: func(_ context.Context, _, _, _ string, _ storage.LegalHold) (storage.LegalHold, error) {
+						return storage.LegalHold{}, errorBoom
 					},
 				},
-				container: v1alpha3test.NewMockContainer(testContainerName).Container,
-				kube:      test.NewMockClient(),
 			},
-			args: args{ctx: ctx},
 			want: want{
-				res:  reconcile.Result{},
-				cont: v1alpha3test.NewMockContainer(testContainerName).Container,
+				cr:  container(withLegalHold("audit")),
+				err: errors.Wrap(errorBoom, errSetLegalHold),
 			},
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			csd := &containerSyncdeleter{
-				createupdater:       tt.fields.createupdater,
-				ContainerOperations: tt.fields.ContainerOperations,
-				kube:                tt.fields.kube,
-				container:           tt.fields.container,
-			}
-			got, err := csd.sync(tt.args.ctx)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("containerSyncdeleter.sync(): -want error, +got error:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("containerSyncdeleter.sync(): -want, +got:\n%s", diff)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c, containers: tc.containers}
+			_, err := e.Update(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
 			}
-			if diff := cmp.Diff(tt.want.cont, tt.fields.container, test.EquateConditions()); diff != "" {
-				t.Errorf("containerSyncdeleter.sync() container: -want, +got:\n%s", diff)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
 			}
 		})
 	}
 }
 
-func Test_containerCreateUpdater_create(t *testing.T) {
-	ctx := context.TODO()
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		ContainerOperations storage.ContainerOperations
-		kube                client.Client
-		container           *v1alpha3.Container
-	}
+func TestDelete(t *testing.T) {
 	type args struct {
-		ctx context.Context
+		cr *v1beta1.Container
+		c  azurestorage.ContainerOperations
 	}
 	type want struct {
-		res  reconcile.Result
-		err  error
-		cont *v1alpha3.Container
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   want
+		cr  *v1beta1.Container
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
 	}{
-		{
-			name: "UpdateFinalizerFailed",
-			fields: fields{
-				container: v1alpha3test.NewMockContainer(testContainerName).Container,
-				kube: &test.MockClient{
-					MockUpdate: func(ctx context.Context, obj client.Object, _ ...client.UpdateOption) error {
-						return errors.New("test-update-error")
-					},
+		"Successful": {
+			args: args{
+				cr: container(),
+				c: &fake.MockContainerOperations{
+					MockDelete: func(_ context.Context) error { return nil },
 				},
 			},
-			args: args{ctx: ctx},
 			want: want{
-				res: resultRequeue,
-				err: errors.Wrapf(errors.New("test-update-error"), "failed to update container spec"),
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithFinalizer(finalizer).
-					WithStatusConditions(xpv1.Creating()).
-					Container,
-			},
-		},
-		{
-			name: "CreateFailed",
-			fields: fields{
-				container: v1alpha3test.NewMockContainer(testContainerName).Container,
-				ContainerOperations: &azurestoragefake.MockContainerOperations{
-					MockCreate: func(ctx context.Context, pub azblob.PublicAccessType, meta azblob.Metadata) error {
-						return errBoom
-					},
+				cr: container(withConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyDeleted": {
+			args: args{
+				cr: container(),
+				c: &fake.MockContainerOperations{
+					MockDelete: func(_ context.Context) error { return notFoundError{} },
 				},
-				kube: test.NewMockClient(),
 			},
-			args: args{ctx: ctx},
 			want: want{
-				res: resultRequeue,
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithFinalizer(finalizer).
-					WithStatusConditions(xpv1.Creating(), xpv1.ReconcileError(errBoom)).
-					Container,
+				cr: container(withConditions(xpv1.Deleting())),
 			},
 		},
-		{
-			name: "CreateSuccessful",
-			fields: fields{
-				container:           v1alpha3test.NewMockContainer(testContainerName).Container,
-				ContainerOperations: azurestoragefake.NewMockContainerOperations(),
-				kube:                test.NewMockClient(),
+		"Failed": {
+			args: args{
+				cr: container(),
+				c: &fake.MockContainerOperations{
+					MockDelete: func(_ context.Context) error { return errorBoom },
+				},
 			},
-			args: args{ctx: ctx},
 			want: want{
-				res: reconcile.Result{},
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithFinalizer(finalizer).
-					WithStatusConditions(xpv1.Available(), xpv1.ReconcileSuccess()).
-					Container,
+				cr:  container(withConditions(xpv1.Deleting())),
+				err: errors.Wrap(errorBoom, errDeleteFailed),
 			},
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ccu := &containerCreateUpdater{
-				ContainerOperations: tt.fields.ContainerOperations,
-				kube:                tt.fields.kube,
-				container:           tt.fields.container,
-			}
-			got, err := ccu.create(tt.args.ctx)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("containerCreateUpdater.create(): -want error, +got error:\n%s", diff)
-			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("containerCreateUpdater.create(): -want, +got:\n%s", diff)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
 			}
-			if diff := cmp.Diff(tt.want.cont, tt.fields.container); diff != "" {
-				t.Errorf("containerCreateUpdater.create() container: -want, +got:\n%s", diff)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
 			}
 		})
 	}
 }
 
-func Test_containerCreateUpdater_update(t *testing.T) {
-	ctx := context.TODO()
-	errBoom := errors.New("boom")
-
-	type fields struct {
-		ContainerOperations storage.ContainerOperations
-		kube                client.Client
-		container           *v1alpha3.Container
-		poll                time.Duration
-	}
+func TestSharedAccessSignature(t *testing.T) {
 	type args struct {
-		ctx        context.Context
-		accessType *azblob.PublicAccessType
-		meta       azblob.Metadata
+		cr       *v1beta1.Container
+		accounts *fake.MockAccountsClient
+		delegate azurestorage.UserDelegationCredentialGetter
 	}
 	type want struct {
-		res  reconcile.Result
-		err  error
-		cont *v1alpha3.Container
-	}
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   want
+		token   bool
+		renewed bool
+		err     error
+	}
+
+	cases := map[string]struct {
+		args
+		want
 	}{
-		{
-			name: "NoChange",
-			fields: fields{
-				container: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecPAC(azblob.PublicAccessContainer).Container,
-				kube: test.NewMockClient(),
-				poll: time.Minute,
+		"NotRequested": {
+			args: args{
+				cr: container(),
 			},
+		},
+		"NotDue": {
 			args: args{
-				ctx:        ctx,
-				accessType: azurestoragefake.PublicAccessTypePtr(azblob.PublicAccessContainer),
+				cr: container(withSAS(v1beta1.SASTypeService), withSASExpiry(time.Now().Add(20*time.Hour))),
+			},
+		},
+		"DefaultTypeSpecified": {
+			args: args{
+				cr: container(withSAS(""), withSASExpiry(time.Now().Add(20*time.Hour)), withSASType(v1beta1.SASTypeService)),
+			},
+		},
+		"PermissionsChanged": {
+			args: args{
+				cr: container(withSAS(v1beta1.SASTypeService), withSASExpiry(time.Now().Add(20*time.Hour)), withSASPermissions("rwl")),
+				accounts: &fake.MockAccountsClient{
+					MockListServiceSAS: func(_ context.Context, _ string, _ string, p storage.ServiceSasParameters) (storage.ListServiceSasResponse, error) {
+						if p.Permissions != "rwl" {
+							return storage.ListServiceSasResponse{}, errorBoom
+						}
+						return storage.ListServiceSasResponse{ServiceSasToken: azure.ToStringPtr("sv=cool")}, nil
+					},
+				},
 			},
 			want: want{
-				res: reconcile.Result{RequeueAfter: time.Minute},
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecPAC(azblob.PublicAccessContainer).
-					WithStatusConditions(xpv1.Available(), xpv1.ReconcileSuccess()).
-					Container,
-			},
-		},
-		{
-			name: "ContainerUpdateFailed",
-			fields: fields{
-				container: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecPAC(azblob.PublicAccessContainer).
-					WithStatusConditions().
-					Container,
-				ContainerOperations: &azurestoragefake.MockContainerOperations{
-					MockUpdate: func(ctx context.Context, publicAccessType azblob.PublicAccessType, meta azblob.Metadata) error {
-						return errBoom
+				token:   true,
+				renewed: true,
+			},
+		},
+		"TypeChanged": {
+			args: args{
+				cr: container(withSAS(v1beta1.SASTypeService), withSASExpiry(time.Now().Add(20*time.Hour)), withSASType(v1beta1.SASTypeUserDelegation)),
+				delegate: func(_ context.Context, _, _ time.Time) (azblob.UserDelegationCredential, error) {
+					return azblob.NewUserDelegationCredential(accountName, azblob.UserDelegationKey{Value: "Y29vbA=="}), nil
+				},
+			},
+			want: want{
+				token:   true,
+				renewed: true,
+			},
+		},
+		"ServiceSASFailed": {
+			args: args{
+				cr: container(withSAS(v1beta1.SASTypeService)),
+				accounts: &fake.MockAccountsClient{
+					MockListServiceSAS: func(_ context.Context, _ string, _ string, _ storage.ServiceSasParameters) (storage.ListServiceSasResponse, error) {
+						return storage.ListServiceSasResponse{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				err: errors.Wrap(errorBoom, errIssueSAS),
+			},
+		},
+		"ServiceSAS": {
+			args: args{
+				cr: container(withSAS(v1beta1.SASTypeService), withSASExpiry(time.Now().Add(time.Hour))),
+				accounts: &fake.MockAccountsClient{
+					MockListServiceSAS: func(_ context.Context, _ string, _ string, p storage.ServiceSasParameters) (storage.ListServiceSasResponse, error) {
+						if azure.ToString(p.CanonicalizedResource) != "/blob/"+accountName+"/"+name || p.Permissions != "rl" {
+							return storage.ListServiceSasResponse{}, errorBoom
+						}
+						return storage.ListServiceSasResponse{ServiceSasToken: azure.ToStringPtr("sv=cool")}, nil
 					},
 				},
-				kube: test.NewMockClient(),
 			},
+			want: want{
+				token:   true,
+				renewed: true,
+			},
+		},
+		"UserDelegationKeyFailed": {
 			args: args{
-				ctx:        ctx,
-				accessType: azurestoragefake.PublicAccessTypePtr(azblob.PublicAccessContainer),
-				meta: azblob.Metadata{
-					"foo": "bar",
+				cr: container(withSAS(v1beta1.SASTypeUserDelegation)),
+				delegate: func(_ context.Context, _, _ time.Time) (azblob.UserDelegationCredential, error) {
+					return azblob.UserDelegationCredential{}, errorBoom
 				},
 			},
 			want: want{
-				res: resultRequeue,
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecPAC(azblob.PublicAccessContainer).
-					WithStatusConditions(xpv1.ReconcileError(errBoom)).
-					Container,
-			},
-		},
-		{
-			name: "ContainerUpdateSuccessful",
-			fields: fields{
-				container: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecPAC(azblob.PublicAccessContainer).
-					Container,
-				ContainerOperations: azurestoragefake.NewMockContainerOperations(),
-				kube:                test.NewMockClient(),
-				poll:                time.Minute,
+				err: errors.Wrap(errorBoom, errGetUDK),
 			},
+		},
+		"UserDelegationSAS": {
 			args: args{
-				ctx:        ctx,
-				accessType: azurestoragefake.PublicAccessTypePtr(azblob.PublicAccessContainer),
-				meta: azblob.Metadata{
-					"foo": "bar",
+				cr: container(withSAS(v1beta1.SASTypeUserDelegation)),
+				delegate: func(_ context.Context, _, _ time.Time) (azblob.UserDelegationCredential, error) {
+					return azblob.NewUserDelegationCredential(accountName, azblob.UserDelegationKey{Value: "Y29vbA=="}), nil
 				},
 			},
 			want: want{
-				res: reconcile.Result{RequeueAfter: time.Minute},
-				cont: v1alpha3test.NewMockContainer(testContainerName).
-					WithSpecPAC(azblob.PublicAccessContainer).
-					WithStatusConditions(xpv1.Available(), xpv1.ReconcileSuccess()).
-					Container,
+				token:   true,
+				renewed: true,
+			},
+		},
+	}
+
+	for n, tc := range cases {
+		t.Run(n, func(t *testing.T) {
+			before := tc.args.cr.Status.SharedAccessSignature.DeepCopy()
+			e := external{accounts: tc.args.accounts, delegate: tc.args.delegate}
+			conn, err := e.sharedAccessSignature(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("sharedAccessSignature(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.token, len(conn[azurestorage.ConnectionSecretKeySASToken]) > 0); diff != "" {
+				t.Errorf("sharedAccessSignature(...): -want token, +got token\n%s", diff)
+			}
+			if tc.want.token {
+				if diff := cmp.Diff(azurestorage.ContainerURL(accountName, name), string(conn[xpv1.ResourceCredentialsSecretEndpointKey])); diff != "" {
+					t.Errorf("sharedAccessSignature(...): -want endpoint, +got endpoint\n%s", diff)
+				}
+			}
+			if diff := cmp.Diff(tc.want.renewed, !cmp.Equal(before, tc.args.cr.Status.SharedAccessSignature)); diff != "" {
+				t.Errorf("sharedAccessSignature(...): -want renewed, +got renewed\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSharedKeyContainerHandle(t *testing.T) {
+	cases := map[string]struct {
+		accounts *fake.MockAccountsClient
+		err      error
+	}{
+		"ListKeysFailed": {
+			accounts: &fake.MockAccountsClient{
+				MockListKeys: func(_ context.Context, _, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+					return storage.AccountListKeysResult{}, errorBoom
+				},
+			},
+			err: errors.Wrap(errorBoom, errListKeys),
+		},
+		"NoKeys": {
+			accounts: &fake.MockAccountsClient{
+				MockListKeys: func(_ context.Context, _, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+					return storage.AccountListKeysResult{}, nil
+				},
+			},
+			err: errors.New(errNoKeys),
+		},
+		"Successful": {
+			accounts: &fake.MockAccountsClient{
+				MockListKeys: func(_ context.Context, _, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+					return storage.AccountListKeysResult{Keys: &[]storage.AccountKey{{Value: azure.ToStringPtr("Y29vbGtleQ==")}}}, nil
+				},
 			},
 		},
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			ccu := &containerCreateUpdater{
-				ContainerOperations: tt.fields.ContainerOperations,
-				kube:                tt.fields.kube,
-				container:           tt.fields.container,
-				poll:                tt.fields.poll,
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := sharedKeyContainerHandle(context.Background(), tc.accounts, container())
+			if diff := cmp.Diff(tc.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("sharedKeyContainerHandle(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTokenContainerHandle(t *testing.T) {
+	accounts := &fake.MockAccountsClient{
+		MockGetProperties: func(_ context.Context, _, _ string, _ storage.AccountExpand) (storage.Account, error) {
+			return storage.Account{}, errorBoom
+		},
+	}
+	_, err := tokenContainerHandle(context.Background(), nil, accounts, container(withAuthorizationMode(v1beta1.AuthorizationModeAzureAD)))
+	if diff := cmp.Diff(errors.Wrap(errorBoom, errGetAccount), err, test.EquateErrors()); diff != "" {
+		t.Errorf("tokenContainerHandle(...): -want, +got\n%s", diff)
+	}
+}
+
+func TestMigratorInitialize(t *testing.T) {
+	legacy := func() *v1beta1.Container {
+		cr := container()
+		cr.Spec.ForProvider = v1beta1.ContainerParameters{}
+		cr.Spec.DeprecatedMetadata = map[string]string{"Cool": "very"}
+		cr.Spec.DeprecatedPublicAccessType = v1beta1.PublicAccessBlob
+		cr.SetProviderConfigReference(&xpv1.Reference{Name: "coolaccountcr"})
+		return cr
+	}
+	migrated := func() *v1beta1.Container {
+		cr := container()
+		cr.Spec.ForProvider.AccountName = ""
+		cr.Spec.ForProvider.AccountNameRef = &xpv1.Reference{Name: "coolaccountcr"}
+		cr.SetProviderConfigReference(&xpv1.Reference{Name: "coolconfig"})
+		return cr
+	}
+	getAccount := func(legacy bool) test.MockGetFn {
+		return func(_ context.Context, key client.ObjectKey, obj client.Object) error {
+			if key.Name != "coolaccountcr" {
+				return errors.New("unexpected account")
 			}
-			got, err := ccu.update(tt.args.ctx, tt.args.accessType, tt.args.meta)
-			if diff := cmp.Diff(tt.want.err, err, test.EquateErrors()); diff != "" {
-				t.Errorf("containerCreateUpdater.update(): -want error, +got error:\n%s", diff)
+			a := obj.(*v1beta1.Account)
+			a.SetName(key.Name)
+			a.SetProviderConfigReference(&xpv1.Reference{Name: "coolconfig"})
+			if legacy {
+				a.Spec.DeprecatedResourceGroupName = resourceGroup
+				a.Spec.DeprecatedStorageAccountSpec = &v1alpha3.StorageAccountSpec{}
+				return nil
 			}
-			if diff := cmp.Diff(tt.want.res, got); diff != "" {
-				t.Errorf("containerCreateUpdater.update(): -want, +got:\n%s", diff)
+			a.Spec.ForProvider.ResourceGroupName = resourceGroup
+			return nil
+		}
+	}
+
+	type want struct {
+		cr  *v1beta1.Container
+		err error
+	}
+
+	cases := map[string]struct {
+		kube client.Client
+		cr   *v1beta1.Container
+		want want
+	}{
+		"NotLegacy": {
+			kube: &test.MockClient{MockUpdate: test.NewMockUpdateFn(errors.New("unexpected update"))},
+			cr:   container(),
+			want: want{cr: container()},
+		},
+		"Migrated": {
+			kube: &test.MockClient{MockGet: getAccount(false), MockUpdate: test.NewMockUpdateFn(nil)},
+			cr:   legacy(),
+			want: want{cr: migrated()},
+		},
+		"AccountNotYetMigrated": {
+			kube: &test.MockClient{MockGet: getAccount(true), MockUpdate: test.NewMockUpdateFn(nil)},
+			cr:   legacy(),
+			want: want{cr: migrated()},
+		},
+		"GetAccountError": {
+			kube: &test.MockClient{MockGet: test.NewMockGetFn(errorBoom)},
+			cr:   legacy(),
+			want: want{
+				cr:  legacy(),
+				err: errors.Wrap(errorBoom, errGetAccount),
+			},
+		},
+		"UpdateError": {
+			kube: &test.MockClient{MockGet: getAccount(false), MockUpdate: test.NewMockUpdateFn(errorBoom)},
+			cr:   legacy(),
+			want: want{
+				cr:  migrated(),
+				err: errors.Wrap(errorBoom, errMigrate),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			m := &migrator{kube: tc.kube}
+			err := m.Initialize(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Initialize(...): -want error, +got error\n%s", diff)
 			}
-			if diff := cmp.Diff(tt.want.cont, tt.fields.container, test.EquateConditions()); diff != "" {
-				t.Errorf("containerCreateUpdater.update() container: -want, +got:\n%s", diff)
+			if diff := cmp.Diff(tc.want.cr, tc.cr); diff != "" {
+				t.Errorf("Initialize(...): -want, +got\n%s", diff)
 			}
 		})
 	}
@@ -0,0 +1,223 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileshare
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotFileShare  = "managed resource is not a storage FileShare"
+	errConnectFailed = "cannot connect to Azure API"
+	errGetFailed     = "cannot get file share"
+	errGetAccount    = "cannot get storage Account"
+	errListKeys      = "cannot list storage Account keys"
+	errNoKeys        = "storage Account has no keys"
+	errNoActiveKey   = "storage Account has no active key"
+	errGetManaged    = "cannot get managed storage Account"
+	errListManaged   = "cannot list managed storage Accounts"
+	errCreateFailed  = "cannot create file share"
+	errUpdateFailed  = "cannot update file share"
+	errDeleteFailed  = "cannot delete file share"
+)
+
+// Setup adds a controller that reconciles FileShares.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1beta1.FileShareGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1beta1.FileShare{}).
+		Complete(tracing.Reconciler(name, v1beta1.FileShareGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.FileShareGroupVersionKind),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{kube: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.atProvider.id"))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	kube client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azure.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
+	}
+	cl := storage.NewFileSharesClient(creds[azure.CredentialsKeySubscriptionID])
+	cl.Authorizer = auth
+	acl := storage.NewAccountsClient(creds[azure.CredentialsKeySubscriptionID])
+	acl.Authorizer = auth
+	return &external{kube: c.kube, client: cl, accounts: acl}, nil
+}
+
+type external struct {
+	kube     client.Client
+	client   storageapi.FileSharesClientAPI
+	accounts storageapi.AccountsClientAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.FileShare)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotFileShare)
+	}
+
+	az, err := e.client.Get(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, meta.GetExternalName(cr), storage.Stats)
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azure.IsNotFound, err), errGetFailed)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	azurestorage.LateInitializeFileShare(&cr.Spec.ForProvider, az)
+	cr.Status.AtProvider = azurestorage.GenerateFileShareObservation(az)
+
+	conn, err := e.connectionDetails(ctx, cr)
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        azurestorage.IsFileShareUpToDate(cr.Spec.ForProvider, az),
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+		ConnectionDetails:       conn,
+	}, nil
+}
+
+// connectionDetails returns the URL of the supplied FileShare, and the name
+// and active access key of its storage Account, keyed as expected by the Azure
+// Files CSI driver.
+func (e *external) connectionDetails(ctx context.Context, cr *v1beta1.FileShare) (managed.ConnectionDetails, error) {
+	acct, err := e.accounts.GetProperties(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, "")
+	if err != nil {
+		return nil, errors.Wrap(err, errGetAccount)
+	}
+	active, err := e.activeKeyName(ctx, cr)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := e.accounts.ListKeys(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, "")
+	if err != nil {
+		return nil, errors.Wrap(err, errListKeys)
+	}
+	if keys.Keys == nil || len(*keys.Keys) == 0 {
+		return nil, errors.New(errNoKeys)
+	}
+	conn := managed.ConnectionDetails{
+		azurestorage.ConnectionSecretKeyAccountName: []byte(cr.Spec.ForProvider.AccountName),
+	}
+	for _, k := range *keys.Keys {
+		if azure.ToString(k.KeyName) == active {
+			conn[azurestorage.ConnectionSecretKeyAccountKey] = []byte(azure.ToString(k.Value))
+		}
+	}
+	if conn[azurestorage.ConnectionSecretKeyAccountKey] == nil {
+		return nil, errors.New(errNoActiveKey)
+	}
+	if acct.AccountProperties != nil && acct.PrimaryEndpoints != nil && acct.PrimaryEndpoints.File != nil {
+		conn[xpv1.ResourceCredentialsSecretEndpointKey] = []byte(azurestorage.FileShareURL(*acct.PrimaryEndpoints.File, meta.GetExternalName(cr)))
+	}
+	return conn, nil
+}
+
+// activeKeyName returns the name of the access key that the storage Account
+// of the supplied FileShare publishes, so that the FileShare does not publish
+// a key that is about to be rotated. The primary key is active unless the
+// Account is managed by Crossplane and rotates its keys.
+func (e *external) activeKeyName(ctx context.Context, cr *v1beta1.FileShare) (string, error) {
+	if ref := cr.Spec.ForProvider.AccountNameRef; ref != nil {
+		acct := &v1beta1.Account{}
+		if err := e.kube.Get(ctx, types.NamespacedName{Name: ref.Name}, acct); resource.IgnoreNotFound(err) != nil {
+			return "", errors.Wrap(err, errGetManaged)
+		}
+		return azurestorage.ActiveKeyName(acct), nil
+	}
+	l := &v1beta1.AccountList{}
+	if err := e.kube.List(ctx, l); err != nil {
+		return "", errors.Wrap(err, errListManaged)
+	}
+	for i := range l.Items {
+		// Storage Account names are globally unique.
+		if meta.GetExternalName(&l.Items[i]) == cr.Spec.ForProvider.AccountName {
+			return azurestorage.ActiveKeyName(&l.Items[i]), nil
+		}
+	}
+	return v1beta1.KeyNamePrimary, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.FileShare)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotFileShare)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+	_, err := e.client.Create(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, meta.GetExternalName(cr), azurestorage.NewFileShare(cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.FileShare)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotFileShare)
+	}
+	_, err := e.client.Update(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, meta.GetExternalName(cr), azurestorage.NewFileShareUpdate(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.FileShare)
+	if !ok {
+		return errors.New(errNotFileShare)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.client.Delete(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, meta.GetExternalName(cr))
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteFailed)
+}
@@ -0,0 +1,490 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fileshare
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+)
+
+const (
+	resourceGroup = "coolgroup"
+	accountName   = "coolaccount"
+	shareName     = "coolshare"
+	accountKey    = "coolkey"
+	secondaryKey  = "coolsecondarykey"
+	fileEndpoint  = "https://coolaccount.file.core.windows.net/"
+	id            = "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount/fileServices/default/shares/coolshare"
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type shareModifier func(*v1beta1.FileShare)
+
+func withConditions(c ...xpv1.Condition) shareModifier {
+	return func(cr *v1beta1.FileShare) { cr.Status.ConditionedStatus.Conditions = c }
+}
+
+func withQuota(q int) shareModifier {
+	return func(cr *v1beta1.FileShare) { cr.Spec.ForProvider.ShareQuota = &q }
+}
+
+func withAccessTier(t string) shareModifier {
+	return func(cr *v1beta1.FileShare) { cr.Spec.ForProvider.AccessTier = &t }
+}
+
+func withProtocols(p string) shareModifier {
+	return func(cr *v1beta1.FileShare) { cr.Spec.ForProvider.EnabledProtocols = &p }
+}
+
+func withAccountNameRef(name string) shareModifier {
+	return func(cr *v1beta1.FileShare) { cr.Spec.ForProvider.AccountNameRef = &xpv1.Reference{Name: name} }
+}
+
+func withObservation(o v1beta1.FileShareObservation) shareModifier {
+	return func(cr *v1beta1.FileShare) { cr.Status.AtProvider = o }
+}
+
+func share(m ...shareModifier) *v1beta1.FileShare {
+	cr := &v1beta1.FileShare{
+		Spec: v1beta1.FileShareSpec{
+			ForProvider: v1beta1.FileShareParameters{
+				ResourceGroupName: resourceGroup,
+				AccountName:       accountName,
+			},
+		},
+	}
+	meta.SetExternalName(cr, shareName)
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func azureShare() storage.FileShare {
+	return storage.FileShare{
+		ID: azure.ToStringPtr(id),
+		FileShareProperties: &storage.FileShareProperties{
+			ShareQuota:       azure.ToInt32Ptr(100),
+			AccessTier:       storage.ShareAccessTierHot,
+			EnabledProtocols: storage.SMB,
+			ShareUsageBytes:  azure.ToInt32Ptr(42),
+		},
+	}
+}
+
+func accounts() *fake.MockAccountsClient {
+	return &fake.MockAccountsClient{
+		MockGetProperties: func(_ context.Context, _ string, _ string, _ storage.AccountExpand) (storage.Account, error) {
+			return storage.Account{AccountProperties: &storage.AccountProperties{
+				PrimaryEndpoints: &storage.Endpoints{File: azure.ToStringPtr(fileEndpoint)},
+			}}, nil
+		},
+		MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+			return storage.AccountListKeysResult{Keys: &[]storage.AccountKey{
+				{KeyName: azure.ToStringPtr(v1beta1.KeyNamePrimary), Value: azure.ToStringPtr(accountKey)},
+				{KeyName: azure.ToStringPtr(v1beta1.KeyNameSecondary), Value: azure.ToStringPtr(secondaryKey)},
+			}}, nil
+		},
+	}
+}
+
+// rotatedAccount returns a storage Account whose secondary key is active.
+func rotatedAccount() *v1beta1.Account {
+	a := &v1beta1.Account{
+		Spec: v1beta1.AccountSpec{
+			KeyRotation: &v1beta1.KeyRotation{},
+		},
+		Status: v1beta1.AccountStatus{
+			KeyRotation: &v1beta1.KeyRotationStatus{ActiveKeyName: v1beta1.KeyNameSecondary},
+		},
+	}
+	meta.SetExternalName(a, accountName)
+	return a
+}
+
+func noManagedAccounts() client.Client {
+	return &test.MockClient{MockList: test.NewMockListFn(nil)}
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		cr       *v1beta1.FileShare
+		kube     client.Client
+		client   *fake.MockFileSharesClient
+		accounts *fake.MockAccountsClient
+	}
+	type want struct {
+		cr  *v1beta1.FileShare
+		o   managed.ExternalObservation
+		err error
+	}
+
+	obs := v1beta1.FileShareObservation{ID: id, ShareUsageBytes: 42}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"NotFound": {
+			args: args{
+				cr: share(),
+				client: &fake.MockFileSharesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string, _ storage.GetShareExpand) (storage.FileShare, error) {
+						return storage.FileShare{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+					},
+				},
+			},
+			want: want{
+				cr: share(),
+			},
+		},
+		"GetFailed": {
+			args: args{
+				cr: share(),
+				client: &fake.MockFileSharesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string, _ storage.GetShareExpand) (storage.FileShare, error) {
+						return storage.FileShare{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  share(),
+				err: errors.Wrap(errorBoom, errGetFailed),
+			},
+		},
+		"ListKeysFailed": {
+			args: args{
+				cr: share(withQuota(100), withAccessTier("Hot"), withProtocols("SMB")),
+				client: &fake.MockFileSharesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string, _ storage.GetShareExpand) (storage.FileShare, error) {
+						return azureShare(), nil
+					},
+				},
+				accounts: func() *fake.MockAccountsClient {
+					c := accounts()
+					c.MockListKeys = func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return storage.AccountListKeysResult{}, errorBoom
+					}
+					return c
+				}(),
+			},
+			want: want{
+				cr:  share(withQuota(100), withAccessTier("Hot"), withProtocols("SMB"), withObservation(obs)),
+				err: errors.Wrap(errorBoom, errListKeys),
+			},
+		},
+		"UpToDateAndLateInitialized": {
+			args: args{
+				cr: share(),
+				client: &fake.MockFileSharesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string, _ storage.GetShareExpand) (storage.FileShare, error) {
+						return azureShare(), nil
+					},
+				},
+				accounts: accounts(),
+			},
+			want: want{
+				cr: share(withQuota(100), withAccessTier("Hot"), withProtocols("SMB"), withObservation(obs), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						azurestorage.ConnectionSecretKeyAccountName: []byte(accountName),
+						azurestorage.ConnectionSecretKeyAccountKey:  []byte(accountKey),
+						xpv1.ResourceCredentialsSecretEndpointKey:   []byte(fileEndpoint + shareName),
+					},
+				},
+			},
+		},
+		"ListManagedAccountsFailed": {
+			args: args{
+				cr:   share(withQuota(100), withAccessTier("Hot"), withProtocols("SMB")),
+				kube: &test.MockClient{MockList: test.NewMockListFn(errorBoom)},
+				client: &fake.MockFileSharesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string, _ storage.GetShareExpand) (storage.FileShare, error) {
+						return azureShare(), nil
+					},
+				},
+				accounts: accounts(),
+			},
+			want: want{
+				cr:  share(withQuota(100), withAccessTier("Hot"), withProtocols("SMB"), withObservation(obs)),
+				err: errors.Wrap(errorBoom, errListManaged),
+			},
+		},
+		"RotatedKeyOfManagedAccount": {
+			args: args{
+				cr: share(withQuota(100), withAccessTier("Hot"), withProtocols("SMB")),
+				kube: &test.MockClient{MockList: test.NewMockListFn(nil, func(obj client.ObjectList) error {
+					obj.(*v1beta1.AccountList).Items = []v1beta1.Account{*rotatedAccount()}
+					return nil
+				})},
+				client: &fake.MockFileSharesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string, _ storage.GetShareExpand) (storage.FileShare, error) {
+						return azureShare(), nil
+					},
+				},
+				accounts: accounts(),
+			},
+			want: want{
+				cr: share(withQuota(100), withAccessTier("Hot"), withProtocols("SMB"), withObservation(obs), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						azurestorage.ConnectionSecretKeyAccountName: []byte(accountName),
+						azurestorage.ConnectionSecretKeyAccountKey:  []byte(secondaryKey),
+						xpv1.ResourceCredentialsSecretEndpointKey:   []byte(fileEndpoint + shareName),
+					},
+				},
+			},
+		},
+		"RotatedKeyOfReferencedAccount": {
+			args: args{
+				cr: share(withQuota(100), withAccessTier("Hot"), withProtocols("SMB"), withAccountNameRef("cool")),
+				kube: &test.MockClient{MockGet: test.NewMockGetFn(nil, func(obj client.Object) error {
+					*obj.(*v1beta1.Account) = *rotatedAccount()
+					return nil
+				})},
+				client: &fake.MockFileSharesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string, _ storage.GetShareExpand) (storage.FileShare, error) {
+						return azureShare(), nil
+					},
+				},
+				accounts: accounts(),
+			},
+			want: want{
+				cr: share(withQuota(100), withAccessTier("Hot"), withProtocols("SMB"), withAccountNameRef("cool"), withObservation(obs), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						azurestorage.ConnectionSecretKeyAccountName: []byte(accountName),
+						azurestorage.ConnectionSecretKeyAccountKey:  []byte(secondaryKey),
+						xpv1.ResourceCredentialsSecretEndpointKey:   []byte(fileEndpoint + shareName),
+					},
+				},
+			},
+		},
+		"NeedsUpdate": {
+			args: args{
+				cr: share(withQuota(200), withAccessTier("Hot"), withProtocols("SMB")),
+				client: &fake.MockFileSharesClient{
+					MockGet: func(_ context.Context, _ string, _ string, _ string, _ storage.GetShareExpand) (storage.FileShare, error) {
+						return azureShare(), nil
+					},
+				},
+				accounts: accounts(),
+			},
+			want: want{
+				cr: share(withQuota(200), withAccessTier("Hot"), withProtocols("SMB"), withObservation(obs), withConditions(xpv1.Available())),
+				o: managed.ExternalObservation{
+					ResourceExists: true,
+					ConnectionDetails: managed.ConnectionDetails{
+						azurestorage.ConnectionSecretKeyAccountName: []byte(accountName),
+						azurestorage.ConnectionSecretKeyAccountKey:  []byte(accountKey),
+						xpv1.ResourceCredentialsSecretEndpointKey:   []byte(fileEndpoint + shareName),
+					},
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			kube := tc.args.kube
+			if kube == nil {
+				kube = noManagedAccounts()
+			}
+			e := external{kube: kube, client: tc.args.client, accounts: tc.args.accounts}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr  *v1beta1.FileShare
+		err error
+	}
+
+	cases := map[string]struct {
+		cr     *v1beta1.FileShare
+		client *fake.MockFileSharesClient
+		want   want
+	}{
+		"CreateFailed": {
+			cr: share(),
+			client: &fake.MockFileSharesClient{
+				MockCreate: func(_ context.Context, _ string, _ string, _ string, _ storage.FileShare) (storage.FileShare, error) {
+					return storage.FileShare{}, errorBoom
+				},
+			},
+			want: want{
+				cr:  share(withConditions(xpv1.Creating())),
+				err: errors.Wrap(errorBoom, errCreateFailed),
+			},
+		},
+		"Successful": {
+			cr: share(withProtocols("NFS")),
+			client: &fake.MockFileSharesClient{
+				MockCreate: func(_ context.Context, _ string, _ string, name string, fs storage.FileShare) (storage.FileShare, error) {
+					if name != shareName || fs.EnabledProtocols != storage.NFS {
+						return storage.FileShare{}, errorBoom
+					}
+					return azureShare(), nil
+				},
+			},
+			want: want{
+				cr: share(withProtocols("NFS"), withConditions(xpv1.Creating())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		cr     *v1beta1.FileShare
+		client *fake.MockFileSharesClient
+		want   error
+	}{
+		"UpdateFailed": {
+			cr: share(),
+			client: &fake.MockFileSharesClient{
+				MockUpdate: func(_ context.Context, _ string, _ string, _ string, _ storage.FileShare) (storage.FileShare, error) {
+					return storage.FileShare{}, errorBoom
+				},
+			},
+			want: errors.Wrap(errorBoom, errUpdateFailed),
+		},
+		"ProtocolsOmitted": {
+			cr: share(withQuota(200), withProtocols("SMB")),
+			client: &fake.MockFileSharesClient{
+				MockUpdate: func(_ context.Context, _ string, _ string, _ string, fs storage.FileShare) (storage.FileShare, error) {
+					if fs.EnabledProtocols != "" || azure.ToInt(fs.ShareQuota) != 200 {
+						return storage.FileShare{}, errorBoom
+					}
+					return azureShare(), nil
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		cr  *v1beta1.FileShare
+		err error
+	}
+
+	cases := map[string]struct {
+		cr     *v1beta1.FileShare
+		client *fake.MockFileSharesClient
+		want   want
+	}{
+		"NotFound": {
+			cr: share(),
+			client: &fake.MockFileSharesClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+			want: want{
+				cr: share(withConditions(xpv1.Deleting())),
+			},
+		},
+		"DeleteFailed": {
+			cr: share(),
+			client: &fake.MockFileSharesClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: want{
+				cr:  share(withConditions(xpv1.Deleting())),
+				err: errors.Wrap(errorBoom, errDeleteFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			err := e.Delete(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
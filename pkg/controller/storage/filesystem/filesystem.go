@@ -0,0 +1,192 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystem
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake/storagedatalakeapi"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotFilesystem = "managed resource is not a storage Filesystem"
+	errConnectFailed = "cannot connect to Azure API"
+	errListKeys      = "cannot list storage Account keys"
+	errNoKeys        = "storage Account has no keys"
+	errNewClient     = "cannot create filesystem client"
+	errGetFailed     = "cannot get filesystem"
+	errCreateFailed  = "cannot create filesystem"
+	errUpdateFailed  = "cannot update filesystem"
+	errDeleteFailed  = "cannot delete filesystem"
+)
+
+// Setup adds a controller that reconciles Filesystems.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1beta1.FilesystemGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1beta1.Filesystem{}).
+		Complete(tracing.Reconciler(name, v1beta1.FilesystemGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.FilesystemGroupVersionKind),
+			managed.WithExternalConnecter(&connecter{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	kube client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azure.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
+	}
+	acl := storage.NewAccountsClient(creds[azure.CredentialsKeySubscriptionID])
+	acl.Authorizer = auth
+	return &external{accounts: acl, newClient: azurestorage.NewFilesystemClient}, nil
+}
+
+type external struct {
+	accounts  storageapi.AccountsClientAPI
+	newClient func(accountName, accountKey string) (storagedatalakeapi.FilesystemClientAPI, error)
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.Filesystem)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotFilesystem)
+	}
+	c, err := e.client(ctx, cr)
+	if azure.IsNotFound(errors.Cause(err)) && meta.WasDeleted(cr) {
+		// The Filesystem was deleted along with its storage Account.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	rsp, err := c.GetProperties(ctx, meta.GetExternalName(cr), "", nil, "")
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azure.IsNotFound, err), errGetFailed)
+	}
+	h := azurestorage.ResponseHeader(rsp)
+	props, err := azurestorage.ParseFilesystemProperties(h.Get(azurestorage.HeaderProperties))
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetFailed)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	azurestorage.LateInitializeFilesystem(&cr.Spec.ForProvider, props)
+	cr.Status.AtProvider = azurestorage.GenerateFilesystemObservation(h)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        azurestorage.IsFilesystemUpToDate(cr.Spec.ForProvider, props),
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.Filesystem)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotFilesystem)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+	c, err := e.client(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	_, err = c.Create(ctx, meta.GetExternalName(cr), azurestorage.NewFilesystemProperties(cr.Spec.ForProvider.Properties), "", nil, "")
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.Filesystem)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotFilesystem)
+	}
+	c, err := e.client(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	_, err = c.SetProperties(ctx, meta.GetExternalName(cr), azurestorage.NewFilesystemProperties(cr.Spec.ForProvider.Properties), "", "", "", nil, "")
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.Filesystem)
+	if !ok {
+		return errors.New(errNotFilesystem)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	c, err := e.client(ctx, cr)
+	if azure.IsNotFound(errors.Cause(err)) {
+		// The Filesystem was deleted along with its storage Account.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = c.Delete(ctx, meta.GetExternalName(cr), "", "", "", nil, "")
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteFailed)
+}
+
+// client returns a client for the Filesystems of the storage Account of the
+// supplied Filesystem, authorized by the Account's first access key.
+func (e *external) client(ctx context.Context, cr *v1beta1.Filesystem) (storagedatalakeapi.FilesystemClientAPI, error) {
+	keys, err := e.accounts.ListKeys(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, "")
+	if err != nil {
+		return nil, errors.Wrap(err, errListKeys)
+	}
+	if keys.Keys == nil || len(*keys.Keys) == 0 {
+		return nil, errors.New(errNoKeys)
+	}
+	c, err := e.newClient(cr.Spec.ForProvider.AccountName, azure.ToString((*keys.Keys)[0].Value))
+	return c, errors.Wrap(err, errNewClient)
+}
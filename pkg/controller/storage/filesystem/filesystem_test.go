@@ -0,0 +1,389 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filesystem
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake/storagedatalakeapi"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+)
+
+const (
+	resourceGroup  = "coolgroup"
+	accountName    = "coolaccount"
+	accountKey     = "coolkey"
+	filesystemName = "coolfilesystem"
+	etag           = "0x8D8B0B0B0B0B0B0"
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type filesystemModifier func(*v1beta1.Filesystem)
+
+func withConditions(c ...xpv1.Condition) filesystemModifier {
+	return func(cr *v1beta1.Filesystem) { cr.Status.ConditionedStatus.Conditions = c }
+}
+
+func withProperties(p map[string]string) filesystemModifier {
+	return func(cr *v1beta1.Filesystem) { cr.Spec.ForProvider.Properties = p }
+}
+
+func withDeletionTimestamp() filesystemModifier {
+	return func(cr *v1beta1.Filesystem) { cr.SetDeletionTimestamp(&metav1.Time{Time: time.Unix(1, 0)}) }
+}
+
+func withObservation(o v1beta1.FilesystemObservation) filesystemModifier {
+	return func(cr *v1beta1.Filesystem) { cr.Status.AtProvider = o }
+}
+
+func filesystem(m ...filesystemModifier) *v1beta1.Filesystem {
+	cr := &v1beta1.Filesystem{
+		Spec: v1beta1.FilesystemSpec{
+			ForProvider: v1beta1.FilesystemParameters{
+				ResourceGroupName: resourceGroup,
+				AccountName:       accountName,
+			},
+		},
+	}
+	meta.SetExternalName(cr, filesystemName)
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func response(props map[string]string) autorest.Response {
+	h := http.Header{}
+	h.Set(azurestorage.HeaderETag, etag)
+	if props != nil {
+		h.Set(azurestorage.HeaderProperties, azurestorage.NewFilesystemProperties(props))
+	}
+	return autorest.Response{Response: &http.Response{Header: h}}
+}
+
+func accounts() *fake.MockAccountsClient {
+	return &fake.MockAccountsClient{
+		MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+			return storage.AccountListKeysResult{Keys: &[]storage.AccountKey{{Value: azure.ToStringPtr(accountKey)}}}, nil
+		},
+	}
+}
+
+func accountNotFound() *fake.MockAccountsClient {
+	return &fake.MockAccountsClient{
+		MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+			return storage.AccountListKeysResult{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+		},
+	}
+}
+
+func newClient(c *fake.MockFilesystemClient) func(string, string) (storagedatalakeapi.FilesystemClientAPI, error) {
+	return func(name, key string) (storagedatalakeapi.FilesystemClientAPI, error) {
+		if name != accountName || key != accountKey {
+			return nil, errors.Errorf("unexpected account %q or key %q", name, key)
+		}
+		return c, nil
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		cr       *v1beta1.Filesystem
+		client   *fake.MockFilesystemClient
+		accounts *fake.MockAccountsClient
+	}
+	type want struct {
+		cr  *v1beta1.Filesystem
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"NotFound": {
+			args: args{
+				cr: filesystem(),
+				client: &fake.MockFilesystemClient{
+					MockGetProperties: func(_ context.Context, _ string) (autorest.Response, error) {
+						return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+					},
+				},
+				accounts: accounts(),
+			},
+			want: want{
+				cr: filesystem(),
+			},
+		},
+		"GetFailed": {
+			args: args{
+				cr: filesystem(),
+				client: &fake.MockFilesystemClient{
+					MockGetProperties: func(_ context.Context, _ string) (autorest.Response, error) {
+						return autorest.Response{}, errorBoom
+					},
+				},
+				accounts: accounts(),
+			},
+			want: want{
+				cr:  filesystem(),
+				err: errors.Wrap(errorBoom, errGetFailed),
+			},
+		},
+		"ListKeysFailed": {
+			args: args{
+				cr: filesystem(),
+				accounts: &fake.MockAccountsClient{
+					MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+						return storage.AccountListKeysResult{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  filesystem(),
+				err: errors.Wrap(errorBoom, errListKeys),
+			},
+		},
+		"AccountDeleted": {
+			args: args{
+				cr:       filesystem(withDeletionTimestamp()),
+				accounts: accountNotFound(),
+			},
+			want: want{
+				cr: filesystem(withDeletionTimestamp()),
+			},
+		},
+		"AccountNotFound": {
+			args: args{
+				cr:       filesystem(),
+				accounts: accountNotFound(),
+			},
+			want: want{
+				cr:  filesystem(),
+				err: errors.Wrap(autorest.DetailedError{StatusCode: http.StatusNotFound}, errListKeys),
+			},
+		},
+		"LateInitialized": {
+			args: args{
+				cr: filesystem(),
+				client: &fake.MockFilesystemClient{
+					MockGetProperties: func(_ context.Context, _ string) (autorest.Response, error) {
+						return response(map[string]string{"team": "cool"}), nil
+					},
+				},
+				accounts: accounts(),
+			},
+			want: want{
+				cr: filesystem(
+					withProperties(map[string]string{"team": "cool"}),
+					withObservation(v1beta1.FilesystemObservation{ETag: etag}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"NeedsUpdate": {
+			args: args{
+				cr: filesystem(withProperties(map[string]string{"team": "cooler"})),
+				client: &fake.MockFilesystemClient{
+					MockGetProperties: func(_ context.Context, _ string) (autorest.Response, error) {
+						return response(map[string]string{"team": "cool"}), nil
+					},
+				},
+				accounts: accounts(),
+			},
+			want: want{
+				cr: filesystem(
+					withProperties(map[string]string{"team": "cooler"}),
+					withObservation(v1beta1.FilesystemObservation{ETag: etag}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{accounts: tc.accounts, newClient: newClient(tc.client)}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	props := map[string]string{"team": "cool"}
+
+	cases := map[string]struct {
+		cr     *v1beta1.Filesystem
+		client *fake.MockFilesystemClient
+		want   error
+	}{
+		"Successful": {
+			cr: filesystem(withProperties(props)),
+			client: &fake.MockFilesystemClient{
+				MockCreate: func(_ context.Context, name string, p string) (autorest.Response, error) {
+					if name != filesystemName || p != azurestorage.NewFilesystemProperties(props) {
+						return autorest.Response{}, errors.Errorf("unexpected filesystem %q or properties %q", name, p)
+					}
+					return autorest.Response{}, nil
+				},
+			},
+		},
+		"Failed": {
+			cr: filesystem(),
+			client: &fake.MockFilesystemClient{
+				MockCreate: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: errors.Wrap(errorBoom, errCreateFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{accounts: accounts(), newClient: newClient(tc.client)}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		cr     *v1beta1.Filesystem
+		client *fake.MockFilesystemClient
+		want   error
+	}{
+		"Successful": {
+			cr: filesystem(withProperties(map[string]string{"team": "cool"})),
+			client: &fake.MockFilesystemClient{
+				MockSetProperties: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, nil
+				},
+			},
+		},
+		"Failed": {
+			cr: filesystem(),
+			client: &fake.MockFilesystemClient{
+				MockSetProperties: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: errors.Wrap(errorBoom, errUpdateFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{accounts: accounts(), newClient: newClient(tc.client)}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		client   *fake.MockFilesystemClient
+		accounts *fake.MockAccountsClient
+		want     error
+	}{
+		"Successful": {
+			client: &fake.MockFilesystemClient{
+				MockDelete: func(_ context.Context, _ string) (autorest.Response, error) {
+					return autorest.Response{}, nil
+				},
+			},
+		},
+		"AlreadyDeleted": {
+			client: &fake.MockFilesystemClient{
+				MockDelete: func(_ context.Context, _ string) (autorest.Response, error) {
+					return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+		},
+		"AccountDeleted": {
+			accounts: accountNotFound(),
+		},
+		"Failed": {
+			client: &fake.MockFilesystemClient{
+				MockDelete: func(_ context.Context, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: errors.Wrap(errorBoom, errDeleteFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := tc.accounts
+			if a == nil {
+				a = accounts()
+			}
+			e := external{accounts: a, newClient: newClient(tc.client)}
+			err := e.Delete(context.Background(), filesystem())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managementpolicy
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotManagementPolicy = "managed resource is not a storage ManagementPolicy"
+	errConnectFailed       = "cannot connect to Azure API"
+	errGetFailed           = "cannot get storage ManagementPolicy"
+	errCreateFailed        = "cannot create storage ManagementPolicy"
+	errUpdateFailed        = "cannot update storage ManagementPolicy"
+	errDeleteFailed        = "cannot delete storage ManagementPolicy"
+)
+
+// Setup adds a controller that reconciles ManagementPolicies.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1beta1.ManagementPolicyGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1beta1.ManagementPolicy{}).
+		Complete(tracing.Reconciler(name, v1beta1.ManagementPolicyGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.ManagementPolicyGroupVersionKind),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{kube: mgr.GetClient()}, mgr.GetClient(), protection.IDAt("status.atProvider.id"))),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	kube client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azure.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
+	}
+	cl := azurestorage.NewManagementPolicyClient(creds[azure.CredentialsKeySubscriptionID])
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client azurestorage.ManagementPolicyClientAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.ManagementPolicy)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotManagementPolicy)
+	}
+
+	az, err := e.client.Get(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName)
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azure.IsNotFound, err), errGetFailed)
+	}
+
+	cr.Status.AtProvider = azurestorage.GenerateManagementPolicyObservation(az)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: azurestorage.IsManagementPolicyUpToDate(cr.Spec.ForProvider, az),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.ManagementPolicy)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotManagementPolicy)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+	_, err := e.client.CreateOrUpdate(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, azurestorage.NewManagementPolicy(cr.Spec.ForProvider))
+	return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.ManagementPolicy)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotManagementPolicy)
+	}
+	_, err := e.client.CreateOrUpdate(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, azurestorage.NewManagementPolicy(cr.Spec.ForProvider))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateFailed)
+}
+
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.ManagementPolicy)
+	if !ok {
+		return errors.New(errNotManagementPolicy)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	_, err := e.client.Delete(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName)
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteFailed)
+}
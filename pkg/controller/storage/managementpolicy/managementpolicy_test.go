@@ -0,0 +1,370 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managementpolicy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+)
+
+const (
+	resourceGroup = "coolgroup"
+	accountName   = "coolaccount"
+	id            = "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount/managementPolicies/default"
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type policyModifier func(*v1beta1.ManagementPolicy)
+
+func withConditions(c ...xpv1.Condition) policyModifier {
+	return func(cr *v1beta1.ManagementPolicy) { cr.Status.ConditionedStatus.Conditions = c }
+}
+
+func withID(id string) policyModifier {
+	return func(cr *v1beta1.ManagementPolicy) { cr.Status.AtProvider.ID = id }
+}
+
+func withDeleteAfter(days int) policyModifier {
+	return func(cr *v1beta1.ManagementPolicy) {
+		cr.Spec.ForProvider.Rules[0].Definition.Actions.BaseBlob.Delete = &v1beta1.DateAfterModification{DaysAfterModificationGreaterThan: days}
+	}
+}
+
+func policy(m ...policyModifier) *v1beta1.ManagementPolicy {
+	cr := &v1beta1.ManagementPolicy{
+		Spec: v1beta1.ManagementPolicySpec{
+			ForProvider: v1beta1.ManagementPolicyParameters{
+				ResourceGroupName: resourceGroup,
+				AccountName:       accountName,
+				Rules: []v1beta1.ManagementPolicyRule{{
+					Name: "expiry",
+					Definition: v1beta1.ManagementPolicyDefinition{
+						Actions: v1beta1.ManagementPolicyActions{
+							BaseBlob: &v1beta1.ManagementPolicyBaseBlob{
+								TierToCool: &v1beta1.DateAfterModification{DaysAfterModificationGreaterThan: 30},
+								Delete:     &v1beta1.DateAfterModification{DaysAfterModificationGreaterThan: 365},
+							},
+						},
+						Filters: &v1beta1.ManagementPolicyFilters{BlobTypes: []string{"blockBlob"}},
+					},
+				}},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func azurePolicy() azurestorage.ManagementPolicy {
+	p := azurestorage.NewManagementPolicy(policy().Spec.ForProvider)
+	(*p.Policy.Rules)[0].Enabled = azure.ToBoolPtr(true)
+	p.ID = azure.ToStringPtr(id)
+	return p
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		cr *v1beta1.ManagementPolicy
+		c  azurestorage.ManagementPolicyClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.ManagementPolicy
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"NotFound": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockGet: func(_ context.Context, _ string, _ string) (azurestorage.ManagementPolicy, error) {
+						return azurestorage.ManagementPolicy{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+					},
+				},
+			},
+			want: want{
+				cr: policy(),
+			},
+		},
+		"GetFailed": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockGet: func(_ context.Context, _ string, _ string) (azurestorage.ManagementPolicy, error) {
+						return azurestorage.ManagementPolicy{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  policy(),
+				err: errors.Wrap(errorBoom, errGetFailed),
+			},
+		},
+		"UpToDate": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockGet: func(_ context.Context, _ string, _ string) (azurestorage.ManagementPolicy, error) {
+						return azurePolicy(), nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withID(id), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NeedsUpdate": {
+			args: args{
+				cr: policy(withDeleteAfter(730)),
+				c: &fake.MockManagementPoliciesClient{
+					MockGet: func(_ context.Context, _ string, _ string) (azurestorage.ManagementPolicy, error) {
+						return azurePolicy(), nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withDeleteAfter(730), withID(id), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type args struct {
+		cr *v1beta1.ManagementPolicy
+		c  azurestorage.ManagementPolicyClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.ManagementPolicy
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ azurestorage.ManagementPolicy) (azurestorage.ManagementPolicy, error) {
+						return azurestorage.ManagementPolicy{}, nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withConditions(xpv1.Creating())),
+			},
+		},
+		"Failed": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ azurestorage.ManagementPolicy) (azurestorage.ManagementPolicy, error) {
+						return azurestorage.ManagementPolicy{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withConditions(xpv1.Creating())),
+				err: errors.Wrap(errorBoom, errCreateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			_, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type args struct {
+		cr *v1beta1.ManagementPolicy
+		c  azurestorage.ManagementPolicyClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.ManagementPolicy
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ azurestorage.ManagementPolicy) (azurestorage.ManagementPolicy, error) {
+						return azurestorage.ManagementPolicy{}, nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(),
+			},
+		},
+		"Failed": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _ string, _ string, _ azurestorage.ManagementPolicy) (azurestorage.ManagementPolicy, error) {
+						return azurestorage.ManagementPolicy{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  policy(),
+				err: errors.Wrap(errorBoom, errUpdateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			_, err := e.Update(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		cr *v1beta1.ManagementPolicy
+		c  azurestorage.ManagementPolicyClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.ManagementPolicy
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockDelete: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+						return autorest.Response{}, nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyDeleted": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockDelete: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+						return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+					},
+				},
+			},
+			want: want{
+				cr: policy(withConditions(xpv1.Deleting())),
+			},
+		},
+		"Failed": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockManagementPoliciesClient{
+					MockDelete: func(_ context.Context, _ string, _ string) (autorest.Response, error) {
+						return autorest.Response{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withConditions(xpv1.Deleting())),
+				err: errors.Wrap(errorBoom, errDeleteFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
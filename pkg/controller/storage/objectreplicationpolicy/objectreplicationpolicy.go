@@ -0,0 +1,215 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectreplicationpolicy
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	"github.com/crossplane/provider-azure/pkg/clients/protection"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotObjectReplicationPolicy = "managed resource is not a storage ObjectReplicationPolicy"
+	errConnectFailed              = "cannot connect to Azure API"
+	errGetDestination             = "cannot get storage ObjectReplicationPolicy of destination Account"
+	errGetSource                  = "cannot get storage ObjectReplicationPolicy of source Account"
+	errCreateFailed               = "cannot create storage ObjectReplicationPolicy"
+	errNoPolicyID                 = "destination Account did not return an ObjectReplicationPolicy ID"
+	errUpdateDestination          = "cannot update storage ObjectReplicationPolicy of destination Account"
+	errUpdateSource               = "cannot update storage ObjectReplicationPolicy of source Account"
+	errDeleteDestination          = "cannot delete storage ObjectReplicationPolicy of destination Account"
+	errDeleteSource               = "cannot delete storage ObjectReplicationPolicy of source Account"
+)
+
+// Setup adds a controller that reconciles ObjectReplicationPolicies.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1beta1.ObjectReplicationPolicyGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1beta1.ObjectReplicationPolicy{}).
+		Complete(tracing.Reconciler(name, v1beta1.ObjectReplicationPolicyGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.ObjectReplicationPolicyGroupVersionKind),
+			managed.WithExternalConnecter(protection.NewExternalConnecter(&connecter{kube: mgr.GetClient()}, mgr.GetClient(), replicationPolicyID)),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			// The external name of an ObjectReplicationPolicy is the ID that
+			// Azure generates when it is created, so it must not default to
+			// the name of the managed resource.
+			managed.WithInitializers(managed.NewDefaultProviderConfig(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+// replicationPolicyID returns the Azure resource ID of an
+// ObjectReplicationPolicy of its destination storage Account, on which the
+// policy is created first. It returns an empty string until Azure has
+// generated the policy's ID.
+func replicationPolicyID(mg resource.Managed, subscriptionID string) string {
+	cr, ok := mg.(*v1beta1.ObjectReplicationPolicy)
+	if !ok || meta.GetExternalName(cr) == "" {
+		return ""
+	}
+	return "/subscriptions/" + subscriptionID +
+		"/resourceGroups/" + cr.Spec.ForProvider.DestinationResourceGroupName +
+		"/providers/Microsoft.Storage/storageAccounts/" + cr.Spec.ForProvider.DestinationAccountName +
+		"/objectReplicationPolicies/" + meta.GetExternalName(cr)
+}
+
+type connecter struct {
+	kube client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azure.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
+	}
+	cl := storage.NewObjectReplicationPoliciesClient(creds[azure.CredentialsKeySubscriptionID])
+	cl.Authorizer = auth
+	return &external{client: cl}, nil
+}
+
+type external struct {
+	client storageapi.ObjectReplicationPoliciesClientAPI
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.ObjectReplicationPolicy)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotObjectReplicationPolicy)
+	}
+	id := meta.GetExternalName(cr)
+	if id == "" {
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+
+	p := cr.Spec.ForProvider
+	dst, err := e.client.Get(ctx, p.DestinationResourceGroupName, p.DestinationAccountName, id)
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azure.IsNotFound, err), errGetDestination)
+	}
+
+	// The policy is created on the destination Account first, so it may not
+	// yet exist on the source Account.
+	var src *storage.ObjectReplicationPolicy
+	az, err := e.client.Get(ctx, p.SourceResourceGroupName, p.SourceAccountName, id)
+	if resource.Ignore(azure.IsNotFound, err) != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetSource)
+	}
+	if err == nil {
+		src = &az
+	}
+
+	cr.Status.AtProvider = azurestorage.GenerateObjectReplicationPolicyObservation(dst, src)
+	if src == nil {
+		cr.Status.SetConditions(xpv1.Creating())
+		return managed.ExternalObservation{ResourceExists: true}, nil
+	}
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:   true,
+		ResourceUpToDate: azurestorage.IsObjectReplicationPolicyUpToDate(p, dst) && azurestorage.IsObjectReplicationPolicyUpToDate(p, *src),
+	}, nil
+}
+
+// Create the policy on the destination Account, which generates its ID. The
+// policy is created on the source Account by the subsequent update.
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.ObjectReplicationPolicy)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotObjectReplicationPolicy)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+
+	p := cr.Spec.ForProvider
+	az, err := e.client.CreateOrUpdate(ctx, p.DestinationResourceGroupName, p.DestinationAccountName, azurestorage.ObjectReplicationPolicyIDDefault, azurestorage.NewObjectReplicationPolicy(p, nil))
+	if err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	id := azurestorage.GenerateObjectReplicationPolicyObservation(az, nil).PolicyID
+	if id == "" {
+		return managed.ExternalCreation{}, errors.New(errNoPolicyID)
+	}
+	meta.SetExternalName(cr, id)
+	return managed.ExternalCreation{ExternalNameAssigned: true}, nil
+}
+
+// Update the policy on the destination Account, then on the source Account.
+// The destination Account generates the IDs of new rules, which the source
+// Account requires.
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.ObjectReplicationPolicy)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotObjectReplicationPolicy)
+	}
+
+	p := cr.Spec.ForProvider
+	id := meta.GetExternalName(cr)
+	dst, err := e.client.CreateOrUpdate(ctx, p.DestinationResourceGroupName, p.DestinationAccountName, id, azurestorage.NewObjectReplicationPolicy(p, cr.Status.AtProvider.Rules))
+	if err != nil {
+		return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateDestination)
+	}
+	rules := azurestorage.GenerateObjectReplicationPolicyObservation(dst, nil).Rules
+	_, err = e.client.CreateOrUpdate(ctx, p.SourceResourceGroupName, p.SourceAccountName, id, azurestorage.NewObjectReplicationPolicy(p, rules))
+	return managed.ExternalUpdate{}, errors.Wrap(err, errUpdateSource)
+}
+
+// Delete the policy from the source Account, then from the destination
+// Account.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.ObjectReplicationPolicy)
+	if !ok {
+		return errors.New(errNotObjectReplicationPolicy)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+
+	p := cr.Spec.ForProvider
+	id := meta.GetExternalName(cr)
+	if _, err := e.client.Delete(ctx, p.SourceResourceGroupName, p.SourceAccountName, id); resource.Ignore(azure.IsNotFound, err) != nil {
+		return errors.Wrap(err, errDeleteSource)
+	}
+	_, err := e.client.Delete(ctx, p.DestinationResourceGroupName, p.DestinationAccountName, id)
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteDestination)
+}
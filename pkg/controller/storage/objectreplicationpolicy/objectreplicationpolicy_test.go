@@ -0,0 +1,515 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package objectreplicationpolicy
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+)
+
+const (
+	resourceGroup = "coolgroup"
+	source        = "coolsource"
+	destination   = "cooldestination"
+	policyID      = "3f6a4b0e-1111-4c1d-9c6a-0d5f2f1b7e21"
+	ruleID        = "0c7d2f6e-2222-4f3a-8a41-1d9e5c0b8f10"
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type policyModifier func(*v1beta1.ObjectReplicationPolicy)
+
+func withConditions(c ...xpv1.Condition) policyModifier {
+	return func(cr *v1beta1.ObjectReplicationPolicy) { cr.Status.ConditionedStatus.Conditions = c }
+}
+
+func withExternalName(n string) policyModifier {
+	return func(cr *v1beta1.ObjectReplicationPolicy) { meta.SetExternalName(cr, n) }
+}
+
+func withObservation(o v1beta1.ObjectReplicationPolicyObservation) policyModifier {
+	return func(cr *v1beta1.ObjectReplicationPolicy) { cr.Status.AtProvider = o }
+}
+
+func withPrefixMatch(prefixes ...string) policyModifier {
+	return func(cr *v1beta1.ObjectReplicationPolicy) {
+		cr.Spec.ForProvider.Rules[0].Filters = &v1beta1.ObjectReplicationFilters{PrefixMatch: prefixes}
+	}
+}
+
+func policy(m ...policyModifier) *v1beta1.ObjectReplicationPolicy {
+	cr := &v1beta1.ObjectReplicationPolicy{
+		Spec: v1beta1.ObjectReplicationPolicySpec{
+			ForProvider: v1beta1.ObjectReplicationPolicyParameters{
+				SourceResourceGroupName:      resourceGroup,
+				SourceAccountName:            source,
+				DestinationResourceGroupName: resourceGroup,
+				DestinationAccountName:       destination,
+				Rules: []v1beta1.ObjectReplicationRule{{
+					SourceContainer:      "reports",
+					DestinationContainer: "reports",
+				}},
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func observation() v1beta1.ObjectReplicationPolicyObservation {
+	return v1beta1.ObjectReplicationPolicyObservation{
+		PolicyID: policyID,
+		Rules: []v1beta1.ObjectReplicationRuleObservation{{
+			RuleID:               ruleID,
+			SourceContainer:      "reports",
+			DestinationContainer: "reports",
+		}},
+	}
+}
+
+func azurePolicy() storage.ObjectReplicationPolicy {
+	p := azurestorage.NewObjectReplicationPolicy(policy().Spec.ForProvider, observation().Rules)
+	p.PolicyID = azure.ToStringPtr(policyID)
+	return p
+}
+
+func TestReplicationPolicyID(t *testing.T) {
+	cases := map[string]struct {
+		cr   *v1beta1.ObjectReplicationPolicy
+		want string
+	}{
+		"NotYetCreated": {
+			cr: policy(),
+		},
+		"Created": {
+			cr:   policy(withExternalName(policyID)),
+			want: "/subscriptions/coolsub/resourceGroups/" + resourceGroup + "/providers/Microsoft.Storage/storageAccounts/" + destination + "/objectReplicationPolicies/" + policyID,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, replicationPolicyID(tc.cr, "coolsub")); diff != "" {
+				t.Errorf("replicationPolicyID(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type args struct {
+		cr *v1beta1.ObjectReplicationPolicy
+		c  storageapi.ObjectReplicationPoliciesClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.ObjectReplicationPolicy
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"NotCreated": {
+			args: args{
+				cr: policy(),
+			},
+			want: want{
+				cr: policy(),
+			},
+		},
+		"NotFound": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockGet: func(_ context.Context, _, _, _ string) (storage.ObjectReplicationPolicy, error) {
+						return storage.ObjectReplicationPolicy{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+					},
+				},
+			},
+			want: want{
+				cr: policy(withExternalName(policyID)),
+			},
+		},
+		"GetDestinationFailed": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockGet: func(_ context.Context, _, _, _ string) (storage.ObjectReplicationPolicy, error) {
+						return storage.ObjectReplicationPolicy{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withExternalName(policyID)),
+				err: errors.Wrap(errorBoom, errGetDestination),
+			},
+		},
+		"GetSourceFailed": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockGet: func(_ context.Context, _, account, _ string) (storage.ObjectReplicationPolicy, error) {
+						if account == source {
+							return storage.ObjectReplicationPolicy{}, errorBoom
+						}
+						return azurePolicy(), nil
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withExternalName(policyID)),
+				err: errors.Wrap(errorBoom, errGetSource),
+			},
+		},
+		"SourceNotCreated": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockGet: func(_ context.Context, _, account, _ string) (storage.ObjectReplicationPolicy, error) {
+						if account == source {
+							return storage.ObjectReplicationPolicy{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+						}
+						return azurePolicy(), nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withExternalName(policyID), withObservation(observation()), withConditions(xpv1.Creating())),
+				o:  managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+		"UpToDate": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockGet: func(_ context.Context, _, _, _ string) (storage.ObjectReplicationPolicy, error) {
+						return azurePolicy(), nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withExternalName(policyID), withObservation(observation()), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true},
+			},
+		},
+		"NeedsUpdate": {
+			args: args{
+				cr: policy(withExternalName(policyID), withPrefixMatch("2021/")),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockGet: func(_ context.Context, _, _, _ string) (storage.ObjectReplicationPolicy, error) {
+						return azurePolicy(), nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withExternalName(policyID), withPrefixMatch("2021/"), withObservation(observation()), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			o, err := e.Observe(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type args struct {
+		cr *v1beta1.ObjectReplicationPolicy
+		c  storageapi.ObjectReplicationPoliciesClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.ObjectReplicationPolicy
+		o   managed.ExternalCreation
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _, account, id string, _ storage.ObjectReplicationPolicy) (storage.ObjectReplicationPolicy, error) {
+						if account != destination || id != azurestorage.ObjectReplicationPolicyIDDefault {
+							t.Errorf("CreateOrUpdate(...): want policy %q on %q, got %q on %q", azurestorage.ObjectReplicationPolicyIDDefault, destination, id, account)
+						}
+						return azurePolicy(), nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withExternalName(policyID), withConditions(xpv1.Creating())),
+				o:  managed.ExternalCreation{ExternalNameAssigned: true},
+			},
+		},
+		"NoPolicyID": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ storage.ObjectReplicationPolicy) (storage.ObjectReplicationPolicy, error) {
+						return storage.ObjectReplicationPolicy{}, nil
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withConditions(xpv1.Creating())),
+				err: errors.New(errNoPolicyID),
+			},
+		},
+		"Failed": {
+			args: args{
+				cr: policy(),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ storage.ObjectReplicationPolicy) (storage.ObjectReplicationPolicy, error) {
+						return storage.ObjectReplicationPolicy{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withConditions(xpv1.Creating())),
+				err: errors.Wrap(errorBoom, errCreateFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			o, err := e.Create(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	type args struct {
+		cr *v1beta1.ObjectReplicationPolicy
+		c  storageapi.ObjectReplicationPoliciesClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.ObjectReplicationPolicy
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _, account, _ string, p storage.ObjectReplicationPolicy) (storage.ObjectReplicationPolicy, error) {
+						if account == destination {
+							// The destination Account generates the ID of
+							// the new rule.
+							return azurePolicy(), nil
+						}
+						if diff := cmp.Diff(azurestorage.NewObjectReplicationPolicy(policy().Spec.ForProvider, observation().Rules), p); diff != "" {
+							t.Errorf("CreateOrUpdate(...): -want, +got\n%s", diff)
+						}
+						return p, nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withExternalName(policyID)),
+			},
+		},
+		"UpdateDestinationFailed": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _, _, _ string, _ storage.ObjectReplicationPolicy) (storage.ObjectReplicationPolicy, error) {
+						return storage.ObjectReplicationPolicy{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withExternalName(policyID)),
+				err: errors.Wrap(errorBoom, errUpdateDestination),
+			},
+		},
+		"UpdateSourceFailed": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockCreateOrUpdate: func(_ context.Context, _, account, _ string, _ storage.ObjectReplicationPolicy) (storage.ObjectReplicationPolicy, error) {
+						if account == source {
+							return storage.ObjectReplicationPolicy{}, errorBoom
+						}
+						return azurePolicy(), nil
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withExternalName(policyID)),
+				err: errors.Wrap(errorBoom, errUpdateSource),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			_, err := e.Update(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type args struct {
+		cr *v1beta1.ObjectReplicationPolicy
+		c  storageapi.ObjectReplicationPoliciesClientAPI
+	}
+	type want struct {
+		cr  *v1beta1.ObjectReplicationPolicy
+		err error
+	}
+
+	cases := map[string]struct {
+		args
+		want
+	}{
+		"Successful": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockDelete: func(_ context.Context, _, _, _ string) (autorest.Response, error) {
+						return autorest.Response{}, nil
+					},
+				},
+			},
+			want: want{
+				cr: policy(withExternalName(policyID), withConditions(xpv1.Deleting())),
+			},
+		},
+		"AlreadyDeleted": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockDelete: func(_ context.Context, _, _, _ string) (autorest.Response, error) {
+						return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+					},
+				},
+			},
+			want: want{
+				cr: policy(withExternalName(policyID), withConditions(xpv1.Deleting())),
+			},
+		},
+		"DeleteSourceFailed": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockDelete: func(_ context.Context, _, account, _ string) (autorest.Response, error) {
+						if account == destination {
+							t.Errorf("Delete(...): policy deleted from destination before source")
+						}
+						return autorest.Response{}, errorBoom
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withExternalName(policyID), withConditions(xpv1.Deleting())),
+				err: errors.Wrap(errorBoom, errDeleteSource),
+			},
+		},
+		"DeleteDestinationFailed": {
+			args: args{
+				cr: policy(withExternalName(policyID)),
+				c: &fake.MockObjectReplicationPoliciesClient{
+					MockDelete: func(_ context.Context, _, account, _ string) (autorest.Response, error) {
+						if account == destination {
+							return autorest.Response{}, errorBoom
+						}
+						return autorest.Response{}, nil
+					},
+				},
+			},
+			want: want{
+				cr:  policy(withExternalName(policyID), withConditions(xpv1.Deleting())),
+				err: errors.Wrap(errorBoom, errDeleteDestination),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.c}
+			err := e.Delete(context.Background(), tc.args.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.args.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
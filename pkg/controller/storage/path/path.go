@@ -0,0 +1,210 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package path
+
+import (
+	"context"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake"
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake/storagedatalakeapi"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage/storageapi"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/event"
+	"github.com/crossplane/crossplane-runtime/pkg/logging"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/ratelimiter"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/resource"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/tracing"
+)
+
+// Error strings.
+const (
+	errNotPath         = "managed resource is not a storage Path"
+	errConnectFailed   = "cannot connect to Azure API"
+	errListKeys        = "cannot list storage Account keys"
+	errNoKeys          = "storage Account has no keys"
+	errNewClient       = "cannot create path client"
+	errGetFailed       = "cannot get path"
+	errCreateFailed    = "cannot create path"
+	errSetAccessFailed = "cannot set path access control"
+	errDeleteFailed    = "cannot delete path"
+)
+
+// Setup adds a controller that reconciles Paths.
+func Setup(mgr ctrl.Manager, l logging.Logger, rl workqueue.RateLimiter, poll time.Duration, concurrency int) error {
+	name := managed.ControllerName(v1beta1.PathGroupKind)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		WithOptions(controller.Options{
+			RateLimiter:             ratelimiter.NewDefaultManagedRateLimiter(rl),
+			MaxConcurrentReconciles: concurrency,
+		}).
+		For(&v1beta1.Path{}).
+		Complete(tracing.Reconciler(name, v1beta1.PathGroupVersionKind, managed.NewReconciler(mgr,
+			resource.ManagedKind(v1beta1.PathGroupVersionKind),
+			managed.WithExternalConnecter(&connecter{kube: mgr.GetClient()}),
+			managed.WithReferenceResolver(managed.NewAPISimpleReferenceResolver(mgr.GetClient())),
+			managed.WithPollInterval(poll),
+			managed.WithLogger(l.WithValues("controller", name)),
+			managed.WithRecorder(event.NewAPIRecorder(mgr.GetEventRecorderFor(name))))))
+}
+
+type connecter struct {
+	kube client.Client
+}
+
+func (c *connecter) Connect(ctx context.Context, mg resource.Managed) (managed.ExternalClient, error) {
+	creds, auth, err := azure.GetAuthInfo(ctx, c.kube, mg)
+	if err != nil {
+		return nil, errors.Wrap(err, errConnectFailed)
+	}
+	acl := storage.NewAccountsClient(creds[azure.CredentialsKeySubscriptionID])
+	acl.Authorizer = auth
+	return &external{accounts: acl, newClient: azurestorage.NewPathClient}, nil
+}
+
+type external struct {
+	accounts  storageapi.AccountsClientAPI
+	newClient func(accountName, accountKey string) (storagedatalakeapi.PathClientAPI, error)
+}
+
+func (e *external) Observe(ctx context.Context, mg resource.Managed) (managed.ExternalObservation, error) {
+	cr, ok := mg.(*v1beta1.Path)
+	if !ok {
+		return managed.ExternalObservation{}, errors.New(errNotPath)
+	}
+	c, err := e.client(ctx, cr)
+	if azure.IsNotFound(errors.Cause(err)) && meta.WasDeleted(cr) {
+		// The Path was deleted along with its storage Account.
+		return managed.ExternalObservation{ResourceExists: false}, nil
+	}
+	if err != nil {
+		return managed.ExternalObservation{}, err
+	}
+
+	p := cr.Spec.ForProvider
+	rsp, err := c.GetProperties(ctx, p.FilesystemName, p.Path, storagedatalake.GetAccessControl, nil, "", "", "", "", "", "", "", nil, "")
+	if err != nil {
+		return managed.ExternalObservation{ResourceExists: false}, errors.Wrap(resource.Ignore(azure.IsNotFound, err), errGetFailed)
+	}
+	h := azurestorage.ResponseHeader(rsp)
+	ac, err := azurestorage.GetPathAccessControl(h)
+	if err != nil {
+		return managed.ExternalObservation{}, errors.Wrap(err, errGetFailed)
+	}
+
+	current := cr.Spec.ForProvider.DeepCopy()
+	azurestorage.LateInitializePath(&cr.Spec.ForProvider, ac)
+	cr.Status.AtProvider = azurestorage.GeneratePathObservation(h, ac)
+	cr.Status.SetConditions(xpv1.Available())
+
+	return managed.ExternalObservation{
+		ResourceExists:          true,
+		ResourceUpToDate:        azurestorage.IsPathUpToDate(cr.Spec.ForProvider, ac),
+		ResourceLateInitialized: !cmp.Equal(current, &cr.Spec.ForProvider),
+	}, nil
+}
+
+func (e *external) Create(ctx context.Context, mg resource.Managed) (managed.ExternalCreation, error) {
+	cr, ok := mg.(*v1beta1.Path)
+	if !ok {
+		return managed.ExternalCreation{}, errors.New(errNotPath)
+	}
+	cr.Status.SetConditions(xpv1.Creating())
+	c, err := e.client(ctx, cr)
+	if err != nil {
+		return managed.ExternalCreation{}, err
+	}
+	p := cr.Spec.ForProvider
+	if _, err := c.Create(ctx, p.FilesystemName, p.Path, storagedatalake.Directory, "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", "", nil, ""); err != nil {
+		return managed.ExternalCreation{}, errors.Wrap(err, errCreateFailed)
+	}
+	if p.Owner == nil && p.Group == nil && p.ACL == nil {
+		return managed.ExternalCreation{}, nil
+	}
+	return managed.ExternalCreation{}, e.setAccessControl(ctx, c, p)
+}
+
+func (e *external) Update(ctx context.Context, mg resource.Managed) (managed.ExternalUpdate, error) {
+	cr, ok := mg.(*v1beta1.Path)
+	if !ok {
+		return managed.ExternalUpdate{}, errors.New(errNotPath)
+	}
+	c, err := e.client(ctx, cr)
+	if err != nil {
+		return managed.ExternalUpdate{}, err
+	}
+	return managed.ExternalUpdate{}, e.setAccessControl(ctx, c, cr.Spec.ForProvider)
+}
+
+// Delete deletes the directory of the supplied Path. Directories that are not
+// empty are not deleted; Azure returns an error until their contents have
+// been deleted.
+func (e *external) Delete(ctx context.Context, mg resource.Managed) error {
+	cr, ok := mg.(*v1beta1.Path)
+	if !ok {
+		return errors.New(errNotPath)
+	}
+	cr.Status.SetConditions(xpv1.Deleting())
+	c, err := e.client(ctx, cr)
+	if azure.IsNotFound(errors.Cause(err)) {
+		// The Path was deleted along with its storage Account.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	_, err = c.Delete(ctx, cr.Spec.ForProvider.FilesystemName, cr.Spec.ForProvider.Path, azure.ToBoolPtr(false, azure.FieldRequired), "", "", "", "", "", "", "", nil, "")
+	return errors.Wrap(resource.Ignore(azure.IsNotFound, err), errDeleteFailed)
+}
+
+// setAccessControl sets the owner, group, and ACL of the supplied Path. Those
+// that are not specified are not changed.
+func (e *external) setAccessControl(ctx context.Context, c storagedatalakeapi.PathClientAPI, p v1beta1.PathParameters) error {
+	_, err := c.Update(ctx, storagedatalake.SetAccessControl, p.FilesystemName, p.Path, nil, nil, nil, nil, "", "", "", "", "", "", "", "", "",
+		azure.ToString(p.Owner), azure.ToString(p.Group), "", azurestorage.NewACL(p.ACL), "", "", "", "", nil, "", nil, "")
+	return errors.Wrap(err, errSetAccessFailed)
+}
+
+// client returns a client for the Paths of the storage Account of the
+// supplied Path, authorized by the Account's first access key.
+func (e *external) client(ctx context.Context, cr *v1beta1.Path) (storagedatalakeapi.PathClientAPI, error) {
+	keys, err := e.accounts.ListKeys(ctx, cr.Spec.ForProvider.ResourceGroupName, cr.Spec.ForProvider.AccountName, "")
+	if err != nil {
+		return nil, errors.Wrap(err, errListKeys)
+	}
+	if keys.Keys == nil || len(*keys.Keys) == 0 {
+		return nil, errors.New(errNoKeys)
+	}
+	c, err := e.newClient(cr.Spec.ForProvider.AccountName, azure.ToString((*keys.Keys)[0].Value))
+	return c, errors.Wrap(err, errNewClient)
+}
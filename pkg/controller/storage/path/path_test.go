@@ -0,0 +1,410 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package path
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake"
+	"github.com/Azure/azure-sdk-for-go/services/storage/datalake/2019-10-31/storagedatalake/storagedatalakeapi"
+	"github.com/Azure/azure-sdk-for-go/services/storage/mgmt/2019-06-01/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+)
+
+const (
+	resourceGroup  = "coolgroup"
+	accountName    = "coolaccount"
+	accountKey     = "coolkey"
+	filesystemName = "coolfilesystem"
+	path           = "raw/sales"
+	owner          = "coolowner"
+	group          = "coolgroup"
+	acl            = "user::rwx,group::r-x,other::---"
+	permissions    = "rwxr-x---"
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type pathModifier func(*v1beta1.Path)
+
+func withConditions(c ...xpv1.Condition) pathModifier {
+	return func(cr *v1beta1.Path) { cr.Status.ConditionedStatus.Conditions = c }
+}
+
+func withOwner(o string) pathModifier {
+	return func(cr *v1beta1.Path) { cr.Spec.ForProvider.Owner = azure.ToStringPtr(o) }
+}
+
+func withGroup(g string) pathModifier {
+	return func(cr *v1beta1.Path) { cr.Spec.ForProvider.Group = azure.ToStringPtr(g) }
+}
+
+func withACL(a string) pathModifier {
+	return func(cr *v1beta1.Path) { cr.Spec.ForProvider.ACL, _ = azurestorage.ParseACL(a) }
+}
+
+func withDeletionTimestamp() pathModifier {
+	return func(cr *v1beta1.Path) { cr.SetDeletionTimestamp(&metav1.Time{Time: time.Unix(1, 0)}) }
+}
+
+func withObservation(o v1beta1.PathObservation) pathModifier {
+	return func(cr *v1beta1.Path) { cr.Status.AtProvider = o }
+}
+
+func dir(m ...pathModifier) *v1beta1.Path {
+	cr := &v1beta1.Path{
+		Spec: v1beta1.PathSpec{
+			ForProvider: v1beta1.PathParameters{
+				ResourceGroupName: resourceGroup,
+				AccountName:       accountName,
+				FilesystemName:    filesystemName,
+				Path:              path,
+			},
+		},
+	}
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func response() autorest.Response {
+	h := http.Header{}
+	h.Set(azurestorage.HeaderOwner, owner)
+	h.Set(azurestorage.HeaderGroup, group)
+	h.Set(azurestorage.HeaderPermissions, permissions)
+	h.Set(azurestorage.HeaderACL, acl)
+	return autorest.Response{Response: &http.Response{Header: h}}
+}
+
+func accounts() *fake.MockAccountsClient {
+	return &fake.MockAccountsClient{
+		MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+			return storage.AccountListKeysResult{Keys: &[]storage.AccountKey{{Value: azure.ToStringPtr(accountKey)}}}, nil
+		},
+	}
+}
+
+func accountNotFound() *fake.MockAccountsClient {
+	return &fake.MockAccountsClient{
+		MockListKeys: func(_ context.Context, _ string, _ string, _ storage.ListKeyExpand) (storage.AccountListKeysResult, error) {
+			return storage.AccountListKeysResult{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+		},
+	}
+}
+
+func newClient(c *fake.MockPathClient) func(string, string) (storagedatalakeapi.PathClientAPI, error) {
+	return func(name, key string) (storagedatalakeapi.PathClientAPI, error) {
+		if name != accountName || key != accountKey {
+			return nil, errors.Errorf("unexpected account %q or key %q", name, key)
+		}
+		return c, nil
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr  *v1beta1.Path
+		o   managed.ExternalObservation
+		err error
+	}
+
+	cases := map[string]struct {
+		cr       *v1beta1.Path
+		client   *fake.MockPathClient
+		accounts *fake.MockAccountsClient
+		want
+	}{
+		"NotFound": {
+			cr: dir(),
+			client: &fake.MockPathClient{
+				MockGetProperties: func(_ context.Context, _ string, _ string, _ storagedatalake.PathGetPropertiesAction) (autorest.Response, error) {
+					return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+			want: want{
+				cr: dir(),
+			},
+		},
+		"GetFailed": {
+			cr: dir(),
+			client: &fake.MockPathClient{
+				MockGetProperties: func(_ context.Context, _ string, _ string, _ storagedatalake.PathGetPropertiesAction) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: want{
+				cr:  dir(),
+				err: errors.Wrap(errorBoom, errGetFailed),
+			},
+		},
+		"AccountDeleted": {
+			cr:       dir(withDeletionTimestamp()),
+			accounts: accountNotFound(),
+			want: want{
+				cr: dir(withDeletionTimestamp()),
+			},
+		},
+		"AccountNotFound": {
+			cr:       dir(),
+			accounts: accountNotFound(),
+			want: want{
+				cr:  dir(),
+				err: errors.Wrap(autorest.DetailedError{StatusCode: http.StatusNotFound}, errListKeys),
+			},
+		},
+		"LateInitialized": {
+			cr: dir(),
+			client: &fake.MockPathClient{
+				MockGetProperties: func(_ context.Context, fs string, p string, a storagedatalake.PathGetPropertiesAction) (autorest.Response, error) {
+					if fs != filesystemName || p != path || a != storagedatalake.GetAccessControl {
+						return autorest.Response{}, errors.Errorf("unexpected filesystem %q, path %q, or action %q", fs, p, a)
+					}
+					return response(), nil
+				},
+			},
+			want: want{
+				cr: dir(
+					withOwner(owner),
+					withGroup(group),
+					withACL(acl),
+					withObservation(v1beta1.PathObservation{Permissions: permissions}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:          true,
+					ResourceUpToDate:        true,
+					ResourceLateInitialized: true,
+				},
+			},
+		},
+		"NeedsUpdate": {
+			cr: dir(withOwner(owner), withGroup(group), withACL("user::rwx,group::rwx,other::---")),
+			client: &fake.MockPathClient{
+				MockGetProperties: func(_ context.Context, _ string, _ string, _ storagedatalake.PathGetPropertiesAction) (autorest.Response, error) {
+					return response(), nil
+				},
+			},
+			want: want{
+				cr: dir(
+					withOwner(owner),
+					withGroup(group),
+					withACL("user::rwx,group::rwx,other::---"),
+					withObservation(v1beta1.PathObservation{Permissions: permissions}),
+					withConditions(xpv1.Available()),
+				),
+				o: managed.ExternalObservation{
+					ResourceExists:   true,
+					ResourceUpToDate: false,
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := tc.accounts
+			if a == nil {
+				a = accounts()
+			}
+			e := external{accounts: a, newClient: newClient(tc.client)}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	cases := map[string]struct {
+		cr     *v1beta1.Path
+		client *fake.MockPathClient
+		want   error
+	}{
+		"Successful": {
+			cr: dir(),
+			client: &fake.MockPathClient{
+				MockCreate: func(_ context.Context, _ string, _ string, r storagedatalake.PathResourceType) (autorest.Response, error) {
+					if r != storagedatalake.Directory {
+						return autorest.Response{}, errors.Errorf("unexpected resource type %q", r)
+					}
+					return autorest.Response{}, nil
+				},
+			},
+		},
+		"SuccessfulWithACL": {
+			cr: dir(withACL(acl)),
+			client: &fake.MockPathClient{
+				MockCreate: func(_ context.Context, _ string, _ string, _ storagedatalake.PathResourceType) (autorest.Response, error) {
+					return autorest.Response{}, nil
+				},
+				MockSetAccessControl: func(_ context.Context, _ string, _ string, o string, g string, a string) (autorest.Response, error) {
+					if o != "" || g != "" || a != acl {
+						return autorest.Response{}, errors.Errorf("unexpected owner %q, group %q, or ACL %q", o, g, a)
+					}
+					return autorest.Response{}, nil
+				},
+			},
+		},
+		"CreateFailed": {
+			cr: dir(),
+			client: &fake.MockPathClient{
+				MockCreate: func(_ context.Context, _ string, _ string, _ storagedatalake.PathResourceType) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: errors.Wrap(errorBoom, errCreateFailed),
+		},
+		"SetAccessControlFailed": {
+			cr: dir(withOwner(owner)),
+			client: &fake.MockPathClient{
+				MockCreate: func(_ context.Context, _ string, _ string, _ storagedatalake.PathResourceType) (autorest.Response, error) {
+					return autorest.Response{}, nil
+				},
+				MockSetAccessControl: func(_ context.Context, _ string, _ string, _ string, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: errors.Wrap(errorBoom, errSetAccessFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{accounts: accounts(), newClient: newClient(tc.client)}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		cr     *v1beta1.Path
+		client *fake.MockPathClient
+		want   error
+	}{
+		"Successful": {
+			cr: dir(withOwner(owner), withGroup(group), withACL(acl)),
+			client: &fake.MockPathClient{
+				MockSetAccessControl: func(_ context.Context, _ string, _ string, o string, g string, a string) (autorest.Response, error) {
+					if o != owner || g != group || a != acl {
+						return autorest.Response{}, errors.Errorf("unexpected owner %q, group %q, or ACL %q", o, g, a)
+					}
+					return autorest.Response{}, nil
+				},
+			},
+		},
+		"Failed": {
+			cr: dir(withACL(acl)),
+			client: &fake.MockPathClient{
+				MockSetAccessControl: func(_ context.Context, _ string, _ string, _ string, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: errors.Wrap(errorBoom, errSetAccessFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{accounts: accounts(), newClient: newClient(tc.client)}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	cases := map[string]struct {
+		client   *fake.MockPathClient
+		accounts *fake.MockAccountsClient
+		want     error
+	}{
+		"Successful": {
+			client: &fake.MockPathClient{
+				MockDelete: func(_ context.Context, _ string, _ string, recursive *bool) (autorest.Response, error) {
+					if recursive == nil || *recursive {
+						return autorest.Response{}, errors.New("directory must not be deleted recursively")
+					}
+					return autorest.Response{}, nil
+				},
+			},
+		},
+		"AlreadyDeleted": {
+			client: &fake.MockPathClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ *bool) (autorest.Response, error) {
+					return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+		},
+		"AccountDeleted": {
+			accounts: accountNotFound(),
+		},
+		"Failed": {
+			client: &fake.MockPathClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ *bool) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: errors.Wrap(errorBoom, errDeleteFailed),
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			a := tc.accounts
+			if a == nil {
+				a = accounts()
+			}
+			e := external{accounts: a, newClient: newClient(tc.client)}
+			err := e.Delete(context.Background(), dir())
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package queue
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+)
+
+const (
+	resourceGroup = "coolgroup"
+	accountName   = "coolaccount"
+	queueName     = "coolqueue"
+	id            = "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount/queueServices/default/queues/coolqueue"
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type queueModifier func(*v1beta1.Queue)
+
+func withConditions(c ...xpv1.Condition) queueModifier {
+	return func(cr *v1beta1.Queue) { cr.Status.ConditionedStatus.Conditions = c }
+}
+
+func withMetadata(m map[string]string) queueModifier {
+	return func(cr *v1beta1.Queue) { cr.Spec.ForProvider.Metadata = m }
+}
+
+func withObservation(o v1beta1.QueueObservation) queueModifier {
+	return func(cr *v1beta1.Queue) { cr.Status.AtProvider = o }
+}
+
+func queue(m ...queueModifier) *v1beta1.Queue {
+	cr := &v1beta1.Queue{
+		Spec: v1beta1.QueueSpec{
+			ForProvider: v1beta1.QueueParameters{
+				ResourceGroupName: resourceGroup,
+				AccountName:       accountName,
+			},
+		},
+	}
+	meta.SetExternalName(cr, queueName)
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func azureQueue() azurestorage.Queue {
+	return azurestorage.Queue{
+		ID: azure.ToStringPtr(id),
+		QueueProperties: &azurestorage.QueueProperties{
+			Metadata:                map[string]*string{"team": azure.ToStringPtr("cool")},
+			ApproximateMessageCount: azure.ToInt32Ptr(3),
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr  *v1beta1.Queue
+		o   managed.ExternalObservation
+		err error
+	}
+
+	obs := v1beta1.QueueObservation{ID: id, ApproximateMessageCount: 3}
+
+	cases := map[string]struct {
+		cr     *v1beta1.Queue
+		client *fake.MockQueueClient
+		want   want
+	}{
+		"NotFound": {
+			cr: queue(),
+			client: &fake.MockQueueClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (azurestorage.Queue, error) {
+					return azurestorage.Queue{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+			want: want{
+				cr: queue(),
+			},
+		},
+		"GetFailed": {
+			cr: queue(),
+			client: &fake.MockQueueClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (azurestorage.Queue, error) {
+					return azurestorage.Queue{}, errorBoom
+				},
+			},
+			want: want{
+				cr:  queue(),
+				err: errors.Wrap(errorBoom, errGetFailed),
+			},
+		},
+		"LateInitialized": {
+			cr: queue(),
+			client: &fake.MockQueueClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (azurestorage.Queue, error) {
+					return azureQueue(), nil
+				},
+			},
+			want: want{
+				cr: queue(withMetadata(map[string]string{"team": "cool"}), withObservation(obs), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"NeedsUpdate": {
+			cr: queue(withMetadata(map[string]string{"team": "cooler"})),
+			client: &fake.MockQueueClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (azurestorage.Queue, error) {
+					return azureQueue(), nil
+				},
+			},
+			want: want{
+				cr: queue(withMetadata(map[string]string{"team": "cooler"}), withObservation(obs), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCreate(t *testing.T) {
+	type want struct {
+		cr  *v1beta1.Queue
+		err error
+	}
+
+	cases := map[string]struct {
+		cr     *v1beta1.Queue
+		client *fake.MockQueueClient
+		want   want
+	}{
+		"CreateFailed": {
+			cr: queue(),
+			client: &fake.MockQueueClient{
+				MockCreate: func(_ context.Context, _ string, _ string, _ string, _ azurestorage.Queue) (azurestorage.Queue, error) {
+					return azurestorage.Queue{}, errorBoom
+				},
+			},
+			want: want{
+				cr:  queue(withConditions(xpv1.Creating())),
+				err: errors.Wrap(errorBoom, errCreateFailed),
+			},
+		},
+		"Successful": {
+			cr: queue(withMetadata(map[string]string{"team": "cool"})),
+			client: &fake.MockQueueClient{
+				MockCreate: func(_ context.Context, _ string, _ string, name string, q azurestorage.Queue) (azurestorage.Queue, error) {
+					if name != queueName || azure.ToString(q.Metadata["team"]) != "cool" {
+						return azurestorage.Queue{}, errorBoom
+					}
+					return azureQueue(), nil
+				},
+			},
+			want: want{
+				cr: queue(withMetadata(map[string]string{"team": "cool"}), withConditions(xpv1.Creating())),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			_, err := e.Create(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Create(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDelete(t *testing.T) {
+	type want struct {
+		cr  *v1beta1.Queue
+		err error
+	}
+
+	cases := map[string]struct {
+		cr     *v1beta1.Queue
+		client *fake.MockQueueClient
+		want   want
+	}{
+		"NotFound": {
+			cr: queue(),
+			client: &fake.MockQueueClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+			want: want{
+				cr: queue(withConditions(xpv1.Deleting())),
+			},
+		},
+		"DeleteFailed": {
+			cr: queue(),
+			client: &fake.MockQueueClient{
+				MockDelete: func(_ context.Context, _ string, _ string, _ string) (autorest.Response, error) {
+					return autorest.Response{}, errorBoom
+				},
+			},
+			want: want{
+				cr:  queue(withConditions(xpv1.Deleting())),
+				err: errors.Wrap(errorBoom, errDeleteFailed),
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			err := e.Delete(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Delete(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package table
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+
+	xpv1 "github.com/crossplane/crossplane-runtime/apis/common/v1"
+	"github.com/crossplane/crossplane-runtime/pkg/meta"
+	"github.com/crossplane/crossplane-runtime/pkg/reconciler/managed"
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/storage/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+	azurestorage "github.com/crossplane/provider-azure/pkg/clients/storage"
+	"github.com/crossplane/provider-azure/pkg/clients/storage/fake"
+)
+
+const (
+	resourceGroup = "coolgroup"
+	accountName   = "coolaccount"
+	tableName     = "cooltable"
+	id            = "/subscriptions/cool/resourceGroups/coolgroup/providers/Microsoft.Storage/storageAccounts/coolaccount/tableServices/default/tables/cooltable"
+)
+
+var errorBoom = errors.New("boom")
+
+var _ managed.ExternalClient = &external{}
+var _ managed.ExternalConnecter = &connecter{}
+
+type tableModifier func(*v1beta1.Table)
+
+func withConditions(c ...xpv1.Condition) tableModifier {
+	return func(cr *v1beta1.Table) { cr.Status.ConditionedStatus.Conditions = c }
+}
+
+func withPolicy(id, permission string) tableModifier {
+	return func(cr *v1beta1.Table) {
+		cr.Spec.ForProvider.SignedIdentifiers = append(cr.Spec.ForProvider.SignedIdentifiers, v1beta1.TableSignedIdentifier{
+			ID:           id,
+			AccessPolicy: &v1beta1.TableAccessPolicy{Permission: permission},
+		})
+	}
+}
+
+func withObservation(o v1beta1.TableObservation) tableModifier {
+	return func(cr *v1beta1.Table) { cr.Status.AtProvider = o }
+}
+
+func table(m ...tableModifier) *v1beta1.Table {
+	cr := &v1beta1.Table{
+		Spec: v1beta1.TableSpec{
+			ForProvider: v1beta1.TableParameters{
+				ResourceGroupName: resourceGroup,
+				AccountName:       accountName,
+			},
+		},
+	}
+	meta.SetExternalName(cr, tableName)
+	for _, f := range m {
+		f(cr)
+	}
+	return cr
+}
+
+func azureTable() azurestorage.Table {
+	return azurestorage.Table{
+		ID: azure.ToStringPtr(id),
+		TableProperties: &azurestorage.TableProperties{
+			TableName: azure.ToStringPtr(tableName),
+			SignedIdentifiers: &[]azurestorage.TableSignedIdentifier{{
+				ID:           azure.ToStringPtr("readers"),
+				AccessPolicy: &azurestorage.TableAccessPolicy{Permission: azure.ToStringPtr("r")},
+			}},
+		},
+	}
+}
+
+func TestObserve(t *testing.T) {
+	type want struct {
+		cr  *v1beta1.Table
+		o   managed.ExternalObservation
+		err error
+	}
+
+	obs := v1beta1.TableObservation{ID: id, TableName: tableName}
+
+	cases := map[string]struct {
+		cr     *v1beta1.Table
+		client *fake.MockTableClient
+		want   want
+	}{
+		"NotFound": {
+			cr: table(),
+			client: &fake.MockTableClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (azurestorage.Table, error) {
+					return azurestorage.Table{}, autorest.DetailedError{StatusCode: http.StatusNotFound}
+				},
+			},
+			want: want{
+				cr: table(),
+			},
+		},
+		"GetFailed": {
+			cr: table(),
+			client: &fake.MockTableClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (azurestorage.Table, error) {
+					return azurestorage.Table{}, errorBoom
+				},
+			},
+			want: want{
+				cr:  table(),
+				err: errors.Wrap(errorBoom, errGetFailed),
+			},
+		},
+		"LateInitialized": {
+			cr: table(),
+			client: &fake.MockTableClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (azurestorage.Table, error) {
+					return azureTable(), nil
+				},
+			},
+			want: want{
+				cr: table(withPolicy("readers", "r"), withObservation(obs), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true, ResourceUpToDate: true, ResourceLateInitialized: true},
+			},
+		},
+		"NeedsUpdate": {
+			cr: table(withPolicy("readers", "r"), withPolicy("writers", "au")),
+			client: &fake.MockTableClient{
+				MockGet: func(_ context.Context, _ string, _ string, _ string) (azurestorage.Table, error) {
+					return azureTable(), nil
+				},
+			},
+			want: want{
+				cr: table(withPolicy("readers", "r"), withPolicy("writers", "au"), withObservation(obs), withConditions(xpv1.Available())),
+				o:  managed.ExternalObservation{ResourceExists: true},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			o, err := e.Observe(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want.cr, tc.cr, test.EquateConditions()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.o, o); diff != "" {
+				t.Errorf("Observe(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	cases := map[string]struct {
+		cr     *v1beta1.Table
+		client *fake.MockTableClient
+		want   error
+	}{
+		"UpdateFailed": {
+			cr: table(),
+			client: &fake.MockTableClient{
+				MockUpdate: func(_ context.Context, _ string, _ string, _ string, _ azurestorage.Table) (azurestorage.Table, error) {
+					return azurestorage.Table{}, errorBoom
+				},
+			},
+			want: errors.Wrap(errorBoom, errUpdateFailed),
+		},
+		"Successful": {
+			cr: table(withPolicy("writers", "au")),
+			client: &fake.MockTableClient{
+				MockUpdate: func(_ context.Context, _ string, _ string, name string, tb azurestorage.Table) (azurestorage.Table, error) {
+					if name != tableName || len(*tb.SignedIdentifiers) != 1 {
+						return azurestorage.Table{}, errorBoom
+					}
+					return azureTable(), nil
+				},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			e := external{client: tc.client}
+			_, err := e.Update(context.Background(), tc.cr)
+			if diff := cmp.Diff(tc.want, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Update(...): -want, +got\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health contains checks used to determine whether the provider is
+// live and ready.
+package health
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+	azure "github.com/crossplane/provider-azure/pkg/clients"
+)
+
+// Error strings.
+const (
+	errListProviderConfigs = "cannot list ProviderConfigs"
+	errNoProviderConfigs   = "no ProviderConfigs exist"
+	errNoneAuthenticated   = "no ProviderConfig could authenticate to Azure"
+	errAuthenticate        = "cannot authenticate using ProviderConfig"
+)
+
+// DefaultAuthenticationTTL is the period for which a successful authentication
+// is trusted before the ProviderConfigs are authenticated again.
+const DefaultAuthenticationTTL = 5 * time.Minute
+
+// APIServer returns a checker that succeeds when the API server can be reached
+// and ProviderConfigs can be listed. The supplied reader should not be backed
+// by a cache.
+func APIServer(c client.Reader) healthz.Checker {
+	return func(req *http.Request) error {
+		return errors.Wrap(c.List(req.Context(), &v1beta1.ProviderConfigList{}, client.Limit(1)), errListProviderConfigs)
+	}
+}
+
+// An AuthenticateFn authenticates to Azure using the supplied ProviderConfig.
+type AuthenticateFn func(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) error
+
+// Authenticate to Azure using the credentials of the supplied ProviderConfig.
+func Authenticate(ctx context.Context, c client.Client, pc *v1beta1.ProviderConfig) error {
+	creds, err := azure.ProviderConfigCredentials(ctx, c, pc)
+	if err != nil {
+		return err
+	}
+	return azure.Authenticate(ctx, creds)
+}
+
+// A ProviderConfigChecker checks that at least one ProviderConfig can be used
+// to authenticate to Azure.
+type ProviderConfigChecker struct {
+	client       client.Client
+	authenticate AuthenticateFn
+	ttl          time.Duration
+	now          func() time.Time
+
+	mu            sync.Mutex
+	authenticated time.Time
+}
+
+// A ProviderConfigCheckerOption configures a ProviderConfigChecker.
+type ProviderConfigCheckerOption func(*ProviderConfigChecker)
+
+// WithAuthenticateFn configures how a ProviderConfigChecker authenticates.
+func WithAuthenticateFn(fn AuthenticateFn) ProviderConfigCheckerOption {
+	return func(c *ProviderConfigChecker) {
+		c.authenticate = fn
+	}
+}
+
+// WithAuthenticationTTL configures for how long a ProviderConfigChecker trusts
+// a successful authentication.
+func WithAuthenticationTTL(ttl time.Duration) ProviderConfigCheckerOption {
+	return func(c *ProviderConfigChecker) {
+		c.ttl = ttl
+	}
+}
+
+// NewProviderConfigChecker returns a ProviderConfigChecker.
+func NewProviderConfigChecker(c client.Client, o ...ProviderConfigCheckerOption) *ProviderConfigChecker {
+	pcc := &ProviderConfigChecker{
+		client:       c,
+		authenticate: Authenticate,
+		ttl:          DefaultAuthenticationTTL,
+		now:          time.Now,
+	}
+	for _, fn := range o {
+		fn(pcc)
+	}
+	return pcc
+}
+
+// Check succeeds if any ProviderConfig authenticated successfully within the
+// authentication TTL, or can authenticate now.
+func (c *ProviderConfigChecker) Check(req *http.Request) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.now().Sub(c.authenticated) < c.ttl {
+		return nil
+	}
+
+	l := &v1beta1.ProviderConfigList{}
+	if err := c.client.List(req.Context(), l); err != nil {
+		return errors.Wrap(err, errListProviderConfigs)
+	}
+	if len(l.Items) == 0 {
+		return errors.New(errNoProviderConfigs)
+	}
+
+	var err error
+	for i := range l.Items {
+		pc := &l.Items[i]
+		if err = c.authenticate(req.Context(), c.client, pc); err != nil {
+			err = errors.Wrapf(err, "%s %q", errAuthenticate, pc.GetName())
+			continue
+		}
+		c.authenticated = c.now()
+		return nil
+	}
+	return errors.Wrap(err, errNoneAuthenticated)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+
+	"github.com/crossplane/provider-azure/apis/v1beta1"
+)
+
+var errBoom = errors.New("boom")
+
+func listProviderConfigs(names ...string) test.MockListFn {
+	return func(_ context.Context, obj client.ObjectList, _ ...client.ListOption) error {
+		l := obj.(*v1beta1.ProviderConfigList)
+		for _, n := range names {
+			l.Items = append(l.Items, v1beta1.ProviderConfig{ObjectMeta: metav1.ObjectMeta{Name: n}})
+		}
+		return nil
+	}
+}
+
+func TestAPIServer(t *testing.T) {
+	cases := map[string]struct {
+		c    client.Reader
+		want error
+	}{
+		"ListError": {
+			c:    &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			want: errors.Wrap(errBoom, errListProviderConfigs),
+		},
+		"Success": {
+			c: &test.MockClient{MockList: test.NewMockListFn(nil)},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := APIServer(tc.c)(httptest.NewRequest("GET", "/readyz", nil))
+			if diff := cmp.Diff(tc.want, got, test.EquateErrors()); diff != "" {
+				t.Errorf("APIServer(...): -want error, +got error:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestProviderConfigChecker(t *testing.T) {
+	now := time.Now()
+
+	type args struct {
+		c             client.Client
+		authenticate  AuthenticateFn
+		authenticated time.Time
+	}
+	type want struct {
+		err           error
+		authenticated time.Time
+	}
+
+	cases := map[string]struct {
+		args args
+		want want
+	}{
+		"RecentlyAuthenticated": {
+			args: args{
+				c:             &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+				authenticated: now.Add(-time.Minute),
+			},
+			want: want{
+				authenticated: now.Add(-time.Minute),
+			},
+		},
+		"ListError": {
+			args: args{
+				c: &test.MockClient{MockList: test.NewMockListFn(errBoom)},
+			},
+			want: want{
+				err: errors.Wrap(errBoom, errListProviderConfigs),
+			},
+		},
+		"NoProviderConfigs": {
+			args: args{
+				c: &test.MockClient{MockList: listProviderConfigs()},
+			},
+			want: want{
+				err: errors.New(errNoProviderConfigs),
+			},
+		},
+		"NoneAuthenticated": {
+			args: args{
+				c:            &test.MockClient{MockList: listProviderConfigs("a", "b")},
+				authenticate: func(_ context.Context, _ client.Client, _ *v1beta1.ProviderConfig) error { return errBoom },
+			},
+			want: want{
+				err: errors.Wrap(errors.Wrapf(errBoom, "%s %q", errAuthenticate, "b"), errNoneAuthenticated),
+			},
+		},
+		"OneAuthenticated": {
+			args: args{
+				c: &test.MockClient{MockList: listProviderConfigs("a", "b")},
+				authenticate: func(_ context.Context, _ client.Client, pc *v1beta1.ProviderConfig) error {
+					if pc.GetName() == "a" {
+						return errBoom
+					}
+					return nil
+				},
+				authenticated: now.Add(-time.Hour),
+			},
+			want: want{
+				authenticated: now,
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			c := NewProviderConfigChecker(tc.args.c, WithAuthenticateFn(tc.args.authenticate))
+			c.now = func() time.Time { return now }
+			c.authenticated = tc.args.authenticated
+
+			err := c.Check(httptest.NewRequest("GET", "/readyz", nil))
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Check(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.authenticated, c.authenticated); diff != "" {
+				t.Errorf("Check(...): -want authenticated, +got authenticated:\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,221 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package tracing instruments reconciles and Azure API calls using
+// OpenTelemetry.
+package tracing
+
+import (
+	"context"
+	"net/http"
+
+	autorest "github.com/Azure/go-autorest/tracing"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpgrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/semconv"
+	"go.opentelemetry.io/otel/trace"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Error strings.
+const (
+	errNewExporter = "cannot create OTLP trace exporter"
+)
+
+const (
+	// ServiceName identifies the provider in exported traces.
+	ServiceName = "provider-azure"
+
+	instrumentationName = "github.com/crossplane/provider-azure"
+)
+
+// Span attribute keys.
+const (
+	AttributeController         = attribute.Key("crossplane.controller")
+	AttributeName               = attribute.Key("k8s.object.name")
+	AttributeNamespace          = attribute.Key("k8s.object.namespace")
+	AttributeGroup              = attribute.Key("k8s.object.group")
+	AttributeVersion            = attribute.Key("k8s.object.version")
+	AttributeKind               = attribute.Key("k8s.object.kind")
+	AttributeRequeue            = attribute.Key("crossplane.requeue")
+	AttributeAzureRequestID     = attribute.Key("azure.request_id")
+	AttributeAzureCorrelationID = attribute.Key("azure.correlation_request_id")
+	AttributeAzureRoutingID     = attribute.Key("azure.routing_request_id")
+)
+
+// Azure response headers that identify a request.
+const (
+	headerRequestID     = "x-ms-request-id"
+	headerCorrelationID = "x-ms-correlation-request-id"
+	headerRoutingID     = "x-ms-routing-request-id"
+)
+
+// Options configure how traces are exported.
+type Options struct {
+	// Endpoint of the OTLP gRPC collector to which traces are exported.
+	Endpoint string
+
+	// Insecure disables transport security when connecting to the collector.
+	Insecure bool
+
+	// SampleRatio is the fraction of reconciles that are traced.
+	SampleRatio float64
+}
+
+// Setup exports traces to the configured OTLP collector and instruments all
+// Azure SDK clients that are created afterwards. The returned function flushes
+// and stops the exporter.
+func Setup(ctx context.Context, o Options) (func(context.Context) error, error) {
+	do := []otlpgrpc.Option{otlpgrpc.WithEndpoint(o.Endpoint)}
+	if o.Insecure {
+		do = append(do, otlpgrpc.WithInsecure())
+	}
+	exp, err := otlp.NewExporter(ctx, otlpgrpc.NewDriver(do...))
+	if err != nil {
+		return nil, errors.Wrap(err, errNewExporter)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exp),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(o.SampleRatio))),
+		sdktrace.WithResource(resource.NewWithAttributes(semconv.ServiceNameKey.String(ServiceName))),
+	)
+	otel.SetTracerProvider(tp)
+	autorest.Register(&AzureTracer{})
+
+	return tp.Shutdown, nil
+}
+
+// Tracer returns the tracer used by the provider. It is a no-op unless Setup
+// has been called.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// End the supplied span, recording the supplied error, if any.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// A reconciler that traces each reconcile.
+type reconciler struct {
+	name    string
+	gvk     schema.GroupVersionKind
+	wrapped reconcile.Reconciler
+}
+
+// Reconciler wraps the supplied reconciler of the supplied kind of managed
+// resource such that each reconcile is traced as a span. Spans started while
+// reconciling, for example those of Azure API calls, are children of this
+// span.
+func Reconciler(controller string, gvk schema.GroupVersionKind, r reconcile.Reconciler) reconcile.Reconciler {
+	return &reconciler{name: controller, gvk: gvk, wrapped: r}
+}
+
+func (r *reconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	ctx, span := Tracer().Start(ctx, "Reconcile", trace.WithAttributes(
+		AttributeController.String(r.name),
+		AttributeGroup.String(r.gvk.Group),
+		AttributeVersion.String(r.gvk.Version),
+		AttributeKind.String(r.gvk.Kind),
+		AttributeName.String(req.Name),
+		AttributeNamespace.String(req.Namespace),
+	))
+	result, err := r.wrapped.Reconcile(ctx, req)
+	span.SetAttributes(AttributeRequeue.Bool(result.Requeue || result.RequeueAfter > 0))
+	End(span, err)
+	return result, err
+}
+
+// An AzureTracer traces Azure SDK operations, and the HTTP requests they make,
+// as OpenTelemetry spans. It must be registered with the autorest tracing
+// package before any Azure SDK clients are created.
+type AzureTracer struct{}
+
+// NewTransport returns an HTTP transport that traces each request.
+func (t *AzureTracer) NewTransport(base *http.Transport) http.RoundTripper {
+	return &Transport{Base: base}
+}
+
+// StartSpan starts a span for the named Azure SDK operation.
+func (t *AzureTracer) StartSpan(ctx context.Context, name string) context.Context {
+	ctx, _ = Tracer().Start(ctx, name)
+	return ctx
+}
+
+// EndSpan ends the span of an Azure SDK operation.
+func (t *AzureTracer) EndSpan(ctx context.Context, httpStatusCode int, err error) {
+	span := trace.SpanFromContext(ctx)
+	if httpStatusCode > 0 {
+		span.SetAttributes(semconv.HTTPStatusCodeKey.Int(httpStatusCode))
+	}
+	End(span, err)
+}
+
+// A Transport traces each HTTP request made using its Base transport. The
+// request IDs Azure returns are recorded so that a span can be correlated
+// with Azure activity logs and support requests.
+type Transport struct {
+	Base http.RoundTripper
+}
+
+// RoundTrip traces an HTTP request.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// The query is omitted because it may contain secrets, such as a SAS.
+	u := *req.URL
+	u.RawQuery = ""
+	u.User = nil
+
+	ctx, span := Tracer().Start(req.Context(), "HTTP "+req.Method,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPMethodKey.String(req.Method),
+			semconv.HTTPURLKey.String(u.String()),
+		))
+
+	rsp, err := t.Base.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		End(span, err)
+		return rsp, err
+	}
+
+	span.SetAttributes(semconv.HTTPStatusCodeKey.Int(rsp.StatusCode))
+	for k, h := range map[attribute.Key]string{
+		AttributeAzureRequestID:     headerRequestID,
+		AttributeAzureCorrelationID: headerCorrelationID,
+		AttributeAzureRoutingID:     headerRoutingID,
+	} {
+		if v := rsp.Header.Get(h); v != "" {
+			span.SetAttributes(k.String(v))
+		}
+	}
+	if rsp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(rsp.StatusCode))
+	}
+	span.End()
+	return rsp, nil
+}
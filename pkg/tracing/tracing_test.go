@@ -0,0 +1,203 @@
+/*
+Copyright 2021 The Crossplane Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/semconv"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	"github.com/crossplane/crossplane-runtime/pkg/test"
+)
+
+var errBoom = errors.New("boom")
+
+// A span is the subset of a recorded span that these tests compare.
+type span struct {
+	Name       string
+	Attributes map[attribute.Key]attribute.Value
+	Status     codes.Code
+}
+
+func record(t *testing.T) func() []span {
+	t.Helper()
+	exp := tracetest.NewInMemoryExporter()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exp)))
+	return func() []span {
+		var spans []span
+		for _, s := range exp.GetSpans() {
+			a := map[attribute.Key]attribute.Value{}
+			for _, kv := range s.Attributes {
+				a[kv.Key] = kv.Value
+			}
+			spans = append(spans, span{Name: s.Name, Attributes: a, Status: s.StatusCode})
+		}
+		return spans
+	}
+}
+
+func TestReconciler(t *testing.T) {
+	type want struct {
+		result reconcile.Result
+		err    error
+		spans  []span
+	}
+
+	cases := map[string]struct {
+		r    reconcile.Reconciler
+		want want
+	}{
+		"Error": {
+			r: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{}, errBoom
+			}),
+			want: want{
+				err: errBoom,
+				spans: []span{{
+					Name: "Reconcile",
+					Attributes: map[attribute.Key]attribute.Value{
+						AttributeController: attribute.StringValue("cool"),
+						AttributeGroup:      attribute.StringValue("cool.example.org"),
+						AttributeVersion:    attribute.StringValue("v1"),
+						AttributeKind:       attribute.StringValue("Cool"),
+						AttributeName:       attribute.StringValue("coolness"),
+						AttributeNamespace:  attribute.StringValue(""),
+						AttributeRequeue:    attribute.BoolValue(false),
+					},
+					Status: codes.Error,
+				}},
+			},
+		},
+		"Requeue": {
+			r: reconcile.Func(func(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+				return reconcile.Result{RequeueAfter: time.Minute}, nil
+			}),
+			want: want{
+				result: reconcile.Result{RequeueAfter: time.Minute},
+				spans: []span{{
+					Name: "Reconcile",
+					Attributes: map[attribute.Key]attribute.Value{
+						AttributeController: attribute.StringValue("cool"),
+						AttributeGroup:      attribute.StringValue("cool.example.org"),
+						AttributeVersion:    attribute.StringValue("v1"),
+						AttributeKind:       attribute.StringValue("Cool"),
+						AttributeName:       attribute.StringValue("coolness"),
+						AttributeNamespace:  attribute.StringValue(""),
+						AttributeRequeue:    attribute.BoolValue(true),
+					},
+				}},
+			},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spans := record(t)
+			got, err := Reconciler("cool", schema.GroupVersionKind{Group: "cool.example.org", Version: "v1", Kind: "Cool"}, tc.r).Reconcile(context.Background(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "coolness"}})
+			if diff := cmp.Diff(tc.want.err, err, test.EquateErrors()); diff != "" {
+				t.Errorf("Reconcile(...): -want error, +got error:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.result, got); diff != "" {
+				t.Errorf("Reconcile(...): -want result, +got result:\n%s", diff)
+			}
+			if diff := cmp.Diff(tc.want.spans, spans(), cmp.AllowUnexported(attribute.Value{})); diff != "" {
+				t.Errorf("Reconcile(...): -want spans, +got spans:\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTransport(t *testing.T) {
+	cases := map[string]struct {
+		status  int
+		headers map[string]string
+		want    []span
+	}{
+		"Success": {
+			status: http.StatusOK,
+			headers: map[string]string{
+				headerRequestID:     "req",
+				headerCorrelationID: "corr",
+				headerRoutingID:     "route",
+			},
+			want: []span{{
+				Name: "HTTP GET",
+				Attributes: map[attribute.Key]attribute.Value{
+					semconv.HTTPMethodKey:       attribute.StringValue(http.MethodGet),
+					semconv.HTTPURLKey:          attribute.StringValue("/subscriptions/cool"),
+					semconv.HTTPStatusCodeKey:   attribute.IntValue(http.StatusOK),
+					AttributeAzureRequestID:     attribute.StringValue("req"),
+					AttributeAzureCorrelationID: attribute.StringValue("corr"),
+					AttributeAzureRoutingID:     attribute.StringValue("route"),
+				},
+			}},
+		},
+		"NotFound": {
+			status: http.StatusNotFound,
+			want: []span{{
+				Name: "HTTP GET",
+				Attributes: map[attribute.Key]attribute.Value{
+					semconv.HTTPMethodKey:     attribute.StringValue(http.MethodGet),
+					semconv.HTTPURLKey:        attribute.StringValue("/subscriptions/cool"),
+					semconv.HTTPStatusCodeKey: attribute.IntValue(http.StatusNotFound),
+				},
+				Status: codes.Error,
+			}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			spans := record(t)
+			rt := roundTripFn(func(_ *http.Request) (*http.Response, error) {
+				w := httptest.NewRecorder()
+				for k, v := range tc.headers {
+					w.Header().Set(k, v)
+				}
+				w.WriteHeader(tc.status)
+				return w.Result(), nil
+			})
+			req := httptest.NewRequest(http.MethodGet, "/subscriptions/cool?sig=secret", nil)
+			if _, err := (&Transport{Base: rt}).RoundTrip(req); err != nil {
+				t.Fatalf("RoundTrip(...): %s", err)
+			}
+			if diff := cmp.Diff(tc.want, spans(), cmp.AllowUnexported(attribute.Value{})); diff != "" {
+				t.Errorf("RoundTrip(...): -want spans, +got spans:\n%s", diff)
+			}
+		})
+	}
+}
+
+type roundTripFn func(*http.Request) (*http.Response, error)
+
+func (fn roundTripFn) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}